@@ -31,8 +31,10 @@ import (
 
 	sharedclientset "github.com/knative/pkg/client/clientset/versioned"
 	"github.com/knative/pkg/controller"
+	"github.com/tektoncd/pipeline/pkg/commitstatus"
 	"github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/scheduledpipelinerun"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun"
 	"github.com/tektoncd/pipeline/pkg/system"
 
@@ -92,12 +94,17 @@ func main() {
 	}
 
 	configMapWatcher := configmap.NewInformedWatcher(kubeClient, system.GetNamespace())
+	cloudEvents := reconciler.NewCloudEventSender(logger, stopCh)
+	cloudEvents.WatchConfigs(configMapWatcher)
+	commitStatuses := commitstatus.NewQueue(logger, stopCh)
 
 	opt := reconciler.Options{
 		KubeClientSet:     kubeClient,
 		SharedClientSet:   sharedClient,
 		PipelineClientSet: pipelineClient,
 		ConfigMapWatcher:  configMapWatcher,
+		CloudEvents:       cloudEvents,
+		CommitStatuses:    commitStatuses,
 		ResyncPeriod:      resyncPeriod,
 		Logger:            logger,
 	}
@@ -109,6 +116,10 @@ func main() {
 	clusterTaskInformer := pipelineInformerFactory.Tekton().V1alpha1().ClusterTasks()
 	taskRunInformer := pipelineInformerFactory.Tekton().V1alpha1().TaskRuns()
 	resourceInformer := pipelineInformerFactory.Tekton().V1alpha1().PipelineResources()
+	conditionInformer := pipelineInformerFactory.Tekton().V1alpha1().Conditions()
+	approvalInformer := pipelineInformerFactory.Tekton().V1alpha1().Approvals()
+	runInformer := pipelineInformerFactory.Tekton().V1alpha1().Runs()
+	scheduledPipelineRunInformer := pipelineInformerFactory.Tekton().V1alpha1().ScheduledPipelineRuns()
 	podInformer := kubeInformerFactory.Core().V1().Pods()
 
 	pipelineInformer := pipelineInformerFactory.Tekton().V1alpha1().Pipelines()
@@ -131,16 +142,26 @@ func main() {
 		clusterTaskInformer,
 		taskRunInformer,
 		resourceInformer,
+		conditionInformer,
+		approvalInformer,
+		runInformer,
 		timeoutHandler,
 	)
+	sprc := scheduledpipelinerun.NewController(opt,
+		scheduledPipelineRunInformer,
+		pipelineRunInformer,
+	)
 	// Build all of our controllers, with the clients constructed above.
 	controllers := []*controller.Impl{
 		// Pipeline Controllers
 		trc,
 		prc,
+		sprc,
 	}
 	timeoutHandler.SetTaskRunCallbackFunc(trc.Enqueue)
 	timeoutHandler.SetPipelineRunCallbackFunc(prc.Enqueue)
+	timeoutHandler.SetApprovalCallbackFunc(prc.EnqueueControllerOf)
+	timeoutHandler.SetRunCallbackFunc(prc.EnqueueControllerOf)
 	timeoutHandler.CheckTimeouts()
 
 	// Watch the logging config map and dynamically update logging levels.
@@ -159,6 +180,10 @@ func main() {
 		clusterTaskInformer.Informer().HasSynced,
 		taskRunInformer.Informer().HasSynced,
 		resourceInformer.Informer().HasSynced,
+		conditionInformer.Informer().HasSynced,
+		approvalInformer.Informer().HasSynced,
+		runInformer.Informer().HasSynced,
+		scheduledPipelineRunInformer.Informer().HasSynced,
 		podInformer.Informer().HasSynced,
 	} {
 		if ok := cache.WaitForCacheSync(stopCh, synced); !ok {
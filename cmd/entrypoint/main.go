@@ -17,50 +17,67 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
-	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
+	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
 var (
-	ep       = flag.String("entrypoint", "", "Original specified entrypoint to execute")
-	waitFile = flag.String("wait_file", "", "If specified, file to wait for")
-	postFile = flag.String("post_file", "", "If specified, file to write upon completion")
+	ep              = flag.String("entrypoint", "", "Original specified entrypoint to execute")
+	waitFile        = flag.String("wait_file", "", "If specified, file to wait for")
+	waitFileContent = flag.Bool("wait_file_content", false, "If specified, expect wait_file to have content")
+	postFile        = flag.String("post_file", "", "If specified, file to write upon completion")
+
+	resultsDir      = flag.String("results_dir", "", "If specified, directory to read results from upon completion")
+	timeout         = flag.Duration("timeout", 0, "If specified, how long the command may run before being killed")
+	onError         = flag.String("on_error", "", "If set to \"continue\", write post_file even if the command fails")
+	terminationPath = flag.String("termination_path", "/dev/termination-log", "File to report how the step went to")
 )
 
 func main() {
 	flag.Parse()
 
+	// Stop waiting, or kill the command, once the pod is being deleted, e.g.
+	// as its TaskRun was cancelled: running as the first process of the
+	// container, the entrypoint isn't terminated by default.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		<-signals
+		cancel()
+	}()
+
 	e := entrypoint.Entrypointer{
-		Entrypoint: *ep,
-		WaitFile:   *waitFile,
-		PostFile:   *postFile,
-		Args:       flag.Args(),
-		Waiter:     &RealWaiter{},
-		Runner:     &RealRunner{},
-		PostWriter: &RealPostWriter{},
+		Entrypoint:        *ep,
+		WaitFile:          *waitFile,
+		WaitFileContent:   *waitFileContent,
+		PostFile:          *postFile,
+		ResultsDir:        *resultsDir,
+		Timeout:           *timeout,
+		OnError:           *onError,
+		Args:              flag.Args(),
+		Waiter:            &RealWaiter{},
+		Runner:            &RealRunner{},
+		PostWriter:        &RealPostWriter{},
+		TerminationWriter: &RealTerminationWriter{path: *terminationPath},
 	}
-	if err := e.Go(); err != nil {
+	if err := e.Go(ctx); err != nil {
 		switch err.(type) {
-		case skipError:
+		case entrypoint.SkipError:
+			// The step is reported as skipped in its termination message.
 			os.Exit(0)
 		case *exec.ExitError:
-			// Copied from https://stackoverflow.com/questions/10385551/get-exit-code-go
-			// This works on both Unix and Windows. Although
-			// package syscall is generally platform dependent,
-			// WaitStatus is defined for both Unix and Windows and
-			// in both cases has an ExitStatus() method with the
-			// same signature.
-			if status, ok := err.(*exec.ExitError).Sys().(syscall.WaitStatus); ok {
-				os.Exit(status.ExitStatus())
-			}
-			log.Fatalf("Error executing command (ExitError): %v", err)
+			os.Exit(entrypoint.ExitCode(err))
 		default:
 			log.Fatalf("Error executing command: %v", err)
 		}
@@ -70,41 +87,18 @@ func main() {
 // TODO(jasonhall): Test that original exit code is propagated and that
 // stdout/stderr are collected -- needs e2e tests.
 
-// RealWaiter actually waits for files, by polling.
-type RealWaiter struct{ waitFile string }
-
-var _ entrypoint.Waiter = (*RealWaiter)(nil)
-
-func (*RealWaiter) Wait(file string) error {
-	if file == "" {
-		return nil
-	}
-	for ; ; time.Sleep(time.Second) {
-		// Watch for the post file
-		if _, err := os.Stat(file); err == nil {
-			return nil
-		} else if !os.IsNotExist(err) {
-			return fmt.Errorf("Waiting for %q: %v", file, err)
-		}
-		// Watch for the post error file
-		if _, err := os.Stat(file + ".err"); err == nil {
-			return skipError("error file present, bail and skip the step")
-		}
-	}
-}
-
 // RealRunner actually runs commands.
 type RealRunner struct{}
 
 var _ entrypoint.Runner = (*RealRunner)(nil)
 
-func (*RealRunner) Run(args ...string) error {
+func (*RealRunner) Run(ctx context.Context, args ...string) error {
 	if len(args) == 0 {
 		return nil
 	}
 	name, args := args[0], args[1:]
 
-	cmd := exec.Command(name, args...)
+	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -128,8 +122,16 @@ func (*RealPostWriter) Write(file string) {
 	}
 }
 
-type skipError string
+// RealTerminationWriter actually writes the termination message as JSON to
+// the container's termination message file.
+type RealTerminationWriter struct{ path string }
 
-func (e skipError) Error() string {
-	return string(e)
+var _ entrypoint.TerminationWriter = (*RealTerminationWriter)(nil)
+
+func (w *RealTerminationWriter) Write(m entrypoint.TerminationMessage) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(w.path, b, 0644)
 }
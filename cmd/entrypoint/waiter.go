@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+const (
+	// minPollInterval and maxPollInterval bound how often files are polled
+	// for when their directory can't be watched: first quickly, as steps
+	// usually follow each other, then backing off.
+	minPollInterval = 10 * time.Millisecond
+	maxPollInterval = time.Second
+)
+
+// RealWaiter actually waits for files, watching their directory for changes
+// with inotify, or polling for them where it isn't available.
+type RealWaiter struct {
+	// pollOnly disables watching, to measure the polling fallback.
+	pollOnly bool
+}
+
+var _ entrypoint.Waiter = (*RealWaiter)(nil)
+
+// changes notifies of changes to the files of a directory.
+type changes interface {
+	// next returns a channel receiving a value once the files may have
+	// changed since the previous call.
+	next() <-chan struct{}
+	close()
+}
+
+func (rw *RealWaiter) Wait(ctx context.Context, file string, expectContent bool) error {
+	if file == "" {
+		return nil
+	}
+	// Start watching before looking for the file, so that it can't be
+	// written in between unnoticed.
+	var c changes
+	if !rw.pollOnly {
+		// If inotify isn't available, or we're out of watches, we poll.
+		c, _ = watch(filepath.Dir(file))
+	}
+	if c == nil {
+		c = &poller{interval: minPollInterval}
+	}
+	defer c.close()
+	for {
+		// Watch for the post file
+		if info, err := os.Stat(file); err == nil {
+			if !expectContent || info.Size() > 0 {
+				return nil
+			}
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("Waiting for %q: %v", file, err)
+		}
+		// Watch for the post error file
+		if _, err := os.Stat(file + ".err"); err == nil {
+			return entrypoint.SkipError("error file present, bail and skip the step")
+		}
+		select {
+		case <-c.next():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// poller reports changes at intervals doubling from minPollInterval up to
+// maxPollInterval, as it can't tell whether files actually changed.
+type poller struct {
+	interval time.Duration
+}
+
+func (p *poller) next() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	time.AfterFunc(p.interval, func() { ch <- struct{}{} })
+	if p.interval *= 2; p.interval > maxPollInterval {
+		p.interval = maxPollInterval
+	}
+	return ch
+}
+
+func (p *poller) close() {}
@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// inotifyChanges reports the changes to the files of a directory notified
+// by inotify.
+type inotifyChanges struct {
+	file   *os.File
+	events chan struct{}
+	// failed is closed, before events, if reading the notifications fails,
+	// in which case the changes are polled for instead.
+	failed    chan struct{}
+	poller    *poller
+	closeOnce sync.Once
+}
+
+const watchMask = unix.IN_CREATE | unix.IN_MOVED_TO | unix.IN_CLOSE_WRITE | unix.IN_MODIFY | unix.IN_ATTRIB
+
+// watch starts watching the files of dir for changes.
+func watch(dir string) (changes, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := unix.InotifyAddWatch(fd, dir, watchMask); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	c := &inotifyChanges{
+		// As the descriptor is non-blocking, reading it doesn't tie up a
+		// thread and closing it interrupts the read.
+		file:   os.NewFile(uintptr(fd), "inotify"),
+		events: make(chan struct{}, 1),
+		failed: make(chan struct{}),
+		poller: &poller{interval: minPollInterval},
+	}
+	go c.read()
+	return c, nil
+}
+
+func (c *inotifyChanges) read() {
+	// Only the fact that something changed matters, so the events
+	// themselves aren't decoded.
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		if _, err := c.file.Read(buf); err != nil {
+			close(c.failed)
+			close(c.events)
+			return
+		}
+		select {
+		case c.events <- struct{}{}:
+		default:
+			// A change is already pending.
+		}
+	}
+}
+
+func (c *inotifyChanges) next() <-chan struct{} {
+	select {
+	case <-c.failed:
+		return c.poller.next()
+	default:
+		return c.events
+	}
+}
+
+func (c *inotifyChanges) close() {
+	// Closing the file waits for the pending read to be interrupted, which
+	// the step doesn't need to wait for.
+	c.closeOnce.Do(func() { go c.file.Close() })
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+func TestRealWaiter(t *testing.T) {
+	for _, pollOnly := range []bool{false, true} {
+		for _, c := range []struct {
+			desc          string
+			expectContent bool
+			write         func(file string) error
+			wantErr       error
+		}{{
+			desc: "file written",
+			write: func(file string) error {
+				return ioutil.WriteFile(file, nil, 0644)
+			},
+		}, {
+			desc:          "file written with content",
+			expectContent: true,
+			write: func(file string) error {
+				if err := ioutil.WriteFile(file, nil, 0644); err != nil {
+					return err
+				}
+				time.Sleep(20 * time.Millisecond)
+				return ioutil.WriteFile(file, []byte("READY"), 0644)
+			},
+		}, {
+			desc: "error file written",
+			write: func(file string) error {
+				return ioutil.WriteFile(file+".err", nil, 0644)
+			},
+			wantErr: entrypoint.SkipError("error file present, bail and skip the step"),
+		}} {
+			t.Run(c.desc+pollOnlySuffix(pollOnly), func(t *testing.T) {
+				dir := tempDir(t)
+				defer os.RemoveAll(dir)
+				file := filepath.Join(dir, "0")
+
+				errs := make(chan error, 1)
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					errs <- c.write(file)
+				}()
+				err := (&RealWaiter{pollOnly: pollOnly}).Wait(context.Background(), file, c.expectContent)
+				if err != c.wantErr {
+					t.Errorf("Wait() = %v, want %v", err, c.wantErr)
+				}
+				if err := <-errs; err != nil {
+					t.Fatalf("Writing file: %v", err)
+				}
+				if c.expectContent {
+					if b, _ := ioutil.ReadFile(file); len(b) == 0 {
+						t.Error("Wait() returned before the file had content")
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestRealWaiter_Cancelled(t *testing.T) {
+	for _, pollOnly := range []bool{false, true} {
+		t.Run("cancelled"+pollOnlySuffix(pollOnly), func(t *testing.T) {
+			dir := tempDir(t)
+			defer os.RemoveAll(dir)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(10*time.Millisecond, cancel)
+			err := (&RealWaiter{pollOnly: pollOnly}).Wait(ctx, filepath.Join(dir, "0"), false)
+			if err != context.Canceled {
+				t.Errorf("Wait() = %v, want %v", err, context.Canceled)
+			}
+		})
+	}
+}
+
+func TestRealWaiter_NoFile(t *testing.T) {
+	if err := (&RealWaiter{}).Wait(context.Background(), "", false); err != nil {
+		t.Errorf("Wait() = %v, want nil", err)
+	}
+}
+
+// BenchmarkRealWaiter measures how long it takes for a step to start once
+// the previous step has written its post file, watching for it.
+func BenchmarkRealWaiter(b *testing.B) {
+	benchmarkRealWaiter(b, &RealWaiter{})
+}
+
+// BenchmarkRealWaiter_Polling measures how long it takes for a step to start
+// once the previous step has written its post file, polling for it.
+func BenchmarkRealWaiter_Polling(b *testing.B) {
+	benchmarkRealWaiter(b, &RealWaiter{pollOnly: true})
+}
+
+func benchmarkRealWaiter(b *testing.B, rw *RealWaiter) {
+	dir := tempDir(b)
+	defer os.RemoveAll(dir)
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		file := filepath.Join(dir, strconv.Itoa(i))
+		done := make(chan error)
+		go func() { done <- rw.Wait(context.Background(), file, false) }()
+		// Let the waiter look for the file first, as the next step
+		// does while the previous one runs.
+		time.Sleep(time.Millisecond)
+		b.StartTimer()
+
+		if err := ioutil.WriteFile(file, nil, 0644); err != nil {
+			b.Fatalf("Writing file: %v", err)
+		}
+		if err := <-done; err != nil {
+			b.Fatalf("Wait() = %v", err)
+		}
+	}
+}
+
+func pollOnlySuffix(pollOnly bool) string {
+	if pollOnly {
+		return " polling"
+	}
+	return ""
+}
+
+func tempDir(tb testing.TB) string {
+	dir, err := ioutil.TempDir("", "waiter")
+	if err != nil {
+		tb.Fatalf("Creating temp dir: %v", err)
+	}
+	return dir
+}
@@ -83,11 +83,16 @@ func main() {
 		Client:  kubeClient,
 		Options: options,
 		Handlers: map[schema.GroupVersionKind]webhook.GenericCRD{
-			v1alpha1.SchemeGroupVersion.WithKind("Pipeline"):         &v1alpha1.Pipeline{},
-			v1alpha1.SchemeGroupVersion.WithKind("PipelineResource"): &v1alpha1.PipelineResource{},
-			v1alpha1.SchemeGroupVersion.WithKind("Task"):             &v1alpha1.Task{},
-			v1alpha1.SchemeGroupVersion.WithKind("TaskRun"):          &v1alpha1.TaskRun{},
-			v1alpha1.SchemeGroupVersion.WithKind("PipelineRun"):      &v1alpha1.PipelineRun{},
+			v1alpha1.SchemeGroupVersion.WithKind("Pipeline"):             &v1alpha1.Pipeline{},
+			v1alpha1.SchemeGroupVersion.WithKind("PipelineResource"):     &v1alpha1.PipelineResource{},
+			v1alpha1.SchemeGroupVersion.WithKind("Task"):                 &v1alpha1.Task{},
+			v1alpha1.SchemeGroupVersion.WithKind("TaskRun"):              &v1alpha1.TaskRun{},
+			v1alpha1.SchemeGroupVersion.WithKind("PipelineRun"):          &v1alpha1.PipelineRun{},
+			v1alpha1.SchemeGroupVersion.WithKind("Condition"):            &v1alpha1.Condition{},
+			v1alpha1.SchemeGroupVersion.WithKind("Approval"):             &v1alpha1.Approval{},
+			v1alpha1.SchemeGroupVersion.WithKind("Run"):                  &v1alpha1.Run{},
+			v1alpha1.SchemeGroupVersion.WithKind("ScheduledPipelineRun"): &v1alpha1.ScheduledPipelineRun{},
+			v1alpha1.SchemeGroupVersion.WithKind("EventTrigger"):         &v1alpha1.EventTrigger{},
 		},
 		Logger: logger,
 	}
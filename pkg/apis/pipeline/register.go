@@ -23,4 +23,13 @@ const (
 	TaskRunLabelKey     = "/taskRun"
 	PipelineLabelKey    = "/pipeline"
 	PipelineRunLabelKey = "/pipelineRun"
+	ConditionCheckKey   = "/conditionCheck"
+	ConditionNameKey    = "/conditionName"
+	ArtifactStorageKey  = "/artifactStorage"
+	// ConcurrencyGroupLabelKey labels the PipelineRuns with the hash of their concurrency group
+	ConcurrencyGroupLabelKey = "/concurrencyGroup"
+	// ScheduledPipelineRunLabelKey labels the PipelineRuns with the name of the ScheduledPipelineRun which created them
+	ScheduledPipelineRunLabelKey = "/scheduledPipelineRun"
+	// EventTriggerLabelKey labels the PipelineRuns with the name of the EventTrigger which created them
+	EventTriggerLabelKey = "/eventTrigger"
 )
@@ -0,0 +1,37 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/knative/pkg/apis"
+)
+
+func (a *Approval) SetDefaults(ctx context.Context) {
+	a.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults records the user setting the decision as the approver.
+func (as *ApprovalSpec) SetDefaults(ctx context.Context) {
+	if as.Decision == "" || as.Approver != "" {
+		return
+	}
+	if user := apis.GetUserInfo(ctx); user != nil {
+		as.Approver = user.Username
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Check that Approval may be validated and defaulted.
+var _ apis.Validatable = (*Approval)(nil)
+var _ apis.Defaultable = (*Approval)(nil)
+
+// ApprovalGate makes a PipelineTask wait for a manual approval instead of
+// running a Task. No pod is created: the PipelineTask succeeds once one of
+// its approvers approves the Approval created for it, and fails if one
+// rejects it or if nobody decides before the timeout of the PipelineTask.
+type ApprovalGate struct {
+	// Users are the names of the users allowed to approve or reject.
+	// +optional
+	Users []string `json:"users,omitempty"`
+	// Groups are the groups whose members are allowed to approve or reject.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// Message is shown to the approvers to explain what they are deciding on.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ApprovalDecision is the decision recorded by an approver on an Approval.
+type ApprovalDecision string
+
+const (
+	// ApprovalDecisionApproved lets the PipelineTask of the Approval succeed.
+	ApprovalDecisionApproved ApprovalDecision = "approved"
+	// ApprovalDecisionRejected makes the PipelineTask of the Approval fail.
+	ApprovalDecisionRejected ApprovalDecision = "rejected"
+)
+
+const (
+	// ApprovalReasonApproved indicates that an approver approved the Approval.
+	ApprovalReasonApproved = "Approved"
+	// ApprovalReasonRejected indicates that an approver rejected the Approval.
+	ApprovalReasonRejected = "Rejected"
+	// ApprovalReasonTimedOut indicates that nobody decided on the Approval
+	// before its timeout.
+	ApprovalReasonTimedOut = "ApprovalTimeout"
+	// ApprovalReasonCancelled indicates that the PipelineRun waiting for the
+	// Approval was cancelled or timed out before anyone decided.
+	ApprovalReasonCancelled = "ApprovalCancelled"
+	// ApprovalReasonWaiting indicates that the Approval waits for a decision.
+	ApprovalReasonWaiting = "WaitingForApproval"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Approval is created by a PipelineRun for a PipelineTask which is an
+// approval gate. An approver records their decision by setting its
+// spec.decision.
+// +k8s:openapi-gen=true
+type Approval struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ApprovalSpec `json:"spec,omitempty"`
+	// +optional
+	Status ApprovalStatus `json:"status,omitempty"`
+}
+
+// ApprovalSpec defines who may decide on the Approval, and the decision once
+// it is made.
+type ApprovalSpec struct {
+	ApprovalGate `json:",inline"`
+
+	// Timeout is the time after which the Approval fails if nobody decided.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Decision is set by an approver to approve or reject. It can't be
+	// changed once set.
+	// +optional
+	Decision ApprovalDecision `json:"decision,omitempty"`
+
+	// Approver is the name of the user who set the decision. It defaults to
+	// the user setting the decision, and can't be anyone else.
+	// +optional
+	Approver string `json:"approver,omitempty"`
+}
+
+// ApprovalStatus defines the observed state of Approval
+type ApprovalStatus struct {
+	duckv1beta1.Status `json:",inline"`
+
+	// StartTime is the time the Approval started waiting for a decision.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the Approval was decided or timed out.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+var approvalCondSet = apis.NewBatchConditionSet()
+
+// GetCondition returns the Condition matching the given type.
+func (as *ApprovalStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return approvalCondSet.Manage(as).GetCondition(t)
+}
+
+// InitializeConditions will set all conditions in approvalCondSet to unknown for the Approval
+// and set the started time to the current time
+func (as *ApprovalStatus) InitializeConditions() {
+	if as.StartTime.IsZero() {
+		as.StartTime = &metav1.Time{Time: time.Now()}
+	}
+	approvalCondSet.Manage(as).InitializeConditions()
+}
+
+// SetCondition sets the condition, unsetting previous conditions with the same
+// type as necessary.
+func (as *ApprovalStatus) SetCondition(newCond *apis.Condition) {
+	if newCond != nil {
+		approvalCondSet.Manage(as).SetCondition(*newCond)
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ApprovalList contains a list of Approval
+type ApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Approval `json:"items"`
+}
+
+// IsDone returns true if the Approval's status indicates that it was decided
+// or has timed out.
+func (a *Approval) IsDone() bool {
+	return !a.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
+}
+
+// HasStarted returns true if the Approval started waiting for a decision.
+func (a *Approval) HasStarted() bool {
+	return a.Status.StartTime != nil && !a.Status.StartTime.IsZero()
+}
+
+// IsDecided returns true if an approver set the decision of the Approval.
+func (a *Approval) IsDecided() bool {
+	return a.Spec.Decision != ""
+}
+
+// GetRunKey return the approval key for timeout handler map
+func (a *Approval) GetRunKey() string {
+	return fmt.Sprintf("Approval/%s/%s", a.Namespace, a.Name)
+}
+
+// IsApprover returns true if user is one of the users of g or a member of one
+// of its groups.
+func (g *ApprovalGate) IsApprover(user *authenticationv1.UserInfo) bool {
+	if user == nil {
+		return false
+	}
+	for _, u := range g.Users {
+		if u == user.Username {
+			return true
+		}
+	}
+	for _, group := range g.Groups {
+		for _, ug := range user.Groups {
+			if group == ug {
+				return true
+			}
+		}
+	}
+	return false
+}
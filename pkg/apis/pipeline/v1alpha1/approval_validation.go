@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// Validate checks that the Approval is valid, and on update that only one of
+// its approvers sets its decision, once.
+func (a *Approval) Validate(ctx context.Context) *apis.FieldError {
+	if err := validateObjectMetadata(a.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	if err := a.Spec.Validate(ctx); err != nil {
+		return err.ViaField("spec")
+	}
+	if apis.IsInCreate(ctx) && (a.Spec.Decision != "" || a.Spec.Approver != "") {
+		return apis.ErrDisallowedFields("spec.decision", "spec.approver")
+	}
+	if old, ok := apis.GetBaseline(ctx).(*Approval); ok && apis.IsInUpdate(ctx) {
+		return a.validateUpdate(ctx, old)
+	}
+	return nil
+}
+
+// Validate checks that the ApprovalSpec names approvers and holds a valid decision.
+func (as *ApprovalSpec) Validate(ctx context.Context) *apis.FieldError {
+	if err := as.ApprovalGate.Validate(ctx); err != nil {
+		return err
+	}
+	if as.Timeout != nil && as.Timeout.Duration <= 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", as.Timeout.Duration.String()), "timeout")
+	}
+	switch as.Decision {
+	case "", ApprovalDecisionApproved, ApprovalDecisionRejected:
+	default:
+		return apis.ErrInvalidValue(string(as.Decision), "decision")
+	}
+	if as.Decision == "" && as.Approver != "" {
+		return apis.ErrMissingField("decision")
+	}
+	return nil
+}
+
+// Validate checks that the ApprovalGate lets someone decide.
+func (g *ApprovalGate) Validate(ctx context.Context) *apis.FieldError {
+	if len(g.Users) == 0 && len(g.Groups) == 0 {
+		return apis.ErrMissingField("users", "groups")
+	}
+	return nil
+}
+
+// validateUpdate checks that the approvers of a, its timeout and a decision already
+// made aren't changed, and that a new decision is made by one of the approvers of old.
+func (a *Approval) validateUpdate(ctx context.Context, old *Approval) *apis.FieldError {
+	if !equality.Semantic.DeepEqual(a.Spec.ApprovalGate, old.Spec.ApprovalGate) || !equality.Semantic.DeepEqual(a.Spec.Timeout, old.Spec.Timeout) {
+		return &apis.FieldError{
+			Message: "the approvers and timeout of an Approval can't be changed",
+			Paths:   []string{"spec.users", "spec.groups", "spec.message", "spec.timeout"},
+		}
+	}
+	if old.IsDecided() {
+		if a.Spec.Decision != old.Spec.Decision || a.Spec.Approver != old.Spec.Approver {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("Approval was already %s by %s", old.Spec.Decision, old.Spec.Approver),
+				Paths:   []string{"spec.decision", "spec.approver"},
+			}
+		}
+		return nil
+	}
+	if !a.IsDecided() {
+		return nil
+	}
+	if old.IsDone() {
+		return &apis.FieldError{
+			Message: "Approval can't be decided on anymore: " + old.Status.GetCondition(apis.ConditionSucceeded).Message,
+			Paths:   []string{"spec.decision"},
+		}
+	}
+	user := apis.GetUserInfo(ctx)
+	if !old.Spec.IsApprover(user) {
+		username := ""
+		if user != nil {
+			username = user.Username
+		}
+		return &apis.FieldError{
+			Message: fmt.Sprintf("user %q isn't one of the approvers of the Approval", username),
+			Paths:   []string{"spec.decision"},
+		}
+	}
+	if a.Spec.Approver != user.Username {
+		return apis.ErrInvalidValue(a.Spec.Approver, "spec.approver")
+	}
+	return nil
+}
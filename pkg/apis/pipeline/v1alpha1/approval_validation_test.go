@@ -0,0 +1,152 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func withUser(ctx context.Context, name string, groups ...string) context.Context {
+	return apis.WithUserInfo(ctx, &authenticationv1.UserInfo{Username: name, Groups: groups})
+}
+
+func TestApproval_Validate_Valid(t *testing.T) {
+	waiting := tb.Approval("approval", "foo",
+		tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers")),
+		tb.ApprovalTimeout(time.Hour),
+		tb.ApprovalStatus(tb.ApprovalStartTime(time.Now())),
+	)
+	tests := []struct {
+		name string
+		a    *v1alpha1.Approval
+		ctx  context.Context
+	}{{
+		name: "created without decision",
+		a:    waiting,
+		ctx:  apis.WithinCreate(context.Background()),
+	}, {
+		name: "approved by a user",
+		a:    tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers")), tb.ApprovalTimeout(time.Hour), tb.ApprovalDecision(v1alpha1.ApprovalDecisionApproved, "alice")),
+		ctx:  withUser(apis.WithinUpdate(context.Background(), waiting), "alice"),
+	}, {
+		name: "rejected by a member of a group",
+		a:    tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers")), tb.ApprovalTimeout(time.Hour), tb.ApprovalDecision(v1alpha1.ApprovalDecisionRejected, "bob")),
+		ctx:  withUser(apis.WithinUpdate(context.Background(), waiting), "bob", "devs", "releasers"),
+	}, {
+		name: "status updated after the decision",
+		a:    tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice")), tb.ApprovalDecision(v1alpha1.ApprovalDecisionApproved, "alice")),
+		ctx:  withUser(apis.WithinUpdate(context.Background(), tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice")), tb.ApprovalDecision(v1alpha1.ApprovalDecisionApproved, "alice"))), "controller"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.a.Validate(tt.ctx); err != nil {
+				t.Errorf("Approval.Validate() returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestApproval_Validate_Error(t *testing.T) {
+	waiting := tb.Approval("approval", "foo",
+		tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers")),
+		tb.ApprovalStatus(tb.ApprovalStartTime(time.Now())),
+	)
+	approved := tb.Approval("approval", "foo",
+		tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers")),
+		tb.ApprovalDecision(v1alpha1.ApprovalDecisionApproved, "alice"),
+	)
+	timedOut := tb.Approval("approval", "foo",
+		tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers")),
+		tb.ApprovalStatus(tb.ApprovalStartTime(time.Now()), tb.ApprovalStatusCondition(apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1alpha1.ApprovalReasonTimedOut,
+			Message: "timed out",
+		})),
+	)
+	tests := []struct {
+		name string
+		a    *v1alpha1.Approval
+		ctx  context.Context
+	}{{
+		name: "no approvers",
+		a:    tb.Approval("approval", "foo"),
+		ctx:  context.Background(),
+	}, {
+		name: "invalid decision",
+		a:    tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice")), tb.ApprovalDecision("maybe", "alice")),
+		ctx:  context.Background(),
+	}, {
+		name: "negative timeout",
+		a:    tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice")), tb.ApprovalTimeout(-time.Minute)),
+		ctx:  context.Background(),
+	}, {
+		name: "created with a decision",
+		a:    approved,
+		ctx:  withUser(apis.WithinCreate(context.Background()), "alice"),
+	}, {
+		name: "decided by someone else",
+		a:    tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers")), tb.ApprovalDecision(v1alpha1.ApprovalDecisionApproved, "mallory")),
+		ctx:  withUser(apis.WithinUpdate(context.Background(), waiting), "mallory", "devs"),
+	}, {
+		name: "decided on behalf of another approver",
+		a:    tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers")), tb.ApprovalDecision(v1alpha1.ApprovalDecisionApproved, "alice")),
+		ctx:  withUser(apis.WithinUpdate(context.Background(), waiting), "bob", "releasers"),
+	}, {
+		name: "approvers added before deciding",
+		a:    tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice", "mallory"), tb.ApprovalGroups("releasers")), tb.ApprovalDecision(v1alpha1.ApprovalDecisionApproved, "mallory")),
+		ctx:  withUser(apis.WithinUpdate(context.Background(), waiting), "mallory"),
+	}, {
+		name: "decision changed",
+		a:    tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers")), tb.ApprovalDecision(v1alpha1.ApprovalDecisionRejected, "alice")),
+		ctx:  withUser(apis.WithinUpdate(context.Background(), approved), "alice"),
+	}, {
+		name: "decided after timing out",
+		a:    tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers")), tb.ApprovalDecision(v1alpha1.ApprovalDecisionApproved, "alice")),
+		ctx:  withUser(apis.WithinUpdate(context.Background(), timedOut), "alice"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.a.Validate(tt.ctx); err == nil {
+				t.Errorf("Expected an error, got nothing for %v", tt.a)
+			}
+		})
+	}
+}
+
+func TestApproval_SetDefaults(t *testing.T) {
+	a := tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice")))
+	a.Spec.Decision = v1alpha1.ApprovalDecisionApproved
+	a.SetDefaults(withUser(context.Background(), "alice"))
+	if a.Spec.Approver != "alice" {
+		t.Errorf("Expected the approver to default to alice but was %q", a.Spec.Approver)
+	}
+
+	waiting := tb.Approval("approval", "foo", tb.ApprovalApprovers(tb.ApprovalUsers("alice")))
+	waiting.SetDefaults(withUser(context.Background(), "alice"))
+	if waiting.Spec.Approver != "" {
+		t.Errorf("Expected no approver without a decision but was %q", waiting.Spec.Approver)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/knative/pkg/apis"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Check that Condition may be validated and defaulted.
+var _ apis.Validatable = (*Condition)(nil)
+var _ apis.Defaultable = (*Condition)(nil)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Condition declares a check that is run before a PipelineTask to decide
+// whether the PipelineTask should be executed: the check passes if its
+// container exits successfully.
+// +k8s:openapi-gen=true
+type Condition struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the Condition from the client
+	// +optional
+	Spec ConditionSpec `json:"spec"`
+}
+
+// ConditionSpec defines the desired state of the Condition
+type ConditionSpec struct {
+	// Check declares the container which is run to evaluate the Condition;
+	// the Condition is true if the container exits with code 0.
+	Check corev1.Container `json:"check"`
+
+	// Params is the list of parameters the check can use, the same way
+	// the steps of a Task use its input parameters.
+	// +optional
+	Params []TaskParam `json:"params,omitempty"`
+
+	// Resources is the list of PipelineResources the check needs, the same
+	// way the steps of a Task use its input resources.
+	// +optional
+	Resources []TaskResource `json:"resources,omitempty"`
+}
+
+// TaskSpec returns the spec of a Task with a single step running the check
+// of the Condition, which is how the check gets executed.
+func (cs *ConditionSpec) TaskSpec() *TaskSpec {
+	ts := &TaskSpec{
+		Steps: []Step{{Container: *cs.Check.DeepCopy()}},
+	}
+	if len(cs.Params) > 0 || len(cs.Resources) > 0 {
+		ts.Inputs = &Inputs{
+			Params:    cs.Params,
+			Resources: cs.Resources,
+		}
+	}
+	return ts
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConditionList contains a list of Conditions
+type ConditionList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Condition `json:"items"`
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// Validate checks that the Condition and its check are valid.
+func (c *Condition) Validate(ctx context.Context) *apis.FieldError {
+	if err := validateObjectMetadata(c.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return c.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate checks that the check of the Condition is a valid step using
+// only the parameters and resources that the Condition declares.
+func (cs *ConditionSpec) Validate(ctx context.Context) *apis.FieldError {
+	if equality.Semantic.DeepEqual(cs, &ConditionSpec{}) {
+		return apis.ErrMissingField(apis.CurrentField)
+	}
+	if cs.Check.Image == "" {
+		return apis.ErrMissingField("check.image")
+	}
+	for _, resource := range cs.Resources {
+		if err := validateResourceType(resource, fmt.Sprintf("resources.%s.type", resource.Name)); err != nil {
+			return err
+		}
+	}
+	if err := checkForDuplicates(cs.Resources, "resources.name"); err != nil {
+		return err
+	}
+
+	ts := cs.TaskSpec()
+	if err := validateInputParameterVariables(ts.Steps, ts.Inputs); err != nil {
+		return err
+	}
+	return validateResourceVariables(ts.Steps, ts.Inputs, nil)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+)
+
+func TestCondition_Validate_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *v1alpha1.Condition
+	}{{
+		name: "check only",
+		c: tb.ConditionCRD("condition", "foo", tb.ConditionSpec(
+			tb.ConditionSpecCheck("check", "ubuntu", tb.Command("true")),
+		)),
+	}, {
+		name: "check using params and resources",
+		c: tb.ConditionCRD("condition", "foo", tb.ConditionSpec(
+			tb.ConditionParam("path", tb.ParamDefault("README.md")),
+			tb.ConditionResource("workspace", v1alpha1.PipelineResourceTypeGit),
+			tb.ConditionSpecCheck("check", "ubuntu", tb.Command("test"),
+				tb.Args("-f", "${inputs.resources.workspace.path}/${inputs.params.path}")),
+		)),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(context.Background()); err != nil {
+				t.Errorf("Condition.Validate() returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCondition_Validate_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *v1alpha1.Condition
+	}{{
+		name: "empty spec",
+		c:    tb.ConditionCRD("condition", "foo"),
+	}, {
+		name: "check without image",
+		c: tb.ConditionCRD("condition", "foo", tb.ConditionSpec(
+			tb.ConditionSpecCheck("check", "", tb.Command("true")),
+		)),
+	}, {
+		name: "invalid name",
+		c: tb.ConditionCRD("condition.name", "foo", tb.ConditionSpec(
+			tb.ConditionSpecCheck("check", "ubuntu", tb.Command("true")),
+		)),
+	}, {
+		name: "invalid resource type",
+		c: tb.ConditionCRD("condition", "foo", tb.ConditionSpec(
+			tb.ConditionResource("workspace", "not-a-type"),
+			tb.ConditionSpecCheck("check", "ubuntu", tb.Command("true")),
+		)),
+	}, {
+		name: "duplicated resources",
+		c: tb.ConditionCRD("condition", "foo", tb.ConditionSpec(
+			tb.ConditionResource("workspace", v1alpha1.PipelineResourceTypeGit),
+			tb.ConditionResource("workspace", v1alpha1.PipelineResourceTypeGit),
+			tb.ConditionSpecCheck("check", "ubuntu", tb.Command("true")),
+		)),
+	}, {
+		name: "undeclared param",
+		c: tb.ConditionCRD("condition", "foo", tb.ConditionSpec(
+			tb.ConditionSpecCheck("check", "ubuntu", tb.Command("echo"), tb.Args("${inputs.params.missing}")),
+		)),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.c.Validate(context.Background()); err == nil {
+				t.Error("Condition.Validate() did not return error, wanted error")
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -73,7 +74,7 @@ func visit(currentName string, nodes []*Node, path []string, visited map[string]
 	for _, n := range nodes {
 		path = append(path, n.Task.Name)
 		if _, ok := visited[n.Task.Name]; ok {
-			return fmt.Errorf(getVisitedPath(path))
+			return errors.New(getVisitedPath(path))
 		}
 		visited[currentName+"."+n.Task.Name] = true
 		if err := visit(n.Task.Name, n.Prev, path, visited); err != nil {
@@ -114,7 +115,7 @@ func BuildDAG(tasks []PipelineTask) (*DAG, error) {
 			return nil, fmt.Errorf("task %s is already present in DAG, can't add it again: %v", pt.Name, err)
 		}
 	}
-	// Process all from and runAfter constraints to add task dependency
+	// Process all from, runAfter and result reference constraints to add task dependency
 	for _, pt := range tasks {
 		for _, previousTask := range pt.RunAfter {
 			if err := addLink(pt, previousTask, d.Nodes); err != nil {
@@ -130,6 +131,51 @@ func BuildDAG(tasks []PipelineTask) (*DAG, error) {
 				}
 			}
 		}
+		for _, ref := range GetPipelineTaskResultRefs(pt) {
+			if err := addLink(pt, ref.PipelineTask, d.Nodes); err != nil {
+				return nil, fmt.Errorf("couldn't add link between %s and %s: %v", pt.Name, ref.PipelineTask, err)
+			}
+		}
 	}
 	return d, nil
 }
+
+// CheckPipelineRefCycles returns an error if the Pipelines referenced by tasks, and in turn by
+// the PipelineTasks of these Pipelines, end up referencing the Pipeline named name, or form a
+// cycle among themselves. getPipeline is used to retrieve the referenced Pipelines.
+func CheckPipelineRefCycles(name string, tasks []PipelineTask, getPipeline func(name string) (*Pipeline, error)) error {
+	path := []string{}
+	if name != "" {
+		path = append(path, name)
+	}
+	return visitPipelineRefs(tasks, path, map[string]bool{}, getPipeline)
+}
+
+func visitPipelineRefs(tasks []PipelineTask, path []string, checked map[string]bool, getPipeline func(name string) (*Pipeline, error)) error {
+	for _, pt := range tasks {
+		if pt.PipelineRef == nil {
+			continue
+		}
+		ref := pt.PipelineRef.Name
+		refPath := make([]string, len(path), len(path)+1)
+		copy(refPath, path)
+		refPath = append(refPath, ref)
+		for _, name := range path {
+			if name == ref {
+				return fmt.Errorf("cycle detected: %s", strings.Join(refPath, " -> "))
+			}
+		}
+		if checked[ref] {
+			continue
+		}
+		p, err := getPipeline(ref)
+		if err != nil {
+			return fmt.Errorf("couldn't get Pipeline %s run by PipelineTask %s: %v", ref, pt.Name, err)
+		}
+		if err := visitPipelineRefs(append(append([]PipelineTask{}, p.Spec.Tasks...), p.Spec.Finally...), refPath, checked, getPipeline); err != nil {
+			return err
+		}
+		checked[ref] = true
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/tektoncd/pipeline/pkg/list"
@@ -231,6 +232,45 @@ func TestBuild_FanInFanOut(t *testing.T) {
 	assertSameDAG(t, expectedDAG, g)
 }
 
+func TestBuild_ResultReferences(t *testing.T) {
+	a := PipelineTask{Name: "a"}
+	b := PipelineTask{Name: "b"}
+	cUsesAAndB := PipelineTask{
+		Name: "c",
+		Params: []Param{{
+			Name:  "image",
+			Value: "gcr.io/foo@${tasks.a.results.digest}",
+		}, {
+			Name:  "version",
+			Value: "${tasks.b.results.version}",
+		}},
+	}
+
+	//   a   b
+	//    \ /
+	//     c
+	nodeA := &Node{Task: a}
+	nodeB := &Node{Task: b}
+	nodeC := &Node{Task: cUsesAAndB}
+
+	nodeA.Next = []*Node{nodeC}
+	nodeB.Next = []*Node{nodeC}
+	nodeC.Prev = []*Node{nodeA, nodeB}
+
+	expectedDAG := &DAG{
+		Nodes: map[string]*Node{
+			"a": nodeA,
+			"b": nodeB,
+			"c": nodeC,
+		},
+	}
+	g, err := BuildDAG([]PipelineTask{a, b, cUsesAAndB})
+	if err != nil {
+		t.Fatalf("didn't expect error creating valid Pipeline but got %v", err)
+	}
+	assertSameDAG(t, expectedDAG, g)
+}
+
 func TestBuild_Invalid(t *testing.T) {
 	a := PipelineTask{Name: "a"}
 	xDependsOnA := PipelineTask{
@@ -283,6 +323,14 @@ func TestBuild_Invalid(t *testing.T) {
 		Name:     "a",
 		RunAfter: []string{"none"},
 	}
+	invalidTaskResult := PipelineTask{
+		Name:   "a",
+		Params: []Param{{Name: "foo", Value: "${tasks.none.results.bar}"}},
+	}
+	aUsesZResult := PipelineTask{
+		Name:   "a",
+		Params: []Param{{Name: "foo", Value: "${tasks.z.results.bar}"}},
+	}
 
 	tcs := []struct {
 		name string
@@ -311,6 +359,12 @@ func TestBuild_Invalid(t *testing.T) {
 	}, {
 		name: "invalid-task-name-after",
 		spec: PipelineSpec{Tasks: []PipelineTask{invalidTaskAfter}},
+	}, {
+		name: "invalid-task-name-result",
+		spec: PipelineSpec{Tasks: []PipelineTask{invalidTaskResult}},
+	}, {
+		name: "cycle-result",
+		spec: PipelineSpec{Tasks: []PipelineTask{xAfterA, zAfterX, aUsesZResult}},
 	},
 	}
 	for _, tc := range tcs {
@@ -325,3 +379,62 @@ func TestBuild_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckPipelineRefCycles(t *testing.T) {
+	nested := func(name string, refs ...string) *Pipeline {
+		p := &Pipeline{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for _, ref := range refs {
+			p.Spec.Tasks = append(p.Spec.Tasks, PipelineTask{Name: "run-" + ref, PipelineRef: &PipelineRef{Name: ref}})
+		}
+		p.Spec.Tasks = append(p.Spec.Tasks, PipelineTask{Name: "task", TaskRef: &TaskRef{Name: "task"}})
+		return p
+	}
+	tcs := []struct {
+		name      string
+		pipelines []*Pipeline
+		expected  string
+	}{{
+		name:      "no nested pipelines",
+		pipelines: []*Pipeline{nested("root")},
+	}, {
+		name:      "nested pipelines",
+		pipelines: []*Pipeline{nested("root", "build", "test"), nested("build"), nested("test", "build")},
+	}, {
+		name:      "self reference",
+		pipelines: []*Pipeline{nested("root", "root")},
+		expected:  "cycle detected: root -> root",
+	}, {
+		name:      "indirect cycle",
+		pipelines: []*Pipeline{nested("root", "build"), nested("build", "test"), nested("test", "root")},
+		expected:  "cycle detected: root -> build -> test -> root",
+	}, {
+		name:      "cycle among nested pipelines",
+		pipelines: []*Pipeline{nested("root", "build"), nested("build", "test"), nested("test", "build")},
+		expected:  "cycle detected: root -> build -> test -> build",
+	}, {
+		name:      "missing nested pipeline",
+		pipelines: []*Pipeline{nested("root", "build")},
+		expected:  "couldn't get Pipeline build run by PipelineTask run-build: not found",
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			pipelines := map[string]*Pipeline{}
+			for _, p := range tc.pipelines {
+				pipelines[p.Name] = p
+			}
+			getPipeline := func(name string) (*Pipeline, error) {
+				if p, ok := pipelines[name]; ok {
+					return p, nil
+				}
+				return nil, errors.New("not found")
+			}
+			err := CheckPipelineRefCycles("root", tc.pipelines[0].Spec.Tasks, getPipeline)
+			switch {
+			case tc.expected == "" && err != nil:
+				t.Errorf("Expected no error but got %v", err)
+			case tc.expected != "" && (err == nil || err.Error() != tc.expected):
+				t.Errorf("Expected error %q but got %v", tc.expected, err)
+			}
+		})
+	}
+}
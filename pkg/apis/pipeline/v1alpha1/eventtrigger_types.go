@@ -0,0 +1,129 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/knative/pkg/apis"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Check that EventTrigger may be validated and defaulted.
+var _ apis.Validatable = (*EventTrigger)(nil)
+var _ apis.Defaultable = (*EventTrigger)(nil)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EventTrigger describes how the event listener turns the events it receives, such as the
+// webhooks of a Git provider, into PipelineRuns.
+// +k8s:openapi-gen=true
+type EventTrigger struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec EventTriggerSpec `json:"spec,omitempty"`
+}
+
+// EventTriggerSpec defines which events trigger a PipelineRun, and how the PipelineRun is
+// created from the payload of the event.
+type EventTriggerSpec struct {
+	// EventTypeHeader is the header holding the type of the event, such as
+	// X-GitHub-Event or X-Gitlab-Event.
+	// +optional
+	EventTypeHeader string `json:"eventTypeHeader,omitempty"`
+
+	// EventTypes are the types of the events which trigger a PipelineRun. All
+	// the events do if it is empty.
+	// +optional
+	EventTypes []string `json:"eventTypes,omitempty"`
+
+	// DeliveryIDHeader is the header holding the unique ID of the delivery of
+	// the event, such as X-GitHub-Delivery.
+	// +optional
+	DeliveryIDHeader string `json:"deliveryIDHeader,omitempty"`
+
+	// Signature verifies that the events were signed with a secret shared with
+	// their source. It is required unless InsecureSkipSignature is set.
+	// +optional
+	Signature *EventSignature `json:"signature,omitempty"`
+
+	// InsecureSkipSignature accepts the events which aren't signed, from anyone
+	// who can reach the event listener, instead of a Signature.
+	// +optional
+	InsecureSkipSignature bool `json:"insecureSkipSignature,omitempty"`
+
+	// Params are added to the params of the PipelineRun. Their values are
+	// JSONPath templates over the body and the headers of the event.
+	// +optional
+	Params []Param `json:"params,omitempty"`
+
+	// Resources are created for each event, and bound to the PipelineRun. The
+	// values of their params are JSONPath templates over the body and the
+	// headers of the event.
+	// +optional
+	Resources []EventResource `json:"resources,omitempty"`
+
+	// PipelineRunTemplate is the PipelineRun to create for each event.
+	PipelineRunTemplate PipelineRunTemplate `json:"pipelineRunTemplate"`
+}
+
+// EventSignature describes how the events are signed, either with the HMAC of their body
+// or with a token.
+type EventSignature struct {
+	// Type is how the events are signed. Defaults to hmac.
+	// +optional
+	Type EventSignatureType `json:"type,omitempty"`
+	// Header is the header holding the signature. For hmac, it is hex encoded and
+	// prefixed by the hash function, such as X-Hub-Signature-256 holding
+	// sha256=<signature>. For token, it is the secret itself, such as
+	// X-Gitlab-Token.
+	Header string `json:"header"`
+	// SecretRef is the key of the Secret holding the secret the events are
+	// signed with.
+	SecretRef corev1.SecretKeySelector `json:"secretRef"`
+}
+
+// EventSignatureType is how the events sent to an EventTrigger are signed
+type EventSignatureType string
+
+const (
+	// EventSignatureTypeHMAC indicates that the events are signed with the HMAC of their body
+	EventSignatureTypeHMAC EventSignatureType = "hmac"
+	// EventSignatureTypeToken indicates that the events hold the secret itself in a header
+	EventSignatureTypeToken EventSignatureType = "token"
+)
+
+// EventResource is a PipelineResource created for each event and bound to the PipelineRun.
+type EventResource struct {
+	// Name is the name of the PipelineResource in the Pipeline's declaration.
+	Name string `json:"name"`
+	// Spec is the spec of the PipelineResource.
+	Spec PipelineResourceSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EventTriggerList contains a list of EventTrigger
+type EventTriggerList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EventTrigger `json:"items"`
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Validate checks that the EventTrigger has valid filters, field extractions and PipelineRun template.
+func (et *EventTrigger) Validate(ctx context.Context) *apis.FieldError {
+	if err := validateObjectMetadata(et.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return et.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate checks that the events are signed unless explicitly allowed not to be, that the
+// values of the params and resources are valid JSONPath templates, and that the template is
+// a valid PipelineRun.
+func (ets *EventTriggerSpec) Validate(ctx context.Context) *apis.FieldError {
+	if len(ets.EventTypes) > 0 && ets.EventTypeHeader == "" {
+		return apis.ErrMissingField("eventTypeHeader")
+	}
+	if ets.Signature == nil && !ets.InsecureSkipSignature {
+		return apis.ErrMissingField("signature")
+	}
+	if ets.Signature != nil && ets.InsecureSkipSignature {
+		return apis.ErrMultipleOneOf("signature", "insecureSkipSignature")
+	}
+	if s := ets.Signature; s != nil {
+		switch s.Type {
+		case "", EventSignatureTypeHMAC, EventSignatureTypeToken:
+		default:
+			return apis.ErrInvalidValue(string(s.Type), "signature.type")
+		}
+		if s.Header == "" {
+			return apis.ErrMissingField("signature.header")
+		}
+		if s.SecretRef.Name == "" || s.SecretRef.Key == "" {
+			return apis.ErrMissingField("signature.secretRef.name", "signature.secretRef.key")
+		}
+	}
+
+	names := map[string]struct{}{}
+	for i, p := range ets.Params {
+		if p.Name == "" {
+			return apis.ErrMissingField(fmt.Sprintf("params[%d].name", i))
+		}
+		if _, ok := names[p.Name]; ok {
+			return apis.ErrMultipleOneOf(fmt.Sprintf("params[%d].name", i))
+		}
+		names[p.Name] = struct{}{}
+		if err := validateJSONPathTemplate(p.Value); err != nil {
+			return apis.ErrInvalidValue(err.Error(), fmt.Sprintf("params[%d].value", i))
+		}
+	}
+
+	names = map[string]struct{}{}
+	for i, r := range ets.Resources {
+		if r.Name == "" {
+			return apis.ErrMissingField(fmt.Sprintf("resources[%d].name", i))
+		}
+		if _, ok := names[r.Name]; ok {
+			return apis.ErrMultipleOneOf(fmt.Sprintf("resources[%d].name", i))
+		}
+		names[r.Name] = struct{}{}
+		if !isKnownResourceType(r.Spec.Type) {
+			return apis.ErrInvalidValue(string(r.Spec.Type), fmt.Sprintf("resources[%d].spec.type", i))
+		}
+		for j, p := range r.Spec.Params {
+			if err := validateJSONPathTemplate(p.Value); err != nil {
+				return apis.ErrInvalidValue(err.Error(), fmt.Sprintf("resources[%d].spec.params[%d].value", i, j))
+			}
+		}
+	}
+
+	template := ets.PipelineRunTemplate.Spec
+	if template.Trigger.Type == "" {
+		template.Trigger.Type = PipelineTriggerTypeEvent
+	}
+	if template.Status != "" {
+		return apis.ErrDisallowedFields("pipelineRunTemplate.spec.status")
+	}
+	if template.ResumeFrom != nil {
+		return apis.ErrDisallowedFields("pipelineRunTemplate.spec.resumeFrom")
+	}
+	// The resources created from the events are bound to the PipelineRuns as well
+	template.Resources = append([]PipelineResourceBinding{}, template.Resources...)
+	for _, r := range ets.Resources {
+		template.Resources = append(template.Resources, PipelineResourceBinding{Name: r.Name})
+	}
+	if err := template.Validate(ctx); err != nil {
+		return err.ViaField("pipelineRunTemplate")
+	}
+	return nil
+}
+
+func isKnownResourceType(t PipelineResourceType) bool {
+	for _, allowed := range AllResourceTypes {
+		if t == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func validateJSONPathTemplate(template string) error {
+	return jsonpath.New("").Parse(template)
+}
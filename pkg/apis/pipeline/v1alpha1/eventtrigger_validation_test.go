@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+)
+
+var signed = tb.EventTriggerSignature("X-Hub-Signature-256", "webhook", "secret")
+
+func TestEventTrigger_Validate_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		et   *v1alpha1.EventTrigger
+	}{{
+		name: "all events",
+		et:   tb.EventTrigger("push", "foo", "pipeline", signed),
+	}, {
+		name: "unsigned events",
+		et:   tb.EventTrigger("push", "foo", "pipeline", tb.EventTriggerInsecureSkipSignature()),
+	}, {
+		name: "signed push events",
+		et: tb.EventTrigger("push", "foo", "pipeline",
+			tb.EventTriggerEventTypes("X-GitHub-Event", "push"),
+			tb.EventTriggerDeliveryIDHeader("X-GitHub-Delivery"),
+			tb.EventTriggerSignature("X-Hub-Signature-256", "webhook", "secret"),
+		),
+	}, {
+		name: "token signature",
+		et: tb.EventTrigger("push", "foo", "pipeline",
+			tb.EventTriggerSignature("X-Gitlab-Token", "webhook", "secret", tb.EventSignatureType(v1alpha1.EventSignatureTypeToken)),
+		),
+	}, {
+		name: "params and resources",
+		et: tb.EventTrigger("push", "foo", "pipeline", signed,
+			tb.EventTriggerParam("revision", "{.body.head_commit.id}"),
+			tb.EventTriggerParam("ref", "refs/heads/{.body.repository.default_branch}"),
+			tb.EventTriggerResource("source", v1alpha1.PipelineResourceTypeGit,
+				tb.PipelineResourceSpecParam("url", "{.body.repository.clone_url}"),
+			),
+		),
+	}, {
+		name: "commit status on a resource of the event",
+		et: tb.EventTrigger("push", "foo", "pipeline", signed,
+			tb.EventTriggerResource("source", v1alpha1.PipelineResourceTypeGit,
+				tb.PipelineResourceSpecParam("url", "{.body.repository.clone_url}"),
+				tb.PipelineResourceSpecParam("revision", "{.body.head_commit.id}"),
+			),
+			tb.EventTriggerTemplateSpec(
+				tb.PipelineRunCommitStatus("source", v1alpha1.CommitStatusProviderGitHub, "github", "token"),
+			),
+		),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.et.Validate(context.Background()); err != nil {
+				t.Errorf("EventTrigger.Validate() returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestEventTrigger_Validate_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		et   *v1alpha1.EventTrigger
+	}{{
+		name: "no signature",
+		et:   tb.EventTrigger("push", "foo", "pipeline"),
+	}, {
+		name: "signature and unsigned events",
+		et:   tb.EventTrigger("push", "foo", "pipeline", signed, tb.EventTriggerInsecureSkipSignature()),
+	}, {
+		name: "event types without header",
+		et:   tb.EventTrigger("push", "foo", "pipeline", signed, tb.EventTriggerEventTypes("", "push")),
+	}, {
+		name: "signature without header",
+		et:   tb.EventTrigger("push", "foo", "pipeline", tb.EventTriggerSignature("", "webhook", "secret")),
+	}, {
+		name: "signature without secret key",
+		et:   tb.EventTrigger("push", "foo", "pipeline", tb.EventTriggerSignature("X-Hub-Signature-256", "webhook", "")),
+	}, {
+		name: "unknown signature type",
+		et: tb.EventTrigger("push", "foo", "pipeline",
+			tb.EventTriggerSignature("X-Hub-Signature-256", "webhook", "secret", tb.EventSignatureType("rsa")),
+		),
+	}, {
+		name: "invalid JSONPath",
+		et:   tb.EventTrigger("push", "foo", "pipeline", signed, tb.EventTriggerParam("revision", "{.body.head_commit.id")),
+	}, {
+		name: "duplicate params",
+		et: tb.EventTrigger("push", "foo", "pipeline", signed,
+			tb.EventTriggerParam("revision", "{.body.after}"),
+			tb.EventTriggerParam("revision", "{.body.head_commit.id}"),
+		),
+	}, {
+		name: "unknown resource type",
+		et:   tb.EventTrigger("push", "foo", "pipeline", signed, tb.EventTriggerResource("source", "svn")),
+	}, {
+		name: "invalid JSONPath in resource",
+		et: tb.EventTrigger("push", "foo", "pipeline", signed, tb.EventTriggerResource("source", v1alpha1.PipelineResourceTypeGit,
+			tb.PipelineResourceSpecParam("url", "{.body.repository[}"),
+		)),
+	}, {
+		name: "no pipeline",
+		et:   tb.EventTrigger("push", "foo", "", signed),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.et.Validate(context.Background()); err == nil {
+				t.Errorf("Expected an error, got nothing for %v", tt.et)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// MatrixParam declares the values a param of a PipelineTask takes in turn, each
+// combination of the values of the matrix params being run by a separate TaskRun.
+type MatrixParam struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// GetMatrixCombinations returns every combination of the values of the matrix
+// params of pt, in a deterministic order where the values of the first matrix
+// param change the least often. It returns nil if pt doesn't fan out over a matrix.
+func (pt PipelineTask) GetMatrixCombinations() [][]Param {
+	if len(pt.Matrix) == 0 {
+		return nil
+	}
+	combinations := [][]Param{{}}
+	for _, mp := range pt.Matrix {
+		next := make([][]Param, 0, len(combinations)*len(mp.Values))
+		for _, c := range combinations {
+			for _, v := range mp.Values {
+				combination := make([]Param, len(c), len(c)+1)
+				copy(combination, c)
+				next = append(next, append(combination, Param{Name: mp.Name, Value: v}))
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetMatrixCombinations(t *testing.T) {
+	tcs := []struct {
+		name     string
+		matrix   []MatrixParam
+		expected [][]Param
+	}{{
+		name:     "no matrix",
+		expected: nil,
+	}, {
+		name: "single matrix param",
+		matrix: []MatrixParam{{
+			Name:   "go-version",
+			Values: []string{"1.11", "1.12"},
+		}},
+		expected: [][]Param{
+			{{Name: "go-version", Value: "1.11"}},
+			{{Name: "go-version", Value: "1.12"}},
+		},
+	}, {
+		name: "multiple matrix params",
+		matrix: []MatrixParam{{
+			Name:   "go-version",
+			Values: []string{"1.11", "1.12"},
+		}, {
+			Name:   "os",
+			Values: []string{"ubuntu", "alpine", "debian"},
+		}},
+		expected: [][]Param{
+			{{Name: "go-version", Value: "1.11"}, {Name: "os", Value: "ubuntu"}},
+			{{Name: "go-version", Value: "1.11"}, {Name: "os", Value: "alpine"}},
+			{{Name: "go-version", Value: "1.11"}, {Name: "os", Value: "debian"}},
+			{{Name: "go-version", Value: "1.12"}, {Name: "os", Value: "ubuntu"}},
+			{{Name: "go-version", Value: "1.12"}, {Name: "os", Value: "alpine"}},
+			{{Name: "go-version", Value: "1.12"}, {Name: "os", Value: "debian"}},
+		},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			pt := PipelineTask{Name: "test", Matrix: tc.matrix}
+			if d := cmp.Diff(tc.expected, pt.GetMatrixCombinations()); d != "" {
+				t.Errorf("GetMatrixCombinations diff -want, +got: %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// ParamType indicates the type of the values a declared param takes.
+type ParamType string
+
+const (
+	// ParamTypeString indicates that the param takes a single string. It is the
+	// type of the params which don't declare one.
+	ParamTypeString ParamType = "string"
+	// ParamTypeArray indicates that the param takes a list of strings.
+	ParamTypeArray ParamType = "array"
+)
+
+// AllParamTypes can be used for ParamType validation.
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray}
+
+// MarshalJSON writes the value of p as a list if it is an array.
+func (p Param) MarshalJSON() ([]byte, error) {
+	type param Param
+	return json.Marshal(struct {
+		param
+		Value interface{} `json:"value"`
+	}{param: param(p), Value: stringOrArray(p.Value, p.ArrayValue, false)})
+}
+
+// UnmarshalJSON sets ArrayValue instead of Value if the value of p is a list.
+func (p *Param) UnmarshalJSON(data []byte) error {
+	type param Param
+	aux := struct {
+		*param
+		Value json.RawMessage `json:"value"`
+	}{param: (*param)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	p.Value, p.ArrayValue, err = unmarshalStringOrArray(aux.Value)
+	return err
+}
+
+// MarshalJSON writes the default of tp as a list if it is an array.
+func (tp TaskParam) MarshalJSON() ([]byte, error) {
+	type taskParam TaskParam
+	return json.Marshal(struct {
+		taskParam
+		Default interface{} `json:"default,omitempty"`
+	}{taskParam: taskParam(tp), Default: stringOrArray(tp.Default, tp.ArrayDefault, true)})
+}
+
+// UnmarshalJSON sets ArrayDefault instead of Default if the default of tp is a list.
+func (tp *TaskParam) UnmarshalJSON(data []byte) error {
+	type taskParam TaskParam
+	aux := struct {
+		*taskParam
+		Default json.RawMessage `json:"default,omitempty"`
+	}{taskParam: (*taskParam)(tp)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	tp.Default, tp.ArrayDefault, err = unmarshalStringOrArray(aux.Default)
+	return err
+}
+
+// MarshalJSON writes the default of pp as a list if it is an array.
+func (pp PipelineParam) MarshalJSON() ([]byte, error) {
+	type pipelineParam PipelineParam
+	return json.Marshal(struct {
+		pipelineParam
+		Default interface{} `json:"default,omitempty"`
+	}{pipelineParam: pipelineParam(pp), Default: stringOrArray(pp.Default, pp.ArrayDefault, true)})
+}
+
+// UnmarshalJSON sets ArrayDefault instead of Default if the default of pp is a list.
+func (pp *PipelineParam) UnmarshalJSON(data []byte) error {
+	type pipelineParam PipelineParam
+	aux := struct {
+		*pipelineParam
+		Default json.RawMessage `json:"default,omitempty"`
+	}{pipelineParam: (*pipelineParam)(pp)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	pp.Default, pp.ArrayDefault, err = unmarshalStringOrArray(aux.Default)
+	return err
+}
+
+// stringOrArray returns the value to serialize for a field which holds either s
+// or, if it isn't nil, a. If omitEmpty is true, nil is returned when neither is set.
+func stringOrArray(s string, a []string, omitEmpty bool) interface{} {
+	switch {
+	case a != nil:
+		return a
+	case s == "" && omitEmpty:
+		return nil
+	default:
+		return s
+	}
+}
+
+// unmarshalStringOrArray parses data, which holds either a string or a list of strings.
+func unmarshalStringOrArray(data json.RawMessage) (string, []string, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return "", nil, nil
+	}
+	if data[0] == '[' {
+		a := []string{}
+		err := json.Unmarshal(data, &a)
+		return "", a, err
+	}
+	var s string
+	err := json.Unmarshal(data, &s)
+	return s, nil, err
+}
+
+// IsArray returns true if tp is an array param.
+func (tp TaskParam) IsArray() bool {
+	return tp.Type == ParamTypeArray
+}
+
+// HasDefault returns true if tp has a default value.
+func (tp TaskParam) HasDefault() bool {
+	return tp.Default != "" || tp.ArrayDefault != nil
+}
+
+// IsArray returns true if pp is an array param.
+func (pp PipelineParam) IsArray() bool {
+	return pp.Type == ParamTypeArray
+}
+
+// HasDefault returns true if pp has a default value.
+func (pp PipelineParam) HasDefault() bool {
+	return pp.Default != "" || pp.ArrayDefault != nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+func TestParam_JSON(t *testing.T) {
+	tcs := []struct {
+		name  string
+		json  string
+		param v1alpha1.Param
+	}{{
+		name:  "string value",
+		json:  `{"name":"level","value":"debug"}`,
+		param: v1alpha1.Param{Name: "level", Value: "debug"},
+	}, {
+		name:  "empty string value",
+		json:  `{"name":"level","value":""}`,
+		param: v1alpha1.Param{Name: "level"},
+	}, {
+		name:  "array value",
+		json:  `{"name":"flags","value":["-v","-q"]}`,
+		param: v1alpha1.Param{Name: "flags", ArrayValue: []string{"-v", "-q"}},
+	}, {
+		name:  "empty array value",
+		json:  `{"name":"flags","value":[]}`,
+		param: v1alpha1.Param{Name: "flags", ArrayValue: []string{}},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var p v1alpha1.Param
+			if err := json.Unmarshal([]byte(tc.json), &p); err != nil {
+				t.Fatalf("Unexpected error unmarshalling %s: %v", tc.json, err)
+			}
+			if d := cmp.Diff(tc.param, p); d != "" {
+				t.Errorf("Unexpected Param -want, +got: %v", d)
+			}
+			b, err := json.Marshal(tc.param)
+			if err != nil {
+				t.Fatalf("Unexpected error marshalling %v: %v", tc.param, err)
+			}
+			if string(b) != tc.json {
+				t.Errorf("Expected %v to be marshalled as %s but was %s", tc.param, tc.json, b)
+			}
+		})
+	}
+}
+
+func TestParam_JSON_Invalid(t *testing.T) {
+	for _, j := range []string{
+		`{"name":"flags","value":[1,2]}`,
+		`{"name":"flags","value":{"a":"b"}}`,
+	} {
+		var p v1alpha1.Param
+		if err := json.Unmarshal([]byte(j), &p); err == nil {
+			t.Errorf("Expected an error unmarshalling %s but got %v", j, p)
+		}
+	}
+}
+
+func TestTaskParam_JSON(t *testing.T) {
+	tcs := []struct {
+		name  string
+		json  string
+		param v1alpha1.TaskParam
+	}{{
+		name:  "no default",
+		json:  `{"name":"level"}`,
+		param: v1alpha1.TaskParam{Name: "level"},
+	}, {
+		name:  "string default",
+		json:  `{"name":"level","enum":["debug","info"],"pattern":"^[a-z]+$","default":"info"}`,
+		param: v1alpha1.TaskParam{Name: "level", Default: "info", Enum: []string{"debug", "info"}, Pattern: "^[a-z]+$"},
+	}, {
+		name:  "array default",
+		json:  `{"name":"flags","type":"array","default":["-v"]}`,
+		param: v1alpha1.TaskParam{Name: "flags", Type: v1alpha1.ParamTypeArray, ArrayDefault: []string{"-v"}},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var p v1alpha1.TaskParam
+			if err := json.Unmarshal([]byte(tc.json), &p); err != nil {
+				t.Fatalf("Unexpected error unmarshalling %s: %v", tc.json, err)
+			}
+			if d := cmp.Diff(tc.param, p); d != "" {
+				t.Errorf("Unexpected TaskParam -want, +got: %v", d)
+			}
+			b, err := json.Marshal(tc.param)
+			if err != nil {
+				t.Fatalf("Unexpected error marshalling %v: %v", tc.param, err)
+			}
+			if string(b) != tc.json {
+				t.Errorf("Expected %v to be marshalled as %s but was %s", tc.param, tc.json, b)
+			}
+		})
+	}
+}
+
+func TestPipelineParam_JSON(t *testing.T) {
+	j := `{"name":"flags","description":"the flags","type":"array","default":["-v","-q"]}`
+	expected := v1alpha1.PipelineParam{Name: "flags", Description: "the flags", Type: v1alpha1.ParamTypeArray, ArrayDefault: []string{"-v", "-q"}}
+
+	var p v1alpha1.PipelineParam
+	if err := json.Unmarshal([]byte(j), &p); err != nil {
+		t.Fatalf("Unexpected error unmarshalling %s: %v", j, err)
+	}
+	if d := cmp.Diff(expected, p); d != "" {
+		t.Errorf("Unexpected PipelineParam -want, +got: %v", d)
+	}
+	b, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatalf("Unexpected error marshalling %v: %v", expected, err)
+	}
+	if string(b) != j {
+		t.Errorf("Expected %v to be marshalled as %s but was %s", expected, j, b)
+	}
+}
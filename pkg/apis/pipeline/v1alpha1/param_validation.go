@@ -0,0 +1,181 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/knative/pkg/apis"
+)
+
+// paramSpec holds the fields TaskParam and PipelineParam have in common, so
+// they can be validated the same way.
+type paramSpec struct {
+	Name         string
+	Type         ParamType
+	Default      string
+	ArrayDefault []string
+	Enum         []string
+	Pattern      string
+}
+
+func taskParamSpecs(params []TaskParam) []paramSpec {
+	specs := make([]paramSpec, 0, len(params))
+	for _, p := range params {
+		specs = append(specs, paramSpec{Name: p.Name, Type: p.Type, Default: p.Default, ArrayDefault: p.ArrayDefault, Enum: p.Enum, Pattern: p.Pattern})
+	}
+	return specs
+}
+
+func pipelineParamSpecs(params []PipelineParam) []paramSpec {
+	specs := make([]paramSpec, 0, len(params))
+	for _, p := range params {
+		specs = append(specs, paramSpec{Name: p.Name, Type: p.Type, Default: p.Default, ArrayDefault: p.ArrayDefault, Enum: p.Enum, Pattern: p.Pattern})
+	}
+	return specs
+}
+
+// validateParamSpecs validates the declarations of params: their names must be
+// unique, their types known, their patterns valid regular expressions and their
+// defaults must be valid values.
+func validateParamSpecs(specs []paramSpec, path string) *apis.FieldError {
+	names := map[string]struct{}{}
+	for _, s := range specs {
+		if _, ok := names[s.Name]; ok {
+			return apis.ErrMultipleOneOf(fmt.Sprintf("%s.name", path))
+		}
+		names[s.Name] = struct{}{}
+
+		switch s.Type {
+		case "", ParamTypeString, ParamTypeArray:
+		default:
+			return apis.ErrInvalidValue(string(s.Type), fmt.Sprintf("%s.type", path))
+		}
+		if s.Pattern != "" {
+			if _, err := regexp.Compile(s.Pattern); err != nil {
+				return apis.ErrInvalidValue(s.Pattern, fmt.Sprintf("%s.pattern", path))
+			}
+		}
+		if s.Default == "" && s.ArrayDefault == nil {
+			continue
+		}
+		if err := s.validateValue(Param{Name: s.Name, Value: s.Default, ArrayValue: s.ArrayDefault}); err != nil {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("invalid default: %s", err),
+				Paths:   []string{fmt.Sprintf("%s.default", path)},
+			}
+		}
+	}
+	return nil
+}
+
+// validateValue returns an error if the value of p doesn't match the type of s,
+// or if it, or one of its items for an array, isn't one of the values of the enum
+// of s or doesn't match its pattern. Values which still contain variables are
+// only checked once these have been replaced.
+func (s paramSpec) validateValue(p Param) error {
+	values := []string{p.Value}
+	if s.Type == ParamTypeArray {
+		if p.ArrayValue == nil && p.Value != "" {
+			return fmt.Errorf("param %q is an array but was given the string %q", s.Name, p.Value)
+		}
+		values = p.ArrayValue
+	} else if p.ArrayValue != nil {
+		return fmt.Errorf("param %q is a string but was given the array %q", s.Name, p.ArrayValue)
+	}
+	for _, v := range values {
+		if strings.Contains(v, "${") {
+			continue
+		}
+		if len(s.Enum) > 0 && !contains(s.Enum, v) {
+			return fmt.Errorf("param %q must be one of %q but was %q", s.Name, s.Enum, v)
+		}
+		if s.Pattern != "" {
+			// The pattern of a valid declaration always compiles
+			if matched, err := regexp.MatchString(s.Pattern, v); err != nil || !matched {
+				return fmt.Errorf("param %q must match %q but was %q", s.Name, s.Pattern, v)
+			}
+		}
+	}
+	return nil
+}
+
+// validateParamValues returns an error if params are missing a value for a
+// declared param without a default, provide a value for a param which isn't
+// declared, or provide a value which isn't valid for its declaration.
+func validateParamValues(specs []paramSpec, params []Param) error {
+	declared := make(map[string]paramSpec, len(specs))
+	for _, s := range specs {
+		declared[s.Name] = s
+	}
+	provided := make(map[string]struct{}, len(params))
+	extra := []string{}
+	for _, p := range params {
+		provided[p.Name] = struct{}{}
+		if _, ok := declared[p.Name]; !ok {
+			extra = append(extra, p.Name)
+		}
+	}
+	missing := []string{}
+	for _, s := range specs {
+		if _, ok := provided[s.Name]; !ok && s.Default == "" && s.ArrayDefault == nil {
+			missing = append(missing, s.Name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("missing values for these params which have no default values: %s", missing)
+	}
+	if len(extra) > 0 {
+		sort.Strings(extra)
+		return fmt.Errorf("didn't need these params but they were provided anyway: %s", extra)
+	}
+	for _, p := range params {
+		if err := declared[p.Name].validateValue(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateTaskParams returns an error if params don't provide a value for each
+// of the declared params of a Task which don't have a default, provide one for
+// a param the Task doesn't declare, or provide one which doesn't match the type,
+// enum or pattern of the param.
+func ValidateTaskParams(declared []TaskParam, params []Param) error {
+	return validateParamValues(taskParamSpecs(declared), params)
+}
+
+// ValidatePipelineParams returns an error if params don't provide a value for
+// each of the declared params of a Pipeline which don't have a default, provide
+// one for a param the Pipeline doesn't declare, or provide one which doesn't
+// match the type, enum or pattern of the param.
+func ValidatePipelineParams(declared []PipelineParam, params []Param) error {
+	return validateParamValues(pipelineParamSpecs(declared), params)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -24,7 +24,7 @@ func (p *Pipeline) SetDefaults(ctx context.Context) {
 
 func (ps *PipelineSpec) SetDefaults(ctx context.Context) {
 	for _, pt := range ps.Tasks {
-		if pt.TaskRef.Kind == "" {
+		if pt.TaskRef != nil && pt.TaskRef.Kind == "" && !pt.TaskRef.IsCustomTask() {
 			pt.TaskRef.Kind = NamespacedTaskKind
 		}
 	}
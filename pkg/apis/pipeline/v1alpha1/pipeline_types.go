@@ -18,7 +18,9 @@ package v1alpha1
 
 import (
 	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // PipelineSpec defines the desired state of PipeLine.
@@ -26,6 +28,10 @@ type PipelineSpec struct {
 	Resources []PipelineDeclaredResource `json:"resources,omitempty"`
 	Tasks     []PipelineTask             `json:"tasks,omitempty"`
 	Params    []PipelineParam            `json:"params,omitempty"`
+	// Finally declares the PipelineTasks which are run once all of the Tasks
+	// are done, whether they succeeded or not
+	// +optional
+	Finally []PipelineTask `json:"finally,omitempty"`
 }
 
 // PipelineStatus does not contain anything because Pipelines on their own
@@ -70,18 +76,85 @@ type Pipeline struct {
 // PipelineTask defines a task in a Pipeline, passing inputs from both
 // Params and from the output of previous tasks.
 type PipelineTask struct {
-	Name    string  `json:"name,omitempty"`
-	TaskRef TaskRef `json:"taskRef"`
+	Name string `json:"name,omitempty"`
+	// TaskRef refers to the Task to run. When its apiVersion isn't a Tekton
+	// one it refers to a custom task instead, which is run by a Run
+	// fulfilled by an external controller.
+	// +optional
+	TaskRef *TaskRef `json:"taskRef,omitempty"`
+	// TaskSpec is the Task to run, embedded in the PipelineTask instead of
+	// referring to a Task.
+	// +optional
+	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
+	// PipelineRef is the Pipeline to run instead of a Task. It is run by a
+	// PipelineRun owned by the PipelineRun running this PipelineTask.
+	// +optional
+	PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
+	// Approval makes the PipelineTask wait for a manual approval instead of
+	// running a Task. It is decided on through the Approval created for it
+	// by the PipelineRun running this PipelineTask.
+	// +optional
+	Approval *ApprovalGate `json:"approval,omitempty"`
 
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
 	// +optional
 	RunAfter []string `json:"runAfter,omitempty"`
 
+	// Retries represents how many times this task should be retried in case
+	// its TaskRun fails.
+	// +optional
+	Retries int `json:"retries,omitempty"`
+
+	// Conditions is a list of conditions that need to be true for the task to run
+	// +optional
+	Conditions []PipelineTaskCondition `json:"conditions,omitempty"`
+
 	// +optional
 	Resources *PipelineTaskResources `json:"resources,omitempty"`
 	// +optional
 	Params []Param `json:"params,omitempty"`
+
+	// Matrix is a list of params taking multiple values. The PipelineTask is
+	// run once for each combination of these values, and is only done once
+	// all of these runs are.
+	// +optional
+	Matrix []MatrixParam `json:"matrix,omitempty"`
+
+	// Timeout is the time after which the TaskRun of this PipelineTask times out,
+	// clamped to the time the PipelineRun has left. Defaults to the time the
+	// PipelineRun has left.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// PendingTimeout is the time after which the TaskRun of this PipelineTask
+	// times out if its pod still hasn't started running.
+	// +optional
+	PendingTimeout *metav1.Duration `json:"pendingTimeout,omitempty"`
+}
+
+// PipelineTaskCondition allows a PipelineTask to declare a Condition to be evaluated before
+// the Task is run.
+type PipelineTaskCondition struct {
+	// ConditionRef is the name of the Condition to use for the conditionCheck
+	ConditionRef string `json:"conditionRef"`
+
+	// Params declare parameters passed to this Condition
+	// +optional
+	Params []Param `json:"params,omitempty"`
+
+	// Resources declare the PipelineResources, declared by the Pipeline, to pass
+	// to the Condition
+	// +optional
+	Resources []PipelineConditionResource `json:"resources,omitempty"`
+}
+
+// PipelineConditionResource allows a Pipeline to declare how its DeclaredPipelineResources
+// should be provided to a Condition as its inputs.
+type PipelineConditionResource struct {
+	// Name is the name of the PipelineResource as declared by the Condition.
+	Name string `json:"name"`
+	// Resource is the name of the DeclaredPipelineResource to use.
+	Resource string `json:"resource"`
 }
 
 // PipelineTaskParam is used to provide arbitrary string parameters to a Task.
@@ -96,8 +169,23 @@ type PipelineParam struct {
 	Name string `json:"name"`
 	// +optional
 	Description string `json:"description,omitempty"`
+	// Type is the type of the param, string or array. Defaults to string.
+	// +optional
+	Type ParamType `json:"type,omitempty"`
 	// +optional
 	Default string `json:"default,omitempty"`
+	// ArrayDefault is the default value of an array param. It is set from
+	// default when it is a list.
+	// +optional
+	ArrayDefault []string `json:"-"`
+	// Enum lists the values the param may take. For an array param, it applies
+	// to each item.
+	// +optional
+	Enum []string `json:"enum,omitempty"`
+	// Pattern is a regular expression the value of the param must match. For
+	// an array param, it applies to each item.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
 }
 
 // PipelineDeclaredResource is used by a Pipeline to declare the types of the
@@ -160,6 +248,18 @@ type TaskRef struct {
 	APIVersion string `json:"apiVersion,omitempty"`
 }
 
+// IsCustomTask returns true if the TaskRef refers to a kind which isn't
+// part of Tekton, which is run by creating a Run for an external controller
+// to fulfil instead of a TaskRun. An apiVersion which can't be parsed isn't a
+// custom task, and is rejected by the validation of the PipelineTask.
+func (tr *TaskRef) IsCustomTask() bool {
+	if tr == nil || tr.APIVersion == "" {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(tr.APIVersion)
+	return err == nil && gv.Group != pipeline.GroupName
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // PipelineList contains a list of Pipeline
@@ -24,6 +24,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/templating"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // Validate checks that the Pipeline structure is valid but does not validate
@@ -37,7 +38,7 @@ func (p *Pipeline) Validate(ctx context.Context) *apis.FieldError {
 
 func validateDeclaredResources(ps *PipelineSpec) error {
 	required := []string{}
-	for _, t := range ps.Tasks {
+	for _, t := range append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...) {
 		if t.Resources != nil {
 			for _, input := range t.Resources.Inputs {
 				required = append(required, input.Resource)
@@ -46,6 +47,11 @@ func validateDeclaredResources(ps *PipelineSpec) error {
 				required = append(required, output.Resource)
 			}
 		}
+		for _, condition := range t.Conditions {
+			for _, cr := range condition.Resources {
+				required = append(required, cr.Resource)
+			}
+		}
 	}
 
 	provided := make([]string, 0, len(ps.Resources))
@@ -110,6 +116,74 @@ func validateGraph(tasks []PipelineTask) error {
 	return nil
 }
 
+// validateFinallyTasks ensures that the finally PipelineTasks don't depend on any other
+// PipelineTask, since they are only run once all of the Tasks of the Pipeline are done.
+func validateFinallyTasks(tasks []PipelineTask) *apis.FieldError {
+	for _, t := range tasks {
+		if t.Retries < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", t.Retries), "spec.finally.retries")
+		}
+		if len(t.RunAfter) > 0 {
+			return apis.ErrDisallowedFields("spec.finally.runAfter")
+		}
+		if len(t.Conditions) > 0 {
+			return apis.ErrDisallowedFields("spec.finally.conditions")
+		}
+		if t.Resources != nil {
+			for _, rd := range t.Resources.Inputs {
+				if len(rd.From) > 0 {
+					return apis.ErrDisallowedFields("spec.finally.resources.inputs.from")
+				}
+			}
+		}
+		if len(GetPipelineTaskResultRefs(t)) > 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("finally task %s can't use the results of other PipelineTasks", t.Name), "spec.finally.params")
+		}
+	}
+	return nil
+}
+
+// validateMatrix ensures that the matrix params of the PipelineTasks have values and
+// aren't passed as regular params as well. Since a PipelineTask fanning out over a matrix
+// emits its results once for each combination, they can't be used by other PipelineTasks.
+func validateMatrix(tasks []PipelineTask) *apis.FieldError {
+	matrixed := map[string]struct{}{}
+	for _, t := range tasks {
+		if len(t.Matrix) > 0 {
+			matrixed[t.Name] = struct{}{}
+		}
+		paramNames := map[string]struct{}{}
+		for _, p := range t.Params {
+			paramNames[p.Name] = struct{}{}
+		}
+		for _, mp := range t.Matrix {
+			if mp.Name == "" {
+				return apis.ErrMissingField("spec.tasks.matrix.name")
+			}
+			if _, ok := paramNames[mp.Name]; ok {
+				return apis.ErrInvalidValue(fmt.Sprintf("param %s of PipelineTask %s is declared more than once", mp.Name, t.Name), "spec.tasks.matrix.name")
+			}
+			paramNames[mp.Name] = struct{}{}
+			if len(mp.Values) == 0 {
+				return apis.ErrMissingField("spec.tasks.matrix.values")
+			}
+			for _, v := range mp.Values {
+				if len(GetResultRefs(v)) > 0 {
+					return apis.ErrInvalidValue(fmt.Sprintf("matrix param %s of PipelineTask %s can't use the results of other PipelineTasks", mp.Name, t.Name), "spec.tasks.matrix.values")
+				}
+			}
+		}
+	}
+	for _, t := range tasks {
+		for _, ref := range GetPipelineTaskResultRefs(t) {
+			if _, ok := matrixed[ref.PipelineTask]; ok {
+				return apis.ErrInvalidValue(fmt.Sprintf("PipelineTask %s can't use %s since PipelineTask %s fans out over a matrix", t.Name, ref, ref.PipelineTask), "spec.tasks.params")
+			}
+		}
+	}
+	return nil
+}
+
 // Validate checks that taskNames in the Pipeline are valid and that the graph
 // of Tasks expressed in the Pipeline makes sense.
 func (ps *PipelineSpec) Validate(ctx context.Context) *apis.FieldError {
@@ -125,6 +199,60 @@ func (ps *PipelineSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 		taskNames[t.Name] = struct{}{}
 	}
+	for _, t := range ps.Finally {
+		if _, ok := taskNames[t.Name]; ok {
+			return apis.ErrMultipleOneOf("spec.finally.name")
+		}
+		taskNames[t.Name] = struct{}{}
+	}
+
+	// Each task must either refer to a Task or embed one
+	for _, t := range append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...) {
+		if err := validatePipelineTaskSpec(ctx, t); err != nil {
+			return err
+		}
+	}
+
+	// Nothing can come from a PipelineTask running a Pipeline but its outcome
+	if err := validateNestedPipelineRefs(append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...)); err != nil {
+		return err
+	}
+
+	// Retries can't be negative
+	for _, t := range ps.Tasks {
+		if t.Retries < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", t.Retries), "spec.tasks.retries")
+		}
+	}
+
+	// Timeouts must be positive
+	for _, t := range append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...) {
+		if t.Timeout != nil && t.Timeout.Duration <= 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", t.Timeout.Duration.String()), "spec.tasks.timeout")
+		}
+		if t.PendingTimeout != nil && t.PendingTimeout.Duration <= 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", t.PendingTimeout.Duration.String()), "spec.tasks.pendingTimeout")
+		}
+	}
+
+	// Matrix params need values, and the results of the PipelineTasks fanning out can't be used
+	if err := validateMatrix(append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...)); err != nil {
+		return err
+	}
+
+	// Conditions must be referenced by name
+	for _, t := range ps.Tasks {
+		for _, c := range t.Conditions {
+			if c.ConditionRef == "" {
+				return apis.ErrMissingField("spec.tasks.conditions.conditionRef")
+			}
+		}
+	}
+
+	// Finally tasks can't depend on other tasks
+	if err := validateFinallyTasks(ps.Finally); err != nil {
+		return err
+	}
 
 	// All declared resources should be used, and the Pipeline shouldn't try to use any resources
 	// that aren't declared
@@ -143,17 +271,187 @@ func (ps *PipelineSpec) Validate(ctx context.Context) *apis.FieldError {
 	}
 
 	// The parameter variables should be valid
+	if err := validateParamSpecs(pipelineParamSpecs(ps.Params), "spec.params"); err != nil {
+		return err
+	}
 	if err := validatePipelineParameterVariables(ps.Tasks, ps.Params); err != nil {
 		return err
 	}
+	if err := validatePipelineParameterVariables(ps.Finally, ps.Params); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+func validatePipelineTaskSpec(ctx context.Context, t PipelineTask) *apis.FieldError {
+	// An approval gate waits for a decision instead of running anything
+	if t.Approval != nil {
+		if (t.TaskRef != nil && t.TaskRef.Name != "") || t.TaskSpec != nil || t.PipelineRef != nil {
+			return apis.ErrDisallowedFields("spec.tasks.approval", "spec.tasks.taskref", "spec.tasks.taskspec", "spec.tasks.pipelineref")
+		}
+		return validateApprovalPipelineTask(ctx, t)
+	}
+	// A nested Pipeline is run instead of a Task
+	if t.PipelineRef != nil {
+		if (t.TaskRef != nil && t.TaskRef.Name != "") || t.TaskSpec != nil {
+			return apis.ErrDisallowedFields("spec.tasks.pipelineref", "spec.tasks.taskref", "spec.tasks.taskspec")
+		}
+		return validateNestedPipelineTask(t)
+	}
+	if t.TaskRef != nil && t.TaskRef.APIVersion != "" {
+		if _, err := schema.ParseGroupVersion(t.TaskRef.APIVersion); err != nil {
+			return apis.ErrInvalidValue(err.Error(), "spec.tasks.taskref.apiVersion")
+		}
+	}
+	// A custom task is run by an external controller instead of a Task
+	if t.TaskRef.IsCustomTask() {
+		if t.TaskSpec != nil {
+			return apis.ErrDisallowedFields("spec.tasks.taskref", "spec.tasks.taskspec")
+		}
+		return validateCustomTaskPipelineTask(t)
+	}
+	// can't have both taskRef and taskSpec at the same time
+	if (t.TaskRef != nil && t.TaskRef.Name != "") && t.TaskSpec != nil {
+		return apis.ErrDisallowedFields("spec.tasks.taskref", "spec.tasks.taskspec")
+	}
+	// Check that one of TaskRef, TaskSpec, PipelineRef and Approval is present
+	if (t.TaskRef == nil || t.TaskRef.Name == "") && t.TaskSpec == nil {
+		return apis.ErrMissingField("spec.tasks.taskref.name", "spec.tasks.taskspec", "spec.tasks.pipelineref.name", "spec.tasks.approval")
+	}
+	if t.TaskSpec != nil {
+		if err := t.TaskSpec.Validate(ctx); err != nil {
+			return err.ViaField("spec.tasks.taskspec")
+		}
+	}
+	return nil
+}
+
+// validateNestedPipelineTask ensures that a PipelineTask running a Pipeline only uses the
+// features supported by the PipelineRun created for it: its params and resources are passed
+// to the PipelineRun, but it can't be retried, guarded by conditions or fanned out.
+func validateNestedPipelineTask(t PipelineTask) *apis.FieldError {
+	if t.PipelineRef.Name == "" {
+		return apis.ErrMissingField("spec.tasks.pipelineref.name")
+	}
+	if t.Retries != 0 {
+		return apis.ErrDisallowedFields("spec.tasks.retries")
+	}
+	if len(t.Conditions) > 0 {
+		return apis.ErrDisallowedFields("spec.tasks.conditions")
+	}
+	if len(t.Matrix) > 0 {
+		return apis.ErrDisallowedFields("spec.tasks.matrix")
+	}
+	if t.PendingTimeout != nil {
+		return apis.ErrDisallowedFields("spec.tasks.pendingTimeout")
+	}
+	if t.Resources != nil {
+		for _, rd := range t.Resources.Inputs {
+			if len(rd.From) > 0 {
+				return apis.ErrDisallowedFields("spec.tasks.resources.inputs.from")
+			}
+		}
+	}
+	return nil
+}
+
+// validateCustomTaskPipelineTask ensures that a PipelineTask running a custom task refers to
+// its kind, and only uses the features supported by the Run created for it: its params are
+// passed to the Run, but it has no resources and can't be retried, guarded by conditions or
+// fanned out.
+func validateCustomTaskPipelineTask(t PipelineTask) *apis.FieldError {
+	if t.TaskRef.Kind == "" {
+		return apis.ErrMissingField("spec.tasks.taskref.kind")
+	}
+	if t.Resources != nil {
+		return apis.ErrDisallowedFields("spec.tasks.resources")
+	}
+	if t.Retries != 0 {
+		return apis.ErrDisallowedFields("spec.tasks.retries")
+	}
+	if len(t.Conditions) > 0 {
+		return apis.ErrDisallowedFields("spec.tasks.conditions")
+	}
+	if len(t.Matrix) > 0 {
+		return apis.ErrDisallowedFields("spec.tasks.matrix")
+	}
+	if t.PendingTimeout != nil {
+		return apis.ErrDisallowedFields("spec.tasks.pendingTimeout")
+	}
+	return nil
+}
+
+// validateApprovalPipelineTask ensures that a PipelineTask which is an approval gate lets
+// someone decide, and doesn't use any of the features of a PipelineTask running something.
+func validateApprovalPipelineTask(ctx context.Context, t PipelineTask) *apis.FieldError {
+	if err := t.Approval.Validate(ctx); err != nil {
+		return err.ViaField("spec.tasks.approval")
+	}
+	if len(t.Params) > 0 {
+		return apis.ErrDisallowedFields("spec.tasks.params")
+	}
+	if t.Resources != nil {
+		return apis.ErrDisallowedFields("spec.tasks.resources")
+	}
+	if t.Retries != 0 {
+		return apis.ErrDisallowedFields("spec.tasks.retries")
+	}
+	if len(t.Conditions) > 0 {
+		return apis.ErrDisallowedFields("spec.tasks.conditions")
+	}
+	if len(t.Matrix) > 0 {
+		return apis.ErrDisallowedFields("spec.tasks.matrix")
+	}
+	if t.PendingTimeout != nil {
+		return apis.ErrDisallowedFields("spec.tasks.pendingTimeout")
+	}
+	return nil
+}
+
+// validateNestedPipelineRefs ensures that no PipelineTask relies on the results or the output
+// resources of a PipelineTask running a Pipeline or of an approval gate, since they have neither.
+func validateNestedPipelineRefs(tasks []PipelineTask) *apis.FieldError {
+	nested := map[string]string{}
+	for _, t := range tasks {
+		switch {
+		case t.PipelineRef != nil:
+			nested[t.Name] = "runs a Pipeline"
+		case t.Approval != nil:
+			nested[t.Name] = "is an approval"
+		}
+	}
+	for _, t := range tasks {
+		for _, ref := range GetPipelineTaskResultRefs(t) {
+			if why, ok := nested[ref.PipelineTask]; ok {
+				return apis.ErrInvalidValue(fmt.Sprintf("PipelineTask %s can't use %s since PipelineTask %s %s", t.Name, ref, ref.PipelineTask, why), "spec.tasks.params")
+			}
+		}
+		if t.Resources == nil {
+			continue
+		}
+		for _, rd := range t.Resources.Inputs {
+			for _, pb := range rd.From {
+				if why, ok := nested[pb]; ok {
+					return apis.ErrInvalidValue(fmt.Sprintf("PipelineTask %s can't use resource %s from PipelineTask %s since it %s", t.Name, rd.Resource, pb, why), "spec.tasks.resources.inputs.from")
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func validatePipelineParameterVariables(tasks []PipelineTask, params []PipelineParam) *apis.FieldError {
 	parameterNames := map[string]struct{}{}
+	arrayParameterNames := map[string]struct{}{}
 	for _, p := range params {
 		parameterNames[p.Name] = struct{}{}
+		if p.IsArray() {
+			arrayParameterNames[p.Name] = struct{}{}
+		}
+	}
+	if err := validatePipelineArrayUsage(tasks, "params", arrayParameterNames); err != nil {
+		return err
 	}
 	return validatePipelineVariables(tasks, "params", parameterNames)
 }
@@ -161,14 +459,74 @@ func validatePipelineParameterVariables(tasks []PipelineTask, params []PipelineP
 func validatePipelineVariables(tasks []PipelineTask, prefix string, vars map[string]struct{}) *apis.FieldError {
 	for _, task := range tasks {
 		for _, param := range task.Params {
-			if err := validatePipelineVariable(fmt.Sprintf("param[%s]", param.Name), param.Value, prefix, vars); err != nil {
+			for _, value := range append([]string{param.Value}, param.ArrayValue...) {
+				if err := validatePipelineVariable(fmt.Sprintf("param[%s]", param.Name), value, prefix, vars); err != nil {
+					return err
+				}
+			}
+		}
+		for _, condition := range task.Conditions {
+			for _, param := range condition.Params {
+				for _, value := range append([]string{param.Value}, param.ArrayValue...) {
+					if err := validatePipelineVariable(fmt.Sprintf("condition[%s].param[%s]", condition.ConditionRef, param.Name), value, prefix, vars); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		for _, mp := range task.Matrix {
+			for _, value := range mp.Values {
+				if err := validatePipelineVariable(fmt.Sprintf("matrix[%s]", mp.Name), value, prefix, vars); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validatePipelineArrayUsage checks that the array params are only used as the
+// entire value of the params of the PipelineTasks and their conditions, since
+// they expand to several items.
+func validatePipelineArrayUsage(tasks []PipelineTask, prefix string, vars map[string]struct{}) *apis.FieldError {
+	for _, task := range tasks {
+		for _, param := range task.Params {
+			if err := templating.ValidateVariableIsolated(fmt.Sprintf("param[%s]", param.Name), param.Value, prefix, "", "task parameter", "pipelinespec.params", vars); err != nil {
 				return err
 			}
+			for _, value := range param.ArrayValue {
+				if err := validatePipelineNoArrayReferenced(fmt.Sprintf("param[%s]", param.Name), value, prefix, vars); err != nil {
+					return err
+				}
+			}
+		}
+		for _, condition := range task.Conditions {
+			for _, param := range condition.Params {
+				if err := templating.ValidateVariableIsolated(fmt.Sprintf("condition[%s].param[%s]", condition.ConditionRef, param.Name), param.Value, prefix, "", "task parameter", "pipelinespec.params", vars); err != nil {
+					return err
+				}
+				for _, value := range param.ArrayValue {
+					if err := validatePipelineNoArrayReferenced(fmt.Sprintf("condition[%s].param[%s]", condition.ConditionRef, param.Name), value, prefix, vars); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		for _, mp := range task.Matrix {
+			for _, value := range mp.Values {
+				if err := validatePipelineNoArrayReferenced(fmt.Sprintf("matrix[%s]", mp.Name), value, prefix, vars); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	return nil
 }
 
+func validatePipelineNoArrayReferenced(name, value, prefix string, arrayNames map[string]struct{}) *apis.FieldError {
+	return templating.ValidateVariableProhibited(name, value, prefix, "", "task parameter", "pipelinespec.params", arrayNames)
+}
+
 func validatePipelineVariable(name, value, prefix string, vars map[string]struct{}) *apis.FieldError {
 	return templating.ValidateVariable(name, value, prefix, "", "task parameter", "pipelinespec.params", vars)
 }
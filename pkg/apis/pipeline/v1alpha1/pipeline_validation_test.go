@@ -19,9 +19,11 @@ package v1alpha1_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestPipelineSpec_Validate_Error(t *testing.T) {
@@ -36,6 +38,59 @@ func TestPipelineSpec_Validate_Error(t *testing.T) {
 				tb.PipelineTask("foo", "foo-task"),
 			)),
 		},
+		{
+			name: "finally task with the same name as a task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinally("foo", "foo-task"),
+			)),
+		},
+		{
+			name: "finally task with runAfter",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinally("cleanup", "cleanup-task", tb.RunAfter("foo")),
+			)),
+		},
+		{
+			name: "finally task with from",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineDeclaredResource("great-resource", v1alpha1.PipelineResourceTypeGit),
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskOutputResource("the-resource", "great-resource")),
+				tb.PipelineFinally("cleanup", "cleanup-task",
+					tb.PipelineTaskInputResource("the-resource", "great-resource", tb.From("foo"))),
+			)),
+		},
+		{
+			name: "finally task with conditions",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinally("cleanup", "cleanup-task", tb.PipelineTaskCondition("cond")),
+			)),
+		},
+		{
+			name: "finally task using task results",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinally("cleanup", "cleanup-task", tb.PipelineTaskParam("digest", "${tasks.foo.results.digest}")),
+			)),
+		},
+		{
+			name: "finally task using undeclared resource",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinally("cleanup", "cleanup-task",
+					tb.PipelineTaskInputResource("the-resource", "missing-resource")),
+			)),
+		},
+		{
+			name: "finally task using undefined parameter variable",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinally("cleanup", "cleanup-task", tb.PipelineTaskParam("env", "${params.does-not-exist}")),
+			)),
+		},
 		{
 			name: "from is on first task",
 			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
@@ -111,6 +166,270 @@ func TestPipelineSpec_Validate_Error(t *testing.T) {
 				tb.PipelineTask("bar", "bar", tb.RunAfter("foo")),
 			)),
 		},
+		{
+			name: "condition without conditionRef",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskCondition("")),
+			)),
+		},
+		{
+			name: "condition using undeclared resource",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskCondition("cond",
+					tb.PipelineTaskConditionResource("workspace", "missing-resource"))),
+			)),
+		},
+		{
+			name: "condition using undefined parameter variable",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskCondition("cond",
+					tb.PipelineTaskConditionParam("env", "${params.does-not-exist}"))),
+			)),
+		},
+		{
+			name: "negative retries",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.Retries(-1)),
+			)),
+		},
+		{
+			name: "task without taskRef nor taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", ""),
+			)),
+		},
+		{
+			name: "task with both taskRef and taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "", tb.PipelineTaskSpec(tb.Step("foo", "ubuntu")), func(pt *v1alpha1.PipelineTask) {
+					pt.TaskRef = &v1alpha1.TaskRef{Name: "foo-task"}
+				}),
+			)),
+		},
+		{
+			name: "invalid embedded taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "", tb.PipelineTaskSpec(tb.TaskInputs(tb.InputsParam("param")))),
+			)),
+		},
+		{
+			name: "finally task without taskRef nor taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinally("cleanup", ""),
+			)),
+		},
+		{
+			name: "task with both taskRef and pipelineRef",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "", tb.PipelineTaskPipelineRef("foo-pipeline"), func(pt *v1alpha1.PipelineTask) {
+					pt.TaskRef = &v1alpha1.TaskRef{Name: "foo-task"}
+				}),
+			)),
+		},
+		{
+			name: "nested pipeline without name",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "", tb.PipelineTaskPipelineRef("")),
+			)),
+		},
+		{
+			name: "nested pipeline with retries",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "", tb.PipelineTaskPipelineRef("foo-pipeline"), tb.Retries(1)),
+			)),
+		},
+		{
+			name: "negative task timeout",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskTimeout(-time.Minute)),
+			)),
+		},
+		{
+			name: "zero finally task pending timeout",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinally("cleanup", "cleanup-task", tb.PipelineTaskPendingTimeout(0)),
+			)),
+		},
+		{
+			name: "nested pipeline with pending timeout",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "", tb.PipelineTaskPipelineRef("foo-pipeline"), tb.PipelineTaskPendingTimeout(time.Minute)),
+			)),
+		},
+		{
+			name: "nested pipeline with matrix",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "", tb.PipelineTaskPipelineRef("foo-pipeline"), tb.PipelineTaskMatrixParam("platform", "linux")),
+			)),
+		},
+		{
+			name: "task using results of a nested pipeline",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "", tb.PipelineTaskPipelineRef("foo-pipeline")),
+				tb.PipelineTask("bar", "bar-task", tb.PipelineTaskParam("digest", "${tasks.foo.results.digest}")),
+			)),
+		},
+		{
+			name: "task using resources from a nested pipeline",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineDeclaredResource("great-resource", v1alpha1.PipelineResourceTypeGit),
+				tb.PipelineTask("foo", "", tb.PipelineTaskPipelineRef("foo-pipeline"),
+					tb.PipelineTaskOutputResource("some-workspace", "great-resource")),
+				tb.PipelineTask("bar", "bar-task",
+					tb.PipelineTaskInputResource("some-workspace", "great-resource", tb.From("foo"))),
+			)),
+		},
+		{
+			name: "approval without approvers",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("approve", "", tb.PipelineTaskApproval(tb.ApprovalMessage("Release?"))),
+			)),
+		},
+		{
+			name: "approval with a taskRef",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("approve", "", tb.PipelineTaskApproval(tb.ApprovalUsers("alice")), func(pt *v1alpha1.PipelineTask) {
+					pt.TaskRef = &v1alpha1.TaskRef{Name: "foo-task"}
+				}),
+			)),
+		},
+		{
+			name: "approval with params",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("approve", "", tb.PipelineTaskApproval(tb.ApprovalUsers("alice")), tb.PipelineTaskParam("foo", "bar")),
+			)),
+		},
+		{
+			name: "approval with retries",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("approve", "", tb.PipelineTaskApproval(tb.ApprovalUsers("alice")), tb.Retries(1)),
+			)),
+		},
+		{
+			name: "task using results of an approval",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("approve", "", tb.PipelineTaskApproval(tb.ApprovalUsers("alice"))),
+				tb.PipelineTask("bar", "bar-task", tb.PipelineTaskParam("approver", "${tasks.approve.results.approver}")),
+			)),
+		},
+		{
+			name: "task with an invalid apiVersion",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("wait", "", tb.PipelineTaskCustomTask("example.dev/v1/wait", "Wait")),
+			)),
+		},
+		{
+			name: "custom task without kind",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("wait", "", tb.PipelineTaskCustomTask("example.dev/v1", "")),
+			)),
+		},
+		{
+			name: "custom task with a taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("wait", "", tb.PipelineTaskCustomTask("example.dev/v1", "Wait"), func(pt *v1alpha1.PipelineTask) {
+					pt.TaskSpec = &v1alpha1.TaskSpec{Steps: []v1alpha1.Step{{Container: corev1.Container{Name: "foo", Image: "myimage"}}}}
+				}),
+			)),
+		},
+		{
+			name: "custom task with resources",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineDeclaredResource("great-resource", v1alpha1.PipelineResourceTypeGit),
+				tb.PipelineTask("wait", "", tb.PipelineTaskCustomTask("example.dev/v1", "Wait"),
+					tb.PipelineTaskInputResource("some-workspace", "great-resource")),
+			)),
+		},
+		{
+			name: "custom task with retries",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("wait", "", tb.PipelineTaskCustomTask("example.dev/v1", "Wait"), tb.Retries(1)),
+			)),
+		},
+		{
+			name: "matrix param without values",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskMatrixParam("platform")),
+			)),
+		},
+		{
+			name: "matrix param without name",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskMatrixParam("", "linux")),
+			)),
+		},
+		{
+			name: "matrix param also passed as a param",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskParam("platform", "linux"),
+					tb.PipelineTaskMatrixParam("platform", "linux", "windows")),
+			)),
+		},
+		{
+			name: "duplicate matrix params",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskMatrixParam("platform", "linux"),
+					tb.PipelineTaskMatrixParam("platform", "windows")),
+			)),
+		},
+		{
+			name: "matrix param using task results",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineTask("bar", "bar-task", tb.PipelineTaskMatrixParam("digest", "${tasks.foo.results.digest}")),
+			)),
+		},
+		{
+			name: "task using results of a task fanning out over a matrix",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskMatrixParam("platform", "linux", "windows")),
+				tb.PipelineTask("bar", "bar-task", tb.PipelineTaskParam("digest", "${tasks.foo.results.digest}")),
+			)),
+		},
+		{
+			name: "matrix param using undefined parameter variable",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskMatrixParam("platform", "${params.does-not-exist}")),
+			)),
+		},
+		{
+			name: "invalid param type",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("flags", tb.PipelineParamType("object")),
+				tb.PipelineTask("foo", "foo-task"),
+			)),
+		},
+		{
+			name: "param default not matching pattern",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("version", tb.PipelineParamPattern(`^v\d+$`), tb.PipelineParamDefault("latest")),
+				tb.PipelineTask("foo", "foo-task"),
+			)),
+		},
+		{
+			name: "array param not isolated in task param",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("flags", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskParam("flags", "--flags=${params.flags}")),
+			)),
+		},
+		{
+			name: "array param used in matrix",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("platforms", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskMatrixParam("platform", "${params.platforms}")),
+			)),
+		},
+		{
+			name: "array param item using undefined parameter variable",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskArrayParam("flags", "-v", "${params.does-not-exist}")),
+			)),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -133,6 +452,86 @@ func TestPipelineSpec_Validate_Valid(t *testing.T) {
 				tb.PipelineTask("bar", "bar-task"),
 			)),
 		},
+		{
+			name: "tasks with timeouts",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskTimeout(time.Hour), tb.PipelineTaskPendingTimeout(5*time.Minute)),
+				tb.PipelineTask("bar", "", tb.PipelineTaskPipelineRef("bar-pipeline"), tb.PipelineTaskTimeout(time.Hour)),
+			)),
+		},
+		{
+			name: "task with finally",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("namespace"),
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineTask("bar", "bar-task", tb.RunAfter("foo")),
+				tb.PipelineFinally("cleanup", "cleanup-task", tb.Retries(1),
+					tb.PipelineTaskParam("namespace", "${params.namespace}")),
+			)),
+		},
+		{
+			name: "task with conditions",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("env"),
+				tb.PipelineDeclaredResource("great-resource", v1alpha1.PipelineResourceTypeGit),
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskCondition("cond",
+					tb.PipelineTaskConditionParam("env", "${params.env}"),
+					tb.PipelineTaskConditionResource("workspace", "great-resource"))),
+			)),
+		},
+		{
+			name: "task with embedded taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "", tb.PipelineTaskSpec(tb.Step("foo", "ubuntu"))),
+				tb.PipelineTask("bar", "bar-task", tb.RunAfter("foo")),
+			)),
+		},
+		{
+			name: "task with matrix",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("platform"),
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskParam("version", "1"),
+					tb.PipelineTaskMatrixParam("platform", "${params.platform}", "windows"),
+					tb.PipelineTaskMatrixParam("browser", "chrome", "firefox")),
+				tb.PipelineTask("bar", "bar-task", tb.RunAfter("foo")),
+			)),
+		},
+		{
+			name: "task with nested pipeline",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("revision"),
+				tb.PipelineDeclaredResource("great-resource", v1alpha1.PipelineResourceTypeGit),
+				tb.PipelineTask("build", "", tb.PipelineTaskPipelineRef("build-pipeline"),
+					tb.PipelineTaskParam("revision", "${params.revision}"),
+					tb.PipelineTaskInputResource("source", "great-resource")),
+				tb.PipelineTask("release", "release-task", tb.RunAfter("build")),
+			)),
+		},
+		{
+			name: "task with approval",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("build", "build-task"),
+				tb.PipelineTask("approve", "", tb.RunAfter("build"), tb.PipelineTaskTimeout(time.Hour),
+					tb.PipelineTaskApproval(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers"), tb.ApprovalMessage("Release?"))),
+				tb.PipelineTask("release", "release-task", tb.RunAfter("approve")),
+			)),
+		},
+		{
+			name: "task with custom task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("duration"),
+				tb.PipelineTask("wait", "", tb.PipelineTaskCustomTask("example.dev/v1", "Wait"), tb.PipelineTaskTimeout(time.Hour),
+					tb.PipelineTaskParam("duration", "${params.duration}")),
+				tb.PipelineTask("release", "release-task", tb.PipelineTaskParam("waited", "${tasks.wait.results.waited}")),
+			)),
+		},
+		{
+			name: "task with retries",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.Retries(3)),
+			)),
+		},
 		{
 			// Adding this case because `task.Resources` is a pointer, explicitly making sure this is handled
 			name: "task without resources",
@@ -172,6 +571,16 @@ func TestPipelineSpec_Validate_Valid(t *testing.T) {
 					tb.PipelineTaskParam("a-param", "${input.workspace.${baz}}")),
 			)),
 		},
+		{
+			name: "array parameter passed to task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("flags", tb.PipelineParamType(v1alpha1.ParamTypeArray), tb.PipelineParamArrayDefault("-v")),
+				tb.PipelineParam("level", tb.PipelineParamEnum("debug", "info")),
+				tb.PipelineTask("bar", "bar-task",
+					tb.PipelineTaskParam("flags", "${params.flags}"),
+					tb.PipelineTaskArrayParam("more-flags", "-q", "--level=${params.level}")),
+			)),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -39,8 +39,13 @@ var (
 
 // PipelineRunSpec defines the desired state of PipelineRun
 type PipelineRunSpec struct {
-	PipelineRef PipelineRef     `json:"pipelineRef"`
-	Trigger     PipelineTrigger `json:"trigger"`
+	// +optional
+	PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
+	// PipelineSpec is the Pipeline to run, embedded in the PipelineRun
+	// instead of referring to a Pipeline.
+	// +optional
+	PipelineSpec *PipelineSpec   `json:"pipelineSpec,omitempty"`
+	Trigger      PipelineTrigger `json:"trigger"`
 	// Resources is a list of bindings specifying which actual instances of
 	// PipelineResources to use for the resources the Pipeline has declared
 	// it needs.
@@ -58,6 +63,20 @@ type PipelineRunSpec struct {
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailurePolicy decides what happens to the other PipelineTasks when one of
+	// them fails. Defaults to not starting any more PipelineTasks while letting
+	// the ones already running finish.
+	// +optional
+	FailurePolicy PipelineRunFailurePolicy `json:"failurePolicy,omitempty"`
+	// MaxParallelTasks is the maximum number of PipelineTasks which can run at
+	// once. The PipelineTasks ready to run beyond it wait for others to finish.
+	// Defaults to no limit.
+	// +optional
+	MaxParallelTasks int `json:"maxParallelTasks,omitempty"`
+	// Concurrency prevents the PipelineRun from running at the same time as the
+	// other PipelineRuns of the same concurrency group.
+	// +optional
+	Concurrency *PipelineRunConcurrency `json:"concurrency,omitempty"`
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
 	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
@@ -69,6 +88,23 @@ type PipelineRunSpec struct {
 	// If specified, the pod's scheduling constraints
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// CloudEventSink is the URL CloudEvents are sent to when the PipelineRun, or
+	// one of its TaskRuns, starts, succeeds, fails or is cancelled. Defaults to the
+	// sink of the config-cloudevents ConfigMap.
+	// +optional
+	CloudEventSink string `json:"cloudEventSink,omitempty"`
+	// CommitStatus reports the status of the PipelineRun on the commit of one of
+	// its git resources to the Git hosting provider, so that it shows on the pull
+	// requests of that commit.
+	// +optional
+	CommitStatus *PipelineRunCommitStatus `json:"commitStatus,omitempty"`
+
+	// ResumeFrom refers to a previous PipelineRun of the same Pipeline. The
+	// PipelineTasks which succeeded in that PipelineRun are not run again: their
+	// TaskRuns and artifacts are reused, and only the failed PipelineTasks and
+	// those which weren't run are scheduled.
+	// +optional
+	ResumeFrom *PipelineRunRef `json:"resumeFrom,omitempty"`
 }
 
 // PipelineRunSpecStatus defines the pipelinerun spec status the user can provide
@@ -78,6 +114,85 @@ const (
 	// PipelineRunSpecStatusCancelled indicates that the user wants to cancel the task,
 	// if not already cancelled or terminated
 	PipelineRunSpecStatusCancelled = "PipelineRunCancelled"
+
+	// PipelineRunSpecStatusPaused indicates that the user wants to pause the PipelineRun:
+	// no new TaskRuns are started and its timeout is suspended until the status is cleared
+	PipelineRunSpecStatusPaused = "PipelineRunPaused"
+)
+
+// PipelineRunFailurePolicy decides how a PipelineRun reacts to the failure of one of its TaskRuns
+type PipelineRunFailurePolicy string
+
+const (
+	// PipelineRunFailurePolicyFailFast indicates that the TaskRuns still running when one of
+	// the TaskRuns fails should be cancelled
+	PipelineRunFailurePolicyFailFast PipelineRunFailurePolicy = "FailFast"
+	// PipelineRunFailurePolicyContinueIndependent indicates that the PipelineTasks which don't
+	// depend on a failed PipelineTask should still be run
+	PipelineRunFailurePolicyContinueIndependent PipelineRunFailurePolicy = "ContinueIndependent"
+)
+
+// PipelineRunConcurrency puts a PipelineRun in a group of PipelineRuns of the same namespace
+// which don't run at the same time, such as the PipelineRuns building the same branch.
+type PipelineRunConcurrency struct {
+	// Group is the key of the concurrency group, which can refer to the params of
+	// the PipelineRun, e.g. "ci-${params.branch}".
+	Group string `json:"group"`
+	// Policy decides what happens when the PipelineRun starts while other PipelineRuns
+	// of its group are in progress. Defaults to queue.
+	// +optional
+	Policy PipelineRunConcurrencyPolicy `json:"policy,omitempty"`
+}
+
+// PipelineRunCommitStatus reports the status of a PipelineRun on the commit which is the
+// revision of one of its git resources, using the commit status API of the Git hosting
+// provider.
+type PipelineRunCommitStatus struct {
+	// Resource is the name of the git resource, as declared by the Pipeline, whose
+	// revision is the commit the status is reported on.
+	Resource string `json:"resource"`
+	// Provider is the Git hosting provider whose API the status is reported to.
+	Provider CommitStatusProvider `json:"provider"`
+	// APIURL is the URL of the API of the provider, for self-hosted instances.
+	// Defaults to https://api.github.com or https://gitlab.com/api/v4.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+	// Context tells the status of the PipelineRun apart from the other statuses of
+	// the commit. Defaults to tekton/<pipeline name>.
+	// +optional
+	Context string `json:"context,omitempty"`
+	// TargetURL is the link of the status, e.g. to a dashboard, in which
+	// ${pipelineRun.name} and ${pipelineRun.namespace} are replaced.
+	// +optional
+	TargetURL string `json:"targetURL,omitempty"`
+	// TokenSecretRef refers to the key of the Secret holding the token the API
+	// is authenticated to with.
+	TokenSecretRef corev1.SecretKeySelector `json:"tokenSecretRef"`
+}
+
+// CommitStatusProvider is the Git hosting provider a commit status is reported to
+type CommitStatusProvider string
+
+const (
+	// CommitStatusProviderGitHub indicates that the status is reported to the API of GitHub
+	CommitStatusProviderGitHub CommitStatusProvider = "github"
+	// CommitStatusProviderGitLab indicates that the status is reported to the API of GitLab
+	CommitStatusProviderGitLab CommitStatusProvider = "gitlab"
+)
+
+// PipelineRunConcurrencyPolicy decides how a PipelineRun reacts to the other PipelineRuns
+// of its concurrency group which are in progress when it starts
+type PipelineRunConcurrencyPolicy string
+
+const (
+	// PipelineRunConcurrencyPolicyCancelInProgress indicates that the older PipelineRuns of
+	// the group which are in progress should be cancelled
+	PipelineRunConcurrencyPolicyCancelInProgress PipelineRunConcurrencyPolicy = "cancel-in-progress"
+	// PipelineRunConcurrencyPolicyQueue indicates that the PipelineRun should wait for the
+	// PipelineRuns of the group created before it to finish
+	PipelineRunConcurrencyPolicyQueue PipelineRunConcurrencyPolicy = "queue"
+	// PipelineRunConcurrencyPolicySkip indicates that the PipelineRun shouldn't run at all
+	PipelineRunConcurrencyPolicySkip PipelineRunConcurrencyPolicy = "skip"
 )
 
 // PipelineResourceRef can be used to refer to a specific instance of a Resource
@@ -99,20 +214,37 @@ type PipelineRef struct {
 	APIVersion string `json:"apiVersion,omitempty"`
 }
 
+// PipelineRunRef can be used to refer to a specific instance of a PipelineRun
+// in the same namespace.
+type PipelineRunRef struct {
+	// Name of the referent; More info: http://kubernetes.io/docs/user-guide/identifiers#names
+	Name string `json:"name,omitempty"`
+}
+
 // PipelineTriggerType indicates the mechanism by which this PipelineRun was created.
 type PipelineTriggerType string
 
 const (
 	// PipelineTriggerTypeManual indicates that this PipelineRun was invoked manually by a user.
 	PipelineTriggerTypeManual PipelineTriggerType = "manual"
+	// PipelineTriggerTypeScheduled indicates that this PipelineRun was created on schedule by
+	// the ScheduledPipelineRun named in the trigger.
+	PipelineTriggerTypeScheduled PipelineTriggerType = "scheduled"
+	// PipelineTriggerTypeEvent indicates that this PipelineRun was created by the event listener
+	// from an event matching the EventTrigger named in the trigger.
+	PipelineTriggerTypeEvent PipelineTriggerType = "event"
 )
 
 // PipelineTrigger describes what triggered this Pipeline to run. It could be triggered manually,
-// or it could have been some kind of external event (not yet designed).
+// on schedule or by an external event.
 type PipelineTrigger struct {
 	Type PipelineTriggerType `json:"type,omitempty"`
 	// +optional
 	Name string `json:"name,omitempty"`
+	// DeliveryID is the unique ID of the delivery of the event which triggered the PipelineRun,
+	// as sent by the source of the event.
+	// +optional
+	DeliveryID string `json:"deliveryID,omitempty"`
 }
 
 // PipelineRunStatus defines the observed state of PipelineRun
@@ -134,15 +266,153 @@ type PipelineRunStatus struct {
 	// map of PipelineRunTaskRunStatus with the taskRun name as the key
 	// +optional
 	TaskRuns map[string]*PipelineRunTaskRunStatus `json:"taskRuns,omitempty"`
+
+	// map of PipelineRunPipelineRunStatus with the name of the PipelineRun
+	// created for a PipelineTask running a Pipeline as the key
+	// +optional
+	PipelineRuns map[string]*PipelineRunPipelineRunStatus `json:"pipelineRuns,omitempty"`
+
+	// map of PipelineRunApprovalStatus with the name of the Approval created
+	// for a PipelineTask which is an approval gate as the key
+	// +optional
+	Approvals map[string]*PipelineRunApprovalStatus `json:"approvals,omitempty"`
+
+	// map of PipelineRunRunStatus with the name of the Run created for a
+	// PipelineTask referring to a custom task as the key
+	// +optional
+	Runs map[string]*PipelineRunRunStatus `json:"runs,omitempty"`
+
+	// SkippedTasks is the list of PipelineTasks which were not run, either
+	// because one of their conditions failed, because they depend on a
+	// PipelineTask which was skipped or because another PipelineTask failed
+	// +optional
+	SkippedTasks []SkippedTask `json:"skippedTasks,omitempty"`
+
+	// PausedTime is the time the PipelineRun was paused, while it is paused.
+	// +optional
+	PausedTime *metav1.Time `json:"pausedTime,omitempty"`
+
+	// PausedDuration is the time the PipelineRun spent paused before its
+	// current pause, which doesn't count towards its timeout.
+	// +optional
+	PausedDuration *metav1.Duration `json:"pausedDuration,omitempty"`
+
+	// ArtifactStorage is the name of the PipelineRun which created the artifact
+	// storage (PVC or bucket path) shared by the TaskRuns of this PipelineRun.
+	// It is only set when this PipelineRun resumes from another one.
+	// +optional
+	ArtifactStorage string `json:"artifactStorage,omitempty"`
+
+	// CommitStatus is the status of the PipelineRun last reported on the commit
+	// of its git resource. It is reported again when the condition of the
+	// PipelineRun changes.
+	// +optional
+	CommitStatus *ReportedCommitStatus `json:"commitStatus,omitempty"`
+}
+
+// ReportedCommitStatus is the status of a PipelineRun which was reported on a commit.
+type ReportedCommitStatus struct {
+	// State is the state of the commit status, e.g. pending or success.
+	State string `json:"state"`
+	// Reason is the reason of the condition of the PipelineRun when it was reported.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Skipped is why the status wasn't reported, when it can't be, such as when the
+	// revision of the git resource isn't a commit.
+	// +optional
+	Skipped string `json:"skipped,omitempty"`
 }
 
 // PipelineRunTaskRunStatus contains the name of the PipelineTask for this TaskRun and the TaskRun's Status
 type PipelineRunTaskRunStatus struct {
 	// PipelineTaskName is the name of the PipelineTask.
 	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// MatrixParams are the values of the matrix params of the PipelineTask
+	// which the corresponding TaskRun was run with.
+	// +optional
+	MatrixParams []Param `json:"matrixParams,omitempty"`
 	// Status is the TaskRunStatus for the corresponding TaskRun
 	// +optional
 	Status *TaskRunStatus `json:"status,omitempty"`
+	// Attempt is the number of times the PipelineTask had been retried when
+	// the corresponding TaskRun was created, starting at 0 for the first TaskRun.
+	// +optional
+	Attempt int `json:"attempt,omitempty"`
+	// PreviousAttempts holds the outcome of the TaskRuns which failed before
+	// the corresponding TaskRun was created to retry the PipelineTask.
+	// +optional
+	PreviousAttempts []PipelineTaskAttempt `json:"previousAttempts,omitempty"`
+	// ConditionChecks maps the name of a condition check to its status
+	// +optional
+	ConditionChecks map[string]*PipelineRunConditionCheckStatus `json:"conditionChecks,omitempty"`
+}
+
+// PipelineRunPipelineRunStatus contains the name of the PipelineTask running a Pipeline and
+// the Status of the PipelineRun created for it
+type PipelineRunPipelineRunStatus struct {
+	// PipelineTaskName is the name of the PipelineTask.
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// Status is the PipelineRunStatus for the corresponding PipelineRun
+	// +optional
+	Status *PipelineRunStatus `json:"status,omitempty"`
+}
+
+// PipelineRunApprovalStatus contains the name of the PipelineTask which is an approval gate,
+// the decision recorded on the Approval created for it and who made it
+type PipelineRunApprovalStatus struct {
+	// PipelineTaskName is the name of the PipelineTask.
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// Decision is the decision of the approver, if one has decided.
+	// +optional
+	Decision ApprovalDecision `json:"decision,omitempty"`
+	// Approver is the name of the user who decided.
+	// +optional
+	Approver string `json:"approver,omitempty"`
+	// Status is the ApprovalStatus for the corresponding Approval
+	// +optional
+	Status *ApprovalStatus `json:"status,omitempty"`
+}
+
+// PipelineRunRunStatus contains the name of the PipelineTask referring to a custom task and
+// the status of the Run created for it
+type PipelineRunRunStatus struct {
+	// PipelineTaskName is the name of the PipelineTask.
+	PipelineTaskName string `json:"pipelineTaskName,omitempty"`
+	// Status is the RunStatus for the corresponding Run
+	// +optional
+	Status *RunStatus `json:"status,omitempty"`
+}
+
+// PipelineRunConditionCheckStatus returns the condition check status
+type PipelineRunConditionCheckStatus struct {
+	// ConditionName is the name of the Condition
+	ConditionName string `json:"conditionName,omitempty"`
+	// Status is the TaskRunStatus for the corresponding condition check
+	// +optional
+	Status *TaskRunStatus `json:"status,omitempty"`
+}
+
+// SkippedTask is a PipelineTask which was not run, along with the reason why
+type SkippedTask struct {
+	// Name is the name of the PipelineTask.
+	Name string `json:"name"`
+	// Reason is a brief CamelCase string explaining why the PipelineTask was skipped.
+	Reason string `json:"reason"`
+	// Message is a human readable explanation of why the PipelineTask was skipped.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PipelineTaskAttempt records why a TaskRun created for a PipelineTask failed.
+type PipelineTaskAttempt struct {
+	// TaskRunName is the name of the TaskRun which failed.
+	TaskRunName string `json:"taskRunName"`
+	// Reason is the reason of the TaskRun's failure.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable explanation of the TaskRun's failure.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 var pipelineRunCondSet = apis.NewBatchConditionSet()
@@ -225,6 +495,26 @@ func (pr *PipelineRun) GetOwnerReference() []metav1.OwnerReference {
 	}
 }
 
+// AddSharedOwnerReference adds the PipelineRun as an owner of obj which isn't its controller,
+// for the objects it shares with the PipelineRun it resumes from, so that they aren't
+// deleted with that PipelineRun while it still uses them. It returns false if the
+// PipelineRun already owns obj.
+func (pr *PipelineRun) AddSharedOwnerReference(obj metav1.Object) bool {
+	refs := obj.GetOwnerReferences()
+	for _, ref := range refs {
+		if ref.Kind == groupVersionKind.Kind && ref.Name == pr.Name && ref.UID == pr.UID {
+			return false
+		}
+	}
+	obj.SetOwnerReferences(append(refs, metav1.OwnerReference{
+		APIVersion: groupVersionKind.GroupVersion().String(),
+		Kind:       groupVersionKind.Kind,
+		Name:       pr.Name,
+		UID:        pr.UID,
+	}))
+	return true
+}
+
 // IsDone returns true if the PipelineRun's status indicates that it is done.
 func (pr *PipelineRun) IsDone() bool {
 	return !pr.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
@@ -240,6 +530,39 @@ func (pr *PipelineRun) IsCancelled() bool {
 	return pr.Spec.Status == PipelineRunSpecStatusCancelled
 }
 
+// IsPaused returns true if the PipelineRun's spec status is set to Paused state
+func (pr *PipelineRun) IsPaused() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusPaused
+}
+
+// GetPausedDuration returns the total time the PipelineRun has spent paused,
+// including its current pause if it is paused.
+func (pr *PipelineRun) GetPausedDuration() time.Duration {
+	var paused time.Duration
+	if pr.Status.PausedDuration != nil {
+		paused = pr.Status.PausedDuration.Duration
+	}
+	if pr.Status.PausedTime != nil {
+		paused += time.Since(pr.Status.PausedTime.Time)
+	}
+	return paused
+}
+
+// IsResumed returns true if the PipelineRun resumes from a previous PipelineRun
+func (pr *PipelineRun) IsResumed() bool {
+	return pr.Spec.ResumeFrom != nil
+}
+
+// GetArtifactStorageName returns the name of the PipelineRun whose artifact storage
+// is used by the PipelineRun, which is the PipelineRun itself unless it was resumed
+// from another one.
+func (pr *PipelineRun) GetArtifactStorageName() string {
+	if pr.Status.ArtifactStorage != "" {
+		return pr.Status.ArtifactStorage
+	}
+	return pr.Name
+}
+
 // GetRunKey return the pipelinerun key for timeout handler map
 func (pr *PipelineRun) GetRunKey() string {
 	return fmt.Sprintf("%s/%s/%s", pipelineRunControllerName, pr.Namespace, pr.Name)
@@ -111,6 +111,51 @@ func TestPipelineRunIsCancelled(t *testing.T) {
 	}
 }
 
+func TestPipelineRunIsPaused(t *testing.T) {
+	pr := &PipelineRun{
+		Spec: PipelineRunSpec{
+			Status: PipelineRunSpecStatusPaused,
+		},
+	}
+	if !pr.IsPaused() {
+		t.Fatal("Expected pipelinerun status to be paused")
+	}
+}
+
+func TestPipelineRunGetPausedDuration(t *testing.T) {
+	pr := &PipelineRun{
+		Status: PipelineRunStatus{
+			PausedDuration: &metav1.Duration{Duration: time.Hour},
+		},
+	}
+	if d := pr.GetPausedDuration(); d != time.Hour {
+		t.Fatalf("Expected pipelinerun to have been paused for %s but got %s", time.Hour, d)
+	}
+	pr.Status.PausedTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	if d := pr.GetPausedDuration(); d < time.Hour+time.Minute {
+		t.Fatalf("Expected the current pause to be added to the paused duration but got %s", d)
+	}
+}
+
+func TestPipelineRunGetArtifactStorageName(t *testing.T) {
+	pr := &PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "prunname",
+		},
+	}
+	if name := pr.GetArtifactStorageName(); name != "prunname" {
+		t.Fatalf("Expected pipelinerun to use its own artifact storage but got %s", name)
+	}
+	pr.Spec.ResumeFrom = &PipelineRunRef{Name: "previous"}
+	pr.Status.ArtifactStorage = "first"
+	if !pr.IsResumed() {
+		t.Fatal("Expected pipelinerun to be resumed")
+	}
+	if name := pr.GetArtifactStorageName(); name != "first" {
+		t.Fatalf("Expected pipelinerun to use the artifact storage of first but got %s", name)
+	}
+}
+
 func TestPipelineRunKey(t *testing.T) {
 	pr := &PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
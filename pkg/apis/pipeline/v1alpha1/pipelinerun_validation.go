@@ -29,6 +29,9 @@ func (pr *PipelineRun) Validate(ctx context.Context) *apis.FieldError {
 	if err := validateObjectMetadata(pr.GetObjectMeta()).ViaField("metadata"); err != nil {
 		return err
 	}
+	if pr.Spec.ResumeFrom != nil && pr.Spec.ResumeFrom.Name == pr.Name {
+		return apis.ErrInvalidValue(fmt.Sprintf("PipelineRun %s can't resume from itself", pr.Name), "spec.resumeFrom.name")
+	}
 	return pr.Spec.Validate(ctx)
 }
 
@@ -37,11 +40,26 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 	if equality.Semantic.DeepEqual(ps, &PipelineRunSpec{}) {
 		return apis.ErrMissingField("spec")
 	}
-	// pipeline reference should be present for pipelinerun
-	if ps.PipelineRef.Name == "" {
-		return apis.ErrMissingField("pipelinerun.spec.Pipelineref.Name")
+	// can't have both pipelineRef and pipelineSpec at the same time
+	if (ps.PipelineRef != nil && ps.PipelineRef.Name != "") && ps.PipelineSpec != nil {
+		return apis.ErrDisallowedFields("spec.pipelineref", "spec.pipelinespec")
+	}
+	// pipeline reference or embedded pipeline should be present for pipelinerun
+	if (ps.PipelineRef == nil || ps.PipelineRef.Name == "") && ps.PipelineSpec == nil {
+		return apis.ErrMissingField("pipelinerun.spec.Pipelineref.Name", "pipelinerun.spec.pipelinespec")
 	}
-	if ps.Trigger.Type != PipelineTriggerTypeManual {
+	if ps.PipelineSpec != nil {
+		if err := ps.PipelineSpec.Validate(ctx); err != nil {
+			return err
+		}
+		// check the params against the ones declared by the embedded pipeline
+		if err := ValidatePipelineParams(ps.PipelineSpec.Params, ps.Params); err != nil {
+			return apis.ErrInvalidValue(err.Error(), "spec.params")
+		}
+	}
+	switch ps.Trigger.Type {
+	case PipelineTriggerTypeManual, PipelineTriggerTypeScheduled, PipelineTriggerTypeEvent:
+	default:
 		return apis.ErrInvalidValue(string(ps.Trigger.Type), "pipelinerun.spec.trigger.type")
 	}
 	// check for results
@@ -58,5 +76,70 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if err := validateURL(ps.CloudEventSink, "spec.cloudEventSink"); err != nil {
+		return err
+	}
+
+	switch ps.FailurePolicy {
+	case "", PipelineRunFailurePolicyFailFast, PipelineRunFailurePolicyContinueIndependent:
+	default:
+		return apis.ErrInvalidValue(string(ps.FailurePolicy), "spec.failurePolicy")
+	}
+
+	if ps.MaxParallelTasks < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", ps.MaxParallelTasks), "spec.maxParallelTasks")
+	}
+
+	if ps.Concurrency != nil {
+		if ps.Concurrency.Group == "" {
+			return apis.ErrMissingField("spec.concurrency.group")
+		}
+		switch ps.Concurrency.Policy {
+		case "", PipelineRunConcurrencyPolicyCancelInProgress, PipelineRunConcurrencyPolicyQueue, PipelineRunConcurrencyPolicySkip:
+		default:
+			return apis.ErrInvalidValue(string(ps.Concurrency.Policy), "spec.concurrency.policy")
+		}
+	}
+
+	if ps.ResumeFrom != nil && ps.ResumeFrom.Name == "" {
+		return apis.ErrMissingField("spec.resumeFrom.name")
+	}
+
+	if ps.CommitStatus != nil {
+		if err := ps.CommitStatus.Validate(ps.Resources); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the commit status is reported on one of the resources bound by the
+// PipelineRun, to a known provider, with a token.
+func (cs *PipelineRunCommitStatus) Validate(resources []PipelineResourceBinding) *apis.FieldError {
+	if cs.Resource == "" {
+		return apis.ErrMissingField("spec.commitStatus.resource")
+	}
+	bound := false
+	for _, r := range resources {
+		bound = bound || r.Name == cs.Resource
+	}
+	if !bound {
+		return apis.ErrInvalidValue(fmt.Sprintf("resource %q isn't bound by the PipelineRun", cs.Resource), "spec.commitStatus.resource")
+	}
+	switch cs.Provider {
+	case CommitStatusProviderGitHub, CommitStatusProviderGitLab:
+	default:
+		return apis.ErrInvalidValue(string(cs.Provider), "spec.commitStatus.provider")
+	}
+	if err := validateURL(cs.APIURL, "spec.commitStatus.apiURL"); err != nil {
+		return err
+	}
+	if cs.TokenSecretRef.Name == "" {
+		return apis.ErrMissingField("spec.commitStatus.tokenSecretRef.name")
+	}
+	if cs.TokenSecretRef.Key == "" {
+		return apis.ErrMissingField("spec.commitStatus.tokenSecretRef.key")
+	}
 	return nil
 }
@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/knative/pkg/apis"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -63,7 +64,42 @@ func TestPipelineRun_Invalidate(t *testing.T) {
 					},
 				},
 			},
-			want: apis.ErrMissingField("pipelinerun.spec.Pipelineref.Name"),
+			want: apis.ErrMissingField("pipelinerun.spec.Pipelineref.Name", "pipelinerun.spec.pipelinespec"),
+		}, {
+			name: "pipeline reference and embedded pipeline",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: &PipelineRef{
+						Name: "prname",
+					},
+					PipelineSpec: &PipelineSpec{
+						Tasks: []PipelineTask{{Name: "mytask", TaskRef: &TaskRef{Name: "mytask"}}},
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.pipelineref", "spec.pipelinespec"),
+		}, {
+			name: "invalid embedded pipeline",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineSpec: &PipelineSpec{
+						Tasks: []PipelineTask{{Name: "mytask"}},
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+				},
+			},
+			want: apis.ErrMissingField("spec.tasks.taskref.name", "spec.tasks.taskspec", "spec.tasks.pipelineref.name", "spec.tasks.approval"),
 		}, {
 			name: "invalid trigger reference",
 			pr: PipelineRun{
@@ -71,7 +107,7 @@ func TestPipelineRun_Invalidate(t *testing.T) {
 					Name: "pipelinelineName",
 				},
 				Spec: PipelineRunSpec{
-					PipelineRef: PipelineRef{
+					PipelineRef: &PipelineRef{
 						Name: "prname",
 					},
 					Trigger: PipelineTrigger{
@@ -87,7 +123,7 @@ func TestPipelineRun_Invalidate(t *testing.T) {
 					Name: "pipelinelineName",
 				},
 				Spec: PipelineRunSpec{
-					PipelineRef: PipelineRef{
+					PipelineRef: &PipelineRef{
 						Name: "prname",
 					},
 					Trigger: PipelineTrigger{
@@ -97,6 +133,160 @@ func TestPipelineRun_Invalidate(t *testing.T) {
 				},
 			},
 			want: apis.ErrInvalidValue("-48h0m0s should be > 0", "spec.timeout"),
+		}, {
+			name: "invalid failure policy",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: &PipelineRef{
+						Name: "prname",
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+					FailurePolicy: "KeepGoing",
+				},
+			},
+			want: apis.ErrInvalidValue("KeepGoing", "spec.failurePolicy"),
+		}, {
+			name: "negative max parallel tasks",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: &PipelineRef{
+						Name: "prname",
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+					MaxParallelTasks: -1,
+				},
+			},
+			want: apis.ErrInvalidValue("-1 should be >= 0", "spec.maxParallelTasks"),
+		}, {
+			name: "concurrency missing group",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: &PipelineRef{
+						Name: "prname",
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+					Concurrency: &PipelineRunConcurrency{Policy: PipelineRunConcurrencyPolicySkip},
+				},
+			},
+			want: apis.ErrMissingField("spec.concurrency.group"),
+		}, {
+			name: "invalid concurrency policy",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: &PipelineRef{
+						Name: "prname",
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+					Concurrency: &PipelineRunConcurrency{Group: "ci-${params.branch}", Policy: "cancel-all"},
+				},
+			},
+			want: apis.ErrInvalidValue("cancel-all", "spec.concurrency.policy"),
+		}, {
+			name: "resume from missing name",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: &PipelineRef{
+						Name: "prname",
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+					ResumeFrom: &PipelineRunRef{},
+				},
+			},
+			want: apis.ErrMissingField("spec.resumeFrom.name"),
+		}, {
+			name: "resume from itself",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: &PipelineRef{
+						Name: "prname",
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+					ResumeFrom: &PipelineRunRef{Name: "pipelinelineName"},
+				},
+			},
+			want: apis.ErrInvalidValue("PipelineRun pipelinelineName can't resume from itself", "spec.resumeFrom.name"),
+		}, {
+			name: "invalid cloudevent sink",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: &PipelineRef{
+						Name: "prname",
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+					CloudEventSink: "not a url",
+				},
+			},
+			want: apis.ErrInvalidValue("not a url", "spec.cloudEventSink"),
+		}, {
+			name: "param not declared by the embedded pipeline",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineSpec: &PipelineSpec{
+						Tasks: []PipelineTask{{Name: "mytask", TaskRef: &TaskRef{Name: "mytask"}}},
+					},
+					Params: []Param{{Name: "unknown", Value: "value"}},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("didn't need these params but they were provided anyway: [unknown]", "spec.params"),
+		}, {
+			name: "string given to an array param of the embedded pipeline",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineSpec: &PipelineSpec{
+						Params: []PipelineParam{{Name: "flags", Type: ParamTypeArray}},
+						Tasks:  []PipelineTask{{Name: "mytask", TaskRef: &TaskRef{Name: "mytask"}}},
+					},
+					Params: []Param{{Name: "flags", Value: "-v"}},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+				},
+			},
+			want: apis.ErrInvalidValue(`param "flags" is an array but was given the string "-v"`, "spec.params"),
 		},
 	}
 
@@ -110,13 +300,37 @@ func TestPipelineRun_Invalidate(t *testing.T) {
 	}
 }
 
+func TestPipelineRun_Validate_EmbeddedPipeline(t *testing.T) {
+	pr := PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinelineName",
+		},
+		Spec: PipelineRunSpec{
+			PipelineSpec: &PipelineSpec{
+				Tasks: []PipelineTask{{
+					Name: "mytask",
+					TaskSpec: &TaskSpec{
+						Steps: []Step{{Container: corev1.Container{Name: "mystep", Image: "myimage"}}},
+					},
+				}},
+			},
+			Trigger: PipelineTrigger{
+				Type: PipelineTriggerTypeManual,
+			},
+		},
+	}
+	if err := pr.Validate(context.Background()); err != nil {
+		t.Errorf("Unexpected PipelineRun.Validate() error = %v", err)
+	}
+}
+
 func TestPipelineRun_Validate(t *testing.T) {
 	tr := PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "pipelinelineName",
 		},
 		Spec: PipelineRunSpec{
-			PipelineRef: PipelineRef{
+			PipelineRef: &PipelineRef{
 				Name: "prname",
 			},
 			Trigger: PipelineTrigger{
@@ -126,9 +340,72 @@ func TestPipelineRun_Validate(t *testing.T) {
 				URL:  "http://www.google.com",
 				Type: "gcs",
 			},
+			FailurePolicy: PipelineRunFailurePolicyContinueIndependent,
+			ResumeFrom:    &PipelineRunRef{Name: "previousrun"},
+			Concurrency: &PipelineRunConcurrency{
+				Group:  "ci-${params.branch}",
+				Policy: PipelineRunConcurrencyPolicyCancelInProgress,
+			},
 		},
 	}
 	if err := tr.Validate(context.Background()); err != nil {
 		t.Errorf("Unexpected PipelineRun.Validate() error = %v", err)
 	}
 }
+
+func TestPipelineRunCommitStatus_Validate(t *testing.T) {
+	resources := []PipelineResourceBinding{{Name: "source", ResourceRef: PipelineResourceRef{Name: "app-git"}}}
+	valid := func(ops ...func(*PipelineRunCommitStatus)) *PipelineRunCommitStatus {
+		cs := &PipelineRunCommitStatus{
+			Resource: "source",
+			Provider: CommitStatusProviderGitHub,
+			TokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "github"},
+				Key:                  "token",
+			},
+		}
+		for _, op := range ops {
+			op(cs)
+		}
+		return cs
+	}
+	tests := []struct {
+		name string
+		cs   *PipelineRunCommitStatus
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		cs: valid(func(cs *PipelineRunCommitStatus) {
+			cs.APIURL = "https://github.example.com/api/v3"
+			cs.TargetURL = "https://dashboard.example.com/${pipelineRun.namespace}/${pipelineRun.name}"
+		}),
+	}, {
+		name: "missing resource",
+		cs:   valid(func(cs *PipelineRunCommitStatus) { cs.Resource = "" }),
+		want: apis.ErrMissingField("spec.commitStatus.resource"),
+	}, {
+		name: "unbound resource",
+		cs:   valid(func(cs *PipelineRunCommitStatus) { cs.Resource = "docs" }),
+		want: apis.ErrInvalidValue(`resource "docs" isn't bound by the PipelineRun`, "spec.commitStatus.resource"),
+	}, {
+		name: "unknown provider",
+		cs:   valid(func(cs *PipelineRunCommitStatus) { cs.Provider = "bitbucket" }),
+		want: apis.ErrInvalidValue("bitbucket", "spec.commitStatus.provider"),
+	}, {
+		name: "invalid api url",
+		cs:   valid(func(cs *PipelineRunCommitStatus) { cs.APIURL = "github" }),
+		want: apis.ErrInvalidValue("github", "spec.commitStatus.apiURL"),
+	}, {
+		name: "missing secret key",
+		cs:   valid(func(cs *PipelineRunCommitStatus) { cs.TokenSecretRef.Key = "" }),
+		want: apis.ErrMissingField("spec.commitStatus.tokenSecretRef.key"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cs.Validate(resources)
+			if d := cmp.Diff(tc.want.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineRunCommitStatus.Validate() (-want, +got) = %v", d)
+			}
+		})
+	}
+}
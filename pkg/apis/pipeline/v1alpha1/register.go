@@ -58,6 +58,16 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PipelineRunList{},
 		&PipelineResource{},
 		&PipelineResourceList{},
+		&Condition{},
+		&ConditionList{},
+		&Approval{},
+		&ApprovalList{},
+		&Run{},
+		&RunList{},
+		&ScheduledPipelineRun{},
+		&ScheduledPipelineRunList{},
+		&EventTrigger{},
+		&EventTriggerList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
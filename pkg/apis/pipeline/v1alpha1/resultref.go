@@ -0,0 +1,63 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// resultRefRegex matches references to the results of other PipelineTasks,
+// e.g. ${tasks.build.results.digest}.
+var resultRefRegex = regexp.MustCompile(`\$\{tasks\.([_a-zA-Z][_a-zA-Z0-9-]*)\.results\.([_a-zA-Z][_a-zA-Z0-9-]*)\}`)
+
+// ResultRef is a reference from a PipelineTask param to a result emitted by
+// another PipelineTask.
+type ResultRef struct {
+	// PipelineTask is the name of the PipelineTask which emits the result.
+	PipelineTask string
+	// Result is the name of the result as declared by the Task.
+	Result string
+}
+
+// String returns the templating expression of the reference, which is how it
+// appears in a PipelineTask param.
+func (r ResultRef) String() string {
+	return fmt.Sprintf("${tasks.%s.results.%s}", r.PipelineTask, r.Result)
+}
+
+// GetResultRefs returns all of the result references found in value.
+func GetResultRefs(value string) []ResultRef {
+	refs := []ResultRef{}
+	for _, m := range resultRefRegex.FindAllStringSubmatch(value, -1) {
+		refs = append(refs, ResultRef{PipelineTask: m[1], Result: m[2]})
+	}
+	return refs
+}
+
+// GetPipelineTaskResultRefs returns all of the result references found in the
+// params of pt.
+func GetPipelineTaskResultRefs(pt PipelineTask) []ResultRef {
+	refs := []ResultRef{}
+	for _, p := range pt.Params {
+		refs = append(refs, GetResultRefs(p.Value)...)
+		for _, v := range p.ArrayValue {
+			refs = append(refs, GetResultRefs(v)...)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetResultRefs(t *testing.T) {
+	tcs := []struct {
+		name     string
+		value    string
+		expected []ResultRef
+	}{{
+		name:     "no references",
+		value:    "${params.foo} ${inputs.params.bar}",
+		expected: []ResultRef{},
+	}, {
+		name:     "single reference",
+		value:    "${tasks.build.results.digest}",
+		expected: []ResultRef{{PipelineTask: "build", Result: "digest"}},
+	}, {
+		name:  "multiple references",
+		value: "gcr.io/${tasks.build.results.image}@${tasks.push-image.results.image_digest}",
+		expected: []ResultRef{
+			{PipelineTask: "build", Result: "image"},
+			{PipelineTask: "push-image", Result: "image_digest"},
+		},
+	}, {
+		name:     "malformed reference",
+		value:    "${tasks.build.digest} ${tasks.build.results.}",
+		expected: []ResultRef{},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			refs := GetResultRefs(tc.value)
+			if d := cmp.Diff(tc.expected, refs); d != "" {
+				t.Errorf("GetResultRefs(%q) diff -want, +got: %s", tc.value, d)
+			}
+			for _, ref := range refs {
+				if got := GetResultRefs(ref.String()); len(got) != 1 || got[0] != ref {
+					t.Errorf("expected %q to be parsed back into %v but got %v", ref.String(), ref, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPipelineTaskResultRefs(t *testing.T) {
+	pt := PipelineTask{
+		Name: "deploy",
+		Params: []Param{{
+			Name:  "image",
+			Value: "${tasks.build.results.digest}",
+		}, {
+			Name:  "env",
+			Value: "${params.env}",
+		}, {
+			Name:  "version",
+			Value: "${tasks.version.results.semver}",
+		}},
+	}
+	expected := []ResultRef{
+		{PipelineTask: "build", Result: "digest"},
+		{PipelineTask: "version", Result: "semver"},
+	}
+	if d := cmp.Diff(expected, GetPipelineTaskResultRefs(pt)); d != "" {
+		t.Errorf("GetPipelineTaskResultRefs diff -want, +got: %s", d)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Check that Run may be validated and defaulted.
+var _ apis.Validatable = (*Run)(nil)
+var _ apis.Defaultable = (*Run)(nil)
+
+// RunSpecStatus defines the run spec status the user can provide
+type RunSpecStatus string
+
+const (
+	// RunSpecStatusCancelled indicates that the user wants to cancel the run.
+	// The controller fulfilling the Run is expected to stop it and mark it as
+	// failed.
+	RunSpecStatusCancelled RunSpecStatus = "RunCancelled"
+)
+
+const (
+	// RunReasonTimedOut indicates that the Run didn't complete before its
+	// timeout.
+	RunReasonTimedOut = "RunTimeout"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Run is created by a PipelineRun for a PipelineTask whose taskRef refers to
+// a kind that isn't a Tekton Task. It is fulfilled by an external controller
+// watching the Runs referring to its kind, which reports the outcome with
+// the Succeeded condition and the results of the Run.
+// +k8s:openapi-gen=true
+type Run struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec RunSpec `json:"spec,omitempty"`
+	// +optional
+	Status RunStatus `json:"status,omitempty"`
+}
+
+// RunSpec defines the custom task to run and its params.
+type RunSpec struct {
+	// Ref refers to the custom task to run, by apiVersion, kind and
+	// optionally name.
+	Ref *TaskRef `json:"ref"`
+
+	// Params are the values of the params of the custom task.
+	// +optional
+	Params []Param `json:"params,omitempty"`
+
+	// Timeout is the time after which the Run fails if it hasn't completed.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Used for cancelling a run (and maybe more later on)
+	// +optional
+	Status RunSpecStatus `json:"status,omitempty"`
+}
+
+// RunStatus defines the observed state of Run
+type RunStatus struct {
+	duckv1beta1.Status `json:",inline"`
+
+	// StartTime is the time the Run started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the Run completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Results are the values emitted by the custom task.
+	// +optional
+	Results []TaskRunResult `json:"results,omitempty"`
+}
+
+var runCondSet = apis.NewBatchConditionSet()
+
+// GetCondition returns the Condition matching the given type.
+func (rs *RunStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return runCondSet.Manage(rs).GetCondition(t)
+}
+
+// InitializeConditions will set all conditions in runCondSet to unknown for the Run
+// and set the started time to the current time
+func (rs *RunStatus) InitializeConditions() {
+	if rs.StartTime.IsZero() {
+		rs.StartTime = &metav1.Time{Time: time.Now()}
+	}
+	runCondSet.Manage(rs).InitializeConditions()
+}
+
+// SetCondition sets the condition, unsetting previous conditions with the same
+// type as necessary.
+func (rs *RunStatus) SetCondition(newCond *apis.Condition) {
+	if newCond != nil {
+		runCondSet.Manage(rs).SetCondition(*newCond)
+	}
+}
+
+// GetResult returns the value emitted for the result called name, and
+// whether such a value was emitted at all.
+func (rs *RunStatus) GetResult(name string) (string, bool) {
+	for _, r := range rs.Results {
+		if r.Name == name {
+			return r.Value, true
+		}
+	}
+	return "", false
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RunList contains a list of Run
+type RunList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Run `json:"items"`
+}
+
+// IsDone returns true if the Run's status indicates that it succeeded or
+// failed.
+func (r *Run) IsDone() bool {
+	return !r.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
+}
+
+// HasStarted returns true if the Run has a start time.
+func (r *Run) HasStarted() bool {
+	return r.Status.StartTime != nil && !r.Status.StartTime.IsZero()
+}
+
+// IsCancelled returns true if the Run's spec status is set to Cancelled state
+func (r *Run) IsCancelled() bool {
+	return r.Spec.Status == RunSpecStatusCancelled
+}
+
+// GetRunKey return the run key for timeout handler map
+func (r *Run) GetRunKey() string {
+	return fmt.Sprintf("Run/%s/%s", r.Namespace, r.Name)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+)
+
+// Validate checks that the Run refers to a custom task.
+func (r *Run) Validate(ctx context.Context) *apis.FieldError {
+	if err := validateObjectMetadata(r.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return r.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate checks that the RunSpec refers to a custom task by apiVersion and
+// kind, and that its timeout and status are valid.
+func (rs *RunSpec) Validate(ctx context.Context) *apis.FieldError {
+	if rs.Ref == nil {
+		return apis.ErrMissingField("ref")
+	}
+	if rs.Ref.APIVersion == "" || rs.Ref.Kind == "" {
+		return apis.ErrMissingField("ref.apiVersion", "ref.kind")
+	}
+	if !rs.Ref.IsCustomTask() {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s %s is not a custom task", rs.Ref.APIVersion, rs.Ref.Kind), "ref")
+	}
+	if rs.Timeout != nil && rs.Timeout.Duration <= 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", rs.Timeout.Duration.String()), "timeout")
+	}
+	if rs.Status != "" && rs.Status != RunSpecStatusCancelled {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", rs.Status, RunSpecStatusCancelled), "status")
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+)
+
+func TestRun_Validate_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *v1alpha1.Run
+	}{{
+		name: "custom task with a name",
+		r:    tb.Run("run", "foo", tb.RunRef("example.dev/v1", "Wait", "wait-a-minute")),
+	}, {
+		name: "custom task without a name",
+		r:    tb.Run("run", "foo", tb.RunRef("example.dev/v1", "Wait", ""), tb.RunParam("duration", "1m")),
+	}, {
+		name: "cancelled run with a timeout",
+		r:    tb.Run("run", "foo", tb.RunRef("example.dev/v1", "Wait", ""), tb.RunTimeout(time.Hour), tb.RunCancelled),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.Validate(context.Background()); err != nil {
+				t.Errorf("Run.Validate() returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRun_Validate_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *v1alpha1.Run
+	}{{
+		name: "no ref",
+		r:    tb.Run("run", "foo"),
+	}, {
+		name: "no kind",
+		r:    tb.Run("run", "foo", tb.RunRef("example.dev/v1", "", "wait")),
+	}, {
+		name: "no apiVersion",
+		r:    tb.Run("run", "foo", tb.RunRef("", "Wait", "wait")),
+	}, {
+		name: "Tekton task",
+		r:    tb.Run("run", "foo", tb.RunRef("tekton.dev/v1alpha1", "Task", "build")),
+	}, {
+		name: "negative timeout",
+		r:    tb.Run("run", "foo", tb.RunRef("example.dev/v1", "Wait", ""), tb.RunTimeout(-time.Minute)),
+	}, {
+		name: "invalid status",
+		r: tb.Run("run", "foo", tb.RunRef("example.dev/v1", "Wait", ""), func(r *v1alpha1.Run) {
+			r.Spec.Status = "RunPaused"
+		}),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.r.Validate(context.Background()); err == nil {
+				t.Errorf("Expected an error, got nothing for %v", tt.r)
+			}
+		})
+	}
+}
+
+func TestTaskRef_IsCustomTask(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  *v1alpha1.TaskRef
+		want bool
+	}{{
+		name: "no ref",
+		ref:  nil,
+		want: false,
+	}, {
+		name: "task without apiVersion",
+		ref:  &v1alpha1.TaskRef{Name: "build", Kind: v1alpha1.NamespacedTaskKind},
+		want: false,
+	}, {
+		name: "tekton cluster task",
+		ref:  &v1alpha1.TaskRef{Name: "build", Kind: v1alpha1.ClusterTaskKind, APIVersion: "tekton.dev/v1alpha1"},
+		want: false,
+	}, {
+		name: "custom task",
+		ref:  &v1alpha1.TaskRef{Name: "wait", Kind: "Wait", APIVersion: "example.dev/v1"},
+		want: true,
+	}, {
+		name: "invalid apiVersion",
+		ref:  &v1alpha1.TaskRef{Name: "wait", Kind: "Wait", APIVersion: "example.dev/v1/wait"},
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ref.IsCustomTask(); got != tt.want {
+				t.Errorf("IsCustomTask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
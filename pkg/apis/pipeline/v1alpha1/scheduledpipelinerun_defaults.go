@@ -0,0 +1,36 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+)
+
+func (spr *ScheduledPipelineRun) SetDefaults(ctx context.Context) {
+	spr.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults sets the concurrency policy to allow, and the trigger of the
+// PipelineRuns to scheduled.
+func (sprs *ScheduledPipelineRunSpec) SetDefaults(ctx context.Context) {
+	if sprs.ConcurrencyPolicy == "" {
+		sprs.ConcurrencyPolicy = ScheduledPipelineRunConcurrencyPolicyAllow
+	}
+	if sprs.PipelineRunTemplate.Spec.Trigger.Type == "" {
+		sprs.PipelineRunTemplate.Spec.Trigger.Type = PipelineTriggerTypeScheduled
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Check that ScheduledPipelineRun may be validated and defaulted.
+var _ apis.Validatable = (*ScheduledPipelineRun)(nil)
+var _ apis.Defaultable = (*ScheduledPipelineRun)(nil)
+
+// ScheduledPipelineRunConcurrencyPolicy decides what happens when a PipelineRun is due while
+// the PipelineRuns created earlier by the same ScheduledPipelineRun are still running.
+type ScheduledPipelineRunConcurrencyPolicy string
+
+const (
+	// ScheduledPipelineRunConcurrencyPolicyAllow indicates that the PipelineRun is created
+	// regardless of the PipelineRuns still running.
+	ScheduledPipelineRunConcurrencyPolicyAllow ScheduledPipelineRunConcurrencyPolicy = "allow"
+	// ScheduledPipelineRunConcurrencyPolicyForbid indicates that the PipelineRun isn't created
+	// while another one is running, which is recorded as a missed run.
+	ScheduledPipelineRunConcurrencyPolicyForbid ScheduledPipelineRunConcurrencyPolicy = "forbid"
+	// ScheduledPipelineRunConcurrencyPolicyReplace indicates that the PipelineRuns still running
+	// are cancelled and replaced by the new one.
+	ScheduledPipelineRunConcurrencyPolicyReplace ScheduledPipelineRunConcurrencyPolicy = "replace"
+)
+
+// DefaultScheduledPipelineRunHistoryLimit is the number of finished PipelineRuns kept by a
+// ScheduledPipelineRun which doesn't set its historyLimit.
+const DefaultScheduledPipelineRunHistoryLimit = 3
+
+var scheduledPipelineRunGroupVersionKind = schema.GroupVersionKind{
+	Group:   SchemeGroupVersion.Group,
+	Version: SchemeGroupVersion.Version,
+	Kind:    "ScheduledPipelineRun",
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScheduledPipelineRun creates PipelineRuns from a template on a cron schedule.
+// +k8s:openapi-gen=true
+type ScheduledPipelineRun struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec ScheduledPipelineRunSpec `json:"spec,omitempty"`
+	// +optional
+	Status ScheduledPipelineRunStatus `json:"status,omitempty"`
+}
+
+// ScheduledPipelineRunSpec defines when to create PipelineRuns, and what they run.
+type ScheduledPipelineRunSpec struct {
+	// Schedule is a cron expression, such as "0 2 * * *" or "@daily".
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the name of the time zone the schedule is in, such as
+	// "Europe/Paris". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// PipelineRunTemplate is the PipelineRun to create on schedule.
+	PipelineRunTemplate PipelineRunTemplate `json:"pipelineRunTemplate"`
+
+	// ConcurrencyPolicy decides what happens when a PipelineRun is due while
+	// the previous ones are still running. Defaults to allow.
+	// +optional
+	ConcurrencyPolicy ScheduledPipelineRunConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// HistoryLimit is the number of finished PipelineRuns to keep, the older
+	// ones being deleted. Defaults to 3.
+	// +optional
+	HistoryLimit *int32 `json:"historyLimit,omitempty"`
+
+	// Suspend stops creating PipelineRuns, without affecting the ones already
+	// created, while it is true.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// PipelineRunTemplate describes the PipelineRuns created by a ScheduledPipelineRun.
+type PipelineRunTemplate struct {
+	// Only the labels and annotations are copied to the PipelineRuns.
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PipelineRunSpec `json:"spec"`
+}
+
+// ScheduledPipelineRunStatus defines the observed state of ScheduledPipelineRun
+type ScheduledPipelineRunStatus struct {
+	// LastScheduleTime is the last time a PipelineRun was due, whether it was
+	// created or missed.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Active is the list of the PipelineRuns which are still running.
+	// +optional
+	Active []string `json:"active,omitempty"`
+
+	// MissedRuns counts the PipelineRuns which were due but weren't created,
+	// either because the controller wasn't running at the time or because the
+	// concurrency policy forbade it.
+	// +optional
+	MissedRuns int64 `json:"missedRuns,omitempty"`
+
+	// LastMissedTime is the last time a PipelineRun was due but wasn't created.
+	// +optional
+	LastMissedTime *metav1.Time `json:"lastMissedTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScheduledPipelineRunList contains a list of ScheduledPipelineRun
+type ScheduledPipelineRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ScheduledPipelineRun `json:"items"`
+}
+
+// GetOwnerReference gets the scheduled pipeline run as owner reference for the PipelineRuns it creates
+func (spr *ScheduledPipelineRun) GetOwnerReference() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		*metav1.NewControllerRef(spr, scheduledPipelineRunGroupVersionKind),
+	}
+}
+
+// GetLocation returns the time zone of the schedule.
+func (sprs *ScheduledPipelineRunSpec) GetLocation() (*time.Location, error) {
+	if sprs.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(sprs.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q: %v", sprs.TimeZone, err)
+	}
+	return loc, nil
+}
+
+// GetHistoryLimit returns the number of finished PipelineRuns to keep.
+func (sprs *ScheduledPipelineRunSpec) GetHistoryLimit() int {
+	if sprs.HistoryLimit == nil {
+		return DefaultScheduledPipelineRunHistoryLimit
+	}
+	return int(*sprs.HistoryLimit)
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/cron"
+)
+
+// Validate checks that the ScheduledPipelineRun has a valid schedule and PipelineRun template.
+func (spr *ScheduledPipelineRun) Validate(ctx context.Context) *apis.FieldError {
+	if err := validateObjectMetadata(spr.GetObjectMeta()); err != nil {
+		return err.ViaField("metadata")
+	}
+	return spr.Spec.Validate(ctx).ViaField("spec")
+}
+
+// Validate checks that the schedule and the time zone can be parsed, and that the template
+// is a valid PipelineRun.
+func (sprs *ScheduledPipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
+	if sprs.Schedule == "" {
+		return apis.ErrMissingField("schedule")
+	}
+	if _, err := cron.Parse(sprs.Schedule); err != nil {
+		return apis.ErrInvalidValue(err.Error(), "schedule")
+	}
+	if _, err := sprs.GetLocation(); err != nil {
+		return apis.ErrInvalidValue(err.Error(), "timeZone")
+	}
+	switch sprs.ConcurrencyPolicy {
+	case "", ScheduledPipelineRunConcurrencyPolicyAllow, ScheduledPipelineRunConcurrencyPolicyForbid, ScheduledPipelineRunConcurrencyPolicyReplace:
+	default:
+		return apis.ErrInvalidValue(string(sprs.ConcurrencyPolicy), "concurrencyPolicy")
+	}
+	if sprs.HistoryLimit != nil && *sprs.HistoryLimit < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *sprs.HistoryLimit), "historyLimit")
+	}
+	template := sprs.PipelineRunTemplate.Spec
+	if template.Trigger.Type == "" {
+		template.Trigger.Type = PipelineTriggerTypeScheduled
+	}
+	if template.Status != "" {
+		return apis.ErrDisallowedFields("pipelineRunTemplate.spec.status")
+	}
+	if template.ResumeFrom != nil {
+		return apis.ErrDisallowedFields("pipelineRunTemplate.spec.resumeFrom")
+	}
+	if err := template.Validate(ctx); err != nil {
+		return err.ViaField("pipelineRunTemplate")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+)
+
+func TestScheduledPipelineRun_Validate_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		spr  *v1alpha1.ScheduledPipelineRun
+	}{{
+		name: "daily schedule",
+		spr:  tb.ScheduledPipelineRun("nightly", "foo", "@daily", "pipeline"),
+	}, {
+		name: "schedule in a time zone",
+		spr: tb.ScheduledPipelineRun("nightly", "foo", "30 2 * * mon-fri", "pipeline",
+			tb.ScheduledPipelineRunTimeZone("Europe/Paris"),
+			tb.ScheduledPipelineRunConcurrencyPolicy(v1alpha1.ScheduledPipelineRunConcurrencyPolicyForbid),
+			tb.ScheduledPipelineRunHistoryLimit(0),
+		),
+	}, {
+		name: "template with params",
+		spr: tb.ScheduledPipelineRun("nightly", "foo", "0 */6 * * *", "pipeline",
+			tb.ScheduledPipelineRunTemplateSpec(tb.PipelineRunParam("branch", "master")),
+		),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spr.Validate(context.Background()); err != nil {
+				t.Errorf("ScheduledPipelineRun.Validate() returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestScheduledPipelineRun_Validate_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		spr  *v1alpha1.ScheduledPipelineRun
+	}{{
+		name: "no schedule",
+		spr:  tb.ScheduledPipelineRun("nightly", "foo", "", "pipeline"),
+	}, {
+		name: "invalid schedule",
+		spr:  tb.ScheduledPipelineRun("nightly", "foo", "0 25 * * *", "pipeline"),
+	}, {
+		name: "unknown time zone",
+		spr:  tb.ScheduledPipelineRun("nightly", "foo", "@daily", "pipeline", tb.ScheduledPipelineRunTimeZone("Mars/Olympus_Mons")),
+	}, {
+		name: "unknown concurrency policy",
+		spr:  tb.ScheduledPipelineRun("nightly", "foo", "@daily", "pipeline", tb.ScheduledPipelineRunConcurrencyPolicy("queue")),
+	}, {
+		name: "negative history limit",
+		spr:  tb.ScheduledPipelineRun("nightly", "foo", "@daily", "pipeline", tb.ScheduledPipelineRunHistoryLimit(-1)),
+	}, {
+		name: "no pipeline",
+		spr:  tb.ScheduledPipelineRun("nightly", "foo", "@daily", ""),
+	}, {
+		name: "cancelled template",
+		spr:  tb.ScheduledPipelineRun("nightly", "foo", "@daily", "pipeline", tb.ScheduledPipelineRunTemplateSpec(tb.PipelineRunCancelled)),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spr.Validate(context.Background()); err == nil {
+				t.Errorf("Expected an error, got nothing for %v", tt.spr)
+			}
+		})
+	}
+}
@@ -44,7 +44,7 @@ type TaskSpec struct {
 
 	// Steps are the steps of the build; each step is run sequentially with the
 	// source mounted into /workspace.
-	Steps []corev1.Container `json:"steps,omitempty"`
+	Steps []Step `json:"steps,omitempty"`
 
 	// Volumes is a collection of volumes that are available to mount into the
 	// steps of the build.
@@ -53,8 +53,48 @@ type TaskSpec struct {
 	// ContainerTemplate can be used as the basis for all step containers within the
 	// Task, so that the steps inherit settings on the base container.
 	ContainerTemplate *corev1.Container `json:"containerTemplate,omitempty"`
+	// Results are the named values the Task's steps may emit, by writing
+	// them to ${results.<name>.path}.
+	// +optional
+	Results []TaskResult `json:"results,omitempty"`
+	// Sidecars are containers run alongside the steps, from before the
+	// first step starts until the last step completes.
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+}
+
+// Step is a container run as a step of a Task, which may run a script
+// rather than a command.
+type Step struct {
+	corev1.Container `json:",inline"`
+
+	// Script is the contents of an executable file the step runs instead of
+	// a command, with its args. It's run with /bin/sh unless it starts with
+	// a shebang line.
+	// +optional
+	Script string `json:"script,omitempty"`
+
+	// Timeout is how long the step may run before it's killed and fails.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// OnError is what happens when the step fails, StepOnErrorStopAndFail if
+	// not specified.
+	// +optional
+	OnError StepOnError `json:"onError,omitempty"`
 }
 
+// StepOnError is what happens when a step fails.
+type StepOnError string
+
+const (
+	// StepOnErrorStopAndFail skips the following steps and fails the Task.
+	StepOnErrorStopAndFail StepOnError = "stopAndFail"
+	// StepOnErrorContinue reports the exit code of the step but still runs
+	// the following steps, and doesn't fail the Task.
+	StepOnErrorContinue StepOnError = "continue"
+)
+
 // Check that Task may be validated and defaulted.
 var _ apis.Validatable = (*Task)(nil)
 var _ apis.Defaultable = (*Task)(nil)
@@ -89,14 +129,42 @@ type TaskParam struct {
 	Name string `json:"name"`
 	// +optional
 	Description string `json:"description,omitempty"`
+	// Type is the type of the param, string or array. Defaults to string.
+	// +optional
+	Type ParamType `json:"type,omitempty"`
 	// +optional
 	Default string `json:"default,omitempty"`
+	// ArrayDefault is the default value of an array param. It is set from
+	// default when it is a list.
+	// +optional
+	ArrayDefault []string `json:"-"`
+	// Enum lists the values the param may take. For an array param, it applies
+	// to each item.
+	// +optional
+	Enum []string `json:"enum,omitempty"`
+	// Pattern is a regular expression the value of the param must match. For
+	// an array param, it applies to each item.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// TaskResult declares a named value that the steps of a Task can emit. The
+// value is surfaced in the TaskRun's status and can be consumed by other
+// PipelineTasks.
+type TaskResult struct {
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
 }
 
 // Param declares a value to use for the Param called Name.
 type Param struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
+	// ArrayValue is the value of an array param. It is set from value when it
+	// is a list.
+	// +optional
+	ArrayValue []string `json:"-"`
 }
 
 // Outputs allow a task to declare what data the Build/Task will be producing,
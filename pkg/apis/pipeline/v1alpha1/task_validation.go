@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/knative/pkg/apis"
@@ -29,6 +30,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+var resultNameRegex = regexp.MustCompile(`^[_a-zA-Z][_a-zA-Z0-9-]*$`)
+
 func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	if err := validateObjectMetadata(t.GetObjectMeta()); err != nil {
 		return err.ViaField("metadata")
@@ -47,7 +50,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
 	if err := ValidateVolumes(ts.Volumes).ViaField("volumes"); err != nil {
 		return err
 	}
-	mergedSteps, err := merge.CombineStepsWithContainerTemplate(ts.ContainerTemplate, ts.Steps)
+	mergedSteps, err := mergeStepsWithContainerTemplate(ts.ContainerTemplate, ts.Steps)
 	if err != nil {
 		return &apis.FieldError{
 			Message: fmt.Sprintf("error merging container template and steps: %s", err),
@@ -71,6 +74,9 @@ func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
 		if err := checkForDuplicates(ts.Inputs.Resources, "taskspec.Inputs.Resources.Name"); err != nil {
 			return err
 		}
+		if err := validateParamSpecs(taskParamSpecs(ts.Inputs.Params), "taskspec.inputs.params"); err != nil {
+			return err
+		}
 	}
 	if ts.Outputs != nil {
 		for _, resource := range ts.Outputs.Resources {
@@ -94,6 +100,14 @@ func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if err := validateResults(ts.Results); err != nil {
+		return err
+	}
+
+	if err := validateSidecars(ts.Sidecars).ViaField("sidecars"); err != nil {
+		return err
+	}
+
 	if err := validateInputParameterVariables(ts.Steps, ts.Inputs); err != nil {
 		return err
 	}
@@ -115,13 +129,44 @@ func ValidateVolumes(volumes []corev1.Volume) *apis.FieldError {
 	return nil
 }
 
-func validateSteps(steps []corev1.Container) *apis.FieldError {
+// mergeStepsWithContainerTemplate returns steps, with the containers they
+// run based on template.
+func mergeStepsWithContainerTemplate(template *corev1.Container, steps []Step) ([]Step, error) {
+	containers := make([]corev1.Container, 0, len(steps))
+	for _, s := range steps {
+		containers = append(containers, s.Container)
+	}
+	merged, err := merge.CombineStepsWithContainerTemplate(template, containers)
+	if err != nil {
+		return nil, err
+	}
+	mergedSteps := make([]Step, 0, len(steps))
+	for i, s := range steps {
+		s.Container = merged[i]
+		mergedSteps = append(mergedSteps, s)
+	}
+	return mergedSteps, nil
+}
+
+func validateSteps(steps []Step) *apis.FieldError {
 	// Task must not have duplicate step names.
 	names := map[string]struct{}{}
 	for _, s := range steps {
 		if s.Image == "" {
 			return apis.ErrMissingField("Image")
 		}
+		// The script is run instead of the command.
+		if s.Script != "" && len(s.Command) > 0 {
+			return apis.ErrMultipleOneOf("script", "command")
+		}
+		if s.Timeout != nil && s.Timeout.Duration < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", s.Timeout.Duration.String()), "timeout")
+		}
+		switch s.OnError {
+		case "", StepOnErrorStopAndFail, StepOnErrorContinue:
+		default:
+			return apis.ErrInvalidValue(string(s.OnError), "onError")
+		}
 
 		if s.Name == "" {
 			continue
@@ -134,17 +179,105 @@ func validateSteps(steps []corev1.Container) *apis.FieldError {
 	return nil
 }
 
-func validateInputParameterVariables(steps []corev1.Container, inputs *Inputs) *apis.FieldError {
+func validateSidecars(sidecars []corev1.Container) *apis.FieldError {
+	// Sidecars need a name to tell their containers apart from the steps'.
+	names := map[string]struct{}{}
+	for _, s := range sidecars {
+		if s.Image == "" {
+			return apis.ErrMissingField("image")
+		}
+		if errs := validation.IsDNS1123Label(s.Name); len(errs) > 0 {
+			return apis.ErrInvalidValue(s.Name, "name")
+		}
+		if _, ok := names[s.Name]; ok {
+			return apis.ErrMultipleOneOf("name")
+		}
+		names[s.Name] = struct{}{}
+	}
+	return nil
+}
+
+func validateResults(results []TaskResult) *apis.FieldError {
+	// Result names are used as file names and in templating, so they must be
+	// valid identifiers and must not be duplicated.
+	names := map[string]struct{}{}
+	for _, r := range results {
+		if !resultNameRegex.MatchString(r.Name) {
+			return apis.ErrInvalidValue(r.Name, "taskspec.results.name")
+		}
+		if _, ok := names[r.Name]; ok {
+			return apis.ErrMultipleOneOf("taskspec.results.name")
+		}
+		names[r.Name] = struct{}{}
+	}
+	return nil
+}
+
+func validateInputParameterVariables(steps []Step, inputs *Inputs) *apis.FieldError {
 	parameterNames := map[string]struct{}{}
+	arrayParameterNames := map[string]struct{}{}
 	if inputs != nil {
 		for _, p := range inputs.Params {
 			parameterNames[p.Name] = struct{}{}
+			if p.IsArray() {
+				arrayParameterNames[p.Name] = struct{}{}
+			}
 		}
 	}
+	if err := validateArrayUsage(steps, "params", arrayParameterNames); err != nil {
+		return err
+	}
 	return validateVariables(steps, "params", parameterNames)
 }
 
-func validateResourceVariables(steps []corev1.Container, inputs *Inputs, outputs *Outputs) *apis.FieldError {
+// validateArrayUsage checks that the array params are only used as an entire
+// item of the command or args of a step, since they expand to several items.
+// They can't be used in its script either.
+func validateArrayUsage(steps []Step, prefix string, vars map[string]struct{}) *apis.FieldError {
+	for _, step := range steps {
+		if err := validateTaskNoArrayReferenced("name", step.Name, prefix, vars); err != nil {
+			return err
+		}
+		if err := validateTaskNoArrayReferenced("image", step.Image, prefix, vars); err != nil {
+			return err
+		}
+		if err := validateTaskNoArrayReferenced("workingDir", step.WorkingDir, prefix, vars); err != nil {
+			return err
+		}
+		if err := validateTaskNoArrayReferenced("script", step.Script, prefix, vars); err != nil {
+			return err
+		}
+		for i, cmd := range step.Command {
+			if err := validateTaskArraysIsolated(fmt.Sprintf("command[%d]", i), cmd, prefix, vars); err != nil {
+				return err
+			}
+		}
+		for i, arg := range step.Args {
+			if err := validateTaskArraysIsolated(fmt.Sprintf("arg[%d]", i), arg, prefix, vars); err != nil {
+				return err
+			}
+		}
+		for _, env := range step.Env {
+			if err := validateTaskNoArrayReferenced(fmt.Sprintf("env[%s]", env.Name), env.Value, prefix, vars); err != nil {
+				return err
+			}
+		}
+		for i, v := range step.VolumeMounts {
+			if err := validateTaskNoArrayReferenced(fmt.Sprintf("volumeMount[%d].Name", i), v.Name, prefix, vars); err != nil {
+				return err
+			}
+			if err := validateTaskNoArrayReferenced(fmt.Sprintf("volumeMount[%d].MountPath", i), v.MountPath, prefix, vars); err != nil {
+				return err
+			}
+			if err := validateTaskNoArrayReferenced(fmt.Sprintf("volumeMount[%d].SubPath", i), v.SubPath, prefix, vars); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateResourceVariables(steps []Step, inputs *Inputs, outputs *Outputs) *apis.FieldError {
 	resourceNames := map[string]struct{}{}
 	if inputs != nil {
 		for _, r := range inputs.Resources {
@@ -159,7 +292,7 @@ func validateResourceVariables(steps []corev1.Container, inputs *Inputs, outputs
 	return validateVariables(steps, "resources", resourceNames)
 }
 
-func validateVariables(steps []corev1.Container, prefix string, vars map[string]struct{}) *apis.FieldError {
+func validateVariables(steps []Step, prefix string, vars map[string]struct{}) *apis.FieldError {
 	for _, step := range steps {
 		if err := validateTaskVariable("name", step.Name, prefix, vars); err != nil {
 			return err
@@ -170,6 +303,9 @@ func validateVariables(steps []corev1.Container, prefix string, vars map[string]
 		if err := validateTaskVariable("workingDir", step.WorkingDir, prefix, vars); err != nil {
 			return err
 		}
+		if err := validateTaskVariable("script", step.Script, prefix, vars); err != nil {
+			return err
+		}
 		for i, cmd := range step.Command {
 			if err := validateTaskVariable(fmt.Sprintf("command[%d]", i), cmd, prefix, vars); err != nil {
 				return err
@@ -204,6 +340,14 @@ func validateTaskVariable(name, value, prefix string, vars map[string]struct{})
 	return templating.ValidateVariable(name, value, prefix, "(?:inputs|outputs).", "step", "taskspec.steps", vars)
 }
 
+func validateTaskNoArrayReferenced(name, value, prefix string, arrayNames map[string]struct{}) *apis.FieldError {
+	return templating.ValidateVariableProhibited(name, value, prefix, "(?:inputs|outputs).", "step", "taskspec.steps", arrayNames)
+}
+
+func validateTaskArraysIsolated(name, value, prefix string, arrayNames map[string]struct{}) *apis.FieldError {
+	return templating.ValidateVariableIsolated(name, value, prefix, "(?:inputs|outputs).", "step", "taskspec.steps", arrayNames)
+}
+
 func checkForDuplicates(resources []TaskResource, path string) *apis.FieldError {
 	encountered := map[string]struct{}{}
 	for _, r := range resources {
@@ -19,11 +19,13 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/knative/pkg/apis"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var validResource = TaskResource{
@@ -31,22 +33,24 @@ var validResource = TaskResource{
 	Type: "git",
 }
 
-var validBuildSteps = []corev1.Container{{
+var validBuildSteps = []Step{{Container: corev1.Container{
 	Name:  "mystep",
 	Image: "myimage",
-}}
+}}}
 
-var invalidBuildSteps = []corev1.Container{{
+var invalidBuildSteps = []Step{{Container: corev1.Container{
 	Name:  "replaceImage",
 	Image: "myimage",
-}}
+}}}
 
 func TestTaskSpecValidate(t *testing.T) {
 	type fields struct {
 		Inputs            *Inputs
 		Outputs           *Outputs
-		BuildSteps        []corev1.Container
+		BuildSteps        []Step
 		ContainerTemplate *corev1.Container
+		Results           []TaskResult
+		Sidecars          []corev1.Container
 	}
 	tests := []struct {
 		name   string
@@ -102,25 +106,97 @@ func TestTaskSpecValidate(t *testing.T) {
 			Outputs: &Outputs{
 				Resources: []TaskResource{validResource},
 			},
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:       "mystep",
 				Image:      "${inputs.resources.foo.url}",
 				Args:       []string{"--flag=${inputs.params.baz} && ${input.params.foo-is-baz}"},
 				WorkingDir: "/foo/bar/${outputs.resources.source}",
-			}},
+			}}},
+		},
+	}, {
+		name: "valid array param",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{
+					Name:         "flags",
+					Type:         ParamTypeArray,
+					ArrayDefault: []string{"-v"},
+					Enum:         []string{"-v", "-q"},
+				}, {
+					Name:    "level",
+					Enum:    []string{"debug", "info"},
+					Default: "info",
+					Pattern: "^[a-z]+$",
+				}},
+			},
+			BuildSteps: []Step{{Container: corev1.Container{
+				Name:    "mystep",
+				Image:   "myimage",
+				Command: []string{"mycmd", "${inputs.params.flags}"},
+				Args:    []string{"${inputs.params.flags}", "--level=${inputs.params.level}"},
+			}}},
 		},
 	}, {
 		name: "container template included in validation",
 		fields: fields{
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:    "astep",
 				Command: []string{"echo"},
 				Args:    []string{"hello"},
-			}},
+			}}},
 			ContainerTemplate: &corev1.Container{
 				Image: "some-image",
 			},
 		},
+	}, {
+		name: "valid script",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{
+					Name: "baz",
+				}},
+			},
+			BuildSteps: []Step{{
+				Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+					Args:  []string{"arg"},
+				},
+				Script: "#!/usr/bin/env bash\necho ${inputs.params.baz} \"$1\" ${HOME}",
+			}},
+		},
+	}, {
+		name: "valid results",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Results: []TaskResult{{
+				Name:        "digest",
+				Description: "the digest of the built image",
+			}, {
+				Name: "commit_sha",
+			}},
+		},
+	}, {
+		name: "valid timeout and on error",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{Name: "lint", Image: "golangci/golangci-lint"},
+				Timeout:   &metav1.Duration{Duration: 5 * time.Minute},
+				OnError:   StepOnErrorContinue,
+			}},
+		},
+	}, {
+		name: "valid sidecars",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Sidecars: []corev1.Container{{
+				Name:  "db",
+				Image: "postgres",
+			}, {
+				Name:  "registry",
+				Image: "registry:2",
+			}},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -129,6 +205,8 @@ func TestTaskSpecValidate(t *testing.T) {
 				Outputs:           tt.fields.Outputs,
 				Steps:             tt.fields.BuildSteps,
 				ContainerTemplate: tt.fields.ContainerTemplate,
+				Results:           tt.fields.Results,
+				Sidecars:          tt.fields.Sidecars,
 			}
 			if err := ts.Validate(context.Background()); err != nil {
 				t.Errorf("TaskSpec.Validate() = %v", err)
@@ -141,7 +219,9 @@ func TestTaskSpecValidateError(t *testing.T) {
 	type fields struct {
 		Inputs     *Inputs
 		Outputs    *Outputs
-		BuildSteps []corev1.Container
+		BuildSteps []Step
+		Results    []TaskResult
+		Sidecars   []corev1.Container
 	}
 	tests := []struct {
 		name          string
@@ -256,7 +336,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Inputs: &Inputs{
 				Resources: []TaskResource{validResource},
 			},
-			BuildSteps: []corev1.Container{},
+			BuildSteps: []Step{},
 		},
 		expectedError: apis.FieldError{
 			Message: "missing field(s)",
@@ -278,11 +358,11 @@ func TestTaskSpecValidateError(t *testing.T) {
 	}, {
 		name: "inexistent input param variable",
 		fields: fields{
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:  "mystep",
 				Image: "myimage",
 				Args:  []string{"--flag=${inputs.params.inexistent}"},
-			}},
+			}}},
 		},
 		expectedError: apis.FieldError{
 			Message: `non-existent variable in "--flag=${inputs.params.inexistent}" for step arg[0]`,
@@ -291,10 +371,10 @@ func TestTaskSpecValidateError(t *testing.T) {
 	}, {
 		name: "inexistent input resource variable",
 		fields: fields{
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:  "mystep",
 				Image: "myimage:${inputs.resources.inputs}",
-			}},
+			}}},
 		},
 		expectedError: apis.FieldError{
 			Message: `non-existent variable in "myimage:${inputs.resources.inputs}" for step image`,
@@ -303,11 +383,11 @@ func TestTaskSpecValidateError(t *testing.T) {
 	}, {
 		name: "inexistent output param variable",
 		fields: fields{
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:       "mystep",
 				Image:      "myimage",
 				WorkingDir: "/foo/bar/${outputs.resources.inexistent}",
-			}},
+			}}},
 		},
 		expectedError: apis.FieldError{
 			Message: `non-existent variable in "/foo/bar/${outputs.resources.inexistent}" for step workingDir`,
@@ -325,23 +405,240 @@ func TestTaskSpecValidateError(t *testing.T) {
 					},
 				},
 			},
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:  "mystep",
 				Image: "myimage",
 				Args:  []string{"${inputs.params.foo} && ${inputs.params.inexistent}"},
-			}},
+			}}},
 		},
 		expectedError: apis.FieldError{
 			Message: `non-existent variable in "${inputs.params.foo} && ${inputs.params.inexistent}" for step arg[0]`,
 			Paths:   []string{"taskspec.steps.arg[0]"},
 		},
+	}, {
+		name: "invalid param type",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "foo", Type: "object"}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: object`,
+			Paths:   []string{"taskspec.inputs.params.type"},
+		},
+	}, {
+		name: "invalid param pattern",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "foo", Pattern: "[a-z"}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: [a-z`,
+			Paths:   []string{"taskspec.inputs.params.pattern"},
+		},
+	}, {
+		name: "param default not in enum",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "foo", Enum: []string{"a", "b"}, Default: "c"}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid default: param "foo" must be one of ["a" "b"] but was "c"`,
+			Paths:   []string{"taskspec.inputs.params.default"},
+		},
+	}, {
+		name: "array default for string param",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "foo", ArrayDefault: []string{"a"}}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid default: param "foo" is a string but was given the array ["a"]`,
+			Paths:   []string{"taskspec.inputs.params.default"},
+		},
+	}, {
+		name: "duplicated params",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "foo"}, {Name: "foo"}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"taskspec.inputs.params.name"},
+		},
+	}, {
+		name: "array param not isolated in args",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "flags", Type: ParamTypeArray}},
+			},
+			BuildSteps: []Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"--flags=${inputs.params.flags}"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "--flags=${inputs.params.flags}" for step arg[0]`,
+			Paths:   []string{"taskspec.steps.arg[0]"},
+		},
+	}, {
+		name: "array param used in env",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "flags", Type: ParamTypeArray}},
+			},
+			BuildSteps: []Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Env:   []corev1.EnvVar{{Name: "FLAGS", Value: "${inputs.params.flags}"}},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "${inputs.params.flags}" for step env[FLAGS]`,
+			Paths:   []string{"taskspec.steps.env[FLAGS]"},
+		},
+	}, {
+		name: "script and command",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{
+					Name:    "mystep",
+					Image:   "myimage",
+					Command: []string{"echo"},
+				},
+				Script: "echo hello",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"steps.script", "steps.command"},
+		},
+	}, {
+		name: "inexistent param variable in script",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+				},
+				Script: "echo ${inputs.params.baz}",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "echo ${inputs.params.baz}" for step script`,
+			Paths:   []string{"taskspec.steps.script"},
+		},
+	}, {
+		name: "array param used in script",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "flags", Type: ParamTypeArray}},
+			},
+			BuildSteps: []Step{{
+				Container: corev1.Container{
+					Name:  "mystep",
+					Image: "myimage",
+				},
+				Script: "echo ${inputs.params.flags}",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "echo ${inputs.params.flags}" for step script`,
+			Paths:   []string{"taskspec.steps.script"},
+		},
+	}, {
+		name: "invalid result name",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Results:    []TaskResult{{Name: "my.result"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: my.result`,
+			Paths:   []string{"taskspec.results.name"},
+		},
+	}, {
+		name: "duplicated results",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Results:    []TaskResult{{Name: "digest"}, {Name: "digest"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"taskspec.results.name"},
+		},
+	}, {
+		name: "negative step timeout",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{Name: "lint", Image: "golangci/golangci-lint"},
+				Timeout:   &metav1.Duration{Duration: -time.Minute},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1m0s should be >= 0`,
+			Paths:   []string{"steps.timeout"},
+		},
+	}, {
+		name: "invalid on error",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{Name: "lint", Image: "golangci/golangci-lint"},
+				OnError:   "ignore",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: ignore`,
+			Paths:   []string{"steps.onError"},
+		},
+	}, {
+		name: "sidecar without image",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Sidecars:   []corev1.Container{{Name: "db"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"sidecars.image"},
+		},
+	}, {
+		name: "invalid sidecar name",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Sidecars:   []corev1.Container{{Name: "my_db", Image: "postgres"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: my_db`,
+			Paths:   []string{"sidecars.name"},
+		},
+	}, {
+		name: "duplicated sidecars",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Sidecars:   []corev1.Container{{Name: "db", Image: "postgres"}, {Name: "db", Image: "mysql"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"sidecars.name"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &TaskSpec{
-				Inputs:  tt.fields.Inputs,
-				Outputs: tt.fields.Outputs,
-				Steps:   tt.fields.BuildSteps,
+				Inputs:   tt.fields.Inputs,
+				Outputs:  tt.fields.Outputs,
+				Steps:    tt.fields.BuildSteps,
+				Results:  tt.fields.Results,
+				Sidecars: tt.fields.Sidecars,
 			}
 			err := ts.Validate(context.Background())
 			if err == nil {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/knative/pkg/apis"
 	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -54,6 +55,12 @@ type TaskRunSpec struct {
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Time after which the build times out if its pod is still pending, which
+	// includes the time it waits to be scheduled and for its images to be pulled.
+	// It doesn't count towards the timeout, which only starts once the pod is
+	// running. Defaults to the timeout.
+	// +optional
+	PendingTimeout *metav1.Duration `json:"pendingTimeout,omitempty"`
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
 	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
@@ -65,6 +72,11 @@ type TaskRunSpec struct {
 	// If specified, the pod's scheduling constraints
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// CloudEventSink is the URL CloudEvents are sent to when the TaskRun starts,
+	// succeeds, fails or is cancelled. Defaults to the sink of the config-cloudevents
+	// ConfigMap.
+	// +optional
+	CloudEventSink string `json:"cloudEventSink,omitempty"`
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -131,6 +143,11 @@ type TaskRunStatus struct {
 	// +optional
 	StartTime *metav1.Time `json:"startTime,omitempty"`
 
+	// RunningTime is the time the pod of the build left Pending, from which
+	// its timeout counts.
+	// +optional
+	RunningTime *metav1.Time `json:"runningTime,omitempty"`
+
 	// CompletionTime is the time the build completed.
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
@@ -138,6 +155,15 @@ type TaskRunStatus struct {
 	// Steps describes the state of each build step container.
 	// +optional
 	Steps []StepState `json:"steps,omitempty"`
+
+	// Sidecars describes the state of each sidecar container.
+	// +optional
+	Sidecars []SidecarState `json:"sidecars,omitempty"`
+
+	// TaskResults are the values emitted by the steps for the results
+	// declared by the Task.
+	// +optional
+	TaskResults []TaskRunResult `json:"taskResults,omitempty"`
 }
 
 // GetCondition returns the Condition matching the given type.
@@ -162,12 +188,59 @@ func (tr *TaskRunStatus) SetCondition(newCond *apis.Condition) {
 	}
 }
 
+// TaskRunResult is the value emitted for one of the Task's declared results.
+type TaskRunResult struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// GetTaskResult returns the value emitted for the result called name, and
+// whether such a value was emitted at all.
+func (tr *TaskRunStatus) GetTaskResult(name string) (string, bool) {
+	for _, r := range tr.TaskResults {
+		if r.Name == name {
+			return r.Value, true
+		}
+	}
+	return "", false
+}
+
 // StepState reports the results of running a step in the Task.
 type StepState struct {
 	corev1.ContainerState
 	Name string `json:"name,omitempty"`
+	// Outcome is how the step went once it has terminated.
+	// +optional
+	Outcome StepOutcome `json:"outcome,omitempty"`
+	// ImageID is the resolved digest of the image the step ran.
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
 }
 
+// SidecarState reports the state of a sidecar running alongside the steps
+// of the Task.
+type SidecarState struct {
+	corev1.ContainerState
+	Name string `json:"name,omitempty"`
+	// ImageID is the resolved digest of the image the sidecar runs.
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+}
+
+// StepOutcome is how a step went once it has terminated.
+type StepOutcome string
+
+const (
+	// StepOutcomeSucceeded indicates that the step ran and exited with code 0.
+	StepOutcomeSucceeded StepOutcome = "Succeeded"
+	// StepOutcomeFailed indicates that the step ran and exited with a non-zero code.
+	StepOutcomeFailed StepOutcome = "Failed"
+	// StepOutcomeSkipped indicates that the step didn't run because a previous step failed.
+	StepOutcomeSkipped StepOutcome = "Skipped"
+	// StepOutcomeTimedOut indicates that the step was killed for running longer than its timeout.
+	StepOutcomeTimedOut StepOutcome = "TimedOut"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -209,6 +282,10 @@ func (tr *TaskRun) GetPipelineRunPVCName() string {
 	if tr == nil {
 		return ""
 	}
+	// TaskRuns of a resumed PipelineRun use the PVC of the PipelineRun they resume from
+	if name := tr.Labels[pipeline.GroupName+pipeline.ArtifactStorageKey]; name != "" {
+		return fmt.Sprintf("%s-pvc", name)
+	}
 	for _, ref := range tr.GetOwnerReferences() {
 		if ref.Kind == pipelineRunControllerName {
 			return fmt.Sprintf("%s-pvc", ref.Name)
@@ -74,6 +74,22 @@ func TestTaskRun_GetPipelineRunPVCName(t *testing.T) {
 			},
 		},
 		expectedPVCName: "testpr-pvc",
+	}, {
+		name: "resumed pipelinerun owner",
+		tr: &TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "taskrunname",
+				Namespace: "testns",
+				Labels: map[string]string{
+					"tekton.dev/artifactStorage": "previouspr",
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					Kind: "PipelineRun",
+					Name: "testpr",
+				}},
+			},
+		},
+		expectedPVCName: "previouspr-pvc",
 	}, {
 		name:            "nil taskrun",
 		expectedPVCName: "",
@@ -64,6 +64,17 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		return err
 	}
 
+	// check the params against the ones declared by an embedded task
+	if ts.TaskSpec != nil {
+		var declared []TaskParam
+		if ts.TaskSpec.Inputs != nil {
+			declared = ts.TaskSpec.Inputs.Params
+		}
+		if err := ValidateTaskParams(declared, ts.Inputs.Params); err != nil {
+			return apis.ErrInvalidValue(err.Error(), "spec.inputs.params")
+		}
+	}
+
 	// check for results
 	if ts.Results != nil {
 		if err := ts.Results.Validate(ctx, "spec.results"); err != nil {
@@ -71,6 +82,14 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if ts.PendingTimeout != nil && ts.PendingTimeout.Duration <= 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", ts.PendingTimeout.Duration.String()), "spec.pendingTimeout")
+	}
+
+	if err := validateURL(ts.CloudEventSink, "spec.cloudEventSink"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -18,6 +18,7 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/knative/pkg/apis"
@@ -137,10 +138,10 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 					Name: "taskrefname",
 				},
 				TaskSpec: &TaskSpec{
-					Steps: []corev1.Container{{
+					Steps: []Step{{Container: corev1.Container{
 						Name:  "mystep",
 						Image: "myimage",
-					}},
+					}}},
 				},
 				Trigger: TaskTrigger{
 					Type: "manual",
@@ -148,6 +149,71 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			},
 			wantErr: apis.ErrDisallowedFields("spec.taskspec", "spec.taskref"),
 		},
+		{
+			name: "negative pending timeout",
+			spec: TaskRunSpec{
+				TaskRef: &TaskRef{
+					Name: "taskrefname",
+				},
+				Trigger: TaskTrigger{
+					Type: "manual",
+				},
+				PendingTimeout: &metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: apis.ErrInvalidValue("-1m0s should be > 0", "spec.pendingTimeout"),
+		},
+		{
+			name: "invalid cloudevent sink",
+			spec: TaskRunSpec{
+				TaskRef: &TaskRef{
+					Name: "taskrefname",
+				},
+				Trigger: TaskTrigger{
+					Type: "manual",
+				},
+				CloudEventSink: "not a url",
+			},
+			wantErr: apis.ErrInvalidValue("not a url", "spec.cloudEventSink"),
+		},
+		{
+			name: "param not matching the embedded taskspec",
+			spec: TaskRunSpec{
+				TaskSpec: &TaskSpec{
+					Inputs: &Inputs{
+						Params: []TaskParam{{Name: "level", Enum: []string{"debug", "info"}}},
+					},
+					Steps: []Step{{Container: corev1.Container{
+						Name:  "mystep",
+						Image: "myimage",
+					}}},
+				},
+				Inputs: TaskRunInputs{
+					Params: []Param{{Name: "level", Value: "trace"}},
+				},
+				Trigger: TaskTrigger{
+					Type: "manual",
+				},
+			},
+			wantErr: apis.ErrInvalidValue(`param "level" must be one of ["debug" "info"] but was "trace"`, "spec.inputs.params"),
+		},
+		{
+			name: "param missing for the embedded taskspec",
+			spec: TaskRunSpec{
+				TaskSpec: &TaskSpec{
+					Inputs: &Inputs{
+						Params: []TaskParam{{Name: "flags", Type: ParamTypeArray}},
+					},
+					Steps: []Step{{Container: corev1.Container{
+						Name:  "mystep",
+						Image: "myimage",
+					}}},
+				},
+				Trigger: TaskTrigger{
+					Type: "manual",
+				},
+			},
+			wantErr: apis.ErrInvalidValue("missing values for these params which have no default values: [flags]", "spec.inputs.params"),
+		},
 	}
 
 	for _, ts := range tests {
@@ -179,6 +245,8 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 					URL:  "http://www.google.com",
 					Type: "gcs",
 				},
+				Timeout:        &metav1.Duration{Duration: time.Hour},
+				PendingTimeout: &metav1.Duration{Duration: 5 * time.Minute},
 			},
 		},
 		{
@@ -197,10 +265,10 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 			name: "taskspec without a taskRef",
 			spec: TaskRunSpec{
 				TaskSpec: &TaskSpec{
-					Steps: []corev1.Container{{
+					Steps: []Step{{Container: corev1.Container{
 						Name:  "mystep",
 						Image: "myimage",
-					}},
+					}}},
 				},
 				Trigger: TaskTrigger{
 					Type: "PiPeLiNeRuN",
@@ -208,6 +276,34 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "params matching the embedded taskspec",
+			spec: TaskRunSpec{
+				TaskSpec: &TaskSpec{
+					Inputs: &Inputs{
+						Params: []TaskParam{{
+							Name: "flags",
+							Type: ParamTypeArray,
+						}, {
+							Name:    "level",
+							Pattern: "^(debug|info)$",
+							Default: "info",
+						}},
+					},
+					Steps: []Step{{Container: corev1.Container{
+						Name:  "mystep",
+						Image: "myimage",
+						Args:  []string{"${inputs.params.flags}"},
+					}}},
+				},
+				Inputs: TaskRunInputs{
+					Params: []Param{{Name: "flags", ArrayValue: []string{"-v", "-q"}}},
+				},
+				Trigger: TaskTrigger{
+					Type: "manual",
+				},
+			},
+		},
 	}
 
 	for _, ts := range tests {
@@ -26,6 +26,154 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Approval) DeepCopyInto(out *Approval) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Approval.
+func (in *Approval) DeepCopy() *Approval {
+	if in == nil {
+		return nil
+	}
+	out := new(Approval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Approval) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalGate) DeepCopyInto(out *ApprovalGate) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalGate.
+func (in *ApprovalGate) DeepCopy() *ApprovalGate {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalList) DeepCopyInto(out *ApprovalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Approval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalList.
+func (in *ApprovalList) DeepCopy() *ApprovalList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApprovalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalSpec) DeepCopyInto(out *ApprovalSpec) {
+	*out = *in
+	in.ApprovalGate.DeepCopyInto(&out.ApprovalGate)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalSpec.
+func (in *ApprovalSpec) DeepCopy() *ApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalStatus) DeepCopyInto(out *ApprovalStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalStatus.
+func (in *ApprovalStatus) DeepCopy() *ApprovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactBucket) DeepCopyInto(out *ArtifactBucket) {
 	*out = *in
@@ -165,6 +313,95 @@ func (in *ClusterTaskList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Condition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionList) DeepCopyInto(out *ConditionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionList.
+func (in *ConditionList) DeepCopy() *ConditionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConditionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionSpec) DeepCopyInto(out *ConditionSpec) {
+	*out = *in
+	in.Check.DeepCopyInto(&out.Check)
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]TaskParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]TaskResource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionSpec.
+func (in *ConditionSpec) DeepCopy() *ConditionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DAG) DeepCopyInto(out *DAG) {
 	*out = *in
@@ -194,94 +431,256 @@ func (in *DAG) DeepCopy() *DAG {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCSResource) DeepCopyInto(out *GCSResource) {
+func (in *EventResource) DeepCopyInto(out *EventResource) {
 	*out = *in
-	if in.Secrets != nil {
-		in, out := &in.Secrets, &out.Secrets
-		*out = make([]SecretParam, len(*in))
-		copy(*out, *in)
-	}
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSResource.
-func (in *GCSResource) DeepCopy() *GCSResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventResource.
+func (in *EventResource) DeepCopy() *EventResource {
 	if in == nil {
 		return nil
 	}
-	out := new(GCSResource)
+	out := new(EventResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitResource) DeepCopyInto(out *GitResource) {
+func (in *EventSignature) DeepCopyInto(out *EventSignature) {
 	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitResource.
-func (in *GitResource) DeepCopy() *GitResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSignature.
+func (in *EventSignature) DeepCopy() *EventSignature {
 	if in == nil {
 		return nil
 	}
-	out := new(GitResource)
+	out := new(EventSignature)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageResource) DeepCopyInto(out *ImageResource) {
+func (in *EventTrigger) DeepCopyInto(out *EventTrigger) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageResource.
-func (in *ImageResource) DeepCopy() *ImageResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventTrigger.
+func (in *EventTrigger) DeepCopy() *EventTrigger {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageResource)
+	out := new(EventTrigger)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EventTrigger) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Inputs) DeepCopyInto(out *Inputs) {
+func (in *EventTriggerList) DeepCopyInto(out *EventTriggerList) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]TaskResource, len(*in))
-		copy(*out, *in)
-	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]TaskParam, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EventTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Inputs.
-func (in *Inputs) DeepCopy() *Inputs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventTriggerList.
+func (in *EventTriggerList) DeepCopy() *EventTriggerList {
 	if in == nil {
 		return nil
 	}
-	out := new(Inputs)
+	out := new(EventTriggerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EventTriggerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Node) DeepCopyInto(out *Node) {
+func (in *EventTriggerSpec) DeepCopyInto(out *EventTriggerSpec) {
 	*out = *in
-	in.Task.DeepCopyInto(&out.Task)
-	if in.Prev != nil {
-		in, out := &in.Prev, &out.Prev
-		*out = make([]*Node, len(*in))
-		for i := range *in {
-			if (*in)[i] == nil {
-				(*out)[i] = nil
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Signature != nil {
+		in, out := &in.Signature, &out.Signature
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(EventSignature)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]EventResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.PipelineRunTemplate.DeepCopyInto(&out.PipelineRunTemplate)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventTriggerSpec.
+func (in *EventTriggerSpec) DeepCopy() *EventTriggerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventTriggerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCSResource) DeepCopyInto(out *GCSResource) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretParam, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCSResource.
+func (in *GCSResource) DeepCopy() *GCSResource {
+	if in == nil {
+		return nil
+	}
+	out := new(GCSResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitResource) DeepCopyInto(out *GitResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitResource.
+func (in *GitResource) DeepCopy() *GitResource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageResource) DeepCopyInto(out *ImageResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageResource.
+func (in *ImageResource) DeepCopy() *ImageResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Inputs) DeepCopyInto(out *Inputs) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]TaskResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]TaskParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Inputs.
+func (in *Inputs) DeepCopy() *Inputs {
+	if in == nil {
+		return nil
+	}
+	out := new(Inputs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixParam) DeepCopyInto(out *MatrixParam) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixParam.
+func (in *MatrixParam) DeepCopy() *MatrixParam {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Node) DeepCopyInto(out *Node) {
+	*out = *in
+	in.Task.DeepCopyInto(&out.Task)
+	if in.Prev != nil {
+		in, out := &in.Prev, &out.Prev
+		*out = make([]*Node, len(*in))
+		for i := range *in {
+			if (*in)[i] == nil {
+				(*out)[i] = nil
 			} else {
 				(*out)[i] = new(Node)
 				(*in)[i].DeepCopyInto((*out)[i])
@@ -342,6 +741,11 @@ func (in *Outputs) DeepCopy() *Outputs {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Param) DeepCopyInto(out *Param) {
 	*out = *in
+	if in.ArrayValue != nil {
+		in, out := &in.ArrayValue, &out.ArrayValue
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -383,6 +787,22 @@ func (in *Pipeline) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineConditionResource) DeepCopyInto(out *PipelineConditionResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineConditionResource.
+func (in *PipelineConditionResource) DeepCopy() *PipelineConditionResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineConditionResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineDeclaredResource) DeepCopyInto(out *PipelineDeclaredResource) {
 	*out = *in
@@ -435,6 +855,16 @@ func (in *PipelineList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineParam) DeepCopyInto(out *PipelineParam) {
 	*out = *in
+	if in.ArrayDefault != nil {
+		in, out := &in.ArrayDefault, &out.ArrayDefault
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -564,7 +994,9 @@ func (in *PipelineResourceSpec) DeepCopyInto(out *PipelineResourceSpec) {
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]Param, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.SecretParams != nil {
 		in, out := &in.SecretParams, &out.SecretParams
@@ -628,6 +1060,89 @@ func (in *PipelineRun) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunApprovalStatus) DeepCopyInto(out *PipelineRunApprovalStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ApprovalStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunApprovalStatus.
+func (in *PipelineRunApprovalStatus) DeepCopy() *PipelineRunApprovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunApprovalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunCommitStatus) DeepCopyInto(out *PipelineRunCommitStatus) {
+	*out = *in
+	in.TokenSecretRef.DeepCopyInto(&out.TokenSecretRef)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunCommitStatus.
+func (in *PipelineRunCommitStatus) DeepCopy() *PipelineRunCommitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunCommitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunConcurrency) DeepCopyInto(out *PipelineRunConcurrency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunConcurrency.
+func (in *PipelineRunConcurrency) DeepCopy() *PipelineRunConcurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunConcurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunConditionCheckStatus) DeepCopyInto(out *PipelineRunConditionCheckStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TaskRunStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunConditionCheckStatus.
+func (in *PipelineRunConditionCheckStatus) DeepCopy() *PipelineRunConditionCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunConditionCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunList) DeepCopyInto(out *PipelineRunList) {
 	*out = *in
@@ -662,36 +1177,130 @@ func (in *PipelineRunList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
+func (in *PipelineRunPipelineRunStatus) DeepCopyInto(out *PipelineRunPipelineRunStatus) {
 	*out = *in
-	out.PipelineRef = in.PipelineRef
-	out.Trigger = in.Trigger
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]PipelineResourceBinding, len(*in))
-		copy(*out, *in)
-	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]Param, len(*in))
-		copy(*out, *in)
-	}
-	if in.Results != nil {
-		in, out := &in.Results, &out.Results
-		if *in == nil {
-			*out = nil
-		} else {
-			*out = new(Results)
-			**out = **in
-		}
-	}
-	if in.Timeout != nil {
-		in, out := &in.Timeout, &out.Timeout
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
 		if *in == nil {
 			*out = nil
 		} else {
-			*out = new(v1.Duration)
-			**out = **in
+			*out = new(PipelineRunStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunPipelineRunStatus.
+func (in *PipelineRunPipelineRunStatus) DeepCopy() *PipelineRunPipelineRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunPipelineRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunRef) DeepCopyInto(out *PipelineRunRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunRef.
+func (in *PipelineRunRef) DeepCopy() *PipelineRunRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunRunStatus) DeepCopyInto(out *PipelineRunRunStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(RunStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunRunStatus.
+func (in *PipelineRunRunStatus) DeepCopy() *PipelineRunRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
+	*out = *in
+	if in.PipelineRef != nil {
+		in, out := &in.PipelineRef, &out.PipelineRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineRef)
+			**out = **in
+		}
+	}
+	if in.PipelineSpec != nil {
+		in, out := &in.PipelineSpec, &out.PipelineSpec
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineSpec)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	out.Trigger = in.Trigger
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PipelineResourceBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(Results)
+			**out = **in
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineRunConcurrency)
+			**out = **in
 		}
 	}
 	if in.NodeSelector != nil {
@@ -717,6 +1326,24 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.CommitStatus != nil {
+		in, out := &in.CommitStatus, &out.CommitStatus
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineRunCommitStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ResumeFrom != nil {
+		in, out := &in.ResumeFrom, &out.ResumeFrom
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineRunRef)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -773,6 +1400,74 @@ func (in *PipelineRunStatus) DeepCopyInto(out *PipelineRunStatus) {
 			}
 		}
 	}
+	if in.PipelineRuns != nil {
+		in, out := &in.PipelineRuns, &out.PipelineRuns
+		*out = make(map[string]*PipelineRunPipelineRunStatus, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(PipelineRunPipelineRunStatus)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
+	if in.Approvals != nil {
+		in, out := &in.Approvals, &out.Approvals
+		*out = make(map[string]*PipelineRunApprovalStatus, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(PipelineRunApprovalStatus)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = make(map[string]*PipelineRunRunStatus, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(PipelineRunRunStatus)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
+	if in.SkippedTasks != nil {
+		in, out := &in.SkippedTasks, &out.SkippedTasks
+		*out = make([]SkippedTask, len(*in))
+		copy(*out, *in)
+	}
+	if in.PausedTime != nil {
+		in, out := &in.PausedTime, &out.PausedTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PausedDuration != nil {
+		in, out := &in.PausedDuration, &out.PausedDuration
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
+	if in.CommitStatus != nil {
+		in, out := &in.CommitStatus, &out.CommitStatus
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ReportedCommitStatus)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -789,6 +1484,13 @@ func (in *PipelineRunStatus) DeepCopy() *PipelineRunStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunTaskRunStatus) DeepCopyInto(out *PipelineRunTaskRunStatus) {
 	*out = *in
+	if in.MatrixParams != nil {
+		in, out := &in.MatrixParams, &out.MatrixParams
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		if *in == nil {
@@ -798,6 +1500,23 @@ func (in *PipelineRunTaskRunStatus) DeepCopyInto(out *PipelineRunTaskRunStatus)
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.PreviousAttempts != nil {
+		in, out := &in.PreviousAttempts, &out.PreviousAttempts
+		*out = make([]PipelineTaskAttempt, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConditionChecks != nil {
+		in, out := &in.ConditionChecks, &out.ConditionChecks
+		*out = make(map[string]*PipelineRunConditionCheckStatus, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(PipelineRunConditionCheckStatus)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
 	return
 }
 
@@ -812,231 +1531,730 @@ func (in *PipelineRunTaskRunStatus) DeepCopy() *PipelineRunTaskRunStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
+func (in *PipelineRunTemplate) DeepCopyInto(out *PipelineRunTemplate) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunTemplate.
+func (in *PipelineRunTemplate) DeepCopy() *PipelineRunTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PipelineDeclaredResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]PipelineTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]PipelineParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Finally != nil {
+		in, out := &in.Finally, &out.Finally
+		*out = make([]PipelineTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSpec.
+func (in *PipelineSpec) DeepCopy() *PipelineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineStatus) DeepCopyInto(out *PipelineStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStatus.
+func (in *PipelineStatus) DeepCopy() *PipelineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
+	*out = *in
+	if in.TaskRef != nil {
+		in, out := &in.TaskRef, &out.TaskRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TaskRef)
+			**out = **in
+		}
+	}
+	if in.TaskSpec != nil {
+		in, out := &in.TaskSpec, &out.TaskSpec
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TaskSpec)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PipelineRef != nil {
+		in, out := &in.PipelineRef, &out.PipelineRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineRef)
+			**out = **in
+		}
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ApprovalGate)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.RunAfter != nil {
+		in, out := &in.RunAfter, &out.RunAfter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]PipelineTaskCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineTaskResources)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = make([]MatrixParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
+	if in.PendingTimeout != nil {
+		in, out := &in.PendingTimeout, &out.PendingTimeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTask.
+func (in *PipelineTask) DeepCopy() *PipelineTask {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskAttempt) DeepCopyInto(out *PipelineTaskAttempt) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskAttempt.
+func (in *PipelineTaskAttempt) DeepCopy() *PipelineTaskAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskCondition) DeepCopyInto(out *PipelineTaskCondition) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PipelineConditionResource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskCondition.
+func (in *PipelineTaskCondition) DeepCopy() *PipelineTaskCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskInputResource) DeepCopyInto(out *PipelineTaskInputResource) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskInputResource.
+func (in *PipelineTaskInputResource) DeepCopy() *PipelineTaskInputResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskInputResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskOutputResource) DeepCopyInto(out *PipelineTaskOutputResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskOutputResource.
+func (in *PipelineTaskOutputResource) DeepCopy() *PipelineTaskOutputResource {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskOutputResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskParam) DeepCopyInto(out *PipelineTaskParam) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskParam.
+func (in *PipelineTaskParam) DeepCopy() *PipelineTaskParam {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskResources) DeepCopyInto(out *PipelineTaskResources) {
+	*out = *in
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]PipelineTaskInputResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]PipelineTaskOutputResource, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskResources.
+func (in *PipelineTaskResources) DeepCopy() *PipelineTaskResources {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskRun) DeepCopyInto(out *PipelineTaskRun) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRun.
+func (in *PipelineTaskRun) DeepCopy() *PipelineTaskRun {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTrigger) DeepCopyInto(out *PipelineTrigger) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTrigger.
+func (in *PipelineTrigger) DeepCopy() *PipelineTrigger {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTrigger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportedCommitStatus) DeepCopyInto(out *ReportedCommitStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportedCommitStatus.
+func (in *ReportedCommitStatus) DeepCopy() *ReportedCommitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportedCommitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultRef) DeepCopyInto(out *ResultRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultRef.
+func (in *ResultRef) DeepCopy() *ResultRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Results) DeepCopyInto(out *Results) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Results.
+func (in *Results) DeepCopy() *Results {
+	if in == nil {
+		return nil
+	}
+	out := new(Results)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Run) DeepCopyInto(out *Run) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Run.
+func (in *Run) DeepCopy() *Run {
+	if in == nil {
+		return nil
+	}
+	out := new(Run)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Run) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunList) DeepCopyInto(out *RunList) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]PipelineDeclaredResource, len(*in))
-		copy(*out, *in)
-	}
-	if in.Tasks != nil {
-		in, out := &in.Tasks, &out.Tasks
-		*out = make([]PipelineTask, len(*in))
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Run, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]PipelineParam, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineSpec.
-func (in *PipelineSpec) DeepCopy() *PipelineSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunList.
+func (in *RunList) DeepCopy() *RunList {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineSpec)
+	out := new(RunList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineStatus) DeepCopyInto(out *PipelineStatus) {
+func (in *RunSpec) DeepCopyInto(out *RunSpec) {
 	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TaskRef)
+			**out = **in
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineStatus.
-func (in *PipelineStatus) DeepCopy() *PipelineStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunSpec.
+func (in *RunSpec) DeepCopy() *RunSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineStatus)
+	out := new(RunSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
+func (in *RunStatus) DeepCopyInto(out *RunStatus) {
 	*out = *in
-	out.TaskRef = in.TaskRef
-	if in.RunAfter != nil {
-		in, out := &in.RunAfter, &out.RunAfter
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.Status.DeepCopyInto(&out.Status)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
 		if *in == nil {
 			*out = nil
 		} else {
-			*out = new(PipelineTaskResources)
+			*out = new(v1.Time)
 			(*in).DeepCopyInto(*out)
 		}
 	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make([]Param, len(*in))
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]TaskRunResult, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTask.
-func (in *PipelineTask) DeepCopy() *PipelineTask {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunStatus.
+func (in *RunStatus) DeepCopy() *RunStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTask)
+	out := new(RunStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskInputResource) DeepCopyInto(out *PipelineTaskInputResource) {
+func (in *ScheduledPipelineRun) DeepCopyInto(out *ScheduledPipelineRun) {
 	*out = *in
-	if in.From != nil {
-		in, out := &in.From, &out.From
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskInputResource.
-func (in *PipelineTaskInputResource) DeepCopy() *PipelineTaskInputResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledPipelineRun.
+func (in *ScheduledPipelineRun) DeepCopy() *ScheduledPipelineRun {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskInputResource)
+	out := new(ScheduledPipelineRun)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledPipelineRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskOutputResource) DeepCopyInto(out *PipelineTaskOutputResource) {
+func (in *ScheduledPipelineRunList) DeepCopyInto(out *ScheduledPipelineRunList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScheduledPipelineRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskOutputResource.
-func (in *PipelineTaskOutputResource) DeepCopy() *PipelineTaskOutputResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledPipelineRunList.
+func (in *ScheduledPipelineRunList) DeepCopy() *ScheduledPipelineRunList {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskOutputResource)
+	out := new(ScheduledPipelineRunList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledPipelineRunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskParam) DeepCopyInto(out *PipelineTaskParam) {
+func (in *ScheduledPipelineRunSpec) DeepCopyInto(out *ScheduledPipelineRunSpec) {
 	*out = *in
+	in.PipelineRunTemplate.DeepCopyInto(&out.PipelineRunTemplate)
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int32)
+			**out = **in
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskParam.
-func (in *PipelineTaskParam) DeepCopy() *PipelineTaskParam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledPipelineRunSpec.
+func (in *ScheduledPipelineRunSpec) DeepCopy() *ScheduledPipelineRunSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskParam)
+	out := new(ScheduledPipelineRunSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskResources) DeepCopyInto(out *PipelineTaskResources) {
+func (in *ScheduledPipelineRunStatus) DeepCopyInto(out *ScheduledPipelineRunStatus) {
 	*out = *in
-	if in.Inputs != nil {
-		in, out := &in.Inputs, &out.Inputs
-		*out = make([]PipelineTaskInputResource, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Time)
+			(*in).DeepCopyInto(*out)
 		}
 	}
-	if in.Outputs != nil {
-		in, out := &in.Outputs, &out.Outputs
-		*out = make([]PipelineTaskOutputResource, len(*in))
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastMissedTime != nil {
+		in, out := &in.LastMissedTime, &out.LastMissedTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskResources.
-func (in *PipelineTaskResources) DeepCopy() *PipelineTaskResources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledPipelineRunStatus.
+func (in *ScheduledPipelineRunStatus) DeepCopy() *ScheduledPipelineRunStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskResources)
+	out := new(ScheduledPipelineRunStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskRun) DeepCopyInto(out *PipelineTaskRun) {
+func (in *SecretParam) DeepCopyInto(out *SecretParam) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRun.
-func (in *PipelineTaskRun) DeepCopy() *PipelineTaskRun {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretParam.
+func (in *SecretParam) DeepCopy() *SecretParam {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskRun)
+	out := new(SecretParam)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTrigger) DeepCopyInto(out *PipelineTrigger) {
+func (in *SidecarState) DeepCopyInto(out *SidecarState) {
 	*out = *in
+	in.ContainerState.DeepCopyInto(&out.ContainerState)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTrigger.
-func (in *PipelineTrigger) DeepCopy() *PipelineTrigger {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarState.
+func (in *SidecarState) DeepCopy() *SidecarState {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTrigger)
+	out := new(SidecarState)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Results) DeepCopyInto(out *Results) {
+func (in *SkippedTask) DeepCopyInto(out *SkippedTask) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Results.
-func (in *Results) DeepCopy() *Results {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkippedTask.
+func (in *SkippedTask) DeepCopy() *SkippedTask {
 	if in == nil {
 		return nil
 	}
-	out := new(Results)
+	out := new(SkippedTask)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretParam) DeepCopyInto(out *SecretParam) {
+func (in *Step) DeepCopyInto(out *Step) {
 	*out = *in
+	in.Container.DeepCopyInto(&out.Container)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretParam.
-func (in *SecretParam) DeepCopy() *SecretParam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Step.
+func (in *Step) DeepCopy() *Step {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretParam)
+	out := new(Step)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1121,6 +2339,16 @@ func (in *TaskList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskParam) DeepCopyInto(out *TaskParam) {
 	*out = *in
+	if in.ArrayDefault != nil {
+		in, out := &in.ArrayDefault, &out.ArrayDefault
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1197,6 +2425,22 @@ func (in *TaskResourceBinding) DeepCopy() *TaskResourceBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskResult) DeepCopyInto(out *TaskResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskResult.
+func (in *TaskResult) DeepCopy() *TaskResult {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRun) DeepCopyInto(out *TaskRun) {
 	*out = *in
@@ -1238,7 +2482,9 @@ func (in *TaskRunInputs) DeepCopyInto(out *TaskRunInputs) {
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]Param, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -1299,7 +2545,9 @@ func (in *TaskRunOutputs) DeepCopyInto(out *TaskRunOutputs) {
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]Param, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -1314,6 +2562,22 @@ func (in *TaskRunOutputs) DeepCopy() *TaskRunOutputs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunResult) DeepCopyInto(out *TaskRunResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunResult.
+func (in *TaskRunResult) DeepCopy() *TaskRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 	*out = *in
@@ -1356,6 +2620,15 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 			**out = **in
 		}
 	}
+	if in.PendingTimeout != nil {
+		in, out := &in.PendingTimeout, &out.PendingTimeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -1414,6 +2687,15 @@ func (in *TaskRunStatus) DeepCopyInto(out *TaskRunStatus) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.RunningTime != nil {
+		in, out := &in.RunningTime, &out.RunningTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	if in.CompletionTime != nil {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		if *in == nil {
@@ -1430,6 +2712,18 @@ func (in *TaskRunStatus) DeepCopyInto(out *TaskRunStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]SidecarState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TaskResults != nil {
+		in, out := &in.TaskResults, &out.TaskResults
+		*out = make([]TaskRunResult, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1466,7 +2760,7 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	}
 	if in.Steps != nil {
 		in, out := &in.Steps, &out.Steps
-		*out = make([]core_v1.Container, len(*in))
+		*out = make([]Step, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1487,6 +2781,18 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]TaskResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]core_v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -189,6 +189,56 @@ func TestInitializeArtifactStorageWithoutConfigMap(t *testing.T) {
 	}
 }
 
+func TestInitializeArtifactStorageResumed(t *testing.T) {
+	logger := logtesting.TestLogger(t)
+	pipelinerun := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Name:      "pipelineruntest-resumed",
+		},
+		Spec: v1alpha1.PipelineRunSpec{
+			ResumeFrom: &v1alpha1.PipelineRunRef{Name: "pipelineruntest"},
+		},
+		Status: v1alpha1.PipelineRunStatus{
+			ArtifactStorage: "pipelineruntest",
+		},
+	}
+	fakekubeclient := fakek8s.NewSimpleClientset(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Name:      "pipelineruntest-pvc",
+		},
+	})
+
+	pvc, err := InitializeArtifactStorage(pipelinerun, fakekubeclient, logger)
+	if err != nil {
+		t.Fatalf("Somehow had error initializing artifact storage run out of fake client: %s", err)
+	}
+	expectedArtifactPVC := &v1alpha1.ArtifactPVC{
+		Name: "pipelineruntest",
+	}
+	if diff := cmp.Diff(pvc, expectedArtifactPVC); diff != "" {
+		t.Fatalf("want %v, but got %v", expectedArtifactPVC, pvc)
+	}
+	for _, a := range fakekubeclient.Actions() {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected the PVC of the resumed pipelinerun to be reused but got action %v", a)
+		}
+	}
+	claim, err := fakekubeclient.CoreV1().PersistentVolumeClaims("foo").Get("pipelineruntest-pvc", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting the PVC out of fake client: %s", err)
+	}
+	expectedOwners := []metav1.OwnerReference{{APIVersion: "tekton.dev/v1alpha1", Kind: "PipelineRun", Name: "pipelineruntest-resumed"}}
+	if diff := cmp.Diff(expectedOwners, claim.OwnerReferences); diff != "" {
+		t.Errorf("Expected the resumed pipelinerun to own the PVC (-want, +got): %s", diff)
+	}
+
+	if _, err := InitializeArtifactStorage(pipelinerun, fakek8s.NewSimpleClientset(), logger); err == nil {
+		t.Error("Expected an error when the PVC of the resumed pipelinerun doesn't exist")
+	}
+}
+
 func TestGetArtifactStorageWithConfigMap(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 	prName := "pipelineruntest"
@@ -53,7 +53,7 @@ func InitializeArtifactStorage(pr *v1alpha1.PipelineRun, c kubernetes.Interface,
 		if err != nil {
 			return nil, err
 		}
-		return &v1alpha1.ArtifactPVC{Name: pr.Name}, nil
+		return &v1alpha1.ArtifactPVC{Name: pr.GetArtifactStorageName()}, nil
 	}
 
 	return NewArtifactBucketConfigFromConfigMap(configMap)
@@ -123,8 +123,14 @@ func NewArtifactBucketConfigFromConfigMap(configMap *corev1.ConfigMap) (*v1alpha
 }
 
 func createPVC(pr *v1alpha1.PipelineRun, c kubernetes.Interface) error {
-	if _, err := c.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(getPVCName(pr), metav1.GetOptions{}); err != nil {
+	pvc, err := c.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(getPVCName(pr), metav1.GetOptions{})
+	if err != nil {
 		if errors.IsNotFound(err) {
+			// A resumed PipelineRun needs the artifacts of the PipelineRun it resumes
+			// from, so an empty PVC can't be used instead
+			if pr.GetArtifactStorageName() != pr.Name {
+				return fmt.Errorf("Persistent Volume %q of PipelineRun %q to resume from doesn't exist", getPVCName(pr), pr.GetArtifactStorageName())
+			}
 			pvc := getPVCSpec(pr)
 			if _, err := c.CoreV1().PersistentVolumeClaims(pr.Namespace).Create(pvc); err != nil {
 				return fmt.Errorf("failed to claim Persistent Volume %q due to error: %s", pr.Name, err)
@@ -133,6 +139,12 @@ func createPVC(pr *v1alpha1.PipelineRun, c kubernetes.Interface) error {
 		}
 		return fmt.Errorf("failed to get claim Persistent Volume %q due to error: %s", pr.Name, err)
 	}
+	// The PVC of the PipelineRun a PipelineRun resumes from is owned by both of them
+	if pr.GetArtifactStorageName() != pr.Name && pr.AddSharedOwnerReference(pvc) {
+		if _, err := c.CoreV1().PersistentVolumeClaims(pr.Namespace).Update(pvc); err != nil {
+			return fmt.Errorf("failed to add PipelineRun %q as an owner of Persistent Volume %q due to error: %s", pr.Name, pvc.Name, err)
+		}
+	}
 	return nil
 }
 
@@ -158,5 +170,5 @@ func getPVCSpec(pr *v1alpha1.PipelineRun) *corev1.PersistentVolumeClaim {
 }
 
 func getPVCName(pr *v1alpha1.PipelineRun) string {
-	return fmt.Sprintf("%s-pvc", pr.Name)
+	return fmt.Sprintf("%s-pvc", pr.GetArtifactStorageName())
 }
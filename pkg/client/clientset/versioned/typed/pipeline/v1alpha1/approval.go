@@ -0,0 +1,171 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	scheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ApprovalsGetter has a method to return a ApprovalInterface.
+// A group's client should implement this interface.
+type ApprovalsGetter interface {
+	Approvals(namespace string) ApprovalInterface
+}
+
+// ApprovalInterface has methods to work with Approval resources.
+type ApprovalInterface interface {
+	Create(*v1alpha1.Approval) (*v1alpha1.Approval, error)
+	Update(*v1alpha1.Approval) (*v1alpha1.Approval, error)
+	UpdateStatus(*v1alpha1.Approval) (*v1alpha1.Approval, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.Approval, error)
+	List(opts v1.ListOptions) (*v1alpha1.ApprovalList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Approval, err error)
+	ApprovalExpansion
+}
+
+// approvals implements ApprovalInterface
+type approvals struct {
+	client rest.Interface
+	ns     string
+}
+
+// newApprovals returns a Approvals
+func newApprovals(c *TektonV1alpha1Client, namespace string) *approvals {
+	return &approvals{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the approval, and returns the corresponding approval object, and an error if there is any.
+func (c *approvals) Get(name string, options v1.GetOptions) (result *v1alpha1.Approval, err error) {
+	result = &v1alpha1.Approval{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("approvals").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Approvals that match those selectors.
+func (c *approvals) List(opts v1.ListOptions) (result *v1alpha1.ApprovalList, err error) {
+	result = &v1alpha1.ApprovalList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("approvals").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested approvals.
+func (c *approvals) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("approvals").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a approval and creates it.  Returns the server's representation of the approval, and an error, if there is any.
+func (c *approvals) Create(approval *v1alpha1.Approval) (result *v1alpha1.Approval, err error) {
+	result = &v1alpha1.Approval{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("approvals").
+		Body(approval).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a approval and updates it. Returns the server's representation of the approval, and an error, if there is any.
+func (c *approvals) Update(approval *v1alpha1.Approval) (result *v1alpha1.Approval, err error) {
+	result = &v1alpha1.Approval{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("approvals").
+		Name(approval.Name).
+		Body(approval).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *approvals) UpdateStatus(approval *v1alpha1.Approval) (result *v1alpha1.Approval, err error) {
+	result = &v1alpha1.Approval{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("approvals").
+		Name(approval.Name).
+		SubResource("status").
+		Body(approval).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the approval and deletes it. Returns an error if one occurs.
+func (c *approvals) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("approvals").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *approvals) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("approvals").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched approval.
+func (c *approvals) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Approval, err error) {
+	result = &v1alpha1.Approval{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("approvals").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	scheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ConditionsGetter has a method to return a ConditionInterface.
+// A group's client should implement this interface.
+type ConditionsGetter interface {
+	Conditions(namespace string) ConditionInterface
+}
+
+// ConditionInterface has methods to work with Condition resources.
+type ConditionInterface interface {
+	Create(*v1alpha1.Condition) (*v1alpha1.Condition, error)
+	Update(*v1alpha1.Condition) (*v1alpha1.Condition, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.Condition, error)
+	List(opts v1.ListOptions) (*v1alpha1.ConditionList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Condition, err error)
+	ConditionExpansion
+}
+
+// conditions implements ConditionInterface
+type conditions struct {
+	client rest.Interface
+	ns     string
+}
+
+// newConditions returns a Conditions
+func newConditions(c *TektonV1alpha1Client, namespace string) *conditions {
+	return &conditions{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the condition, and returns the corresponding condition object, and an error if there is any.
+func (c *conditions) Get(name string, options v1.GetOptions) (result *v1alpha1.Condition, err error) {
+	result = &v1alpha1.Condition{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("conditions").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Conditions that match those selectors.
+func (c *conditions) List(opts v1.ListOptions) (result *v1alpha1.ConditionList, err error) {
+	result = &v1alpha1.ConditionList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("conditions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested conditions.
+func (c *conditions) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("conditions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a condition and creates it.  Returns the server's representation of the condition, and an error, if there is any.
+func (c *conditions) Create(condition *v1alpha1.Condition) (result *v1alpha1.Condition, err error) {
+	result = &v1alpha1.Condition{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("conditions").
+		Body(condition).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a condition and updates it. Returns the server's representation of the condition, and an error, if there is any.
+func (c *conditions) Update(condition *v1alpha1.Condition) (result *v1alpha1.Condition, err error) {
+	result = &v1alpha1.Condition{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("conditions").
+		Name(condition.Name).
+		Body(condition).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the condition and deletes it. Returns an error if one occurs.
+func (c *conditions) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("conditions").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *conditions) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("conditions").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched condition.
+func (c *conditions) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Condition, err error) {
+	result = &v1alpha1.Condition{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("conditions").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
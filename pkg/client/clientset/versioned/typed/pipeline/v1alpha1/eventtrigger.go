@@ -0,0 +1,154 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	scheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// EventTriggersGetter has a method to return a EventTriggerInterface.
+// A group's client should implement this interface.
+type EventTriggersGetter interface {
+	EventTriggers(namespace string) EventTriggerInterface
+}
+
+// EventTriggerInterface has methods to work with EventTrigger resources.
+type EventTriggerInterface interface {
+	Create(*v1alpha1.EventTrigger) (*v1alpha1.EventTrigger, error)
+	Update(*v1alpha1.EventTrigger) (*v1alpha1.EventTrigger, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.EventTrigger, error)
+	List(opts v1.ListOptions) (*v1alpha1.EventTriggerList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.EventTrigger, err error)
+	EventTriggerExpansion
+}
+
+// eventTriggers implements EventTriggerInterface
+type eventTriggers struct {
+	client rest.Interface
+	ns     string
+}
+
+// newEventTriggers returns a EventTriggers
+func newEventTriggers(c *TektonV1alpha1Client, namespace string) *eventTriggers {
+	return &eventTriggers{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the eventTrigger, and returns the corresponding eventTrigger object, and an error if there is any.
+func (c *eventTriggers) Get(name string, options v1.GetOptions) (result *v1alpha1.EventTrigger, err error) {
+	result = &v1alpha1.EventTrigger{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("eventtriggers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of EventTriggers that match those selectors.
+func (c *eventTriggers) List(opts v1.ListOptions) (result *v1alpha1.EventTriggerList, err error) {
+	result = &v1alpha1.EventTriggerList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("eventtriggers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested eventTriggers.
+func (c *eventTriggers) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("eventtriggers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a eventTrigger and creates it.  Returns the server's representation of the eventTrigger, and an error, if there is any.
+func (c *eventTriggers) Create(eventTrigger *v1alpha1.EventTrigger) (result *v1alpha1.EventTrigger, err error) {
+	result = &v1alpha1.EventTrigger{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("eventtriggers").
+		Body(eventTrigger).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a eventTrigger and updates it. Returns the server's representation of the eventTrigger, and an error, if there is any.
+func (c *eventTriggers) Update(eventTrigger *v1alpha1.EventTrigger) (result *v1alpha1.EventTrigger, err error) {
+	result = &v1alpha1.EventTrigger{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("eventtriggers").
+		Name(eventTrigger.Name).
+		Body(eventTrigger).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the eventTrigger and deletes it. Returns an error if one occurs.
+func (c *eventTriggers) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("eventtriggers").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *eventTriggers) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("eventtriggers").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched eventTrigger.
+func (c *eventTriggers) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.EventTrigger, err error) {
+	result = &v1alpha1.EventTrigger{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("eventtriggers").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package fake
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeApprovals implements ApprovalInterface
+type FakeApprovals struct {
+	Fake *FakeTektonV1alpha1
+	ns   string
+}
+
+var approvalsResource = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "approvals"}
+
+var approvalsKind = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1alpha1", Kind: "Approval"}
+
+// Get takes name of the approval, and returns the corresponding approval object, and an error if there is any.
+func (c *FakeApprovals) Get(name string, options v1.GetOptions) (result *v1alpha1.Approval, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(approvalsResource, c.ns, name), &v1alpha1.Approval{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Approval), err
+}
+
+// List takes label and field selectors, and returns the list of Approvals that match those selectors.
+func (c *FakeApprovals) List(opts v1.ListOptions) (result *v1alpha1.ApprovalList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(approvalsResource, approvalsKind, c.ns, opts), &v1alpha1.ApprovalList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ApprovalList{ListMeta: obj.(*v1alpha1.ApprovalList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ApprovalList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested approvals.
+func (c *FakeApprovals) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(approvalsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a approval and creates it.  Returns the server's representation of the approval, and an error, if there is any.
+func (c *FakeApprovals) Create(approval *v1alpha1.Approval) (result *v1alpha1.Approval, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(approvalsResource, c.ns, approval), &v1alpha1.Approval{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Approval), err
+}
+
+// Update takes the representation of a approval and updates it. Returns the server's representation of the approval, and an error, if there is any.
+func (c *FakeApprovals) Update(approval *v1alpha1.Approval) (result *v1alpha1.Approval, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(approvalsResource, c.ns, approval), &v1alpha1.Approval{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Approval), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeApprovals) UpdateStatus(approval *v1alpha1.Approval) (*v1alpha1.Approval, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(approvalsResource, "status", c.ns, approval), &v1alpha1.Approval{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Approval), err
+}
+
+// Delete takes name of the approval and deletes it. Returns an error if one occurs.
+func (c *FakeApprovals) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(approvalsResource, c.ns, name), &v1alpha1.Approval{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeApprovals) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(approvalsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ApprovalList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched approval.
+func (c *FakeApprovals) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.Approval, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(approvalsResource, c.ns, name, data, subresources...), &v1alpha1.Approval{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Approval), err
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package fake
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeEventTriggers implements EventTriggerInterface
+type FakeEventTriggers struct {
+	Fake *FakeTektonV1alpha1
+	ns   string
+}
+
+var eventtriggersResource = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "eventtriggers"}
+
+var eventtriggersKind = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1alpha1", Kind: "EventTrigger"}
+
+// Get takes name of the eventTrigger, and returns the corresponding eventTrigger object, and an error if there is any.
+func (c *FakeEventTriggers) Get(name string, options v1.GetOptions) (result *v1alpha1.EventTrigger, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(eventtriggersResource, c.ns, name), &v1alpha1.EventTrigger{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EventTrigger), err
+}
+
+// List takes label and field selectors, and returns the list of EventTriggers that match those selectors.
+func (c *FakeEventTriggers) List(opts v1.ListOptions) (result *v1alpha1.EventTriggerList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(eventtriggersResource, eventtriggersKind, c.ns, opts), &v1alpha1.EventTriggerList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.EventTriggerList{ListMeta: obj.(*v1alpha1.EventTriggerList).ListMeta}
+	for _, item := range obj.(*v1alpha1.EventTriggerList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested eventTriggers.
+func (c *FakeEventTriggers) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(eventtriggersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a eventTrigger and creates it.  Returns the server's representation of the eventTrigger, and an error, if there is any.
+func (c *FakeEventTriggers) Create(eventTrigger *v1alpha1.EventTrigger) (result *v1alpha1.EventTrigger, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(eventtriggersResource, c.ns, eventTrigger), &v1alpha1.EventTrigger{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EventTrigger), err
+}
+
+// Update takes the representation of a eventTrigger and updates it. Returns the server's representation of the eventTrigger, and an error, if there is any.
+func (c *FakeEventTriggers) Update(eventTrigger *v1alpha1.EventTrigger) (result *v1alpha1.EventTrigger, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(eventtriggersResource, c.ns, eventTrigger), &v1alpha1.EventTrigger{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EventTrigger), err
+}
+
+// Delete takes name of the eventTrigger and deletes it. Returns an error if one occurs.
+func (c *FakeEventTriggers) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(eventtriggersResource, c.ns, name), &v1alpha1.EventTrigger{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeEventTriggers) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(eventtriggersResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.EventTriggerList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched eventTrigger.
+func (c *FakeEventTriggers) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.EventTrigger, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(eventtriggersResource, c.ns, name, data, subresources...), &v1alpha1.EventTrigger{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EventTrigger), err
+}
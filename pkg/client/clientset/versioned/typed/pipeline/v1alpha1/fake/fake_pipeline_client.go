@@ -25,10 +25,22 @@ type FakeTektonV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTektonV1alpha1) Approvals(namespace string) v1alpha1.ApprovalInterface {
+	return &FakeApprovals{c, namespace}
+}
+
 func (c *FakeTektonV1alpha1) ClusterTasks() v1alpha1.ClusterTaskInterface {
 	return &FakeClusterTasks{c}
 }
 
+func (c *FakeTektonV1alpha1) Conditions(namespace string) v1alpha1.ConditionInterface {
+	return &FakeConditions{c, namespace}
+}
+
+func (c *FakeTektonV1alpha1) EventTriggers(namespace string) v1alpha1.EventTriggerInterface {
+	return &FakeEventTriggers{c, namespace}
+}
+
 func (c *FakeTektonV1alpha1) Pipelines(namespace string) v1alpha1.PipelineInterface {
 	return &FakePipelines{c, namespace}
 }
@@ -41,6 +53,14 @@ func (c *FakeTektonV1alpha1) PipelineRuns(namespace string) v1alpha1.PipelineRun
 	return &FakePipelineRuns{c, namespace}
 }
 
+func (c *FakeTektonV1alpha1) Runs(namespace string) v1alpha1.RunInterface {
+	return &FakeRuns{c, namespace}
+}
+
+func (c *FakeTektonV1alpha1) ScheduledPipelineRuns(namespace string) v1alpha1.ScheduledPipelineRunInterface {
+	return &FakeScheduledPipelineRuns{c, namespace}
+}
+
 func (c *FakeTektonV1alpha1) Tasks(namespace string) v1alpha1.TaskInterface {
 	return &FakeTasks{c, namespace}
 }
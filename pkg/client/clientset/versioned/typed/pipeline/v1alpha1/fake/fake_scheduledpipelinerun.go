@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package fake
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeScheduledPipelineRuns implements ScheduledPipelineRunInterface
+type FakeScheduledPipelineRuns struct {
+	Fake *FakeTektonV1alpha1
+	ns   string
+}
+
+var scheduledpipelinerunsResource = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1alpha1", Resource: "scheduledpipelineruns"}
+
+var scheduledpipelinerunsKind = schema.GroupVersionKind{Group: "tekton.dev", Version: "v1alpha1", Kind: "ScheduledPipelineRun"}
+
+// Get takes name of the scheduledPipelineRun, and returns the corresponding scheduledPipelineRun object, and an error if there is any.
+func (c *FakeScheduledPipelineRuns) Get(name string, options v1.GetOptions) (result *v1alpha1.ScheduledPipelineRun, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(scheduledpipelinerunsResource, c.ns, name), &v1alpha1.ScheduledPipelineRun{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScheduledPipelineRun), err
+}
+
+// List takes label and field selectors, and returns the list of ScheduledPipelineRuns that match those selectors.
+func (c *FakeScheduledPipelineRuns) List(opts v1.ListOptions) (result *v1alpha1.ScheduledPipelineRunList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(scheduledpipelinerunsResource, scheduledpipelinerunsKind, c.ns, opts), &v1alpha1.ScheduledPipelineRunList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ScheduledPipelineRunList{ListMeta: obj.(*v1alpha1.ScheduledPipelineRunList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ScheduledPipelineRunList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested scheduledPipelineRuns.
+func (c *FakeScheduledPipelineRuns) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(scheduledpipelinerunsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a scheduledPipelineRun and creates it.  Returns the server's representation of the scheduledPipelineRun, and an error, if there is any.
+func (c *FakeScheduledPipelineRuns) Create(scheduledPipelineRun *v1alpha1.ScheduledPipelineRun) (result *v1alpha1.ScheduledPipelineRun, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(scheduledpipelinerunsResource, c.ns, scheduledPipelineRun), &v1alpha1.ScheduledPipelineRun{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScheduledPipelineRun), err
+}
+
+// Update takes the representation of a scheduledPipelineRun and updates it. Returns the server's representation of the scheduledPipelineRun, and an error, if there is any.
+func (c *FakeScheduledPipelineRuns) Update(scheduledPipelineRun *v1alpha1.ScheduledPipelineRun) (result *v1alpha1.ScheduledPipelineRun, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(scheduledpipelinerunsResource, c.ns, scheduledPipelineRun), &v1alpha1.ScheduledPipelineRun{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScheduledPipelineRun), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeScheduledPipelineRuns) UpdateStatus(scheduledPipelineRun *v1alpha1.ScheduledPipelineRun) (*v1alpha1.ScheduledPipelineRun, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(scheduledpipelinerunsResource, "status", c.ns, scheduledPipelineRun), &v1alpha1.ScheduledPipelineRun{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScheduledPipelineRun), err
+}
+
+// Delete takes name of the scheduledPipelineRun and deletes it. Returns an error if one occurs.
+func (c *FakeScheduledPipelineRuns) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(scheduledpipelinerunsResource, c.ns, name), &v1alpha1.ScheduledPipelineRun{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeScheduledPipelineRuns) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(scheduledpipelinerunsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ScheduledPipelineRunList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched scheduledPipelineRun.
+func (c *FakeScheduledPipelineRuns) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ScheduledPipelineRun, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(scheduledpipelinerunsResource, c.ns, name, data, subresources...), &v1alpha1.ScheduledPipelineRun{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ScheduledPipelineRun), err
+}
@@ -15,14 +15,24 @@ limitations under the License.
 */
 package v1alpha1
 
+type ApprovalExpansion interface{}
+
 type ClusterTaskExpansion interface{}
 
+type ConditionExpansion interface{}
+
+type EventTriggerExpansion interface{}
+
 type PipelineExpansion interface{}
 
 type PipelineResourceExpansion interface{}
 
 type PipelineRunExpansion interface{}
 
+type RunExpansion interface{}
+
+type ScheduledPipelineRunExpansion interface{}
+
 type TaskExpansion interface{}
 
 type TaskRunExpansion interface{}
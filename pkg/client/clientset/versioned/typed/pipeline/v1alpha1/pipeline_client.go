@@ -24,10 +24,15 @@ import (
 
 type TektonV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ApprovalsGetter
 	ClusterTasksGetter
+	ConditionsGetter
+	EventTriggersGetter
 	PipelinesGetter
 	PipelineResourcesGetter
 	PipelineRunsGetter
+	RunsGetter
+	ScheduledPipelineRunsGetter
 	TasksGetter
 	TaskRunsGetter
 }
@@ -37,10 +42,22 @@ type TektonV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TektonV1alpha1Client) Approvals(namespace string) ApprovalInterface {
+	return newApprovals(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) ClusterTasks() ClusterTaskInterface {
 	return newClusterTasks(c)
 }
 
+func (c *TektonV1alpha1Client) Conditions(namespace string) ConditionInterface {
+	return newConditions(c, namespace)
+}
+
+func (c *TektonV1alpha1Client) EventTriggers(namespace string) EventTriggerInterface {
+	return newEventTriggers(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Pipelines(namespace string) PipelineInterface {
 	return newPipelines(c, namespace)
 }
@@ -53,6 +70,14 @@ func (c *TektonV1alpha1Client) PipelineRuns(namespace string) PipelineRunInterfa
 	return newPipelineRuns(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) Runs(namespace string) RunInterface {
+	return newRuns(c, namespace)
+}
+
+func (c *TektonV1alpha1Client) ScheduledPipelineRuns(namespace string) ScheduledPipelineRunInterface {
+	return newScheduledPipelineRuns(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Tasks(namespace string) TaskInterface {
 	return newTasks(c, namespace)
 }
@@ -0,0 +1,171 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	scheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ScheduledPipelineRunsGetter has a method to return a ScheduledPipelineRunInterface.
+// A group's client should implement this interface.
+type ScheduledPipelineRunsGetter interface {
+	ScheduledPipelineRuns(namespace string) ScheduledPipelineRunInterface
+}
+
+// ScheduledPipelineRunInterface has methods to work with ScheduledPipelineRun resources.
+type ScheduledPipelineRunInterface interface {
+	Create(*v1alpha1.ScheduledPipelineRun) (*v1alpha1.ScheduledPipelineRun, error)
+	Update(*v1alpha1.ScheduledPipelineRun) (*v1alpha1.ScheduledPipelineRun, error)
+	UpdateStatus(*v1alpha1.ScheduledPipelineRun) (*v1alpha1.ScheduledPipelineRun, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	Get(name string, options v1.GetOptions) (*v1alpha1.ScheduledPipelineRun, error)
+	List(opts v1.ListOptions) (*v1alpha1.ScheduledPipelineRunList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ScheduledPipelineRun, err error)
+	ScheduledPipelineRunExpansion
+}
+
+// scheduledPipelineRuns implements ScheduledPipelineRunInterface
+type scheduledPipelineRuns struct {
+	client rest.Interface
+	ns     string
+}
+
+// newScheduledPipelineRuns returns a ScheduledPipelineRuns
+func newScheduledPipelineRuns(c *TektonV1alpha1Client, namespace string) *scheduledPipelineRuns {
+	return &scheduledPipelineRuns{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the scheduledPipelineRun, and returns the corresponding scheduledPipelineRun object, and an error if there is any.
+func (c *scheduledPipelineRuns) Get(name string, options v1.GetOptions) (result *v1alpha1.ScheduledPipelineRun, err error) {
+	result = &v1alpha1.ScheduledPipelineRun{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("scheduledpipelineruns").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ScheduledPipelineRuns that match those selectors.
+func (c *scheduledPipelineRuns) List(opts v1.ListOptions) (result *v1alpha1.ScheduledPipelineRunList, err error) {
+	result = &v1alpha1.ScheduledPipelineRunList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("scheduledpipelineruns").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested scheduledPipelineRuns.
+func (c *scheduledPipelineRuns) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("scheduledpipelineruns").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a scheduledPipelineRun and creates it.  Returns the server's representation of the scheduledPipelineRun, and an error, if there is any.
+func (c *scheduledPipelineRuns) Create(scheduledPipelineRun *v1alpha1.ScheduledPipelineRun) (result *v1alpha1.ScheduledPipelineRun, err error) {
+	result = &v1alpha1.ScheduledPipelineRun{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("scheduledpipelineruns").
+		Body(scheduledPipelineRun).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a scheduledPipelineRun and updates it. Returns the server's representation of the scheduledPipelineRun, and an error, if there is any.
+func (c *scheduledPipelineRuns) Update(scheduledPipelineRun *v1alpha1.ScheduledPipelineRun) (result *v1alpha1.ScheduledPipelineRun, err error) {
+	result = &v1alpha1.ScheduledPipelineRun{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("scheduledpipelineruns").
+		Name(scheduledPipelineRun.Name).
+		Body(scheduledPipelineRun).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *scheduledPipelineRuns) UpdateStatus(scheduledPipelineRun *v1alpha1.ScheduledPipelineRun) (result *v1alpha1.ScheduledPipelineRun, err error) {
+	result = &v1alpha1.ScheduledPipelineRun{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("scheduledpipelineruns").
+		Name(scheduledPipelineRun.Name).
+		SubResource("status").
+		Body(scheduledPipelineRun).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the scheduledPipelineRun and deletes it. Returns an error if one occurs.
+func (c *scheduledPipelineRuns) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("scheduledpipelineruns").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *scheduledPipelineRuns) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("scheduledpipelineruns").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched scheduledPipelineRun.
+func (c *scheduledPipelineRuns) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.ScheduledPipelineRun, err error) {
+	result = &v1alpha1.ScheduledPipelineRun{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("scheduledpipelineruns").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
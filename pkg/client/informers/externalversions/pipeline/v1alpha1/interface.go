@@ -21,14 +21,24 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// Approvals returns a ApprovalInformer.
+	Approvals() ApprovalInformer
 	// ClusterTasks returns a ClusterTaskInformer.
 	ClusterTasks() ClusterTaskInformer
+	// Conditions returns a ConditionInformer.
+	Conditions() ConditionInformer
+	// EventTriggers returns a EventTriggerInformer.
+	EventTriggers() EventTriggerInformer
 	// Pipelines returns a PipelineInformer.
 	Pipelines() PipelineInformer
 	// PipelineResources returns a PipelineResourceInformer.
 	PipelineResources() PipelineResourceInformer
 	// PipelineRuns returns a PipelineRunInformer.
 	PipelineRuns() PipelineRunInformer
+	// Runs returns a RunInformer.
+	Runs() RunInformer
+	// ScheduledPipelineRuns returns a ScheduledPipelineRunInformer.
+	ScheduledPipelineRuns() ScheduledPipelineRunInformer
 	// Tasks returns a TaskInformer.
 	Tasks() TaskInformer
 	// TaskRuns returns a TaskRunInformer.
@@ -46,11 +56,26 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// Approvals returns a ApprovalInformer.
+func (v *version) Approvals() ApprovalInformer {
+	return &approvalInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterTasks returns a ClusterTaskInformer.
 func (v *version) ClusterTasks() ClusterTaskInformer {
 	return &clusterTaskInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// Conditions returns a ConditionInformer.
+func (v *version) Conditions() ConditionInformer {
+	return &conditionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// EventTriggers returns a EventTriggerInformer.
+func (v *version) EventTriggers() EventTriggerInformer {
+	return &eventTriggerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Pipelines returns a PipelineInformer.
 func (v *version) Pipelines() PipelineInformer {
 	return &pipelineInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -66,6 +91,16 @@ func (v *version) PipelineRuns() PipelineRunInformer {
 	return &pipelineRunInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// Runs returns a RunInformer.
+func (v *version) Runs() RunInformer {
+	return &runInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ScheduledPipelineRuns returns a ScheduledPipelineRunInformer.
+func (v *version) ScheduledPipelineRuns() ScheduledPipelineRunInformer {
+	return &scheduledPipelineRunInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Tasks returns a TaskInformer.
 func (v *version) Tasks() TaskInformer {
 	return &taskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ApprovalLister helps list Approvals.
+type ApprovalLister interface {
+	// List lists all Approvals in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.Approval, err error)
+	// Approvals returns an object that can list and get Approvals.
+	Approvals(namespace string) ApprovalNamespaceLister
+	ApprovalListerExpansion
+}
+
+// approvalLister implements the ApprovalLister interface.
+type approvalLister struct {
+	indexer cache.Indexer
+}
+
+// NewApprovalLister returns a new ApprovalLister.
+func NewApprovalLister(indexer cache.Indexer) ApprovalLister {
+	return &approvalLister{indexer: indexer}
+}
+
+// List lists all Approvals in the indexer.
+func (s *approvalLister) List(selector labels.Selector) (ret []*v1alpha1.Approval, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.Approval))
+	})
+	return ret, err
+}
+
+// Approvals returns an object that can list and get Approvals.
+func (s *approvalLister) Approvals(namespace string) ApprovalNamespaceLister {
+	return approvalNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ApprovalNamespaceLister helps list and get Approvals.
+type ApprovalNamespaceLister interface {
+	// List lists all Approvals in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1alpha1.Approval, err error)
+	// Get retrieves the Approval from the indexer for a given namespace and name.
+	Get(name string) (*v1alpha1.Approval, error)
+	ApprovalNamespaceListerExpansion
+}
+
+// approvalNamespaceLister implements the ApprovalNamespaceLister
+// interface.
+type approvalNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all Approvals in the indexer for a given namespace.
+func (s approvalNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.Approval, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.Approval))
+	})
+	return ret, err
+}
+
+// Get retrieves the Approval from the indexer for a given namespace and name.
+func (s approvalNamespaceLister) Get(name string) (*v1alpha1.Approval, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("approval"), name)
+	}
+	return obj.(*v1alpha1.Approval), nil
+}
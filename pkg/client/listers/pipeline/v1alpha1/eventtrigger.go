@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// EventTriggerLister helps list EventTriggers.
+type EventTriggerLister interface {
+	// List lists all EventTriggers in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.EventTrigger, err error)
+	// EventTriggers returns an object that can list and get EventTriggers.
+	EventTriggers(namespace string) EventTriggerNamespaceLister
+	EventTriggerListerExpansion
+}
+
+// eventTriggerLister implements the EventTriggerLister interface.
+type eventTriggerLister struct {
+	indexer cache.Indexer
+}
+
+// NewEventTriggerLister returns a new EventTriggerLister.
+func NewEventTriggerLister(indexer cache.Indexer) EventTriggerLister {
+	return &eventTriggerLister{indexer: indexer}
+}
+
+// List lists all EventTriggers in the indexer.
+func (s *eventTriggerLister) List(selector labels.Selector) (ret []*v1alpha1.EventTrigger, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.EventTrigger))
+	})
+	return ret, err
+}
+
+// EventTriggers returns an object that can list and get EventTriggers.
+func (s *eventTriggerLister) EventTriggers(namespace string) EventTriggerNamespaceLister {
+	return eventTriggerNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// EventTriggerNamespaceLister helps list and get EventTriggers.
+type EventTriggerNamespaceLister interface {
+	// List lists all EventTriggers in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1alpha1.EventTrigger, err error)
+	// Get retrieves the EventTrigger from the indexer for a given namespace and name.
+	Get(name string) (*v1alpha1.EventTrigger, error)
+	EventTriggerNamespaceListerExpansion
+}
+
+// eventTriggerNamespaceLister implements the EventTriggerNamespaceLister
+// interface.
+type eventTriggerNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all EventTriggers in the indexer for a given namespace.
+func (s eventTriggerNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.EventTrigger, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.EventTrigger))
+	})
+	return ret, err
+}
+
+// Get retrieves the EventTrigger from the indexer for a given namespace and name.
+func (s eventTriggerNamespaceLister) Get(name string) (*v1alpha1.EventTrigger, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("eventtrigger"), name)
+	}
+	return obj.(*v1alpha1.EventTrigger), nil
+}
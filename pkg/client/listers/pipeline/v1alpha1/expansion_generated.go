@@ -15,10 +15,34 @@ limitations under the License.
 */
 package v1alpha1
 
+// ApprovalListerExpansion allows custom methods to be added to
+// ApprovalLister.
+type ApprovalListerExpansion interface{}
+
+// ApprovalNamespaceListerExpansion allows custom methods to be added to
+// ApprovalNamespaceLister.
+type ApprovalNamespaceListerExpansion interface{}
+
 // ClusterTaskListerExpansion allows custom methods to be added to
 // ClusterTaskLister.
 type ClusterTaskListerExpansion interface{}
 
+// ConditionListerExpansion allows custom methods to be added to
+// ConditionLister.
+type ConditionListerExpansion interface{}
+
+// ConditionNamespaceListerExpansion allows custom methods to be added to
+// ConditionNamespaceLister.
+type ConditionNamespaceListerExpansion interface{}
+
+// EventTriggerListerExpansion allows custom methods to be added to
+// EventTriggerLister.
+type EventTriggerListerExpansion interface{}
+
+// EventTriggerNamespaceListerExpansion allows custom methods to be added to
+// EventTriggerNamespaceLister.
+type EventTriggerNamespaceListerExpansion interface{}
+
 // PipelineListerExpansion allows custom methods to be added to
 // PipelineLister.
 type PipelineListerExpansion interface{}
@@ -43,6 +67,22 @@ type PipelineRunListerExpansion interface{}
 // PipelineRunNamespaceLister.
 type PipelineRunNamespaceListerExpansion interface{}
 
+// RunListerExpansion allows custom methods to be added to
+// RunLister.
+type RunListerExpansion interface{}
+
+// RunNamespaceListerExpansion allows custom methods to be added to
+// RunNamespaceLister.
+type RunNamespaceListerExpansion interface{}
+
+// ScheduledPipelineRunListerExpansion allows custom methods to be added to
+// ScheduledPipelineRunLister.
+type ScheduledPipelineRunListerExpansion interface{}
+
+// ScheduledPipelineRunNamespaceListerExpansion allows custom methods to be added to
+// ScheduledPipelineRunNamespaceLister.
+type ScheduledPipelineRunNamespaceListerExpansion interface{}
+
 // TaskListerExpansion allows custom methods to be added to
 // TaskLister.
 type TaskListerExpansion interface{}
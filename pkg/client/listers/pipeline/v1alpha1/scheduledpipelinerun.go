@@ -0,0 +1,91 @@
+/*
+Copyright 2018 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ScheduledPipelineRunLister helps list ScheduledPipelineRuns.
+type ScheduledPipelineRunLister interface {
+	// List lists all ScheduledPipelineRuns in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.ScheduledPipelineRun, err error)
+	// ScheduledPipelineRuns returns an object that can list and get ScheduledPipelineRuns.
+	ScheduledPipelineRuns(namespace string) ScheduledPipelineRunNamespaceLister
+	ScheduledPipelineRunListerExpansion
+}
+
+// scheduledPipelineRunLister implements the ScheduledPipelineRunLister interface.
+type scheduledPipelineRunLister struct {
+	indexer cache.Indexer
+}
+
+// NewScheduledPipelineRunLister returns a new ScheduledPipelineRunLister.
+func NewScheduledPipelineRunLister(indexer cache.Indexer) ScheduledPipelineRunLister {
+	return &scheduledPipelineRunLister{indexer: indexer}
+}
+
+// List lists all ScheduledPipelineRuns in the indexer.
+func (s *scheduledPipelineRunLister) List(selector labels.Selector) (ret []*v1alpha1.ScheduledPipelineRun, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ScheduledPipelineRun))
+	})
+	return ret, err
+}
+
+// ScheduledPipelineRuns returns an object that can list and get ScheduledPipelineRuns.
+func (s *scheduledPipelineRunLister) ScheduledPipelineRuns(namespace string) ScheduledPipelineRunNamespaceLister {
+	return scheduledPipelineRunNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ScheduledPipelineRunNamespaceLister helps list and get ScheduledPipelineRuns.
+type ScheduledPipelineRunNamespaceLister interface {
+	// List lists all ScheduledPipelineRuns in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1alpha1.ScheduledPipelineRun, err error)
+	// Get retrieves the ScheduledPipelineRun from the indexer for a given namespace and name.
+	Get(name string) (*v1alpha1.ScheduledPipelineRun, error)
+	ScheduledPipelineRunNamespaceListerExpansion
+}
+
+// scheduledPipelineRunNamespaceLister implements the ScheduledPipelineRunNamespaceLister
+// interface.
+type scheduledPipelineRunNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ScheduledPipelineRuns in the indexer for a given namespace.
+func (s scheduledPipelineRunNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.ScheduledPipelineRun, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ScheduledPipelineRun))
+	})
+	return ret, err
+}
+
+// Get retrieves the ScheduledPipelineRun from the indexer for a given namespace and name.
+func (s scheduledPipelineRunNamespaceLister) Get(name string) (*v1alpha1.ScheduledPipelineRun, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("scheduledpipelinerun"), name)
+	}
+	return obj.(*v1alpha1.ScheduledPipelineRun), nil
+}
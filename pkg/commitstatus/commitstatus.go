@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package commitstatus reports statuses on commits to the commit status APIs of Git
+// hosting providers, so that the outcome of PipelineRuns shows on pull requests.
+package commitstatus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+// State is the state of a commit status.
+type State string
+
+const (
+	// StatePending indicates that the PipelineRun hasn't finished yet.
+	StatePending State = "pending"
+	// StateSuccess indicates that the PipelineRun succeeded.
+	StateSuccess State = "success"
+	// StateFailure indicates that the PipelineRun failed.
+	StateFailure State = "failure"
+	// StateCancelled indicates that the PipelineRun was cancelled.
+	StateCancelled State = "cancelled"
+)
+
+// Status is a status reported on a commit.
+type Status struct {
+	State State
+	// Context tells the status apart from the other statuses of the commit.
+	Context string
+	// Description is a short description of the status.
+	Description string
+	// TargetURL is the link of the status.
+	TargetURL string
+}
+
+// Reporter reports statuses on the commits of the repositories of a Git hosting provider.
+type Reporter interface {
+	// Report sets the status s on the commit sha of the repository repo, which is the
+	// path of the repository on the provider, e.g. tektoncd/pipeline.
+	Report(repo, sha string, s Status) error
+}
+
+// maxDescriptionLength is the length of the longest description GitHub accepts.
+const maxDescriptionLength = 140
+
+var (
+	httpClient = &http.Client{Timeout: 10 * time.Second}
+	commitRe   = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+	scpLikeRe  = regexp.MustCompile(`^[^/:@]+@[^/:]+:(.+)$`)
+)
+
+// NewReporter returns the Reporter of provider, calling its API at apiURL, or at the
+// API of the public instance of the provider if apiURL is empty, and authenticating
+// with token.
+func NewReporter(provider v1alpha1.CommitStatusProvider, apiURL, token string) (Reporter, error) {
+	switch provider {
+	case v1alpha1.CommitStatusProviderGitHub:
+		if apiURL == "" {
+			apiURL = "https://api.github.com"
+		}
+		return &gitHubReporter{apiURL: strings.TrimSuffix(apiURL, "/"), token: token}, nil
+	case v1alpha1.CommitStatusProviderGitLab:
+		if apiURL == "" {
+			apiURL = "https://gitlab.com/api/v4"
+		}
+		return &gitLabReporter{apiURL: strings.TrimSuffix(apiURL, "/"), token: token}, nil
+	default:
+		return nil, fmt.Errorf("unknown commit status provider %q", provider)
+	}
+}
+
+// IsCommit returns true if revision is the full SHA of a commit, rather than e.g. a
+// branch, since statuses can only be reported on commits.
+func IsCommit(revision string) bool {
+	return commitRe.MatchString(revision)
+}
+
+// RepositoryFromURL returns the path of the repository cloned from the URL u, which can
+// be an HTTP(S) or an SSH URL, e.g. tektoncd/pipeline for https://github.com/tektoncd/pipeline.git.
+func RepositoryFromURL(u string) (string, error) {
+	var path string
+	if m := scpLikeRe.FindStringSubmatch(u); m != nil {
+		path = m[1]
+	} else {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return "", fmt.Errorf("invalid git URL %q: %v", u, err)
+		}
+		path = parsed.Path
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if !strings.Contains(path, "/") {
+		return "", fmt.Errorf("git URL %q doesn't refer to a repository of an owner", u)
+	}
+	return path, nil
+}
+
+// truncate shortens s to length characters, cutting it between two characters rather
+// than in the middle of the UTF-8 encoding of one.
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+	return string(runes[:length-3]) + "..."
+}
+
+// permanentError is an error reporting a status which fails again if it is retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// Permanent marks err as an error which fails again if the status is reported again,
+// such as a configuration error, so that it isn't retried.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsPermanent returns true if err fails again if the status is reported again.
+func IsPermanent(err error) bool {
+	_, ok := err.(*permanentError)
+	return ok
+}
+
+// post sends body encoded in JSON to the API at u, with the given headers.
+func post(u string, headers map[string]string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("POST %s responded with status %d", u, resp.StatusCode)
+		// The requests the API rejects, e.g. because of the token, are rejected again
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+			return Permanent(err)
+		}
+		return err
+	}
+	return nil
+}
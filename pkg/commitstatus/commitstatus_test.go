@@ -0,0 +1,214 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commitstatus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+const sha = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+type request struct {
+	Path   string
+	Header map[string]string
+	Body   map[string]string
+}
+
+// newAPI returns a stand-in of the API of a provider recording the requests it
+// receives, with the given headers.
+func newAPI(t *testing.T, status int, headers ...string) (*httptest.Server, *[]request) {
+	requests := []request{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := request{Path: r.URL.EscapedPath(), Header: map[string]string{}}
+		for _, h := range headers {
+			req.Header[h] = r.Header.Get(h)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+			t.Errorf("Failed to decode the body of the request: %v", err)
+		}
+		requests = append(requests, req)
+		w.WriteHeader(status)
+	}))
+	return server, &requests
+}
+
+func TestReport(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		provider v1alpha1.CommitStatusProvider
+		repo     string
+		status   Status
+		headers  []string
+		expected request
+	}{{
+		name:     "github",
+		provider: v1alpha1.CommitStatusProviderGitHub,
+		repo:     "tektoncd/pipeline",
+		status: Status{
+			State:       StateCancelled,
+			Context:     "tekton/ci",
+			Description: "PipelineRun \"ci-run\" was cancelled",
+			TargetURL:   "https://dashboard.example.com/ci-run",
+		},
+		headers: []string{"Authorization"},
+		expected: request{
+			Path:   "/repos/tektoncd/pipeline/statuses/" + sha,
+			Header: map[string]string{"Authorization": "token secret-token"},
+			Body: map[string]string{
+				"state":       "error",
+				"context":     "tekton/ci",
+				"description": "PipelineRun \"ci-run\" was cancelled",
+				"target_url":  "https://dashboard.example.com/ci-run",
+			},
+		},
+	}, {
+		name:     "gitlab",
+		provider: v1alpha1.CommitStatusProviderGitLab,
+		repo:     "tekton/infra/pipeline",
+		status: Status{
+			State:       StatePending,
+			Context:     "tekton/ci",
+			Description: strings.Repeat("a", 150),
+		},
+		headers: []string{"Private-Token"},
+		expected: request{
+			Path:   "/projects/tekton%2Finfra%2Fpipeline/statuses/" + sha,
+			Header: map[string]string{"Private-Token": "secret-token"},
+			Body: map[string]string{
+				"state":       "running",
+				"name":        "tekton/ci",
+				"description": strings.Repeat("a", 137) + "...",
+				"target_url":  "",
+			},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			api, requests := newAPI(t, http.StatusCreated, tc.headers...)
+			defer api.Close()
+			r, err := NewReporter(tc.provider, api.URL+"/", "secret-token")
+			if err != nil {
+				t.Fatalf("Unexpected error creating the reporter: %v", err)
+			}
+			if err := r.Report(tc.repo, sha, tc.status); err != nil {
+				t.Fatalf("Unexpected error reporting the status: %v", err)
+			}
+			if d := cmp.Diff([]request{tc.expected}, *requests); d != "" {
+				t.Errorf("Unexpected requests to the API (-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestReport_Error(t *testing.T) {
+	api, _ := newAPI(t, http.StatusUnauthorized)
+	defer api.Close()
+	r, err := NewReporter(v1alpha1.CommitStatusProviderGitHub, api.URL, "wrong-token")
+	if err != nil {
+		t.Fatalf("Unexpected error creating the reporter: %v", err)
+	}
+	if err := r.Report("tektoncd/pipeline", sha, Status{State: StateSuccess}); err == nil {
+		t.Errorf("Expected an error when the API rejects the status")
+	}
+}
+
+func TestReport_PermanentError(t *testing.T) {
+	for _, tc := range []struct {
+		status    int
+		permanent bool
+	}{
+		{status: http.StatusUnauthorized, permanent: true},
+		{status: http.StatusNotFound, permanent: true},
+		{status: http.StatusTooManyRequests, permanent: false},
+		{status: http.StatusBadGateway, permanent: false},
+	} {
+		api, _ := newAPI(t, tc.status)
+		r, err := NewReporter(v1alpha1.CommitStatusProviderGitHub, api.URL, "token")
+		if err != nil {
+			t.Fatalf("Unexpected error creating the reporter: %v", err)
+		}
+		if err := r.Report("tektoncd/pipeline", sha, Status{State: StateSuccess}); IsPermanent(err) != tc.permanent {
+			t.Errorf("Expected IsPermanent() to be %t when the API responds with %d but got error %v", tc.permanent, tc.status, err)
+		}
+		api.Close()
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	for _, tc := range []struct {
+		s, expected string
+	}{
+		{s: "short", expected: "short"},
+		{s: "exactly ten", expected: "exactly..."},
+		{s: "héhéhéhéhé", expected: "héhéhéhéhé"},
+		{s: "日本語のテキストです", expected: "日本語のテキストです"},
+		{s: "日本語のテキストですね", expected: "日本語のテキス..."},
+	} {
+		if actual := truncate(tc.s, 10); actual != tc.expected {
+			t.Errorf("truncate(%q) = %q, want %q", tc.s, actual, tc.expected)
+		}
+	}
+}
+
+func TestNewReporter_UnknownProvider(t *testing.T) {
+	if _, err := NewReporter("bitbucket", "", "token"); err == nil {
+		t.Errorf("Expected an error for an unknown provider")
+	}
+}
+
+func TestRepositoryFromURL(t *testing.T) {
+	for _, tc := range []struct {
+		url      string
+		expected string
+	}{
+		{url: "https://github.com/tektoncd/pipeline", expected: "tektoncd/pipeline"},
+		{url: "https://github.com/tektoncd/pipeline.git", expected: "tektoncd/pipeline"},
+		{url: "https://gitlab.com/tekton/infra/pipeline/", expected: "tekton/infra/pipeline"},
+		{url: "ssh://git@github.com/tektoncd/pipeline.git", expected: "tektoncd/pipeline"},
+		{url: "git@github.com:tektoncd/pipeline.git", expected: "tektoncd/pipeline"},
+	} {
+		repo, err := RepositoryFromURL(tc.url)
+		if err != nil {
+			t.Errorf("Unexpected error getting the repository of %s: %v", tc.url, err)
+		} else if repo != tc.expected {
+			t.Errorf("Expected the repository of %s to be %s but got %s", tc.url, tc.expected, repo)
+		}
+	}
+	if _, err := RepositoryFromURL("https://github.com/tektoncd"); err == nil {
+		t.Errorf("Expected an error for a URL without a repository")
+	}
+}
+
+func TestIsCommit(t *testing.T) {
+	for revision, expected := range map[string]bool{
+		sha:          true,
+		"master":     false,
+		"4b825dc":    false,
+		"v1.0.0":     false,
+		"refs/pr/12": false,
+	} {
+		if IsCommit(revision) != expected {
+			t.Errorf("Expected IsCommit(%q) to be %t", revision, expected)
+		}
+	}
+}
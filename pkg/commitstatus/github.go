@@ -0,0 +1,49 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commitstatus
+
+import (
+	"fmt"
+)
+
+// gitHubReporter reports statuses with the commit status API of GitHub:
+// https://developer.github.com/v3/repos/statuses/
+type gitHubReporter struct {
+	apiURL string
+	token  string
+}
+
+var gitHubStates = map[State]string{
+	StatePending:   "pending",
+	StateSuccess:   "success",
+	StateFailure:   "failure",
+	StateCancelled: "error",
+}
+
+func (r *gitHubReporter) Report(repo, sha string, s Status) error {
+	return post(fmt.Sprintf("%s/repos/%s/statuses/%s", r.apiURL, repo, sha),
+		map[string]string{
+			"Authorization": "token " + r.token,
+			"Accept":        "application/vnd.github.v3+json",
+		},
+		map[string]string{
+			"state":       gitHubStates[s.State],
+			"context":     s.Context,
+			"description": truncate(s.Description, maxDescriptionLength),
+			"target_url":  s.TargetURL,
+		})
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commitstatus
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// gitLabReporter reports statuses with the commit status API of GitLab:
+// https://docs.gitlab.com/ee/api/commits.html#post-the-build-status-to-a-commit
+type gitLabReporter struct {
+	apiURL string
+	token  string
+}
+
+var gitLabStates = map[State]string{
+	StatePending:   "running",
+	StateSuccess:   "success",
+	StateFailure:   "failed",
+	StateCancelled: "canceled",
+}
+
+func (r *gitLabReporter) Report(repo, sha string, s Status) error {
+	// GitLab identifies projects by their URL-encoded path
+	return post(fmt.Sprintf("%s/projects/%s/statuses/%s", r.apiURL, url.PathEscape(repo), sha),
+		map[string]string{
+			"Private-Token": r.token,
+		},
+		map[string]string{
+			"state":       gitLabStates[s.State],
+			"name":        s.Context,
+			"description": truncate(s.Description, maxDescriptionLength),
+			"target_url":  s.TargetURL,
+		})
+}
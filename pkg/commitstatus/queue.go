@@ -0,0 +1,128 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commitstatus
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	// queueWorkers is the number of statuses reported at the same time
+	queueWorkers = 2
+	// maxAttempts is the number of times reporting a status is attempted
+	maxAttempts = 10
+)
+
+// Report is a status to report, queued with a Queue.
+type Report struct {
+	// Key identifies what the status is reported for, such as the namespace and name of
+	// a PipelineRun. A report queued with the same key as one waiting to be sent
+	// replaces it, unless they have the same ID.
+	Key string
+	// ID identifies the status reported, so that queueing it again is a no-op.
+	ID string
+	// Send reports the status, returning an error marked as Permanent when reporting it
+	// again would fail again.
+	Send func() error
+	// Done is called once the status is reported, with nil, or with the error it
+	// failed with once it isn't retried anymore.
+	Done func(error)
+}
+
+// Queue reports statuses asynchronously with a fixed number of workers, so that a provider
+// which is slow or down never holds up reconciliation. Reporting a status is retried with
+// an exponential backoff unless it fails with a Permanent error.
+type Queue struct {
+	logger *zap.SugaredLogger
+	queue  workqueue.RateLimitingInterface
+
+	mu      sync.Mutex
+	reports map[string]*Report
+}
+
+// NewQueue returns a Queue reporting the statuses until stopCh is closed.
+func NewQueue(logger *zap.SugaredLogger, stopCh <-chan struct{}) *Queue {
+	return newQueue(logger, stopCh, workqueue.NewItemExponentialFailureRateLimiter(time.Second, 5*time.Minute))
+}
+
+func newQueue(logger *zap.SugaredLogger, stopCh <-chan struct{}, rateLimiter workqueue.RateLimiter) *Queue {
+	q := &Queue{
+		logger:  logger.Named("commitstatus"),
+		queue:   workqueue.NewNamedRateLimitingQueue(rateLimiter, "commitstatus"),
+		reports: map[string]*Report{},
+	}
+	for i := 0; i < queueWorkers; i++ {
+		go func() {
+			for q.processNext() {
+			}
+		}()
+	}
+	go func() {
+		<-stopCh
+		q.queue.ShutDown()
+	}()
+	return q
+}
+
+// Add queues r to be reported. It never blocks.
+func (q *Queue) Add(r *Report) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if queued, ok := q.reports[r.Key]; ok && queued.ID == r.ID {
+		return
+	}
+	q.reports[r.Key] = r
+	q.queue.Forget(r.Key)
+	q.queue.Add(r.Key)
+}
+
+func (q *Queue) processNext() bool {
+	item, shutdown := q.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer q.queue.Done(item)
+	key := item.(string)
+	q.mu.Lock()
+	r, ok := q.reports[key]
+	q.mu.Unlock()
+	if !ok {
+		q.queue.Forget(key)
+		return true
+	}
+
+	err := r.Send()
+	if err != nil && !IsPermanent(err) && q.queue.NumRequeues(key)+1 < maxAttempts {
+		q.logger.Warnf("Failed to report status %s of %s, retrying: %v", r.ID, key, err)
+		q.queue.AddRateLimited(key)
+		return true
+	}
+	r.Done(err)
+	q.mu.Lock()
+	// The report may have been replaced while it was sent, in which case the new one
+	// was queued again
+	if q.reports[key] == r {
+		delete(q.reports, key)
+		q.queue.Forget(key)
+	}
+	q.mu.Unlock()
+	return true
+}
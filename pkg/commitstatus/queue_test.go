@@ -0,0 +1,151 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commitstatus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	logtesting "github.com/knative/pkg/logging/testing"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func newTestQueue(t *testing.T) (*Queue, chan struct{}) {
+	stopCh := make(chan struct{})
+	q := newQueue(logtesting.TestLogger(t), stopCh, workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, 10*time.Millisecond))
+	return q, stopCh
+}
+
+// queueReport queues a report of key failing with failures in turn, returning how many
+// times it was sent and the error it was done with.
+func queueReport(q *Queue, key string, failures ...error) (*int, chan error) {
+	sent := 0
+	done := make(chan error, 1)
+	q.Add(&Report{
+		Key: key,
+		ID:  "success",
+		Send: func() error {
+			sent++
+			if sent <= len(failures) {
+				return failures[sent-1]
+			}
+			return nil
+		},
+		Done: func(err error) { done <- err },
+	})
+	return &sent, done
+}
+
+func waitDone(t *testing.T, done chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the report to be done")
+		return nil
+	}
+}
+
+func TestQueue_Retries(t *testing.T) {
+	q, stopCh := newTestQueue(t)
+	defer close(stopCh)
+
+	sent, done := queueReport(q, "foo/retried", errors.New("connection refused"), errors.New("connection refused"))
+	if err := waitDone(t, done); err != nil {
+		t.Errorf("Expected the status to be reported once the provider recovers but got %v", err)
+	}
+	if *sent != 3 {
+		t.Errorf("Expected the status to be sent 3 times but it was sent %d times", *sent)
+	}
+}
+
+func TestQueue_GivesUp(t *testing.T) {
+	q, stopCh := newTestQueue(t)
+	defer close(stopCh)
+
+	failures := make([]error, maxAttempts)
+	for i := range failures {
+		failures[i] = errors.New("connection refused")
+	}
+	sent, done := queueReport(q, "foo/down", failures...)
+	if err := waitDone(t, done); err == nil {
+		t.Errorf("Expected an error once the status isn't retried anymore")
+	}
+	if *sent != maxAttempts {
+		t.Errorf("Expected the status to be sent %d times but it was sent %d times", maxAttempts, *sent)
+	}
+}
+
+func TestQueue_Permanent(t *testing.T) {
+	q, stopCh := newTestQueue(t)
+	defer close(stopCh)
+
+	sent, done := queueReport(q, "foo/misconfigured", Permanent(errors.New("secret github has no key token")))
+	if err := waitDone(t, done); !IsPermanent(err) {
+		t.Errorf("Expected a permanent error but got %v", err)
+	}
+	if *sent != 1 {
+		t.Errorf("Expected a permanent error not to be retried but the status was sent %d times", *sent)
+	}
+}
+
+func TestQueue_Replaced(t *testing.T) {
+	q, stopCh := newTestQueue(t)
+	defer close(stopCh)
+
+	// The first report is blocked until the second one replaces it
+	blocked := make(chan struct{})
+	ids := make(chan string, 3)
+	send := func(id string) *Report {
+		return &Report{
+			Key: "foo/run",
+			ID:  id,
+			Send: func() error {
+				<-blocked
+				ids <- id
+				return nil
+			},
+			Done: func(error) {},
+		}
+	}
+	q.Add(send("pending"))
+	q.Add(send("pending"))
+	time.Sleep(10 * time.Millisecond)
+	q.Add(send("success"))
+	q.Add(send("success"))
+	close(blocked)
+
+	var sent []string
+	for len(sent) < 2 {
+		select {
+		case id := <-ids:
+			sent = append(sent, id)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected both statuses to be sent but got %v", sent)
+		}
+	}
+	select {
+	case id := <-ids:
+		t.Errorf("Expected each status to be sent once but %s was sent again", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if sent[0] != "pending" || sent[1] != "success" {
+		t.Errorf("Expected the statuses to be sent in order but got %v", sent)
+	}
+}
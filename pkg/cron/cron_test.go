@@ -0,0 +1,119 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	utc := time.UTC
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	tcs := []struct {
+		spec     string
+		from     time.Time
+		expected time.Time
+	}{{
+		spec:     "* * * * *",
+		from:     time.Date(2019, 7, 1, 10, 20, 30, 0, utc),
+		expected: time.Date(2019, 7, 1, 10, 21, 0, 0, utc),
+	}, {
+		spec:     "30 2 * * *",
+		from:     time.Date(2019, 7, 1, 10, 20, 0, 0, utc),
+		expected: time.Date(2019, 7, 2, 2, 30, 0, 0, utc),
+	}, {
+		spec:     "*/15 9-17 * * mon-fri",
+		from:     time.Date(2019, 7, 5, 17, 50, 0, 0, utc), // a Friday
+		expected: time.Date(2019, 7, 8, 9, 0, 0, 0, utc),
+	}, {
+		spec:     "0 0 1,15 * *",
+		from:     time.Date(2019, 7, 1, 0, 0, 0, 0, utc),
+		expected: time.Date(2019, 7, 15, 0, 0, 0, 0, utc),
+	}, {
+		spec:     "0 0 29 feb *",
+		from:     time.Date(2019, 3, 1, 0, 0, 0, 0, utc),
+		expected: time.Date(2020, 2, 29, 0, 0, 0, 0, utc),
+	}, {
+		// Both days restricted: either of them matches
+		spec:     "0 12 13 * 5",
+		from:     time.Date(2019, 7, 6, 0, 0, 0, 0, utc),
+		expected: time.Date(2019, 7, 12, 12, 0, 0, 0, utc),
+	}, {
+		spec:     "0 0 * * 7",
+		from:     time.Date(2019, 7, 1, 0, 0, 0, 0, utc),
+		expected: time.Date(2019, 7, 7, 0, 0, 0, 0, utc),
+	}, {
+		spec:     "@weekly",
+		from:     time.Date(2019, 7, 1, 0, 0, 0, 0, utc),
+		expected: time.Date(2019, 7, 7, 0, 0, 0, 0, utc),
+	}, {
+		spec:     "@hourly",
+		from:     time.Date(2019, 12, 31, 23, 59, 0, 0, utc),
+		expected: time.Date(2020, 1, 1, 0, 0, 0, 0, utc),
+	}, {
+		spec:     "0 3 * * *",
+		from:     time.Date(2019, 7, 1, 0, 0, 0, 0, paris),
+		expected: time.Date(2019, 7, 1, 1, 0, 0, 0, utc),
+	}, {
+		// 2:30 doesn't exist in Paris on the day summer time starts
+		spec:     "30 2 * * *",
+		from:     time.Date(2019, 3, 30, 12, 0, 0, 0, paris),
+		expected: time.Date(2019, 4, 1, 2, 30, 0, 0, paris),
+	}, {
+		spec:     "0 0 30 feb *",
+		from:     time.Date(2019, 7, 1, 0, 0, 0, 0, utc),
+		expected: time.Time{},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.spec, func(t *testing.T) {
+			s, err := Parse(tc.spec)
+			if err != nil {
+				t.Fatalf("Unexpected error parsing %q: %v", tc.spec, err)
+			}
+			if next := s.Next(tc.from); !next.Equal(tc.expected) {
+				t.Errorf("Expected next time after %s to be %s but got %s", tc.from, tc.expected, next)
+			}
+		})
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+		"@every 5m",
+	} {
+		t.Run(spec, func(t *testing.T) {
+			if _, err := Parse(spec); err == nil {
+				t.Errorf("Expected an error parsing %q but got none", spec)
+			}
+		})
+	}
+}
@@ -17,7 +17,15 @@ limitations under the License.
 package entrypoint
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"time"
 )
 
 // Entrypointer holds fields for running commands with redirected
@@ -30,9 +38,23 @@ type Entrypointer struct {
 	// WaitFile is the file to wait for. If not specified, execution begins
 	// immediately.
 	WaitFile string
+	// WaitFileContent indicates to wait for WaitFile to have content, rather
+	// than only to exist.
+	WaitFileContent bool
 	// PostFile is the file to write when complete. If not specified, no
 	// file is written.
 	PostFile string
+	// ResultsDir is the directory the command writes its results to, one
+	// file per result. If specified, the results found there are reported
+	// in the termination message once the command has completed successfully.
+	ResultsDir string
+	// Timeout is how long the command may run before it's killed. If not
+	// specified, the command isn't limited.
+	Timeout time.Duration
+	// OnError is what to do when the command fails. With ContinueOnError,
+	// the failure is reported but the post file is written as on success,
+	// so that the following steps still run.
+	OnError string
 
 	// Waiter encapsulates waiting for files to exist.
 	Waiter Waiter
@@ -40,17 +62,22 @@ type Entrypointer struct {
 	Runner Runner
 	// PostWriter encapsulates writing files when complete.
 	PostWriter PostWriter
+	// TerminationWriter encapsulates reporting how the step went when
+	// complete. If not specified, nothing is reported.
+	TerminationWriter TerminationWriter
 }
 
 // Waiter encapsulates waiting for files to exist.
 type Waiter interface {
-	// Wait blocks until the specified file exists.
-	Wait(file string) error
+	// Wait blocks until the specified file exists, and has content if
+	// expectContent is true, or until ctx is done.
+	Wait(ctx context.Context, file string, expectContent bool) error
 }
 
 // Runner encapsulates running commands.
 type Runner interface {
-	Run(args ...string) error
+	// Run runs the command args, killing it once ctx is done.
+	Run(ctx context.Context, args ...string) error
 }
 
 // PostWriter encapsulates writing a file when complete.
@@ -59,14 +86,66 @@ type PostWriter interface {
 	Write(file string)
 }
 
-// Go optionally waits for a file, runs the command, and writes a
-// post file.
-func (e Entrypointer) Go() error {
+// TerminationWriter encapsulates reporting how the step went.
+type TerminationWriter interface {
+	// Write reports m, e.g. as the container's termination message.
+	Write(m TerminationMessage) error
+}
+
+// TerminationMessage reports how the step went: when its command actually
+// ran, excluding the time spent waiting for the previous steps, how it
+// exited and whether it was killed for running past its timeout, or whether
+// it was skipped because a previous step failed.
+type TerminationMessage struct {
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	ExitCode   int       `json:"exitCode"`
+	TimedOut   bool      `json:"timedOut,omitempty"`
+	Skipped    bool      `json:"skipped,omitempty"`
+	// Results are the results the command emitted, if it succeeded.
+	Results []Result `json:"results,omitempty"`
+	// Error is why the step failed even though its command succeeded, such
+	// as its results being too large to be reported.
+	Error string `json:"error,omitempty"`
+}
+
+// MaxTerminationMessageSize is the size of the largest termination message,
+// in bytes, beyond which the kubelet truncates it.
+const MaxTerminationMessageSize = 4096
+
+// Result is a named value emitted by a command.
+type Result struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ContinueOnError is the OnError value making the following steps run even
+// though the command failed.
+const ContinueOnError = "continue"
+
+// SkipError is returned by the Waiter when a previous step failed, in which
+// case the step is skipped rather than run.
+type SkipError string
+
+func (e SkipError) Error() string {
+	return string(e)
+}
+
+// Go optionally waits for a file, runs the command, reports how it went
+// and writes a post file. Waiting stops, and the command is killed, once
+// ctx is done.
+func (e Entrypointer) Go(ctx context.Context) error {
 	if e.WaitFile != "" {
-		if err := e.Waiter.Wait(e.WaitFile); err != nil {
+		if err := e.Waiter.Wait(ctx, e.WaitFile, e.WaitFileContent); err != nil {
 			// An error happened while waiting, so we bail
 			// *but* we write postfile to make next steps bail too
 			e.WritePostFile(e.PostFile, err)
+			if _, ok := err.(SkipError); ok {
+				now := time.Now()
+				if werr := e.writeTerminationMessage(TerminationMessage{StartedAt: now, FinishedAt: now, Skipped: true}); werr != nil {
+					return werr
+				}
+			}
 			return err
 		}
 	}
@@ -75,7 +154,35 @@ func (e Entrypointer) Go() error {
 		e.Args = append([]string{e.Entrypoint}, e.Args...)
 	}
 
-	err := e.Runner.Run(e.Args...)
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	m := TerminationMessage{StartedAt: time.Now()}
+	err := e.Runner.Run(ctx, e.Args...)
+	m.FinishedAt = time.Now()
+	m.ExitCode = ExitCode(err)
+	m.TimedOut = err != nil && ctx.Err() == context.DeadlineExceeded
+
+	if err != nil && e.OnError == ContinueOnError {
+		// The failure is only reported in the termination message.
+		err = nil
+	} else if err == nil && e.ResultsDir != "" {
+		if m.Results, err = ReadResults(e.ResultsDir); err == nil {
+			err = checkTerminationMessageSize(m)
+		}
+		if err != nil {
+			// The command succeeded but its results can't be reported, which fails the step.
+			m.Results = nil
+			m.ExitCode = 1
+			m.Error = err.Error()
+		}
+	}
+	if werr := e.writeTerminationMessage(m); err == nil {
+		err = werr
+	}
 
 	// Write the post file *no matter what*
 	e.WritePostFile(e.PostFile, err)
@@ -83,6 +190,71 @@ func (e Entrypointer) Go() error {
 	return err
 }
 
+func (e Entrypointer) writeTerminationMessage(m TerminationMessage) error {
+	if e.TerminationWriter == nil {
+		return nil
+	}
+	return e.TerminationWriter.Write(m)
+}
+
+// checkTerminationMessageSize returns an error if m is too large to be reported
+// whole as the termination message of the container.
+func checkTerminationMessageSize(m TerminationMessage) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if len(b) > MaxTerminationMessageSize {
+		return fmt.Errorf("results too large: reporting them takes %d bytes, more than the %d bytes of a termination message", len(b), MaxTerminationMessageSize)
+	}
+	return nil
+}
+
+// ExitCode returns the code a command which returned err exited with: 0 if it
+// succeeded, its exit status if it ran and failed, 128 plus the signal if it
+// was killed, as shells report it, and 1 otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		// WaitStatus is defined for both Unix and Windows and in both
+		// cases has an ExitStatus() method with the same signature.
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			if status.Signaled() {
+				return 128 + int(status.Signal())
+			}
+			return status.ExitStatus()
+		}
+	}
+	return 1
+}
+
+// ReadResults returns the results found in dir, sorted by name. Each regular
+// file in dir is a result, named after the file, whose value is the content
+// of the file. A missing dir means no results were written.
+func ReadResults(dir string) ([]Result, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading results from %q: %v", dir, err)
+	}
+	results := []Result{}
+	for _, f := range files {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading result %q: %v", f.Name(), err)
+		}
+		results = append(results, Result{Name: f.Name(), Value: string(b)})
+	}
+	return results, nil
+}
+
 func (e Entrypointer) WritePostFile(postFile string, err error) {
 	if err != nil && postFile != "" {
 		postFile = fmt.Sprintf("%s.err", postFile)
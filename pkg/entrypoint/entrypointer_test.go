@@ -17,9 +17,16 @@ limitations under the License.
 package entrypoint
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -69,7 +76,7 @@ func TestEntrypointerFailures(t *testing.T) {
 				Waiter:     fw,
 				Runner:     fr,
 				PostWriter: fpw,
-			}.Go()
+			}.Go(context.Background())
 			if err == nil {
 				t.Fatalf("Entrpointer didn't fail")
 			}
@@ -128,7 +135,7 @@ func TestEntrypointer(t *testing.T) {
 				Waiter:     fw,
 				Runner:     fr,
 				PostWriter: fpw,
-			}.Go()
+			}.Go(context.Background())
 			if err != nil {
 				t.Fatalf("Entrypointer failed: %v", err)
 			}
@@ -173,16 +180,297 @@ func TestEntrypointer(t *testing.T) {
 	}
 }
 
-type fakeWaiter struct{ waited *string }
+func TestEntrypointerWaitFileContent(t *testing.T) {
+	fw := &fakeWaiter{}
+	err := Entrypointer{
+		Entrypoint:      "echo",
+		WaitFile:        "readyfile",
+		WaitFileContent: true,
+		Waiter:          fw,
+		Runner:          &fakeRunner{},
+		PostWriter:      &fakePostWriter{},
+	}.Go(context.Background())
+	if err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+	if fw.waited == nil || *fw.waited != "readyfile" || !fw.expectContent {
+		t.Errorf("Expected to wait for readyfile to have content, waited for %v (content: %t)", fw.waited, fw.expectContent)
+	}
+}
+
+func TestEntrypointerResults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "results")
+	if err != nil {
+		t.Fatalf("Couldn't create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	for name, value := range map[string]string{"version": "v1.2.3", "digest": "sha256:abc"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0644); err != nil {
+			t.Fatalf("Couldn't write result %q: %v", name, err)
+		}
+	}
+
+	ftw := &fakeTerminationWriter{}
+	err = Entrypointer{
+		Entrypoint:        "echo",
+		ResultsDir:        dir,
+		Waiter:            &fakeWaiter{},
+		Runner:            &fakeRunner{},
+		PostWriter:        &fakePostWriter{},
+		TerminationWriter: ftw,
+	}.Go(context.Background())
+	if err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+	want := []Result{{Name: "digest", Value: "sha256:abc"}, {Name: "version", Value: "v1.2.3"}}
+	if ftw.message == nil {
+		t.Fatal("Wanted termination message written, got nil")
+	}
+	if d := cmp.Diff(want, ftw.message.Results); d != "" {
+		t.Errorf("Entrypointer results diff -want, +got: %v", d)
+	}
+}
+
+func TestEntrypointerResults_TooLarge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "results")
+	if err != nil {
+		t.Fatalf("Couldn't create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "manifest"), []byte(strings.Repeat("x", MaxTerminationMessageSize)), 0644); err != nil {
+		t.Fatalf("Couldn't write result: %v", err)
+	}
+
+	ftw, fpw := &fakeTerminationWriter{}, &fakePostWriter{}
+	err = Entrypointer{
+		Entrypoint:        "echo",
+		PostFile:          "writeme",
+		ResultsDir:        dir,
+		Waiter:            &fakeWaiter{},
+		Runner:            &fakeRunner{},
+		PostWriter:        fpw,
+		TerminationWriter: ftw,
+	}.Go(context.Background())
+	if err == nil || !strings.HasPrefix(err.Error(), "results too large") {
+		t.Fatalf("Expected the step to fail because its results are too large, got %v", err)
+	}
+	if ftw.message == nil {
+		t.Fatal("Wanted termination message written, got nil")
+	}
+	if m := ftw.message; m.Results != nil || m.ExitCode != 1 || m.Error != err.Error() {
+		t.Errorf("Expected the termination message to report the failure without the results, got %+v", m)
+	}
+	if fpw.wrote == nil || *fpw.wrote != "writeme.err" {
+		t.Errorf("Expected the error post file to be written, got %v", fpw.wrote)
+	}
+}
 
-func (f *fakeWaiter) Wait(file string) error {
+func TestEntrypointerResults_NoResults(t *testing.T) {
+	for _, c := range []struct {
+		desc       string
+		resultsDir string
+		runner     Runner
+	}{{
+		desc:       "results dir doesn't exist",
+		resultsDir: "/does/not/exist",
+		runner:     &fakeRunner{},
+	}, {
+		desc:       "failing runner",
+		resultsDir: os.TempDir(),
+		runner:     &fakeErrorRunner{},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			ftw := &fakeTerminationWriter{}
+			Entrypointer{
+				Entrypoint:        "echo",
+				ResultsDir:        c.resultsDir,
+				Waiter:            &fakeWaiter{},
+				Runner:            c.runner,
+				PostWriter:        &fakePostWriter{},
+				TerminationWriter: ftw,
+			}.Go(context.Background())
+			if ftw.message != nil && ftw.message.Results != nil {
+				t.Errorf("Wrote results %v when none were expected", ftw.message.Results)
+			}
+		})
+	}
+}
+
+func TestEntrypointerTerminationMessage(t *testing.T) {
+	for _, c := range []struct {
+		desc   string
+		waiter Waiter
+		runner Runner
+		want   TerminationMessage
+	}{{
+		desc:   "succeeding step",
+		waiter: &fakeWaiter{},
+		runner: &fakeRunner{},
+		want:   TerminationMessage{ExitCode: 0},
+	}, {
+		desc:   "failing step",
+		waiter: &fakeWaiter{},
+		runner: &fakeErrorRunner{},
+		want:   TerminationMessage{ExitCode: 1},
+	}, {
+		desc:   "skipped step",
+		waiter: &fakeSkipWaiter{},
+		runner: &fakeRunner{},
+		want:   TerminationMessage{Skipped: true},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			ftw := &fakeTerminationWriter{}
+			before := time.Now()
+			Entrypointer{
+				Entrypoint:        "echo",
+				WaitFile:          "waitforme",
+				Waiter:            c.waiter,
+				Runner:            c.runner,
+				PostWriter:        &fakePostWriter{},
+				TerminationWriter: ftw,
+			}.Go(context.Background())
+			after := time.Now()
+			if ftw.message == nil {
+				t.Fatal("Wanted termination message written, got nil")
+			}
+			m := *ftw.message
+			if m.StartedAt.Before(before) || m.FinishedAt.Before(m.StartedAt) || m.FinishedAt.After(after) {
+				t.Errorf("Expected %v <= startedAt %v <= finishedAt %v <= %v", before, m.StartedAt, m.FinishedAt, after)
+			}
+			m.StartedAt, m.FinishedAt = time.Time{}, time.Time{}
+			if d := cmp.Diff(c.want, m); d != "" {
+				t.Errorf("Entrypointer termination message diff -want, +got: %v", d)
+			}
+		})
+	}
+}
+
+func TestEntrypointerSkipped(t *testing.T) {
+	fr, fpw := &fakeRunner{}, &fakePostWriter{}
+	err := Entrypointer{
+		Entrypoint: "echo",
+		WaitFile:   "waitforme",
+		PostFile:   "writeme",
+		Waiter:     &fakeSkipWaiter{},
+		Runner:     fr,
+		PostWriter: fpw,
+	}.Go(context.Background())
+	if _, ok := err.(SkipError); !ok {
+		t.Errorf("Expected a SkipError, got %v", err)
+	}
+	if fr.args != nil {
+		t.Errorf("Ran %s when the step should have been skipped", *fr.args)
+	}
+	if fpw.wrote == nil || *fpw.wrote != "writeme.err" {
+		t.Errorf("Wrote post file %v, want %q", fpw.wrote, "writeme.err")
+	}
+}
+
+func TestEntrypointerTimeout(t *testing.T) {
+	ftw, fpw := &fakeTerminationWriter{}, &fakePostWriter{}
+	err := Entrypointer{
+		Entrypoint:        "sleep",
+		PostFile:          "writeme",
+		Timeout:           10 * time.Millisecond,
+		Waiter:            &fakeWaiter{},
+		Runner:            &fakeBlockingRunner{},
+		PostWriter:        fpw,
+		TerminationWriter: ftw,
+	}.Go(context.Background())
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected the deadline to be exceeded, got %v", err)
+	}
+	if ftw.message == nil || !ftw.message.TimedOut || ftw.message.ExitCode == 0 {
+		t.Errorf("Expected the step to be reported as timed out with a non-zero exit code, got %+v", ftw.message)
+	}
+	if fpw.wrote == nil || *fpw.wrote != "writeme.err" {
+		t.Errorf("Wrote post file %v, want %q", fpw.wrote, "writeme.err")
+	}
+}
+
+func TestEntrypointerContinueOnError(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		timeout      time.Duration
+		runner       Runner
+		wantTimedOut bool
+	}{{
+		desc:   "failing step",
+		runner: &fakeErrorRunner{},
+	}, {
+		desc:         "timed out step",
+		timeout:      10 * time.Millisecond,
+		runner:       &fakeBlockingRunner{},
+		wantTimedOut: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			ftw, fpw := &fakeTerminationWriter{}, &fakePostWriter{}
+			err := Entrypointer{
+				Entrypoint:        "echo",
+				PostFile:          "writeme",
+				Timeout:           c.timeout,
+				OnError:           ContinueOnError,
+				Waiter:            &fakeWaiter{},
+				Runner:            c.runner,
+				PostWriter:        fpw,
+				TerminationWriter: ftw,
+			}.Go(context.Background())
+			if err != nil {
+				t.Errorf("Expected the failure to be ignored, got %v", err)
+			}
+			if ftw.message == nil || ftw.message.ExitCode == 0 || ftw.message.TimedOut != c.wantTimedOut {
+				t.Errorf("Expected the failure to be reported, got %+v", ftw.message)
+			}
+			if fpw.wrote == nil || *fpw.wrote != "writeme" {
+				t.Errorf("Wrote post file %v, want %q", fpw.wrote, "writeme")
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		err  error
+		want int
+	}{{
+		desc: "no error",
+		want: 0,
+	}, {
+		desc: "command failed",
+		err:  exec.Command("sh", "-c", "exit 3").Run(),
+		want: 3,
+	}, {
+		desc: "command killed",
+		err:  exec.Command("sh", "-c", "kill -9 $$").Run(),
+		want: 137,
+	}, {
+		desc: "command couldn't run",
+		err:  fmt.Errorf("runner failed"),
+		want: 1,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := ExitCode(c.err); got != c.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", c.err, got, c.want)
+			}
+		})
+	}
+}
+
+type fakeWaiter struct {
+	waited        *string
+	expectContent bool
+}
+
+func (f *fakeWaiter) Wait(ctx context.Context, file string, expectContent bool) error {
 	f.waited = &file
+	f.expectContent = expectContent
 	return nil
 }
 
 type fakeRunner struct{ args *[]string }
 
-func (f *fakeRunner) Run(args ...string) error {
+func (f *fakeRunner) Run(ctx context.Context, args ...string) error {
 	f.args = &args
 	return nil
 }
@@ -191,16 +479,38 @@ type fakePostWriter struct{ wrote *string }
 
 func (f *fakePostWriter) Write(file string) { f.wrote = &file }
 
+type fakeTerminationWriter struct{ message *TerminationMessage }
+
+func (f *fakeTerminationWriter) Write(m TerminationMessage) error {
+	f.message = &m
+	return nil
+}
+
+type fakeSkipWaiter struct{ waited *string }
+
+func (f *fakeSkipWaiter) Wait(ctx context.Context, file string, expectContent bool) error {
+	f.waited = &file
+	return SkipError("error file present, bail and skip the step")
+}
+
 type fakeErrorWaiter struct{ waited *string }
 
-func (f *fakeErrorWaiter) Wait(file string) error {
+func (f *fakeErrorWaiter) Wait(ctx context.Context, file string, expectContent bool) error {
 	f.waited = &file
 	return fmt.Errorf("waiter failed")
 }
 
 type fakeErrorRunner struct{ args *[]string }
 
-func (f *fakeErrorRunner) Run(args ...string) error {
+func (f *fakeErrorRunner) Run(ctx context.Context, args ...string) error {
 	f.args = &args
 	return fmt.Errorf("runner failed")
 }
+
+type fakeBlockingRunner struct{ args *[]string }
+
+func (f *fakeBlockingRunner) Run(ctx context.Context, args ...string) error {
+	f.args = &args
+	<-ctx.Done()
+	return ctx.Err()
+}
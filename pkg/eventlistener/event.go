@@ -0,0 +1,146 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// event holds the body and the headers of an event, which the JSONPath templates of the
+// EventTriggers refer to as {.body} and {.header}.
+type event map[string]interface{}
+
+// newEvent decodes the JSON body of an event. The names of the headers are lowercased,
+// since their case isn't significant.
+func newEvent(header http.Header, body []byte) (event, error) {
+	var payload interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// Numbers are kept as they were sent, instead of being printed as floats
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("couldn't decode the JSON body of the event: %v", err)
+	}
+	headers := map[string]interface{}{}
+	for name, values := range header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+	return event{"body": payload, "header": headers}, nil
+}
+
+// extract evaluates the JSONPath template over the event.
+func (e event) extract(template string) (string, error) {
+	j := jsonpath.New("")
+	if err := j.Parse(template); err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := j.Execute(&buf, map[string]interface{}(e)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// newPipelineRun returns the PipelineRun created by the EventTrigger for the event, and the
+// PipelineResources bound to it, with the params and the resources extracted from the event.
+func newPipelineRun(et *v1alpha1.EventTrigger, e event, deliveryID string) (*v1alpha1.PipelineRun, []*v1alpha1.PipelineResource, error) {
+	template := et.Spec.PipelineRunTemplate.DeepCopy()
+	labels := template.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[pipeline.GroupName+pipeline.EventTriggerLabelKey] = et.Name
+
+	pr := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(et.Name),
+			Namespace:   et.Namespace,
+			Labels:      labels,
+			Annotations: template.Annotations,
+		},
+		Spec: template.Spec,
+	}
+	pr.Spec.Trigger = v1alpha1.PipelineTrigger{
+		Type:       v1alpha1.PipelineTriggerTypeEvent,
+		Name:       et.Name,
+		DeliveryID: deliveryID,
+	}
+
+	for _, p := range et.Spec.Params {
+		value, err := e.extract(p.Value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't extract param %s from the event: %v", p.Name, err)
+		}
+		pr.Spec.Params = setParam(pr.Spec.Params, v1alpha1.Param{Name: p.Name, Value: value})
+	}
+
+	resources := []*v1alpha1.PipelineResource{}
+	for _, er := range et.Spec.Resources {
+		r := &v1alpha1.PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      names.SimpleNameGenerator.RestrictLengthWithSuffix(pr.Name, "-"+er.Name),
+				Namespace: et.Namespace,
+				Labels:    map[string]string{pipeline.GroupName + pipeline.EventTriggerLabelKey: et.Name},
+			},
+			Spec: *er.Spec.DeepCopy(),
+		}
+		for i, p := range r.Spec.Params {
+			value, err := e.extract(p.Value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("couldn't extract param %s of resource %s from the event: %v", p.Name, er.Name, err)
+			}
+			r.Spec.Params[i].Value = value
+		}
+		resources = append(resources, r)
+		pr.Spec.Resources = setResourceBinding(pr.Spec.Resources, v1alpha1.PipelineResourceBinding{
+			Name:        er.Name,
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: r.Name},
+		})
+	}
+	return pr, resources, nil
+}
+
+// setParam replaces the param of params with the same name as p, or adds p to them.
+func setParam(params []v1alpha1.Param, p v1alpha1.Param) []v1alpha1.Param {
+	for i := range params {
+		if params[i].Name == p.Name {
+			params[i] = p
+			return params
+		}
+	}
+	return append(params, p)
+}
+
+// setResourceBinding replaces the binding of bindings with the same name as b, or adds b to them.
+func setResourceBinding(bindings []v1alpha1.PipelineResourceBinding, b v1alpha1.PipelineResourceBinding) []v1alpha1.PipelineResourceBinding {
+	for i := range bindings {
+		if bindings[i].Name == b.Name {
+			bindings[i] = b
+			return bindings
+		}
+	}
+	return append(bindings, b)
+}
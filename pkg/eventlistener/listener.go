@@ -0,0 +1,227 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventlistener turns the events it receives over HTTP, such as the webhooks of Git
+// providers, into PipelineRuns as described by EventTriggers.
+package eventlistener
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxPayloadSize is the maximum size of the body of the events, GitHub limiting its
+// payloads to 25MB.
+const maxPayloadSize = 25 << 20
+
+// Listener is an http.Handler creating a PipelineRun for each event it receives at
+// /<namespace>/<name>, as described by the EventTrigger with that name and namespace.
+type Listener struct {
+	KubeClientSet     kubernetes.Interface
+	PipelineClientSet clientset.Interface
+	Logger            *zap.SugaredLogger
+}
+
+// response is the body of the responses of the Listener.
+type response struct {
+	// PipelineRun is the name of the PipelineRun created for the event, if any
+	PipelineRun string `json:"pipelineRun,omitempty"`
+	// Message explains why no PipelineRun was created
+	Message string `json:"message,omitempty"`
+}
+
+// ServeHTTP creates a PipelineRun from the event in the body of r, if it matches the
+// EventTrigger it was sent to.
+func (l *Listener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		l.respond(w, http.StatusMethodNotAllowed, response{Message: fmt.Sprintf("method %s isn't allowed", r.Method)})
+		return
+	}
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(path) != 2 || path[0] == "" || path[1] == "" {
+		l.respond(w, http.StatusNotFound, response{Message: "events should be sent to /<namespace>/<eventTrigger>"})
+		return
+	}
+	namespace, name := path[0], path[1]
+
+	et, err := l.PipelineClientSet.TektonV1alpha1().EventTriggers(namespace).Get(name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		l.respond(w, http.StatusNotFound, response{Message: fmt.Sprintf("EventTrigger %s/%s doesn't exist", namespace, name)})
+		return
+	} else if err != nil {
+		l.Logger.Errorf("Failed to get EventTrigger %s/%s: %v", namespace, name, err)
+		l.respond(w, http.StatusInternalServerError, response{Message: fmt.Sprintf("couldn't get EventTrigger %s/%s", namespace, name)})
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+	if err != nil {
+		l.respond(w, http.StatusBadRequest, response{Message: fmt.Sprintf("couldn't read the event: %v", err)})
+		return
+	}
+	if len(body) > maxPayloadSize {
+		l.respond(w, http.StatusRequestEntityTooLarge, response{Message: fmt.Sprintf("events can't be larger than %d bytes", maxPayloadSize)})
+		return
+	}
+
+	// The events are rejected unless they are signed or the EventTrigger explicitly
+	// accepts them otherwise, in case it was created before signatures were required
+	if et.Spec.Signature == nil && !et.Spec.InsecureSkipSignature {
+		l.Logger.Warnf("Rejected event sent to EventTrigger %s/%s: it has no signature", namespace, name)
+		l.respond(w, http.StatusUnauthorized, response{Message: fmt.Sprintf("EventTrigger %s/%s has no signature to verify the events with", namespace, name)})
+		return
+	}
+	if s := et.Spec.Signature; s != nil {
+		secret, err := l.getSecret(namespace, s)
+		if err != nil {
+			l.Logger.Errorf("Failed to get the secret of EventTrigger %s/%s: %v", namespace, name, err)
+			l.respond(w, http.StatusInternalServerError, response{Message: fmt.Sprintf("couldn't get the secret of EventTrigger %s/%s", namespace, name)})
+			return
+		}
+		if s.Type == v1alpha1.EventSignatureTypeToken {
+			err = verifyToken(r.Header.Get(s.Header), secret)
+		} else {
+			err = verifySignature(r.Header.Get(s.Header), body, secret)
+		}
+		if err != nil {
+			l.Logger.Warnf("Rejected event sent to EventTrigger %s/%s: %v", namespace, name, err)
+			l.respond(w, http.StatusUnauthorized, response{Message: err.Error()})
+			return
+		}
+	}
+
+	if !matchesEventType(et, r.Header) {
+		l.respond(w, http.StatusOK, response{Message: fmt.Sprintf("events of type %q are ignored", r.Header.Get(et.Spec.EventTypeHeader))})
+		return
+	}
+
+	e, err := newEvent(r.Header, body)
+	if err != nil {
+		l.respond(w, http.StatusBadRequest, response{Message: err.Error()})
+		return
+	}
+	deliveryID := ""
+	if et.Spec.DeliveryIDHeader != "" {
+		deliveryID = r.Header.Get(et.Spec.DeliveryIDHeader)
+	}
+
+	pr, resources, err := newPipelineRun(et, e, deliveryID)
+	if err != nil {
+		l.respond(w, http.StatusBadRequest, response{Message: err.Error()})
+		return
+	}
+	if err := l.createPipelineRun(pr, resources); err != nil {
+		l.Logger.Errorf("Failed to create PipelineRun %s/%s for EventTrigger %s: %v", namespace, pr.Name, name, err)
+		l.respond(w, http.StatusInternalServerError, response{Message: fmt.Sprintf("couldn't create PipelineRun %s", pr.Name)})
+		return
+	}
+	l.Logger.Infof("Created PipelineRun %s/%s for delivery %q of EventTrigger %s", namespace, pr.Name, deliveryID, name)
+	l.respond(w, http.StatusCreated, response{PipelineRun: pr.Name})
+}
+
+func (l *Listener) respond(w http.ResponseWriter, status int, resp response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		l.Logger.Warnf("Failed to write response: %v", err)
+	}
+}
+
+// getSecret returns the secret the events sent to the EventTrigger are signed with.
+func (l *Listener) getSecret(namespace string, s *v1alpha1.EventSignature) ([]byte, error) {
+	secret, err := l.KubeClientSet.CoreV1().Secrets(namespace).Get(s.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	value, ok := secret.Data[s.SecretRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no key %s", s.SecretRef.Name, s.SecretRef.Key)
+	}
+	return value, nil
+}
+
+// createPipelineRun creates pr, and the PipelineResources it is bound to which are owned by it.
+// If any of them can't be created, those already created are deleted.
+func (l *Listener) createPipelineRun(pr *v1alpha1.PipelineRun, resources []*v1alpha1.PipelineResource) error {
+	// The PipelineResources are created first so that the PipelineRun doesn't fail to
+	// find them, and then adopted by the PipelineRun so that they are deleted with it
+	created := []*v1alpha1.PipelineResource{}
+	for _, r := range resources {
+		c, err := l.PipelineClientSet.TektonV1alpha1().PipelineResources(r.Namespace).Create(r)
+		if err != nil {
+			l.deleteAll(nil, created)
+			return err
+		}
+		created = append(created, c)
+	}
+	createdPr, err := l.PipelineClientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Create(pr)
+	if err != nil {
+		l.deleteAll(nil, created)
+		return err
+	}
+	for _, r := range created {
+		// The created PipelineResource, unlike the one it was created from, has the
+		// resourceVersion the update is conditioned on.
+		r.OwnerReferences = createdPr.GetOwnerReference()
+		if _, err := l.PipelineClientSet.TektonV1alpha1().PipelineResources(r.Namespace).Update(r); err != nil {
+			l.deleteAll(createdPr, created)
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteAll deletes pr, if any, and resources, which were created for an event which
+// couldn't be turned into a PipelineRun, so that they don't linger.
+func (l *Listener) deleteAll(pr *v1alpha1.PipelineRun, resources []*v1alpha1.PipelineResource) {
+	if pr != nil {
+		if err := l.PipelineClientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Delete(pr.Name, &metav1.DeleteOptions{}); err != nil {
+			l.Logger.Errorf("Failed to delete PipelineRun %s/%s: %v", pr.Namespace, pr.Name, err)
+		}
+	}
+	for _, r := range resources {
+		if err := l.PipelineClientSet.TektonV1alpha1().PipelineResources(r.Namespace).Delete(r.Name, &metav1.DeleteOptions{}); err != nil {
+			l.Logger.Errorf("Failed to delete PipelineResource %s/%s: %v", r.Namespace, r.Name, err)
+		}
+	}
+}
+
+// matchesEventType returns true if the type of the event, read from its headers, is one
+// the EventTrigger creates PipelineRuns for.
+func matchesEventType(et *v1alpha1.EventTrigger, header http.Header) bool {
+	if len(et.Spec.EventTypes) == 0 {
+		return true
+	}
+	eventType := header.Get(et.Spec.EventTypeHeader)
+	for _, t := range et.Spec.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,414 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	fakepipelineclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"github.com/tektoncd/pipeline/test/names"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+const webhookSecret = "It's a Secret to Everybody"
+
+var (
+	githubPush = tb.EventTrigger("github-push", "foo", "build-pipeline",
+		tb.EventTriggerEventTypes("X-GitHub-Event", "push"),
+		tb.EventTriggerDeliveryIDHeader("X-GitHub-Delivery"),
+		tb.EventTriggerSignature("X-Hub-Signature-256", "github-webhook", "secret"),
+		tb.EventTriggerParam("revision", "{.body.head_commit.id}"),
+		tb.EventTriggerParam("branch", "{.body.repository.default_branch}"),
+		tb.EventTriggerParam("event", "{.header.x-github-event}"),
+		tb.EventTriggerResource("source", v1alpha1.PipelineResourceTypeGit,
+			tb.PipelineResourceSpecParam("url", "{.body.repository.clone_url}"),
+			tb.PipelineResourceSpecParam("revision", "{.body.after}"),
+		),
+		tb.EventTriggerTemplateSpec(
+			tb.PipelineRunServiceAccount("builder"),
+			tb.PipelineRunParam("branch", "develop"),
+		),
+	)
+	gitlabPush = tb.EventTrigger("gitlab-push", "foo", "build-pipeline",
+		tb.EventTriggerEventTypes("X-Gitlab-Event", "Push Hook"),
+		tb.EventTriggerInsecureSkipSignature(),
+		tb.EventTriggerParam("project", "{.body.project.path_with_namespace}@{.body.checkout_sha}"),
+		tb.EventTriggerParam("commits", "{.body.total_commits_count}"),
+	)
+	gitlabTokenPush = tb.EventTrigger("gitlab-token-push", "foo", "build-pipeline",
+		tb.EventTriggerEventTypes("X-Gitlab-Event", "Push Hook"),
+		tb.EventTriggerSignature("X-Gitlab-Token", "github-webhook", "secret", tb.EventSignatureType(v1alpha1.EventSignatureTypeToken)),
+	)
+	// unsignedPush was created without a signature before it was required
+	unsignedPush        = tb.EventTrigger("unsigned-push", "foo", "build-pipeline")
+	webhookSecretObject = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-webhook", Namespace: "foo"},
+		Data:       map[string][]byte{"secret": []byte(webhookSecret)},
+	}
+)
+
+func readPayload(t *testing.T, name string) []byte {
+	t.Helper()
+	payload, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Couldn't read recorded payload %s: %v", name, err)
+	}
+	return payload
+}
+
+func sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(webhookSecret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newListener(objs ...*v1alpha1.EventTrigger) *Listener {
+	pipelineObjs := []runtime.Object{}
+	for _, o := range objs {
+		pipelineObjs = append(pipelineObjs, o)
+	}
+	return &Listener{
+		KubeClientSet:     fakekubeclientset.NewSimpleClientset(webhookSecretObject),
+		PipelineClientSet: fakepipelineclientset.NewSimpleClientset(pipelineObjs...),
+		Logger:            zap.NewNop().Sugar(),
+	}
+}
+
+func send(l *Listener, path string, header map[string]string, payload []byte) (*httptest.ResponseRecorder, response) {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload))
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	l.ServeHTTP(rec, req)
+	var resp response
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+func TestListener_GitHubPush(t *testing.T) {
+	names.TestingSeed()
+	payload := readPayload(t, "github-push.json")
+	l := newListener(githubPush)
+
+	rec, resp := send(l, "/foo/github-push", map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-GitHub-Delivery":   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		"X-Hub-Signature-256": sign(payload),
+	}, payload)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d but got %d: %s", http.StatusCreated, rec.Code, resp.Message)
+	}
+	if resp.PipelineRun != "github-push-9l9zj" {
+		t.Errorf("Expected PipelineRun github-push-9l9zj in the response but got %q", resp.PipelineRun)
+	}
+
+	pr, err := l.PipelineClientSet.TektonV1alpha1().PipelineRuns("foo").Get("github-push-9l9zj", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected PipelineRun to be created: %v", err)
+	}
+	expectedPipelineRun := tb.PipelineRun("github-push-9l9zj", "foo",
+		tb.PipelineRunLabel("tekton.dev/eventTrigger", "github-push"),
+		tb.PipelineRunSpec("build-pipeline",
+			tb.PipelineRunServiceAccount("builder"),
+			tb.PipelineRunParam("branch", "master"),
+			tb.PipelineRunParam("revision", "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c"),
+			tb.PipelineRunParam("event", "push"),
+			tb.PipelineRunResourceBinding("source", tb.PipelineResourceBindingRef("github-push-9l9zj-source")),
+		),
+	)
+	expectedPipelineRun.Spec.Trigger = v1alpha1.PipelineTrigger{
+		Type:       v1alpha1.PipelineTriggerTypeEvent,
+		Name:       "github-push",
+		DeliveryID: "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+	}
+	if d := cmp.Diff(expectedPipelineRun, pr); d != "" {
+		t.Errorf("PipelineRun created didn't match, diff: %s", d)
+	}
+
+	r, err := l.PipelineClientSet.TektonV1alpha1().PipelineResources("foo").Get("github-push-9l9zj-source", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected PipelineResource to be created: %v", err)
+	}
+	expectedResource := tb.PipelineResource("github-push-9l9zj-source", "foo",
+		tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeGit,
+			tb.PipelineResourceSpecParam("url", "https://github.com/Codertocat/Hello-World.git"),
+			tb.PipelineResourceSpecParam("revision", "0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c"),
+		),
+	)
+	expectedResource.Labels = map[string]string{"tekton.dev/eventTrigger": "github-push"}
+	expectedResource.OwnerReferences = pr.GetOwnerReference()
+	if d := cmp.Diff(expectedResource, r); d != "" {
+		t.Errorf("PipelineResource created didn't match, diff: %s", d)
+	}
+}
+
+// newStrictListener returns a Listener whose fake clientset sets the resourceVersion of
+// the PipelineResources it creates, and rejects their updates without one, as the API
+// server does.
+func newStrictListener(objs ...*v1alpha1.EventTrigger) *Listener {
+	l := newListener()
+	tracker := ktesting.NewObjectTracker(scheme.Scheme, scheme.Codecs.UniversalDecoder())
+	for _, o := range objs {
+		tracker.Add(o)
+	}
+	c := &fakepipelineclientset.Clientset{}
+	react := ktesting.ObjectReaction(tracker)
+	c.AddReactor("*", "*", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if action.GetResource().Resource == "pipelineresources" {
+			switch action.GetVerb() {
+			case "create":
+				action.(ktesting.CreateAction).GetObject().(*v1alpha1.PipelineResource).ResourceVersion = "1"
+			case "update":
+				if action.(ktesting.UpdateAction).GetObject().(*v1alpha1.PipelineResource).ResourceVersion == "" {
+					return true, nil, errors.NewBadRequest("metadata.resourceVersion must be specified for an update")
+				}
+			}
+		}
+		return react(action)
+	})
+	l.PipelineClientSet = c
+	return l
+}
+
+func TestListener_ResourceVersion(t *testing.T) {
+	names.TestingSeed()
+	payload := readPayload(t, "github-push.json")
+	l := newStrictListener(githubPush)
+
+	rec, resp := send(l, "/foo/github-push", map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-Hub-Signature-256": sign(payload),
+	}, payload)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d but got %d: %s", http.StatusCreated, rec.Code, resp.Message)
+	}
+	r, err := l.PipelineClientSet.TektonV1alpha1().PipelineResources("foo").Get("github-push-9l9zj-source", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected PipelineResource to be created: %v", err)
+	}
+	if len(r.OwnerReferences) != 1 || r.OwnerReferences[0].Name != "github-push-9l9zj" {
+		t.Errorf("Expected PipelineResource to be owned by the PipelineRun but got %v", r.OwnerReferences)
+	}
+}
+
+func TestListener_CleanUp(t *testing.T) {
+	for _, verb := range []string{"create", "update"} {
+		t.Run(verb, func(t *testing.T) {
+			names.TestingSeed()
+			payload := readPayload(t, "github-push.json")
+			l := newStrictListener(githubPush)
+			resource := "pipelineruns"
+			if verb == "update" {
+				resource = "pipelineresources"
+			}
+			l.PipelineClientSet.(*fakepipelineclientset.Clientset).PrependReactor(verb, resource, func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewInternalError(fmt.Errorf("%s failed", verb))
+			})
+
+			rec, resp := send(l, "/foo/github-push", map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": sign(payload),
+			}, payload)
+			if rec.Code != http.StatusInternalServerError {
+				t.Fatalf("Expected status %d but got %d: %s", http.StatusInternalServerError, rec.Code, resp.Message)
+			}
+			prs, err := l.PipelineClientSet.TektonV1alpha1().PipelineRuns("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error listing PipelineRuns out of fake client: %s", err)
+			}
+			if len(prs.Items) != 0 {
+				t.Errorf("Expected the PipelineRun to be deleted but got %v", prs.Items)
+			}
+			rs, err := l.PipelineClientSet.TektonV1alpha1().PipelineResources("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error listing PipelineResources out of fake client: %s", err)
+			}
+			if len(rs.Items) != 0 {
+				t.Errorf("Expected the PipelineResources to be deleted but got %v", rs.Items)
+			}
+		})
+	}
+}
+
+func TestListener_GitLabPush(t *testing.T) {
+	names.TestingSeed()
+	payload := readPayload(t, "gitlab-push.json")
+	l := newListener(gitlabPush)
+
+	rec, resp := send(l, "/foo/gitlab-push", map[string]string{"X-Gitlab-Event": "Push Hook"}, payload)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d but got %d: %s", http.StatusCreated, rec.Code, resp.Message)
+	}
+	pr, err := l.PipelineClientSet.TektonV1alpha1().PipelineRuns("foo").Get(resp.PipelineRun, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected PipelineRun to be created: %v", err)
+	}
+	expectedParams := []v1alpha1.Param{{
+		Name:  "project",
+		Value: "mike/diaspora@da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+	}, {
+		Name:  "commits",
+		Value: "1",
+	}}
+	if d := cmp.Diff(expectedParams, pr.Spec.Params); d != "" {
+		t.Errorf("Params of the PipelineRun didn't match, diff: %s", d)
+	}
+	if pr.Spec.Trigger.Type != v1alpha1.PipelineTriggerTypeEvent || pr.Spec.Trigger.DeliveryID != "" {
+		t.Errorf("Expected an event trigger without delivery ID but got %v", pr.Spec.Trigger)
+	}
+}
+
+func TestListener_GitLabToken(t *testing.T) {
+	names.TestingSeed()
+	l := newListener(gitlabTokenPush)
+
+	header := map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": webhookSecret}
+	rec, resp := send(l, "/foo/gitlab-token-push", header, readPayload(t, "gitlab-push.json"))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d but got %d: %s", http.StatusCreated, rec.Code, resp.Message)
+	}
+	if _, err := l.PipelineClientSet.TektonV1alpha1().PipelineRuns("foo").Get(resp.PipelineRun, metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected PipelineRun to be created: %v", err)
+	}
+}
+
+func TestListener_Rejected(t *testing.T) {
+	githubPayload := readPayload(t, "github-push.json")
+	gitlabPayload := readPayload(t, "gitlab-push.json")
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		header         map[string]string
+		payload        []byte
+		expectedStatus int
+	}{{
+		name:           "not a POST",
+		method:         http.MethodGet,
+		path:           "/foo/github-push",
+		expectedStatus: http.StatusMethodNotAllowed,
+	}, {
+		name:           "no EventTrigger in the path",
+		path:           "/foo",
+		payload:        githubPayload,
+		expectedStatus: http.StatusNotFound,
+	}, {
+		name:           "unknown EventTrigger",
+		path:           "/foo/bitbucket-push",
+		payload:        githubPayload,
+		expectedStatus: http.StatusNotFound,
+	}, {
+		name:           "not signed",
+		path:           "/foo/github-push",
+		header:         map[string]string{"X-GitHub-Event": "push"},
+		payload:        githubPayload,
+		expectedStatus: http.StatusUnauthorized,
+	}, {
+		name:           "EventTrigger without a signature",
+		path:           "/foo/unsigned-push",
+		payload:        githubPayload,
+		expectedStatus: http.StatusUnauthorized,
+	}, {
+		name:           "signed with another payload",
+		path:           "/foo/github-push",
+		header:         map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": sign(gitlabPayload)},
+		payload:        githubPayload,
+		expectedStatus: http.StatusUnauthorized,
+	}, {
+		name:           "signed with an unsupported hash function",
+		path:           "/foo/github-push",
+		header:         map[string]string{"X-GitHub-Event": "push", "X-Hub-Signature-256": "md5=79054025255fb1a26e4bc422aef54eb4"},
+		payload:        githubPayload,
+		expectedStatus: http.StatusUnauthorized,
+	}, {
+		name:           "no token",
+		path:           "/foo/gitlab-token-push",
+		header:         map[string]string{"X-Gitlab-Event": "Push Hook"},
+		payload:        gitlabPayload,
+		expectedStatus: http.StatusUnauthorized,
+	}, {
+		name:           "other token",
+		path:           "/foo/gitlab-token-push",
+		header:         map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "It's a Secret to Nobody"},
+		payload:        gitlabPayload,
+		expectedStatus: http.StatusUnauthorized,
+	}, {
+		name:           "other event type",
+		path:           "/foo/github-push",
+		header:         map[string]string{"X-GitHub-Event": "issues", "X-Hub-Signature-256": sign(githubPayload)},
+		payload:        githubPayload,
+		expectedStatus: http.StatusOK,
+	}, {
+		name:           "not JSON",
+		path:           "/foo/gitlab-push",
+		header:         map[string]string{"X-Gitlab-Event": "Push Hook"},
+		payload:        []byte("ref=refs/heads/master"),
+		expectedStatus: http.StatusBadRequest,
+	}, {
+		name:           "missing field",
+		path:           "/foo/gitlab-push",
+		header:         map[string]string{"X-Gitlab-Event": "Push Hook"},
+		payload:        []byte(`{"project": {"path_with_namespace": "mike/diaspora"}}`),
+		expectedStatus: http.StatusBadRequest,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := newListener(githubPush, gitlabPush, gitlabTokenPush, unsignedPush)
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, tc.path, bytes.NewReader(tc.payload))
+			for k, v := range tc.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			l.ServeHTTP(rec, req)
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Expected status %d but got %d: %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+			prs, err := l.PipelineClientSet.TektonV1alpha1().PipelineRuns("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error listing PipelineRuns out of fake client: %s", err)
+			}
+			if len(prs.Items) != 0 {
+				t.Errorf("Expected no PipelineRun to be created but got %v", prs.Items)
+			}
+		})
+	}
+}
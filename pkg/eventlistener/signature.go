@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlistener
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// verifySignature checks that signature, formatted as <hash function>=<hex encoded HMAC>
+// like the X-Hub-Signature headers of GitHub, is the HMAC of body with secret.
+func verifySignature(signature string, body, secret []byte) error {
+	if signature == "" {
+		return fmt.Errorf("the event isn't signed")
+	}
+	parts := strings.SplitN(signature, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("the signature of the event should be formatted as <hash function>=<signature>")
+	}
+	var h func() hash.Hash
+	switch parts[0] {
+	case "sha1":
+		h = sha1.New
+	case "sha256":
+		h = sha256.New
+	case "sha512":
+		h = sha512.New
+	default:
+		return fmt.Errorf("the event is signed with unsupported hash function %q", parts[0])
+	}
+	expected, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("the signature of the event isn't hex encoded: %v", err)
+	}
+	mac := hmac.New(h, secret)
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return fmt.Errorf("the signature of the event doesn't match its body")
+	}
+	return nil
+}
+
+// verifyToken checks that token, such as the X-Gitlab-Token header of GitLab, is secret.
+func verifyToken(token string, secret []byte) error {
+	if token == "" {
+		return fmt.Errorf("the event has no token")
+	}
+	if subtle.ConstantTimeCompare([]byte(token), secret) != 1 {
+		return fmt.Errorf("the token of the event doesn't match")
+	}
+	return nil
+}
@@ -30,9 +30,11 @@ const (
 	ControllerLogKey = "controller"
 	// WebhookLogKey is the name of the logger for the webhook cmd
 	WebhookLogKey = "webhook"
+	// EventListenerLogKey is the name of the logger for the eventlistener cmd
+	EventListenerLogKey = "eventlistener"
 )
 
-var components = []string{ControllerLogKey, WebhookLogKey}
+var components = []string{ControllerLogKey, WebhookLogKey, EventListenerLogKey}
 
 // NewLogger creates a logger with the supplied configuration.
 // In addition to the logger, it returns AtomicLevel that can
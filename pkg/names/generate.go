@@ -34,6 +34,10 @@ type NameGenerator interface {
 	// RestrictLength generates a valid name from the name of a step specified in a Task,
 	// shortening it to the maximum valid name length if needed.
 	RestrictLength(base string) string
+
+	// RestrictLengthWithSuffix generates a valid name from the base name, adding the given
+	// suffix to the base, which is shortened if needed so that the suffix is kept.
+	RestrictLengthWithSuffix(base, suffix string) string
 }
 
 // simpleNameGenerator generates random names.
@@ -69,3 +73,10 @@ func (simpleNameGenerator) RestrictLength(base string) string {
 	}
 	return base
 }
+
+func (simpleNameGenerator) RestrictLengthWithSuffix(base, suffix string) string {
+	if len(base)+len(suffix) > maxNameLength {
+		base = base[:maxNameLength-len(suffix)]
+	}
+	return base + suffix
+}
@@ -0,0 +1,407 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/knative/pkg/configmap"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// CloudEventsConfigName is the name of the configmap holding the sink the CloudEvents
+	// are sent to, for the TaskRuns and PipelineRuns which don't have their own sink
+	CloudEventsConfigName = "config-cloudevents"
+	// CloudEventsSinkKey is the key of the URL of the sink in the configmap
+	CloudEventsSinkKey = "sink"
+	// CloudEventsAllowedSinksKey is the key of the URLs in the configmap of the sinks
+	// TaskRuns and PipelineRuns may set as their own, separated by commas or spaces.
+	// A sink is allowed if it has the scheme and host of one of them.
+	CloudEventsAllowedSinksKey = "allowed-sinks"
+
+	cloudEventsSpecVersion = "1.0"
+	// cloudEventsQueueSize is the number of CloudEvents waiting to be delivered to a sink
+	// beyond which new ones are dropped, so that a sink which is down never blocks
+	// reconciliation
+	cloudEventsQueueSize = 1000
+	// cloudEventsIdleTimeout is how long the goroutine delivering the CloudEvents of a
+	// sink waits for new ones before exiting
+	cloudEventsIdleTimeout = time.Minute
+)
+
+// CloudEventState is the state of a TaskRun or PipelineRun which is notified with a CloudEvent.
+type CloudEventState string
+
+const (
+	// CloudEventStateStarted is notified when the run starts.
+	CloudEventStateStarted CloudEventState = "started"
+	// CloudEventStateSucceeded is notified when the run succeeds.
+	CloudEventStateSucceeded CloudEventState = "successful"
+	// CloudEventStateFailed is notified when the run fails.
+	CloudEventStateFailed CloudEventState = "failed"
+	// CloudEventStateCancelled is notified when the run is cancelled.
+	CloudEventStateCancelled CloudEventState = "cancelled"
+)
+
+// CloudEventData is the data of the CloudEvents, describing the run whose state changed.
+type CloudEventData struct {
+	Kind      string            `json:"kind"`
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Condition *apis.Condition   `json:"condition,omitempty"`
+	// Results are the results of a TaskRun.
+	Results []v1alpha1.TaskRunResult `json:"results,omitempty"`
+	// TaskResults are the results of the TaskRuns of a PipelineRun, by PipelineTask.
+	TaskResults map[string][]v1alpha1.TaskRunResult `json:"taskResults,omitempty"`
+}
+
+// cloudEvent is a CloudEvent waiting to be delivered to its sink.
+type cloudEvent struct {
+	sink      string
+	id        string
+	eventType string
+	source    string
+	subject   string
+	time      time.Time
+	data      []byte
+}
+
+// CloudEventSender sends CloudEvents over HTTP when TaskRuns and PipelineRuns start,
+// succeed, fail or are cancelled. The events are queued and delivered in order by a
+// goroutine per sink, retrying with an exponential backoff when the sink can't be reached
+// or responds with an error, so that a sink which is down only delays its own events.
+type CloudEventSender struct {
+	logger *zap.SugaredLogger
+	client *http.Client
+	stopCh <-chan struct{}
+
+	queuesMu sync.Mutex
+	queues   map[string]chan *cloudEvent
+
+	// MaxAttempts is the number of times the delivery of an event is attempted
+	MaxAttempts int
+	// Backoff is the delay before the first retry, which doubles on each retry
+	Backoff time.Duration
+	// MaxSinks is the number of sinks events are delivered to at the same time, each
+	// by its own goroutine, beyond which the events of other sinks are dropped
+	MaxSinks int
+
+	mu           sync.RWMutex
+	sink         string
+	allowedSinks []*url.URL
+}
+
+// NewCloudEventSender returns a CloudEventSender delivering the events until stopCh is closed.
+func NewCloudEventSender(logger *zap.SugaredLogger, stopCh <-chan struct{}) *CloudEventSender {
+	s := &CloudEventSender{
+		logger:      logger.Named("cloudevents"),
+		client:      &http.Client{Timeout: 10 * time.Second},
+		stopCh:      stopCh,
+		queues:      map[string]chan *cloudEvent{},
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		MaxSinks:    20,
+	}
+	return s
+}
+
+// WatchConfigs updates the sinks of the CloudEvents when the configmap holding them changes.
+func (s *CloudEventSender) WatchConfigs(w configmap.Watcher) {
+	w.Watch(CloudEventsConfigName, s.updateConfig)
+}
+
+func (s *CloudEventSender) updateConfig(configMap *corev1.ConfigMap) {
+	sink := strings.TrimSpace(configMap.Data[CloudEventsSinkKey])
+	allowedSinks := []*url.URL{}
+	for _, allowed := range strings.FieldsFunc(configMap.Data[CloudEventsAllowedSinksKey], func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	}) {
+		u, err := url.Parse(allowed)
+		if err != nil || u.Host == "" {
+			s.logger.Errorf("Ignoring the allowed CloudEvents sink %q, which isn't a URL", allowed)
+			continue
+		}
+		allowedSinks = append(allowedSinks, u)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sink != s.sink {
+		s.logger.Infof("CloudEvents are now sent to %q", sink)
+	}
+	s.sink = sink
+	s.allowedSinks = allowedSinks
+}
+
+// SetSink sets the sink of the runs which don't have their own.
+func (s *CloudEventSender) SetSink(sink string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sink = sink
+}
+
+func (s *CloudEventSender) defaultSink() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sink
+}
+
+// SetAllowedSinks sets the URLs of the sinks runs may set as their own.
+func (s *CloudEventSender) SetAllowedSinks(sinks ...string) {
+	allowedSinks := []*url.URL{}
+	for _, sink := range sinks {
+		if u, err := url.Parse(sink); err == nil {
+			allowedSinks = append(allowedSinks, u)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.allowedSinks = allowedSinks
+}
+
+// isAllowedSink returns whether a run may send its events to sink, which has to have
+// the scheme and host of one of the allowed sinks, so that runs can't make the
+// controller send requests to any URL.
+func (s *CloudEventSender) isAllowedSink(sink string) bool {
+	u, err := url.Parse(sink)
+	if err != nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, allowed := range s.allowedSinks {
+		if strings.EqualFold(u.Scheme, allowed.Scheme) && strings.EqualFold(u.Host, allowed.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// EmitCloudEvent queues the CloudEvents notifying the changes of state between the before
+// and after versions of a TaskRun or PipelineRun, if it has a sink. It never blocks, the
+// events being dropped if too many are waiting to be delivered.
+func EmitCloudEvent(s *CloudEventSender, before, after runtime.Object) {
+	if s == nil {
+		return
+	}
+	var data CloudEventData
+	var sink, source string
+	var started, wasStarted, cancelled bool
+	var condition, previous *apis.Condition
+	switch run := after.(type) {
+	case *v1alpha1.TaskRun:
+		beforeRun := before.(*v1alpha1.TaskRun)
+		data = CloudEventData{Kind: "TaskRun", Results: run.Status.TaskResults}
+		sink = run.Spec.CloudEventSink
+		started, wasStarted = run.HasStarted(), beforeRun.HasStarted()
+		condition, previous = run.Status.GetCondition(apis.ConditionSucceeded), beforeRun.Status.GetCondition(apis.ConditionSucceeded)
+		cancelled = run.IsCancelled()
+		source = fmt.Sprintf("/apis/%s/namespaces/%s/taskruns/%s", v1alpha1.SchemeGroupVersion, run.Namespace, run.Name)
+	case *v1alpha1.PipelineRun:
+		beforeRun := before.(*v1alpha1.PipelineRun)
+		data = CloudEventData{Kind: "PipelineRun"}
+		for _, trs := range run.Status.TaskRuns {
+			if trs.Status != nil && len(trs.Status.TaskResults) > 0 {
+				if data.TaskResults == nil {
+					data.TaskResults = map[string][]v1alpha1.TaskRunResult{}
+				}
+				data.TaskResults[trs.PipelineTaskName] = trs.Status.TaskResults
+			}
+		}
+		sink = run.Spec.CloudEventSink
+		started, wasStarted = run.HasStarted(), beforeRun.HasStarted()
+		condition, previous = run.Status.GetCondition(apis.ConditionSucceeded), beforeRun.Status.GetCondition(apis.ConditionSucceeded)
+		cancelled = run.IsCancelled()
+		source = fmt.Sprintf("/apis/%s/namespaces/%s/pipelineruns/%s", v1alpha1.SchemeGroupVersion, run.Namespace, run.Name)
+	default:
+		return
+	}
+	meta := after.(interface {
+		GetName() string
+		GetNamespace() string
+		GetLabels() map[string]string
+	})
+
+	states := []CloudEventState{}
+	if started && !wasStarted {
+		states = append(states, CloudEventStateStarted)
+	}
+	if isDone(condition) && !isDone(previous) {
+		switch {
+		case condition.Status == corev1.ConditionTrue:
+			states = append(states, CloudEventStateSucceeded)
+		case cancelled:
+			states = append(states, CloudEventStateCancelled)
+		default:
+			states = append(states, CloudEventStateFailed)
+		}
+	}
+	if len(states) == 0 {
+		return
+	}
+	if sink == "" {
+		sink = s.defaultSink()
+	} else if !s.isAllowedSink(sink) {
+		s.logger.Warnf("Not sending the CloudEvents of %s %s/%s to %s, which isn't one of the %s of the %s configmap",
+			data.Kind, meta.GetNamespace(), meta.GetName(), sink, CloudEventsAllowedSinksKey, CloudEventsConfigName)
+		return
+	}
+	if sink == "" {
+		return
+	}
+
+	data.Name, data.Namespace, data.Labels, data.Condition = meta.GetName(), meta.GetNamespace(), meta.GetLabels(), condition
+	body, err := json.Marshal(data)
+	if err != nil {
+		s.logger.Errorf("Failed to encode the CloudEvent of %s %s/%s: %v", data.Kind, data.Namespace, data.Name, err)
+		return
+	}
+	for _, state := range states {
+		s.enqueue(&cloudEvent{
+			sink:      sink,
+			id:        newCloudEventID(),
+			eventType: fmt.Sprintf("dev.tekton.event.%s.%s.v1", strings.ToLower(data.Kind), state),
+			source:    source,
+			subject:   data.Name,
+			time:      time.Now(),
+			data:      body,
+		})
+	}
+}
+
+// newCloudEventID returns a random id, unique to each CloudEvent.
+func newCloudEventID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+func isDone(c *apis.Condition) bool {
+	return c != nil && c.Status != corev1.ConditionUnknown
+}
+
+// enqueue queues e to be delivered to its sink, starting the goroutine delivering the
+// events of the sink if it isn't running.
+func (s *CloudEventSender) enqueue(e *cloudEvent) {
+	s.queuesMu.Lock()
+	defer s.queuesMu.Unlock()
+	queue, ok := s.queues[e.sink]
+	if !ok {
+		if len(s.queues) >= s.MaxSinks {
+			s.logger.Warnf("Dropped CloudEvent %s of %s: events are already being sent to %d other sinks", e.eventType, e.source, len(s.queues))
+			return
+		}
+		queue = make(chan *cloudEvent, cloudEventsQueueSize)
+		s.queues[e.sink] = queue
+		go s.run(e.sink, queue)
+	}
+	select {
+	case queue <- e:
+	default:
+		s.logger.Warnf("Dropped CloudEvent %s of %s: too many events are waiting to be sent to %s", e.eventType, e.source, e.sink)
+	}
+}
+
+// run delivers the events of queue to sink, until stopCh is closed or no event is queued
+// for a while.
+func (s *CloudEventSender) run(sink string, queue chan *cloudEvent) {
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case e := <-queue:
+			s.deliver(e, s.stopCh)
+		case <-time.After(cloudEventsIdleTimeout):
+			// Events are only queued while holding the lock, so none can be
+			// queued once the queue is removed
+			s.queuesMu.Lock()
+			if len(queue) == 0 {
+				delete(s.queues, sink)
+				s.queuesMu.Unlock()
+				return
+			}
+			s.queuesMu.Unlock()
+		}
+	}
+}
+
+// deliver sends e to its sink, retrying until it is accepted, the maximum number of
+// attempts is reached or stopCh is closed.
+func (s *CloudEventSender) deliver(e *cloudEvent, stopCh <-chan struct{}) {
+	backoff := s.Backoff
+	for attempt := 1; ; attempt++ {
+		err := s.send(e)
+		if err == nil {
+			return
+		}
+		if attempt >= s.MaxAttempts {
+			s.logger.Errorf("Failed to send CloudEvent %s of %s to %s after %d attempts: %v", e.eventType, e.source, e.sink, attempt, err)
+			return
+		}
+		s.logger.Warnf("Failed to send CloudEvent %s of %s to %s, retrying in %s: %v", e.eventType, e.source, e.sink, backoff, err)
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send delivers e in the binary content mode of the HTTP binding of CloudEvents.
+func (s *CloudEventSender) send(e *cloudEvent) error {
+	req, err := http.NewRequest(http.MethodPost, e.sink, bytes.NewReader(e.data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Ce-Specversion", cloudEventsSpecVersion)
+	req.Header.Set("Ce-Id", e.id)
+	req.Header.Set("Ce-Type", e.eventType)
+	req.Header.Set("Ce-Source", e.source)
+	req.Header.Set("Ce-Subject", e.subject)
+	req.Header.Set("Ce-Time", e.time.UTC().Format(time.RFC3339Nano))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	// The body is read to the end so that the connection can be reused
+	defer resp.Body.Close()
+	defer io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the sink responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,432 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	logtesting "github.com/knative/pkg/logging/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// receivedEvent is a CloudEvent received by a fakeSink.
+type receivedEvent struct {
+	header http.Header
+	data   CloudEventData
+}
+
+// fakeSink is a sink recording the CloudEvents it receives, which first fails the
+// given number of requests.
+type fakeSink struct {
+	*httptest.Server
+	mu       sync.Mutex
+	failures int
+	events   chan receivedEvent
+}
+
+func newFakeSink(t *testing.T, failures int) *fakeSink {
+	s := &fakeSink{failures: failures, events: make(chan receivedEvent, 10)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.failures > 0 {
+			s.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Failed to read the CloudEvent: %v", err)
+		}
+		e := receivedEvent{header: r.Header}
+		if err := json.Unmarshal(body, &e.data); err != nil {
+			t.Errorf("Failed to decode the data of the CloudEvent: %v", err)
+		}
+		s.events <- e
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	return s
+}
+
+// receive returns the types of the CloudEvents received by the sink until none is
+// received for a while.
+func (s *fakeSink) receive() []receivedEvent {
+	events := []receivedEvent{}
+	for {
+		select {
+		case e := <-s.events:
+			events = append(events, e)
+		case <-time.After(200 * time.Millisecond):
+			return events
+		}
+	}
+}
+
+func newTestCloudEventSender(t *testing.T) (*CloudEventSender, chan struct{}) {
+	stopCh := make(chan struct{})
+	s := NewCloudEventSender(logtesting.TestLogger(t), stopCh)
+	s.Backoff = time.Millisecond
+	return s, stopCh
+}
+
+func eventTypes(events []receivedEvent) []string {
+	types := []string{}
+	for _, e := range events {
+		types = append(types, e.header.Get("Ce-Type"))
+	}
+	return types
+}
+
+func TestEmitCloudEvent_TaskRun(t *testing.T) {
+	startTime := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	running := tb.Condition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown})
+	succeeded := tb.Condition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	failed := tb.Condition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "Failed"})
+	cancelled := tb.Condition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "TaskRunCancelled"})
+
+	for _, tc := range []struct {
+		name     string
+		before   *v1alpha1.TaskRun
+		after    *v1alpha1.TaskRun
+		expected []string
+	}{{
+		name:     "started",
+		before:   tb.TaskRun("test-taskrun", "foo"),
+		after:    tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(running, tb.TaskRunStartTime(startTime))),
+		expected: []string{"dev.tekton.event.taskrun.started.v1"},
+	}, {
+		name:     "still running",
+		before:   tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(running, tb.TaskRunStartTime(startTime))),
+		after:    tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(running, tb.TaskRunStartTime(startTime), tb.PodName("test-taskrun-pod"))),
+		expected: []string{},
+	}, {
+		name:     "succeeded",
+		before:   tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(running, tb.TaskRunStartTime(startTime))),
+		after:    tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(succeeded, tb.TaskRunStartTime(startTime))),
+		expected: []string{"dev.tekton.event.taskrun.successful.v1"},
+	}, {
+		name:     "failed",
+		before:   tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(running, tb.TaskRunStartTime(startTime))),
+		after:    tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(failed, tb.TaskRunStartTime(startTime))),
+		expected: []string{"dev.tekton.event.taskrun.failed.v1"},
+	}, {
+		name:     "failed before starting",
+		before:   tb.TaskRun("test-taskrun", "foo"),
+		after:    tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(failed, tb.TaskRunStartTime(startTime))),
+		expected: []string{"dev.tekton.event.taskrun.started.v1", "dev.tekton.event.taskrun.failed.v1"},
+	}, {
+		name:     "cancelled",
+		before:   tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(tb.TaskRunCancelled), tb.TaskRunStatus(running, tb.TaskRunStartTime(startTime))),
+		after:    tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(tb.TaskRunCancelled), tb.TaskRunStatus(cancelled, tb.TaskRunStartTime(startTime))),
+		expected: []string{"dev.tekton.event.taskrun.cancelled.v1"},
+	}, {
+		name:     "already done",
+		before:   tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(failed, tb.TaskRunStartTime(startTime))),
+		after:    tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(failed, tb.TaskRunStartTime(startTime))),
+		expected: []string{},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sink := newFakeSink(t, 0)
+			defer sink.Close()
+			s, stopCh := newTestCloudEventSender(t)
+			defer close(stopCh)
+			s.SetSink(sink.URL)
+
+			EmitCloudEvent(s, tc.before, tc.after)
+			if d := cmp.Diff(tc.expected, eventTypes(sink.receive())); d != "" {
+				t.Errorf("Unexpected CloudEvents (-want, +got): %s", d)
+			}
+		})
+	}
+}
+
+func TestEmitCloudEvent_Content(t *testing.T) {
+	sink := newFakeSink(t, 0)
+	defer sink.Close()
+	s, stopCh := newTestCloudEventSender(t)
+	defer close(stopCh)
+	s.SetAllowedSinks(sink.URL)
+
+	condition := apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: "Succeeded"}
+	before := tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(tb.TaskRunStartTime(time.Now())))
+	after := tb.TaskRun("test-taskrun", "foo",
+		tb.TaskRunLabel("app", "deployer"),
+		tb.TaskRunSpec(tb.TaskRunCloudEventSink(sink.URL)),
+		tb.TaskRunStatus(tb.Condition(condition), tb.TaskRunStartTime(time.Now()), tb.TaskRunResult("digest", "sha256:abc")),
+	)
+	EmitCloudEvent(s, before, after)
+
+	events := sink.receive()
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 CloudEvent to be sent to the sink of the TaskRun but got %d", len(events))
+	}
+	for header, expected := range map[string]string{
+		"Ce-Specversion": "1.0",
+		"Ce-Type":        "dev.tekton.event.taskrun.successful.v1",
+		"Ce-Source":      "/apis/tekton.dev/v1alpha1/namespaces/foo/taskruns/test-taskrun",
+		"Ce-Subject":     "test-taskrun",
+		"Content-Type":   "application/json",
+	} {
+		if actual := events[0].header.Get(header); actual != expected {
+			t.Errorf("Expected header %s to be %q but was %q", header, expected, actual)
+		}
+	}
+	if events[0].header.Get("Ce-Id") == "" {
+		t.Errorf("Expected the CloudEvent to have an id")
+	}
+	expected := CloudEventData{
+		Kind:      "TaskRun",
+		Name:      "test-taskrun",
+		Namespace: "foo",
+		Labels:    map[string]string{"app": "deployer"},
+		Condition: &condition,
+		Results:   []v1alpha1.TaskRunResult{{Name: "digest", Value: "sha256:abc"}},
+	}
+	if d := cmp.Diff(expected, events[0].data); d != "" {
+		t.Errorf("Unexpected data of the CloudEvent (-want, +got): %s", d)
+	}
+}
+
+func TestEmitCloudEvent_PipelineRun(t *testing.T) {
+	sink := newFakeSink(t, 0)
+	defer sink.Close()
+	s, stopCh := newTestCloudEventSender(t)
+	defer close(stopCh)
+	s.SetSink(sink.URL)
+
+	condition := apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "PipelineRunCancelled"}
+	before := tb.PipelineRun("test-pipelinerun", "foo", tb.PipelineRunSpec("test-pipeline", tb.PipelineRunCancelled),
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())))
+	after := tb.PipelineRun("test-pipelinerun", "foo", tb.PipelineRunSpec("test-pipeline", tb.PipelineRunCancelled),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(condition), tb.PipelineRunStartTime(time.Now()),
+			tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+				"test-pipelinerun-build-abcde": {
+					PipelineTaskName: "build",
+					Status:           &v1alpha1.TaskRunStatus{TaskResults: []v1alpha1.TaskRunResult{{Name: "digest", Value: "sha256:abc"}}},
+				},
+				"test-pipelinerun-deploy-abcde": {
+					PipelineTaskName: "deploy",
+					Status:           &v1alpha1.TaskRunStatus{},
+				},
+			})))
+	EmitCloudEvent(s, before, after)
+
+	events := sink.receive()
+	if d := cmp.Diff([]string{"dev.tekton.event.pipelinerun.cancelled.v1"}, eventTypes(events)); d != "" {
+		t.Fatalf("Unexpected CloudEvents (-want, +got): %s", d)
+	}
+	if source := events[0].header.Get("Ce-Source"); source != "/apis/tekton.dev/v1alpha1/namespaces/foo/pipelineruns/test-pipelinerun" {
+		t.Errorf("Unexpected source of the CloudEvent %q", source)
+	}
+	expected := CloudEventData{
+		Kind:        "PipelineRun",
+		Name:        "test-pipelinerun",
+		Namespace:   "foo",
+		Condition:   &condition,
+		TaskResults: map[string][]v1alpha1.TaskRunResult{"build": {{Name: "digest", Value: "sha256:abc"}}},
+	}
+	if d := cmp.Diff(expected, events[0].data); d != "" {
+		t.Errorf("Unexpected data of the CloudEvent (-want, +got): %s", d)
+	}
+}
+
+func TestEmitCloudEvent_Retries(t *testing.T) {
+	sink := newFakeSink(t, 2)
+	defer sink.Close()
+	s, stopCh := newTestCloudEventSender(t)
+	defer close(stopCh)
+	s.SetSink(sink.URL)
+
+	EmitCloudEvent(s, tb.TaskRun("test-taskrun", "foo"), tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(tb.TaskRunStartTime(time.Now()))))
+	if d := cmp.Diff([]string{"dev.tekton.event.taskrun.started.v1"}, eventTypes(sink.receive())); d != "" {
+		t.Errorf("Expected the CloudEvent to be sent once the sink recovers (-want, +got): %s", d)
+	}
+}
+
+func TestEmitCloudEvent_GivesUp(t *testing.T) {
+	sink := newFakeSink(t, 5)
+	defer sink.Close()
+	s, stopCh := newTestCloudEventSender(t)
+	defer close(stopCh)
+	s.SetSink(sink.URL)
+	s.MaxAttempts = 3
+
+	before := tb.TaskRun("test-taskrun", "foo")
+	after := tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(tb.TaskRunStartTime(time.Now())))
+	EmitCloudEvent(s, before, after)
+	// The first event is dropped after 3 attempts, the second one is delivered on the 3rd
+	EmitCloudEvent(s, before, after)
+	if events := sink.receive(); len(events) != 1 {
+		t.Errorf("Expected only the second CloudEvent to be received but got %d", len(events))
+	}
+}
+
+func TestEmitCloudEvent_Sink(t *testing.T) {
+	sink := newFakeSink(t, 0)
+	defer sink.Close()
+	s, stopCh := newTestCloudEventSender(t)
+	defer close(stopCh)
+
+	before := tb.TaskRun("test-taskrun", "foo")
+	after := tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(tb.TaskRunStartTime(time.Now())))
+	EmitCloudEvent(s, before, after)
+	if events := sink.receive(); len(events) != 0 {
+		t.Errorf("Expected no CloudEvent to be sent without a sink but got %d", len(events))
+	}
+
+	s.updateConfig(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: CloudEventsConfigName},
+		Data:       map[string]string{CloudEventsSinkKey: sink.URL},
+	})
+	EmitCloudEvent(s, before, after)
+	if events := sink.receive(); len(events) != 1 {
+		t.Errorf("Expected the CloudEvent to be sent to the sink of the configmap but got %d", len(events))
+	}
+
+	// Never fails when there's no sender
+	EmitCloudEvent(nil, before, after)
+}
+
+func TestEmitCloudEvent_DoesNotBlock(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	blocked := make(chan struct{})
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-blocked
+	}))
+	defer sink.Close()
+	defer close(blocked)
+	s := NewCloudEventSender(logtesting.TestLogger(t), stopCh)
+	s.SetSink(sink.URL)
+
+	before := tb.TaskRun("test-taskrun", "foo")
+	after := tb.TaskRun("test-taskrun", "foo", tb.TaskRunStatus(tb.TaskRunStartTime(time.Now())))
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < cloudEventsQueueSize+10; i++ {
+			EmitCloudEvent(s, before, after)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected EmitCloudEvent not to block while the sink doesn't respond")
+	}
+}
+
+func TestEmitCloudEvent_DeadSinkDoesNotDelayOthers(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	blocked := make(chan struct{})
+	dead := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-blocked
+	}))
+	defer dead.Close()
+	defer close(blocked)
+	sink := newFakeSink(t, 0)
+	defer sink.Close()
+	s := NewCloudEventSender(logtesting.TestLogger(t), stopCh)
+	s.SetAllowedSinks(dead.URL, sink.URL)
+
+	before := tb.TaskRun("test-taskrun", "foo")
+	for i := 0; i < 3; i++ {
+		EmitCloudEvent(s, before, tb.TaskRun("test-taskrun", "foo",
+			tb.TaskRunSpec(tb.TaskRunCloudEventSink(dead.URL)),
+			tb.TaskRunStatus(tb.TaskRunStartTime(time.Now())),
+		))
+	}
+	EmitCloudEvent(s, before, tb.TaskRun("test-taskrun", "foo",
+		tb.TaskRunSpec(tb.TaskRunCloudEventSink(sink.URL)),
+		tb.TaskRunStatus(tb.TaskRunStartTime(time.Now())),
+	))
+	if events := sink.receive(); len(events) != 1 {
+		t.Errorf("Expected the CloudEvent to be sent while another sink doesn't respond but got %d", len(events))
+	}
+}
+
+func TestEmitCloudEvent_AllowedSinks(t *testing.T) {
+	sink := newFakeSink(t, 0)
+	defer sink.Close()
+	other := newFakeSink(t, 0)
+	defer other.Close()
+	s, stopCh := newTestCloudEventSender(t)
+	defer close(stopCh)
+	s.SetSink(sink.URL)
+
+	before := tb.TaskRun("test-taskrun", "foo")
+	after := tb.TaskRun("test-taskrun", "foo",
+		tb.TaskRunSpec(tb.TaskRunCloudEventSink(other.URL+"/events")),
+		tb.TaskRunStatus(tb.TaskRunStartTime(time.Now())),
+	)
+	EmitCloudEvent(s, before, after)
+	if events := other.receive(); len(events) != 0 {
+		t.Errorf("Expected no CloudEvent to be sent to a sink which isn't allowed but got %d", len(events))
+	}
+	if events := sink.receive(); len(events) != 0 {
+		t.Errorf("Expected no CloudEvent to be sent to the sink of the configmap instead but got %d", len(events))
+	}
+
+	s.updateConfig(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: CloudEventsConfigName},
+		Data: map[string]string{
+			CloudEventsSinkKey:         sink.URL,
+			CloudEventsAllowedSinksKey: "http://event-display.default.svc.cluster.local, " + other.URL,
+		},
+	})
+	EmitCloudEvent(s, before, after)
+	if events := other.receive(); len(events) != 1 {
+		t.Errorf("Expected the CloudEvent to be sent to an allowed sink but got %d", len(events))
+	}
+}
+
+func TestEmitCloudEvent_MaxSinks(t *testing.T) {
+	sinks := []*fakeSink{}
+	for i := 0; i < 3; i++ {
+		sink := newFakeSink(t, 0)
+		defer sink.Close()
+		sinks = append(sinks, sink)
+	}
+	s, stopCh := newTestCloudEventSender(t)
+	defer close(stopCh)
+	s.MaxSinks = 2
+	s.SetAllowedSinks(sinks[0].URL, sinks[1].URL, sinks[2].URL)
+
+	before := tb.TaskRun("test-taskrun", "foo")
+	for _, sink := range sinks {
+		EmitCloudEvent(s, before, tb.TaskRun("test-taskrun", "foo",
+			tb.TaskRunSpec(tb.TaskRunCloudEventSink(sink.URL)),
+			tb.TaskRunStatus(tb.TaskRunStartTime(time.Now())),
+		))
+	}
+	for i, want := range []int{1, 1, 0} {
+		if events := sinks[i].receive(); len(events) != want {
+			t.Errorf("Expected %d CloudEvents to be sent to sink %d but got %d", want, i, len(events))
+		}
+	}
+}
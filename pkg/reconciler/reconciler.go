@@ -25,6 +25,7 @@ import (
 	"github.com/knative/pkg/logging/logkey"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	pipelineScheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	"github.com/tektoncd/pipeline/pkg/commitstatus"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -45,6 +46,8 @@ type Options struct {
 	ConfigMapWatcher configmap.Watcher
 	Logger           *zap.SugaredLogger
 	Recorder         record.EventRecorder
+	CloudEvents      *CloudEventSender
+	CommitStatuses   *commitstatus.Queue
 
 	ResyncPeriod time.Duration
 }
@@ -78,6 +81,14 @@ type Base struct {
 	// Kubernetes API.
 	Recorder record.EventRecorder
 
+	// CloudEvents sends CloudEvents when TaskRuns and PipelineRuns change state.
+	// No CloudEvents are sent when it is nil.
+	CloudEvents *CloudEventSender
+
+	// CommitStatuses reports the statuses of PipelineRuns on commits asynchronously.
+	// No commit statuses are reported when it is nil.
+	CommitStatuses *commitstatus.Queue
+
 	// Sugared logger is easier to use but is not as performant as the
 	// raw logger. In performance critical paths, call logger.Desugar()
 	// and use the returned raw logger instead. In addition to the
@@ -114,6 +125,8 @@ func NewBase(opt Options, controllerAgentName string) *Base {
 		CachingClientSet:  opt.CachingClientSet,
 		ConfigMapWatcher:  opt.ConfigMapWatcher,
 		Recorder:          recorder,
+		CloudEvents:       opt.CloudEvents,
+		CommitStatuses:    opt.CommitStatuses,
 		Logger:            logger,
 	}
 
@@ -32,6 +32,8 @@ type TimeoutSet struct {
 	pipelineclientset       clientset.Interface
 	taskRunCallbackFunc     func(interface{})
 	pipelineRunCallbackFunc func(interface{})
+	approvalCallbackFunc    func(interface{})
+	runCallbackFunc         func(interface{})
 	stopCh                  <-chan struct{}
 	statusMap               *sync.Map
 	done                    map[string]chan bool
@@ -66,6 +68,16 @@ func (t *TimeoutSet) SetPipelineRunCallbackFunc(f func(interface{})) {
 	t.pipelineRunCallbackFunc = f
 }
 
+// SetApprovalCallbackFunc sets the callback function when timeout occurs for approval objects
+func (t *TimeoutSet) SetApprovalCallbackFunc(f func(interface{})) {
+	t.approvalCallbackFunc = f
+}
+
+// SetRunCallbackFunc sets the callback function when timeout occurs for run objects
+func (t *TimeoutSet) SetRunCallbackFunc(f func(interface{})) {
+	t.runCallbackFunc = f
+}
+
 // Release function deletes key from timeout map
 func (t *TimeoutSet) Release(runObj StatusKey) {
 	key := runObj.GetRunKey()
@@ -79,6 +91,21 @@ func (t *TimeoutSet) Release(runObj StatusKey) {
 	}
 }
 
+// release deletes the key of runObj from the timeout map if finished is still
+// the channel its timer waits for, so that a timer stopped by Release doesn't
+// release the timer started for runObj after it.
+func (t *TimeoutSet) release(runObj StatusKey, finished chan bool) {
+	key := runObj.GetRunKey()
+	t.doneMut.Lock()
+	defer t.doneMut.Unlock()
+
+	if t.done[key] == finished {
+		delete(t.done, key)
+		t.statusMap.Delete(key)
+		close(finished)
+	}
+}
+
 func (t *TimeoutSet) getOrCreateFinishedChan(runObj StatusKey) chan bool {
 	var finished chan bool
 	key := runObj.GetRunKey()
@@ -111,7 +138,7 @@ func (t *TimeoutSet) checkPipelineRunTimeouts(namespace string) {
 	}
 	for _, pipelineRun := range pipelineRuns.Items {
 		pipelineRun := pipelineRun
-		if pipelineRun.IsDone() || pipelineRun.IsCancelled() {
+		if pipelineRun.IsDone() || pipelineRun.IsCancelled() || pipelineRun.IsPaused() {
 			continue
 		}
 		if pipelineRun.HasStarted() {
@@ -120,6 +147,44 @@ func (t *TimeoutSet) checkPipelineRunTimeouts(namespace string) {
 	}
 }
 
+// checkApprovalTimeouts function creates goroutines to wait for approvals to
+// be decided/timeout in a given namespace
+func (t *TimeoutSet) checkApprovalTimeouts(namespace string) {
+	approvals, err := t.pipelineclientset.TektonV1alpha1().Approvals(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		t.logger.Errorf("Can't get approval list in namespace %s: %s", namespace, err)
+		return
+	}
+	for _, approval := range approvals.Items {
+		approval := approval
+		if approval.IsDone() {
+			continue
+		}
+		if approval.HasStarted() && approval.Spec.Timeout != nil {
+			go t.WaitApproval(&approval, approval.Status.StartTime)
+		}
+	}
+}
+
+// checkRunTimeouts function creates goroutines to wait for runs to
+// finish/timeout in a given namespace
+func (t *TimeoutSet) checkRunTimeouts(namespace string) {
+	runs, err := t.pipelineclientset.TektonV1alpha1().Runs(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		t.logger.Errorf("Can't get run list in namespace %s: %s", namespace, err)
+		return
+	}
+	for _, run := range runs.Items {
+		run := run
+		if run.IsDone() || run.IsCancelled() {
+			continue
+		}
+		if run.HasStarted() && run.Spec.Timeout != nil {
+			go t.WaitRun(&run, run.Status.StartTime)
+		}
+	}
+}
+
 // CheckTimeouts function iterates through all namespaces and calls corresponding
 // taskrun/pipelinerun timeout functions
 func (t *TimeoutSet) CheckTimeouts() {
@@ -131,6 +196,8 @@ func (t *TimeoutSet) CheckTimeouts() {
 	for _, namespace := range namespaces.Items {
 		t.checkTaskRunTimeouts(namespace.GetName())
 		t.checkPipelineRunTimeouts(namespace.GetName())
+		t.checkApprovalTimeouts(namespace.GetName())
+		t.checkRunTimeouts(namespace.GetName())
 	}
 }
 
@@ -147,7 +214,9 @@ func (t *TimeoutSet) checkTaskRunTimeouts(namespace string) {
 		if taskrun.IsDone() || taskrun.IsCancelled() {
 			continue
 		}
-		if taskrun.HasStarted() {
+		if taskrun.Status.RunningTime != nil {
+			go t.WaitTaskRun(&taskrun, taskrun.Status.RunningTime)
+		} else if taskrun.HasStarted() {
 			go t.WaitTaskRun(&taskrun, taskrun.Status.StartTime)
 		}
 	}
@@ -155,16 +224,39 @@ func (t *TimeoutSet) checkTaskRunTimeouts(namespace string) {
 
 // WaitTaskRun function creates a blocking function for taskrun to wait for
 // 1. Stop signal, 2. TaskRun to complete or 3. Taskrun to time out, which is
-// determined by checking if the tr's timeout has occurred since the startTime
+// determined by checking if the tr's timeout has occurred since the startTime.
+// The time the pod of the tr spent pending doesn't count towards its timeout:
+// until the tr is running, the startTime is the time it started and the tr
+// waits for its pending timeout instead, which defaults to its timeout. Once it
+// is running, the startTime is its RunningTime.
 func (t *TimeoutSet) WaitTaskRun(tr *v1alpha1.TaskRun, startTime *metav1.Time) {
-	t.waitRun(tr, getTimeout(tr.Spec.Timeout), startTime, t.taskRunCallbackFunc)
+	timeout := getTimeout(tr.Spec.Timeout)
+	if tr.Status.RunningTime == nil && tr.Spec.PendingTimeout != nil {
+		timeout = tr.Spec.PendingTimeout.Duration
+	}
+	t.waitRun(tr, timeout, startTime, t.taskRunCallbackFunc)
 }
 
 // WaitPipelineRun function creates a blocking function for pipelinerun to wait for
 // 1. Stop signal, 2. pipelinerun to complete or 3. pipelinerun to time out which is
-// determined by checking if the tr's timeout has occurred since the startTime
+// determined by checking if the tr's timeout has occurred since the startTime. The time
+// the pipelinerun spent paused doesn't count towards its timeout.
 func (t *TimeoutSet) WaitPipelineRun(pr *v1alpha1.PipelineRun, startTime *metav1.Time) {
-	t.waitRun(pr, getTimeout(pr.Spec.Timeout), startTime, t.pipelineRunCallbackFunc)
+	t.waitRun(pr, getTimeout(pr.Spec.Timeout)+pr.GetPausedDuration(), startTime, t.pipelineRunCallbackFunc)
+}
+
+// WaitApproval function creates a blocking function for approval to wait for
+// 1. Stop signal, 2. approval to be decided or 3. approval to time out, which is
+// determined by checking if its timeout has occurred since the startTime.
+func (t *TimeoutSet) WaitApproval(a *v1alpha1.Approval, startTime *metav1.Time) {
+	t.waitRun(a, getTimeout(a.Spec.Timeout), startTime, t.approvalCallbackFunc)
+}
+
+// WaitRun function creates a blocking function for run to wait for
+// 1. Stop signal, 2. run to complete or 3. run to time out, which is
+// determined by checking if its timeout has occurred since the startTime.
+func (t *TimeoutSet) WaitRun(r *v1alpha1.Run, startTime *metav1.Time) {
+	t.waitRun(r, getTimeout(r.Spec.Timeout), startTime, t.runCallbackFunc)
 }
 
 func (t *TimeoutSet) waitRun(runObj StatusKey, timeout time.Duration, startTime *metav1.Time, callback func(interface{})) {
@@ -172,26 +264,23 @@ func (t *TimeoutSet) waitRun(runObj StatusKey, timeout time.Duration, startTime
 		t.logger.Errorf("startTime must be specified in order for a timeout to be calculated accurately for %s", runObj.GetRunKey())
 		return
 	}
+	if callback == nil {
+		callback = defaultFunc
+	}
 	runtime := time.Since(startTime.Time)
 	finished := t.getOrCreateFinishedChan(runObj)
 
-	defer t.Release(runObj)
+	defer t.release(runObj, finished)
 
 	t.logger.Infof("About to start timeout timer for %s. started at %s, timeout is %s, running for %s", runObj.GetRunKey(), startTime.Time, timeout, runtime)
 
 	select {
 	case <-t.stopCh:
 		t.logger.Info("Stopping timeout timer for %s", runObj.GetRunKey())
-		return
 	case <-finished:
 		t.logger.Info("%s finished, stopping the timeout timer", runObj.GetRunKey())
-		return
 	case <-time.After(timeout - runtime):
 		t.logger.Info("Timeout timer for %s has timed out (started at %s, timeout is %s, running for %s", runObj.GetRunKey(), startTime, timeout, time.Since(startTime.Time))
-		if callback != nil {
-			callback(runObj)
-		} else {
-			defaultFunc(runObj)
-		}
+		callback(runObj)
 	}
 }
@@ -33,6 +33,27 @@ func TestTaskRunCheckTimeouts(t *testing.T) {
 		tb.TaskRunStartTime(time.Now().Add(-10*time.Second)),
 	))
 
+	taskRunPendingTimedout := tb.TaskRun("test-taskrun-run-pending-timedout", testNs, tb.TaskRunSpec(
+		tb.TaskRunTaskRef(simpleTask.Name, tb.TaskRefAPIVersion("a1")),
+		tb.TaskRunTimeout(time.Hour),
+		tb.TaskRunPendingTimeout(1*time.Second),
+	), tb.TaskRunStatus(tb.Condition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown}),
+		tb.TaskRunStartTime(time.Now().Add(-10*time.Second)),
+	))
+
+	taskRunRunningAfterPending := tb.TaskRun("test-taskrun-running-after-pending", testNs, tb.TaskRunSpec(
+		tb.TaskRunTaskRef(simpleTask.Name, tb.TaskRefAPIVersion("a1")),
+		tb.TaskRunTimeout(5*time.Second),
+		tb.TaskRunPendingTimeout(time.Minute),
+	), tb.TaskRunStatus(tb.Condition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown}),
+		tb.TaskRunStartTime(time.Now().Add(-10*time.Second)),
+		tb.TaskRunRunningTime(time.Now()),
+	))
+
 	taskRunRunning := tb.TaskRun("test-taskrun-running", testNs, tb.TaskRunSpec(
 		tb.TaskRunTaskRef(simpleTask.Name, tb.TaskRefAPIVersion("a1")),
 	), tb.TaskRunStatus(tb.Condition(apis.Condition{
@@ -57,7 +78,7 @@ func TestTaskRunCheckTimeouts(t *testing.T) {
 	))
 
 	d := test.Data{
-		TaskRuns: []*v1alpha1.TaskRun{taskRunTimedout, taskRunRunning, taskRunDone, taskRunCancelled},
+		TaskRuns: []*v1alpha1.TaskRun{taskRunTimedout, taskRunPendingTimedout, taskRunRunningAfterPending, taskRunRunning, taskRunDone, taskRunCancelled},
 		Tasks:    []*v1alpha1.Task{simpleTask},
 		Namespaces: []*corev1.Namespace{{
 			ObjectMeta: metav1.ObjectMeta{
@@ -87,6 +108,14 @@ func TestTaskRunCheckTimeouts(t *testing.T) {
 		name:           "timedout",
 		taskRun:        taskRunTimedout,
 		expectCallback: true,
+	}, {
+		name:           "pending timedout",
+		taskRun:        taskRunPendingTimedout,
+		expectCallback: true,
+	}, {
+		name:           "running after pending longer than timeout",
+		taskRun:        taskRunRunningAfterPending,
+		expectCallback: false,
 	}, {
 		name:           "running",
 		taskRun:        taskRunRunning,
@@ -159,8 +188,31 @@ func TestPipelinRunCheckTimeouts(t *testing.T) {
 			Status: corev1.ConditionUnknown}),
 		),
 	)
+	prPaused := tb.PipelineRun("test-pipeline-paused", testNs,
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunTimeout(&metav1.Duration{Duration: 1 * time.Second}),
+			tb.PipelineRunPaused,
+		),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown}),
+			tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1)),
+			tb.PipelineRunPausedTime(time.Now().AddDate(0, 0, -1)),
+		),
+	)
+	prResumed := tb.PipelineRun("test-pipeline-resumed", testNs,
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunTimeout(&metav1.Duration{Duration: 1 * time.Hour}),
+		),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown}),
+			tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1)),
+			tb.PipelineRunPausedDuration(24*time.Hour),
+		),
+	)
 	d := test.Data{
-		PipelineRuns: []*v1alpha1.PipelineRun{prTimeout, prRunning, prDone, prCancelled},
+		PipelineRuns: []*v1alpha1.PipelineRun{prTimeout, prRunning, prDone, prCancelled, prPaused, prResumed},
 		Pipelines:    []*v1alpha1.Pipeline{simplePipeline},
 		Tasks:        []*v1alpha1.Task{ts},
 		Namespaces: []*corev1.Namespace{{
@@ -203,6 +255,14 @@ func TestPipelinRunCheckTimeouts(t *testing.T) {
 		name:           "pr-cancel",
 		pr:             prCancelled,
 		expectCallback: false,
+	}, {
+		name:           "pr-paused",
+		pr:             prPaused,
+		expectCallback: false,
+	}, {
+		name:           "pr-resumed-within-timeout",
+		pr:             prResumed,
+		expectCallback: false,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := wait.PollImmediate(1*time.Second, 5*time.Second, func() (bool, error) {
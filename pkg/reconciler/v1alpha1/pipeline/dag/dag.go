@@ -54,6 +54,37 @@ func GetSchedulable(g *v1alpha1.DAG, doneTasks ...string) (map[string]v1alpha1.P
 	return d, nil
 }
 
+// GetSkipped returns the PipelineTasks which will never be scheduled given a list of
+// skippedTasks: the skipped tasks themselves and all of the tasks which depend on them,
+// directly or not. Each of them is mapped to the name of the skipped task which caused it
+// to be skipped, which for the skippedTasks is their own name.
+func GetSkipped(g *v1alpha1.DAG, skippedTasks ...string) map[string]string {
+	skipped := map[string]string{}
+	for _, s := range skippedTasks {
+		if _, ok := g.Nodes[s]; ok {
+			skipped[s] = s
+		}
+	}
+	for _, s := range skippedTasks {
+		if n, ok := g.Nodes[s]; ok {
+			for _, next := range n.Next {
+				markSkipped(next, s, skipped)
+			}
+		}
+	}
+	return skipped
+}
+
+func markSkipped(n *v1alpha1.Node, cause string, skipped map[string]string) {
+	if _, ok := skipped[n.Task.Name]; ok {
+		return
+	}
+	skipped[n.Task.Name] = cause
+	for _, next := range n.Next {
+		markSkipped(next, cause, skipped)
+	}
+}
+
 func getRoots(g *v1alpha1.DAG) []*v1alpha1.Node {
 	n := []*v1alpha1.Node{}
 	for _, node := range g.Nodes {
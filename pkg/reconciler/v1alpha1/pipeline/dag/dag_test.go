@@ -181,3 +181,52 @@ func TestGetSchedulable_Invalid(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSkipped(t *testing.T) {
+	g := testGraph(t)
+	tcs := []struct {
+		name     string
+		skipped  []string
+		expected map[string]string
+	}{{
+		name:     "nothing-skipped",
+		skipped:  []string{},
+		expected: map[string]string{},
+	}, {
+		name:     "leaf-skipped",
+		skipped:  []string{"z"},
+		expected: map[string]string{"z": "z"},
+	}, {
+		name:    "x-skipped",
+		skipped: []string{"x"},
+		expected: map[string]string{
+			"x": "x",
+			"y": "x",
+			"z": "x",
+			"w": "x",
+		},
+	}, {
+		name:    "b-and-a-skipped",
+		skipped: []string{"b", "a"},
+		expected: map[string]string{
+			"a": "a",
+			"b": "b",
+			"w": "b",
+			"x": "a",
+			"y": "a",
+			"z": "a",
+		},
+	}, {
+		name:     "unknown-task-skipped",
+		skipped:  []string{"unknown"},
+		expected: map[string]string{},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			skipped := GetSkipped(g, tc.skipped...)
+			if d := cmp.Diff(tc.expected, skipped); d != "" {
+				t.Errorf("expected the skipped tasks to match %v. Diff -want, +got: %s", tc.expected, d)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// createApproval creates the Approval the PipelineTask of rprt waits for, with the approvers of
+// the PipelineTask and its timeout.
+func (c *Reconciler) createApproval(rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun) (*v1alpha1.Approval, error) {
+	a := &v1alpha1.Approval{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.ApprovalName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: pr.GetOwnerReference(),
+			Labels:          getTaskRunLabels(pr),
+		},
+		Spec: v1alpha1.ApprovalSpec{
+			ApprovalGate: *rprt.PipelineTask.Approval.DeepCopy(),
+			Timeout:      getTaskRunTimeout(pr, rprt.PipelineTask),
+		},
+	}
+	return c.PipelineClientSet.TektonV1alpha1().Approvals(pr.Namespace).Create(a)
+}
+
+// reconcileApproval updates the status of the Approval of rprt, which nobody else does since
+// no pod runs it: it starts waiting for a decision when it was just created, and is done once
+// an approver decided or once it has timed out.
+func (c *Reconciler) reconcileApproval(rprt *resources.ResolvedPipelineRunTask) error {
+	a := rprt.Approval.DeepCopy()
+	if !a.HasStarted() {
+		a.Status.InitializeConditions()
+		a.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  v1alpha1.ApprovalReasonWaiting,
+			Message: fmt.Sprintf("Approval %q is waiting for a decision", a.Name),
+		})
+		if a.Spec.Timeout != nil {
+			go c.timeoutHandler.WaitApproval(a, a.Status.StartTime)
+		}
+	}
+	switch {
+	case a.Spec.Decision == v1alpha1.ApprovalDecisionApproved:
+		c.Logger.Infof("Approval %s was approved by %s", a.Name, a.Spec.Approver)
+		completeApproval(a, corev1.ConditionTrue, v1alpha1.ApprovalReasonApproved,
+			fmt.Sprintf("Approval %q was approved by %s", a.Name, a.Spec.Approver))
+	case a.Spec.Decision == v1alpha1.ApprovalDecisionRejected:
+		c.Logger.Infof("Approval %s was rejected by %s", a.Name, a.Spec.Approver)
+		completeApproval(a, corev1.ConditionFalse, v1alpha1.ApprovalReasonRejected,
+			fmt.Sprintf("Approval %q was rejected by %s", a.Name, a.Spec.Approver))
+	case a.Spec.Timeout != nil && time.Since(a.Status.StartTime.Time) > a.Spec.Timeout.Duration:
+		c.Logger.Infof("Approval %s has timed out (waiting for %s over %s)", a.Name, time.Since(a.Status.StartTime.Time), a.Spec.Timeout.Duration)
+		completeApproval(a, corev1.ConditionFalse, v1alpha1.ApprovalReasonTimedOut,
+			fmt.Sprintf("Approval %q wasn't decided on within %q", a.Name, a.Spec.Timeout.Duration.String()))
+	}
+	if a.IsDone() {
+		c.timeoutHandler.Release(a)
+	}
+	if equality.Semantic.DeepEqual(a.Status, rprt.Approval.Status) {
+		return nil
+	}
+	updated, err := c.PipelineClientSet.TektonV1alpha1().Approvals(a.Namespace).UpdateStatus(a)
+	if err != nil {
+		return err
+	}
+	rprt.Approval = updated
+	return nil
+}
+
+// completeApproval marks the Approval a as done, with the status, reason and message of its
+// Succeeded condition.
+func completeApproval(a *v1alpha1.Approval, status corev1.ConditionStatus, reason, message string) {
+	a.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	a.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+}
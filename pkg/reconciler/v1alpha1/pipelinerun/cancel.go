@@ -29,7 +29,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// cancelPipelineRun makrs the PipelineRun as cancelled and any resolved taskrun too.
+// cancelPipelineRun makrs the PipelineRun as cancelled and any resolved taskrun or child
+// pipelinerun too.
 func cancelPipelineRun(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask, clientSet clientset.Interface) error {
 	pr.Status.SetCondition(&apis.Condition{
 		Type:    apis.ConditionSucceeded,
@@ -39,22 +40,153 @@ func cancelPipelineRun(pr *v1alpha1.PipelineRun, pipelineState []*resources.Reso
 	})
 	// update pr completed time
 	pr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
-	errs := []string{}
+	errs := cancelPipelineTaskRuns(pipelineState, pr.Namespace, clientSet)
+	if len(errs) > 0 {
+		return fmt.Errorf("Error cancelled PipelineRun's TaskRun(s): %s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// failFast cancels the TaskRuns of the PipelineTasks of the graph d which are still running
+// once one of them has failed, without changing the status of the PipelineRun.
+func failFast(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask, d *v1alpha1.DAG, clientSet clientset.Interface) error {
+	running := []*resources.ResolvedPipelineRunTask{}
 	for _, rprt := range pipelineState {
-		if rprt.TaskRun == nil {
-			// No taskrun yet, pass
+		if _, ok := d.Nodes[rprt.PipelineTask.Name]; !ok {
+			continue
+		}
+		if rprt.TaskRun != nil && (rprt.TaskRun.IsDone() || rprt.TaskRun.IsCancelled()) {
 			continue
 		}
-		rprt.TaskRun.Spec.Status = v1alpha1.TaskRunSpecStatusCancelled
-		if _, err := clientSet.TektonV1alpha1().TaskRuns(pr.Namespace).UpdateStatus(rprt.TaskRun); err != nil {
-			errs = append(errs, err.Error())
+		if rprt.PipelineRun != nil && (rprt.PipelineRun.IsDone() || rprt.PipelineRun.IsCancelled()) {
+			continue
 		}
-		if _, err := clientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Update(rprt.TaskRun); err != nil {
-			errs = append(errs, err.Error())
+		if rprt.Approval != nil && rprt.Approval.IsDone() {
+			continue
+		}
+		if rprt.Run != nil && (rprt.Run.IsDone() || rprt.Run.IsCancelled()) {
+			continue
 		}
+		running = append(running, rprt)
 	}
+	errs := cancelPipelineTaskRuns(running, pr.Namespace, clientSet)
 	if len(errs) > 0 {
-		return fmt.Errorf("Error cancelled PipelineRun's TaskRun(s): %s", strings.Join(errs, "\n"))
+		return fmt.Errorf("Error cancelling PipelineRun %s's running TaskRun(s) after a failure: %s", pr.Name, strings.Join(errs, "\n"))
 	}
 	return nil
 }
+
+// cancelPipelineTaskRuns marks the TaskRuns, the PipelineRuns, the Approvals or the Runs of rprts, and
+// their unfinished condition checks, as cancelled, returning the errors which occurred.
+func cancelPipelineTaskRuns(rprts []*resources.ResolvedPipelineRunTask, namespace string, clientSet clientset.Interface) []string {
+	errs := []string{}
+	for _, rprt := range rprts {
+		for _, rcc := range rprt.ResolvedConditionChecks {
+			if rcc.ConditionCheck != nil && !rcc.ConditionCheck.IsDone() {
+				errs = append(errs, cancelTaskRun(rcc.ConditionCheck, namespace, clientSet)...)
+			}
+		}
+		if rprt.PipelineRun != nil && !rprt.PipelineRun.IsDone() {
+			errs = append(errs, requestPipelineRunCancellation(rprt.PipelineRun, namespace, clientSet)...)
+		}
+		if rprt.Approval != nil && !rprt.Approval.IsDone() {
+			errs = append(errs, cancelApproval(rprt.Approval, namespace, clientSet)...)
+		}
+		if rprt.Run != nil && !rprt.Run.IsDone() {
+			errs = append(errs, requestRunCancellation(rprt.Run, namespace, clientSet)...)
+		}
+		if rprt.TaskRun == nil {
+			// No taskrun yet, pass
+			continue
+		}
+		errs = append(errs, cancelTaskRun(rprt.TaskRun, namespace, clientSet)...)
+	}
+	return errs
+}
+
+// cancelChildPipelineRuns marks the PipelineRuns of rprts running a Pipeline which haven't
+// finished yet as cancelled, returning the errors which occurred.
+func cancelChildPipelineRuns(rprts []*resources.ResolvedPipelineRunTask, namespace string, clientSet clientset.Interface) []string {
+	errs := []string{}
+	for _, rprt := range rprts {
+		if rprt.PipelineRun != nil && !rprt.PipelineRun.IsDone() && !rprt.PipelineRun.IsCancelled() {
+			errs = append(errs, requestPipelineRunCancellation(rprt.PipelineRun, namespace, clientSet)...)
+		}
+	}
+	return errs
+}
+
+// cancelApprovals marks the Approvals of rprts which haven't been decided on yet as
+// cancelled, returning the errors which occurred.
+func cancelApprovals(rprts []*resources.ResolvedPipelineRunTask, namespace string, clientSet clientset.Interface) []string {
+	errs := []string{}
+	for _, rprt := range rprts {
+		if rprt.Approval != nil && !rprt.Approval.IsDone() {
+			errs = append(errs, cancelApproval(rprt.Approval, namespace, clientSet)...)
+		}
+	}
+	return errs
+}
+
+// cancelRuns marks the Runs of rprts running a custom task which haven't finished yet as
+// cancelled, returning the errors which occurred.
+func cancelRuns(rprts []*resources.ResolvedPipelineRunTask, namespace string, clientSet clientset.Interface) []string {
+	errs := []string{}
+	for _, rprt := range rprts {
+		if rprt.Run != nil && !rprt.Run.IsDone() && !rprt.Run.IsCancelled() {
+			errs = append(errs, requestRunCancellation(rprt.Run, namespace, clientSet)...)
+		}
+	}
+	return errs
+}
+
+// cancelApproval marks the Approval a, which hasn't been decided on, as failed since the
+// PipelineRun waiting for it won't go on, returning the errors which occurred.
+func cancelApproval(a *v1alpha1.Approval, namespace string, clientSet clientset.Interface) []string {
+	errs := []string{}
+	a = a.DeepCopy()
+	completeApproval(a, corev1.ConditionFalse, v1alpha1.ApprovalReasonCancelled,
+		fmt.Sprintf("Approval %q was cancelled", a.Name))
+	if _, err := clientSet.TektonV1alpha1().Approvals(namespace).UpdateStatus(a); err != nil {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+// requestPipelineRunCancellation marks the spec of the PipelineRun pr as cancelled, such as
+// one run by a PipelineTask, so that it cancels its own TaskRuns with cancelPipelineRun,
+// returning the errors which occurred.
+func requestPipelineRunCancellation(pr *v1alpha1.PipelineRun, namespace string, clientSet clientset.Interface) []string {
+	errs := []string{}
+	pr = pr.DeepCopy()
+	pr.Spec.Status = v1alpha1.PipelineRunSpecStatusCancelled
+	if _, err := clientSet.TektonV1alpha1().PipelineRuns(namespace).Update(pr); err != nil {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+// requestRunCancellation marks the spec of the Run r as cancelled, so that the controller
+// fulfilling it stops it and marks it as failed, returning the errors which occurred.
+func requestRunCancellation(r *v1alpha1.Run, namespace string, clientSet clientset.Interface) []string {
+	errs := []string{}
+	r = r.DeepCopy()
+	r.Spec.Status = v1alpha1.RunSpecStatusCancelled
+	if _, err := clientSet.TektonV1alpha1().Runs(namespace).Update(r); err != nil {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+// cancelTaskRun marks the TaskRun tr as cancelled, returning the errors which occurred.
+func cancelTaskRun(tr *v1alpha1.TaskRun, namespace string, clientSet clientset.Interface) []string {
+	errs := []string{}
+	tr.Spec.Status = v1alpha1.TaskRunSpecStatusCancelled
+	if _, err := clientSet.TektonV1alpha1().TaskRuns(namespace).UpdateStatus(tr); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if _, err := clientSet.TektonV1alpha1().TaskRuns(namespace).Update(tr); err != nil {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/commitstatus"
+	"github.com/tektoncd/pipeline/pkg/templating"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const eventReasonCommitStatusFailed = "CommitStatusFailed"
+
+// errNotCommit is returned when the status isn't reported because the revision isn't a commit.
+var errNotCommit = commitstatus.Permanent(errors.New("the revision isn't a commit"))
+
+// reportCommitStatus queues the report of the state of pr on the commit of its git resource
+// when its condition changed since the status last recorded, such as when it starts or
+// finishes. Once the status is reported, or can't be, pr is reconciled again and the status
+// recorded in the status of pr. Failing to report it doesn't fail pr, and is recorded as an
+// event.
+func (c *Reconciler) reportCommitStatus(pr *v1alpha1.PipelineRun) {
+	if pr.Spec.CommitStatus == nil || c.CommitStatuses == nil {
+		return
+	}
+	condition := pr.Status.GetCondition(apis.ConditionSucceeded)
+	state := getCommitState(pr, condition)
+	if state == "" {
+		return
+	}
+	if cs := pr.Status.CommitStatus; cs != nil && cs.State == string(state) && cs.Reason == condition.Reason {
+		return
+	}
+
+	key := pr.Namespace + "/" + pr.Name
+	reported := &v1alpha1.ReportedCommitStatus{State: string(state), Reason: condition.Reason}
+	c.commitStatusesMu.Lock()
+	done, ok := c.reportedCommitStatuses[key]
+	delete(c.reportedCommitStatuses, key)
+	c.commitStatusesMu.Unlock()
+	if ok && done.State == reported.State && done.Reason == reported.Reason {
+		pr.Status.CommitStatus = done
+		return
+	}
+
+	pr = pr.DeepCopy()
+	c.CommitStatuses.Add(&commitstatus.Report{
+		Key: key,
+		ID:  reported.State + "/" + reported.Reason,
+		Send: func() error {
+			return c.sendCommitStatus(pr, state, condition.Message)
+		},
+		Done: func(err error) {
+			if err != nil {
+				reported.Skipped = err.Error()
+			}
+			if err != nil && err != errNotCommit {
+				c.Logger.Warnf("Failed to report the status of PipelineRun %s on its commit: %v", key, err)
+				c.Recorder.Eventf(pr, corev1.EventTypeWarning, eventReasonCommitStatusFailed, "Failed to report the status on the commit: %v", err)
+			}
+			c.commitStatusesMu.Lock()
+			c.reportedCommitStatuses[key] = reported
+			c.commitStatusesMu.Unlock()
+			c.enqueue(key)
+		},
+	})
+}
+
+// getCommitState returns the state of the commit status of pr, whose condition is
+// condition, or an empty state if it has no condition yet.
+func getCommitState(pr *v1alpha1.PipelineRun, condition *apis.Condition) commitstatus.State {
+	switch {
+	case condition == nil:
+		return ""
+	case condition.IsUnknown():
+		return commitstatus.StatePending
+	case condition.IsTrue():
+		return commitstatus.StateSuccess
+	case pr.IsCancelled():
+		return commitstatus.StateCancelled
+	default:
+		return commitstatus.StateFailure
+	}
+}
+
+func (c *Reconciler) sendCommitStatus(pr *v1alpha1.PipelineRun, state commitstatus.State, description string) error {
+	cs := pr.Spec.CommitStatus
+	var git *v1alpha1.GitResource
+	for _, binding := range pr.Spec.Resources {
+		if binding.Name != cs.Resource {
+			continue
+		}
+		r, err := c.resourceLister.PipelineResources(pr.Namespace).Get(binding.ResourceRef.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return commitstatus.Permanent(fmt.Errorf("couldn't get resource %s: %v", binding.ResourceRef.Name, err))
+			}
+			return fmt.Errorf("couldn't get resource %s: %v", binding.ResourceRef.Name, err)
+		}
+		if git, err = v1alpha1.NewGitResource(r); err != nil {
+			return commitstatus.Permanent(err)
+		}
+	}
+	if git == nil {
+		return commitstatus.Permanent(fmt.Errorf("resource %q isn't bound", cs.Resource))
+	}
+	if !commitstatus.IsCommit(git.Revision) {
+		// Statuses can only be reported on commits, not on branches or tags
+		c.Logger.Debugf("Not reporting the status of PipelineRun %s/%s: revision %q isn't a commit", pr.Namespace, pr.Name, git.Revision)
+		return errNotCommit
+	}
+	repo, err := commitstatus.RepositoryFromURL(git.URL)
+	if err != nil {
+		return commitstatus.Permanent(err)
+	}
+
+	secret, err := c.KubeClientSet.CoreV1().Secrets(pr.Namespace).Get(cs.TokenSecretRef.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return commitstatus.Permanent(fmt.Errorf("couldn't get the secret of the token: %v", err))
+	} else if err != nil {
+		return fmt.Errorf("couldn't get the secret of the token: %v", err)
+	}
+	token, ok := secret.Data[cs.TokenSecretRef.Key]
+	if !ok {
+		return commitstatus.Permanent(fmt.Errorf("secret %s has no key %s", cs.TokenSecretRef.Name, cs.TokenSecretRef.Key))
+	}
+	reporter, err := commitstatus.NewReporter(cs.Provider, cs.APIURL, string(token))
+	if err != nil {
+		return commitstatus.Permanent(err)
+	}
+
+	context := cs.Context
+	if context == "" {
+		pipelineName := pr.Name
+		if name, ok := pr.Labels[pipeline.GroupName+pipeline.PipelineLabelKey]; ok {
+			pipelineName = name
+		} else if pr.Spec.PipelineRef != nil {
+			pipelineName = pr.Spec.PipelineRef.Name
+		}
+		context = "tekton/" + pipelineName
+	}
+	return reporter.Report(repo, git.Revision, commitstatus.Status{
+		State:       state,
+		Context:     context,
+		Description: description,
+		TargetURL: templating.ApplyReplacements(cs.TargetURL, map[string]string{
+			"pipelineRun.name":      pr.Name,
+			"pipelineRun.namespace": pr.Namespace,
+		}),
+	})
+}
@@ -0,0 +1,407 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/test"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const commitSHA = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// commitStatusRequest is a request received by the stand-in of the API of GitHub.
+type commitStatusRequest struct {
+	Path          string
+	Authorization string
+	Body          map[string]string
+}
+
+// newCommitStatusAPI returns a stand-in of the commit status API of GitHub, recording the
+// requests it receives and responding with statuses in turn, the last one repeatedly.
+func newCommitStatusAPI(t *testing.T, statuses ...int) (*httptest.Server, func() []commitStatusRequest) {
+	var mu sync.Mutex
+	requests := []commitStatusRequest{}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := commitStatusRequest{Path: r.URL.Path, Authorization: r.Header.Get("Authorization")}
+		if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+			t.Errorf("Failed to decode the commit status: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		status := statuses[len(statuses)-1]
+		if len(requests) < len(statuses) {
+			status = statuses[len(requests)]
+		}
+		requests = append(requests, req)
+		w.WriteHeader(status)
+	}))
+	return api, func() []commitStatusRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]commitStatusRequest{}, requests...)
+	}
+}
+
+// watchCommitStatuses returns the keys of the PipelineRuns c enqueues once their commit
+// status is reported, or skipped.
+func watchCommitStatuses(c *Reconciler) <-chan string {
+	keys := make(chan string, 10)
+	c.enqueue = func(key string) { keys <- key }
+	return keys
+}
+
+func waitForCommitStatus(t *testing.T, keys <-chan string) {
+	t.Helper()
+	select {
+	case <-keys:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the commit status to be reported")
+	}
+}
+
+// reconcileCommitStatus reconciles the PipelineRun test-pipeline-run, updating the informers
+// with the PipelineRun and TaskRuns stored, and returns the PipelineRun stored.
+func reconcileCommitStatus(t *testing.T, testAssets test.TestAssets) *v1alpha1.PipelineRun {
+	t.Helper()
+	clients := testAssets.Clients
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+		t.Fatalf("Error reconciling: %s", err)
+	}
+	pr, err := clients.Pipeline.TektonV1alpha1().PipelineRuns("foo").Get("test-pipeline-run", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if err := testAssets.Informers.PipelineRun.Informer().GetIndexer().Update(pr); err != nil {
+		t.Fatalf("Failed to update the informer's copy of the PipelineRun: %v", err)
+	}
+	trs, err := clients.Pipeline.TektonV1alpha1().TaskRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error listing TaskRuns out of fake client: %s", err)
+	}
+	for i := range trs.Items {
+		if err := testAssets.Informers.TaskRun.Informer().GetIndexer().Add(&trs.Items[i]); err != nil {
+			t.Fatalf("Failed to add TaskRun to the informer: %v", err)
+		}
+	}
+	return pr
+}
+
+func getCommitStatusTestAssets(t *testing.T, revision string, prs []*v1alpha1.PipelineRun, recorder record.EventRecorder) test.TestAssets {
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines: []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineDeclaredResource("source", v1alpha1.PipelineResourceTypeGit),
+			tb.PipelineTask("unit-tests", "unit-test-task",
+				tb.PipelineTaskInputResource("workspace", "source"),
+			),
+		))},
+		Tasks: []*v1alpha1.Task{tb.Task("unit-test-task", "foo", tb.TaskSpec(
+			tb.TaskInputs(tb.InputsResource("workspace", v1alpha1.PipelineResourceTypeGit)),
+		))},
+		PipelineResources: []*v1alpha1.PipelineResource{tb.PipelineResource("app-source", "foo", tb.PipelineResourceSpec(
+			v1alpha1.PipelineResourceTypeGit,
+			tb.PipelineResourceSpecParam("url", "https://github.com/tektoncd/pipeline.git"),
+			tb.PipelineResourceSpecParam("revision", revision),
+		))},
+	}
+	testAssets := getPipelineRunController(d, recorder)
+	if _, err := testAssets.Clients.Kube.CoreV1().Secrets("foo").Create(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	}); err != nil {
+		t.Fatalf("Failed to create the secret of the token: %v", err)
+	}
+	return testAssets
+}
+
+func commitStatusPipelineRun(apiURL string, ops ...tb.PipelineRunStatusOp) *v1alpha1.PipelineRun {
+	return tb.PipelineRun("test-pipeline-run", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunResourceBinding("source", tb.PipelineResourceBindingRef("app-source")),
+			tb.PipelineRunCommitStatus("source", v1alpha1.CommitStatusProviderGitHub, "github", "token",
+				tb.CommitStatusAPIURL(apiURL),
+				tb.CommitStatusTargetURL("https://dashboard.example.com/#/namespaces/${pipelineRun.namespace}/pipelineruns/${pipelineRun.name}"),
+			),
+		),
+		tb.PipelineRunStatus(ops...),
+	)
+}
+
+func TestReconcileReportsCommitStatus(t *testing.T) {
+	api, requests := newCommitStatusAPI(t, http.StatusCreated)
+	defer api.Close()
+	prs := []*v1alpha1.PipelineRun{commitStatusPipelineRun(api.URL)}
+	testAssets := getCommitStatusTestAssets(t, commitSHA, prs, record.NewFakeRecorder(2))
+	keys := watchCommitStatuses(testAssets.Controller.Reconciler.(*Reconciler))
+
+	pr := reconcileCommitStatus(t, testAssets)
+	if pr.Status.CommitStatus != nil {
+		t.Errorf("Expected the commit status not to be recorded before it is reported but got %v", pr.Status.CommitStatus)
+	}
+	waitForCommitStatus(t, keys)
+
+	expected := []commitStatusRequest{{
+		Path:          "/repos/tektoncd/pipeline/statuses/" + commitSHA,
+		Authorization: "token secret-token",
+		Body: map[string]string{
+			"state":       "pending",
+			"context":     "tekton/test-pipeline",
+			"description": "Not all Tasks in the Pipeline have finished executing",
+			"target_url":  "https://dashboard.example.com/#/namespaces/foo/pipelineruns/test-pipeline-run",
+		},
+	}}
+	if d := cmp.Diff(expected, requests()); d != "" {
+		t.Errorf("Unexpected commit statuses (-want, +got): %s", d)
+	}
+
+	// The status reported is recorded on the next reconcile
+	pr = reconcileCommitStatus(t, testAssets)
+	if d := cmp.Diff(&v1alpha1.ReportedCommitStatus{State: "pending", Reason: resources.ReasonRunning}, pr.Status.CommitStatus); d != "" {
+		t.Errorf("Unexpected reported commit status (-want, +got): %s", d)
+	}
+	if len(requests()) != 1 {
+		t.Errorf("Expected the commit status to be reported once but got %d requests", len(requests()))
+	}
+}
+
+func TestReportCommitStatus(t *testing.T) {
+	running := tb.PipelineRunStatusCondition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: resources.ReasonRunning,
+	})
+	succeeded := tb.PipelineRunStatusCondition(apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionTrue,
+		Reason:  resources.ReasonSucceeded,
+		Message: "All Tasks have completed executing",
+	})
+	failed := tb.PipelineRunStatusCondition(apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionFalse,
+		Reason:  resources.ReasonFailed,
+		Message: "TaskRun test-pipeline-run-unit-tests has failed",
+	})
+	startTime := tb.PipelineRunStartTime(time.Now())
+	reportedRunning := &v1alpha1.ReportedCommitStatus{State: "pending", Reason: resources.ReasonRunning}
+
+	for _, tc := range []struct {
+		name        string
+		revision    string
+		reported    *v1alpha1.ReportedCommitStatus
+		after       tb.PipelineRunStatusOp
+		cancelled   bool
+		state       string
+		description string
+		expected    *v1alpha1.ReportedCommitStatus
+	}{{
+		name:        "started",
+		revision:    commitSHA,
+		after:       running,
+		state:       "pending",
+		description: "",
+		expected:    reportedRunning,
+	}, {
+		name:        "succeeded",
+		revision:    commitSHA,
+		reported:    reportedRunning,
+		after:       succeeded,
+		state:       "success",
+		description: "All Tasks have completed executing",
+		expected:    &v1alpha1.ReportedCommitStatus{State: "success", Reason: resources.ReasonSucceeded},
+	}, {
+		name:        "failed",
+		revision:    commitSHA,
+		reported:    reportedRunning,
+		after:       failed,
+		state:       "failure",
+		description: "TaskRun test-pipeline-run-unit-tests has failed",
+		expected:    &v1alpha1.ReportedCommitStatus{State: "failure", Reason: resources.ReasonFailed},
+	}, {
+		name:        "cancelled",
+		revision:    commitSHA,
+		reported:    reportedRunning,
+		after:       tb.PipelineRunStatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "PipelineRunCancelled"}),
+		cancelled:   true,
+		state:       "error",
+		description: "",
+		expected:    &v1alpha1.ReportedCommitStatus{State: "cancelled", Reason: "PipelineRunCancelled"},
+	}, {
+		name:        "started after being queued",
+		revision:    commitSHA,
+		reported:    &v1alpha1.ReportedCommitStatus{State: "pending", Reason: ReasonQueued},
+		after:       running,
+		state:       "pending",
+		description: "",
+		expected:    reportedRunning,
+	}, {
+		name:     "still running",
+		revision: commitSHA,
+		reported: reportedRunning,
+		after:    running,
+		expected: reportedRunning,
+	}, {
+		name:     "revision isn't a commit",
+		revision: "master",
+		reported: reportedRunning,
+		after:    succeeded,
+		expected: &v1alpha1.ReportedCommitStatus{State: "success", Reason: resources.ReasonSucceeded, Skipped: "the revision isn't a commit"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			api, requests := newCommitStatusAPI(t, http.StatusCreated)
+			defer api.Close()
+			testAssets := getCommitStatusTestAssets(t, tc.revision, nil, record.NewFakeRecorder(2))
+			pr := commitStatusPipelineRun(api.URL, tc.after, startTime)
+			pr.Status.CommitStatus = tc.reported
+			if tc.cancelled {
+				pr.Spec.Status = v1alpha1.PipelineRunSpecStatusCancelled
+			}
+
+			c := testAssets.Controller.Reconciler.(*Reconciler)
+			keys := watchCommitStatuses(c)
+			c.reportCommitStatus(pr)
+			if tc.expected != tc.reported {
+				waitForCommitStatus(t, keys)
+				c.reportCommitStatus(pr)
+			}
+			if d := cmp.Diff(tc.expected, pr.Status.CommitStatus); d != "" {
+				t.Errorf("Unexpected reported commit status (-want, +got): %s", d)
+			}
+
+			if tc.state == "" {
+				if len(requests()) != 0 {
+					t.Errorf("Expected no commit status to be reported but got %v", requests())
+				}
+				return
+			}
+			if len(requests()) != 1 {
+				t.Fatalf("Expected 1 commit status to be reported but got %d", len(requests()))
+			}
+			actual := requests()[0].Body
+			if actual["state"] != tc.state || actual["description"] != tc.description {
+				t.Errorf("Expected a commit status %q described as %q but got %q described as %q", tc.state, tc.description, actual["state"], actual["description"])
+			}
+		})
+	}
+}
+
+func TestReportCommitStatus_Error(t *testing.T) {
+	api, requests := newCommitStatusAPI(t, http.StatusUnauthorized)
+	defer api.Close()
+	fr := record.NewFakeRecorder(2)
+	testAssets := getCommitStatusTestAssets(t, commitSHA, nil, fr)
+	c := testAssets.Controller.Reconciler.(*Reconciler)
+	keys := watchCommitStatuses(c)
+
+	pr := commitStatusPipelineRun(api.URL, tb.PipelineRunStatusCondition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+	}))
+	c.reportCommitStatus(pr)
+	waitForCommitStatus(t, keys)
+
+	// The status isn't reported again, since the provider rejected the token
+	c.reportCommitStatus(pr)
+	c.reportCommitStatus(pr)
+	message := "POST " + api.URL + "/repos/tektoncd/pipeline/statuses/" + commitSHA + " responded with status 401"
+	if d := cmp.Diff(&v1alpha1.ReportedCommitStatus{State: "pending", Skipped: message}, pr.Status.CommitStatus); d != "" {
+		t.Errorf("Unexpected reported commit status (-want, +got): %s", d)
+	}
+	if len(requests()) != 1 {
+		t.Errorf("Expected the commit status to be reported once but got %d requests", len(requests()))
+	}
+
+	select {
+	case event := <-fr.Events:
+		if event != "Warning CommitStatusFailed Failed to report the status on the commit: "+message {
+			t.Errorf("Unexpected event %q", event)
+		}
+	default:
+		t.Errorf("Expected an event recording the failure to report the commit status")
+	}
+	select {
+	case event := <-fr.Events:
+		t.Errorf("Expected a single event but got %q", event)
+	default:
+	}
+}
+
+func TestReconcileRetriesCommitStatus(t *testing.T) {
+	api, requests := newCommitStatusAPI(t, http.StatusInternalServerError, http.StatusCreated)
+	defer api.Close()
+	prs := []*v1alpha1.PipelineRun{commitStatusPipelineRun(api.URL)}
+	fr := record.NewFakeRecorder(4)
+	testAssets := getCommitStatusTestAssets(t, commitSHA, prs, fr)
+	keys := watchCommitStatuses(testAssets.Controller.Reconciler.(*Reconciler))
+
+	// The status is reported again, with a delay, until it is reported
+	reconcileCommitStatus(t, testAssets)
+	waitForCommitStatus(t, keys)
+	if len(requests()) != 2 {
+		t.Errorf("Expected the commit status to be reported twice but got %d requests", len(requests()))
+	}
+
+	pr := reconcileCommitStatus(t, testAssets)
+	if pr.Status.CommitStatus == nil || pr.Status.CommitStatus.State != "pending" || pr.Status.CommitStatus.Skipped != "" {
+		t.Errorf("Expected the pending commit status to be recorded but got %v", pr.Status.CommitStatus)
+	}
+	select {
+	case event := <-fr.Events:
+		t.Errorf("Expected no event for a commit status eventually reported but got %q", event)
+	default:
+	}
+}
+
+func TestReconcileDoesntWaitForCommitStatus(t *testing.T) {
+	unblock := make(chan struct{})
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer api.Close()
+	defer close(unblock)
+	prs := []*v1alpha1.PipelineRun{commitStatusPipelineRun(api.URL)}
+	testAssets := getCommitStatusTestAssets(t, commitSHA, prs, record.NewFakeRecorder(2))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run"); err != nil {
+			t.Errorf("Error reconciling: %s", err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the PipelineRun to be reconciled while its commit status is being reported")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ConcurrencyConfigName is the name of the configmap limiting how many
+	// PipelineRuns can run at once in each namespace
+	ConcurrencyConfigName = "config-concurrency"
+	// ConcurrencyDefaultKey is the key of the limit applied to the namespaces
+	// which don't have their own key in the configmap
+	ConcurrencyDefaultKey = "default"
+)
+
+// Concurrency holds the maximum number of PipelineRuns which can run at once
+// in a namespace. A limit of 0 means that the PipelineRuns aren't limited.
+// +k8s:deepcopy-gen=false
+type Concurrency struct {
+	Default    int
+	Namespaces map[string]int
+}
+
+// NewConcurrencyFromConfigMap creates a Concurrency from the data of configMap,
+// where each key is the name of a namespace, or the default key, and each
+// value is the number of PipelineRuns which can run at once in it.
+func NewConcurrencyFromConfigMap(configMap *corev1.ConfigMap) (*Concurrency, error) {
+	c := &Concurrency{Namespaces: map[string]int{}}
+	for k, v := range configMap.Data {
+		limit, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the concurrency limit %q of %q: %v", v, k, err)
+		}
+		if limit < 0 {
+			return nil, fmt.Errorf("the concurrency limit of %q can't be negative but was %d", k, limit)
+		}
+		if k == ConcurrencyDefaultKey {
+			c.Default = limit
+		} else {
+			c.Namespaces[k] = limit
+		}
+	}
+	return c, nil
+}
+
+// Limit returns the maximum number of PipelineRuns which can run at once in namespace,
+// or 0 if they aren't limited.
+func (c *Concurrency) Limit(namespace string) int {
+	if limit, ok := c.Namespaces[namespace]; ok {
+		return limit
+	}
+	return c.Default
+}
+
+// DeepCopy returns a copy of c which doesn't share its map of namespaces.
+func (c *Concurrency) DeepCopy() *Concurrency {
+	out := &Concurrency{Default: c.Default, Namespaces: make(map[string]int, len(c.Namespaces))}
+	for k, v := range c.Namespaces {
+		out.Namespaces[k] = v
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewConcurrencyFromConfigMap(t *testing.T) {
+	c, err := NewConcurrencyFromConfigMap(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConcurrencyConfigName},
+		Data: map[string]string{
+			"default": "3",
+			"foo":     " 1 ",
+			"bar":     "0",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := &Concurrency{Default: 3, Namespaces: map[string]int{"foo": 1, "bar": 0}}
+	if d := cmp.Diff(expected, c); d != "" {
+		t.Errorf("Unexpected concurrency config (-want, +got): %s", d)
+	}
+	for ns, limit := range map[string]int{"foo": 1, "bar": 0, "baz": 3} {
+		if c.Limit(ns) != limit {
+			t.Errorf("Expected the limit of namespace %s to be %d but was %d", ns, limit, c.Limit(ns))
+		}
+	}
+}
+
+func TestNewConcurrencyFromConfigMap_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data map[string]string
+	}{{
+		name: "not a number",
+		data: map[string]string{"foo": "many"},
+	}, {
+		name: "negative",
+		data: map[string]string{"default": "-1"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewConcurrencyFromConfigMap(&corev1.ConfigMap{Data: tc.data}); err == nil {
+				t.Error("Expected an error but got none")
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ type cfgKey struct{}
 // +k8s:deepcopy-gen=false
 type Config struct {
 	ArtifactBucket *v1alpha1.ArtifactBucket
+	Concurrency    *Concurrency
 }
 
 func FromContext(ctx context.Context) *Config {
@@ -51,6 +52,7 @@ func NewStore(logger configmap.Logger) *Store {
 			logger,
 			configmap.Constructors{
 				v1alpha1.BucketConfigName: artifacts.NewArtifactBucketConfigFromConfigMap,
+				ConcurrencyConfigName:     NewConcurrencyFromConfigMap,
 			},
 		),
 	}
@@ -61,17 +63,17 @@ func (s *Store) ToContext(ctx context.Context) context.Context {
 }
 
 func (s *Store) Load() *Config {
-	ep := s.UntypedLoad(v1alpha1.BucketConfigName)
-	if ep == nil {
-		return &Config{
-			ArtifactBucket: &v1alpha1.ArtifactBucket{
-				Location: "",
-			},
-		}
+	c := &Config{
+		ArtifactBucket: &v1alpha1.ArtifactBucket{
+			Location: "",
+		},
+		Concurrency: &Concurrency{},
 	}
-
-	return &Config{
-		ArtifactBucket: ep.(*v1alpha1.ArtifactBucket).DeepCopy(),
+	if ep := s.UntypedLoad(v1alpha1.BucketConfigName); ep != nil {
+		c.ArtifactBucket = ep.(*v1alpha1.ArtifactBucket).DeepCopy()
 	}
-
+	if cc := s.UntypedLoad(ConcurrencyConfigName); cc != nil {
+		c.Concurrency = cc.(*Concurrency).DeepCopy()
+	}
+	return c
 }
@@ -30,7 +30,9 @@ import (
 func TestStoreLoadWithContext(t *testing.T) {
 	store := NewStore(logtesting.TestLogger(t))
 	bucketConfig := test.ConfigMapFromTestFile(t, "config-artifact-bucket")
+	concurrencyConfig := test.ConfigMapFromTestFile(t, "config-concurrency")
 	store.OnConfigChanged(bucketConfig)
+	store.OnConfigChanged(concurrencyConfig)
 
 	config := FromContext(store.ToContext(context.Background()))
 
@@ -38,18 +40,27 @@ func TestStoreLoadWithContext(t *testing.T) {
 	if diff := cmp.Diff(expected, config.ArtifactBucket); diff != "" {
 		t.Errorf("Unexpected controller config (-want, +got): %v", diff)
 	}
+	expectedConcurrency, _ := NewConcurrencyFromConfigMap(concurrencyConfig)
+	if diff := cmp.Diff(expectedConcurrency, config.Concurrency); diff != "" {
+		t.Errorf("Unexpected concurrency config (-want, +got): %v", diff)
+	}
 }
 func TestStoreImmutableConfig(t *testing.T) {
 	store := NewStore(logtesting.TestLogger(t))
 	store.OnConfigChanged(test.ConfigMapFromTestFile(t, "config-artifact-bucket"))
+	store.OnConfigChanged(test.ConfigMapFromTestFile(t, "config-concurrency"))
 
 	config := store.Load()
 
 	config.ArtifactBucket.Location = "mutated"
+	config.Concurrency.Namespaces["foo"] = 100
 
 	newConfig := store.Load()
 
 	if newConfig.ArtifactBucket.Location == "mutated" {
 		t.Error("Controller config is not immutable")
 	}
+	if newConfig.Concurrency.Namespaces["foo"] == 100 {
+		t.Error("Concurrency config is not immutable")
+	}
 }
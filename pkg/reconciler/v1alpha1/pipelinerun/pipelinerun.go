@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/knative/pkg/apis"
@@ -51,18 +53,42 @@ const (
 	// ReasonInvalidBindings indicates that the reason for the failure status is that the
 	// PipelineResources bound in the PipelineRun didn't match those declared in the Pipeline
 	ReasonInvalidBindings = "InvalidPipelineResourceBindings"
+	// ReasonInvalidParams indicates that the reason for the failure status is that the
+	// params of the PipelineRun didn't match those declared in the Pipeline
+	ReasonInvalidParams = "InvalidPipelineRunParams"
 	// ReasonCouldntGetTask indicates that the reason for the failure status is that the
 	// associated Pipeline's Tasks couldn't all be retrieved
 	ReasonCouldntGetTask = "CouldntGetTask"
 	// ReasonCouldntGetResource indicates that the reason for the failure status is that the
 	// associated PipelineRun's bound PipelineResources couldn't all be retrieved
 	ReasonCouldntGetResource = "CouldntGetResource"
+	// ReasonCouldntGetCondition indicates that the reason for the failure status is that the
+	// associated Pipeline's Conditions couldn't all be retrieved
+	ReasonCouldntGetCondition = "CouldntGetCondition"
 	// ReasonFailedValidation indicates that the reason for failure status is
 	// that pipelinerun failed runtime validation
 	ReasonFailedValidation = "PipelineValidationFailed"
 	// ReasonInvalidGraph indicates that the reason for the failure status is that the
 	// associated Pipeline is an invalid graph (a.k.a wrong order, cycle, …)
 	ReasonInvalidGraph = "PipelineInvalidGraph"
+	// ReasonInvalidTaskResultReference indicates that the reason for the failure status is that
+	// a PipelineTask refers to a result that the referenced PipelineTask didn't emit
+	ReasonInvalidTaskResultReference = "InvalidTaskResultReference"
+	// ReasonPaused indicates that the reason for the inprogress status is that the
+	// PipelineRun was paused, so no new TaskRuns are started
+	ReasonPaused = "PipelineRunPaused"
+	// ReasonCouldntResume indicates that the reason for the failure status is that the
+	// PipelineRun referred to by resumeFrom couldn't be resumed
+	ReasonCouldntResume = "CouldntResumePipelineRun"
+	// ReasonQueued indicates that the reason for the inprogress status is that the
+	// PipelineRun waits for other PipelineRuns of its namespace to finish before starting
+	ReasonQueued = "PipelineRunQueued"
+	// ReasonSkipped indicates that the reason for the failure status is that the PipelineRun
+	// didn't run because another PipelineRun of its concurrency group was in progress
+	ReasonSkipped = "PipelineRunSkipped"
+	// ReasonSuperseded indicates that the reason for the failure status is that a newer
+	// PipelineRun of the concurrency group of the PipelineRun started before it
+	ReasonSuperseded = "PipelineRunSuperseded"
 	// pipelineRunAgentName defines logging agent name for PipelineRun Controller
 	pipelineRunAgentName = "pipeline-controller"
 	// pipelineRunControllerName defines name for PipelineRun Controller
@@ -89,9 +115,24 @@ type Reconciler struct {
 	taskLister        listers.TaskLister
 	clusterTaskLister listers.ClusterTaskLister
 	resourceLister    listers.PipelineResourceLister
+	conditionLister   listers.ConditionLister
+	approvalLister    listers.ApprovalLister
+	runLister         listers.RunLister
 	tracker           tracker.Interface
 	configStore       configStore
 	timeoutHandler    *reconciler.TimeoutSet
+	enqueue           func(key string)
+
+	// startingPipelineRuns are the keys of the PipelineRuns allowed to start whose start
+	// isn't in the informer's cache yet, guarded by concurrencyMu along with the checks
+	// of the concurrency of the PipelineRuns.
+	concurrencyMu        sync.Mutex
+	startingPipelineRuns map[string]struct{}
+
+	// reportedCommitStatuses are the commit statuses reported, or skipped, by key of
+	// PipelineRun, until they are recorded in the status of the PipelineRuns.
+	commitStatusesMu       sync.Mutex
+	reportedCommitStatuses map[string]*v1alpha1.ReportedCommitStatus
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -106,6 +147,9 @@ func NewController(
 	clusterTaskInformer informers.ClusterTaskInformer,
 	taskRunInformer informers.TaskRunInformer,
 	resourceInformer informers.PipelineResourceInformer,
+	conditionInformer informers.ConditionInformer,
+	approvalInformer informers.ApprovalInformer,
+	runInformer informers.RunInformer,
 	timeoutHandler *reconciler.TimeoutSet,
 ) *controller.Impl {
 
@@ -117,10 +161,17 @@ func NewController(
 		clusterTaskLister: clusterTaskInformer.Lister(),
 		taskRunLister:     taskRunInformer.Lister(),
 		resourceLister:    resourceInformer.Lister(),
+		conditionLister:   conditionInformer.Lister(),
+		approvalLister:    approvalInformer.Lister(),
+		runLister:         runInformer.Lister(),
 		timeoutHandler:    timeoutHandler,
+
+		startingPipelineRuns:   map[string]struct{}{},
+		reportedCommitStatuses: map[string]*v1alpha1.ReportedCommitStatus{},
 	}
 
 	impl := controller.NewImpl(r, r.Logger, pipelineRunControllerName, reconciler.MustNewStatsReporter(pipelineRunControllerName, r.Logger))
+	r.enqueue = impl.EnqueueKey
 
 	r.Logger.Info("Setting up event handlers")
 	pipelineRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -128,11 +179,38 @@ func NewController(
 		UpdateFunc: controller.PassNew(impl.Enqueue),
 		DeleteFunc: impl.Enqueue,
 	})
+	// The PipelineRuns of nested Pipelines update the PipelineRun which created them
+	pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("PipelineRun")),
+		Handler: cache.ResourceEventHandlerFuncs{
+			UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
+		},
+	})
+
+	// The PipelineRuns queued by the concurrency limit of their namespace may start
+	// once another PipelineRun of the namespace is done
+	enqueueQueued := r.enqueueQueuedPipelineRuns(impl)
+	pipelineRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: controller.PassNew(func(obj interface{}) {
+			if pr, ok := obj.(*v1alpha1.PipelineRun); ok && pr.IsDone() {
+				enqueueQueued(obj)
+			}
+		}),
+		DeleteFunc: enqueueQueued,
+	})
 
 	r.tracker = tracker.New(impl.EnqueueKey, 30*time.Minute)
 	taskRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
 	})
+	// The decision of an approver lets the PipelineRun waiting for the Approval go on
+	approvalInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
+	})
+	// The controllers of custom tasks report the outcome of the Runs in their status
+	runInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
+	})
 
 	r.Logger.Info("Setting up ConfigMap receivers")
 	r.configStore = config.NewStore(r.Logger.Named("config-store"))
@@ -165,10 +243,24 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 
 	// Don't modify the informer's copy.
 	pr := original.DeepCopy()
-	if !pr.HasStarted() {
+	waiting := false
+	if !pr.HasStarted() && !pr.IsDone() && !pr.IsCancelled() {
+		// The PipelineRun waits for others to finish, or doesn't run at all, if other
+		// PipelineRuns of its concurrency group are in progress or if its namespace
+		// already runs as many PipelineRuns as it allows
+		if waiting, err = c.checkConcurrency(ctx, pr); err != nil {
+			c.Logger.Errorf("Failed to check the concurrency of PipelineRun %s: %v", pr.Name, err)
+			return err
+		}
+	}
+	if waiting {
+		c.Logger.Infof("PipelineRun %s didn't start: %s", pr.Name, pr.Status.GetCondition(apis.ConditionSucceeded).Message)
+	} else if !pr.HasStarted() {
 		pr.Status.InitializeConditions()
 		// start goroutine to track pipelinerun timeout only startTime is not set
-		go c.timeoutHandler.WaitPipelineRun(pr, pr.Status.StartTime)
+		if !pr.IsPaused() {
+			go c.timeoutHandler.WaitPipelineRun(pr, pr.Status.StartTime)
+		}
 	} else {
 		pr.Status.InitializeConditions()
 	}
@@ -179,7 +271,7 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 			c.Logger.Errorf("Failed to update TaskRun status for PipelineRun %s: %v", pr.Name, err)
 			return err
 		}
-	} else {
+	} else if !waiting {
 		if err := c.tracker.Track(pr.GetTaskRunRef(), pr); err != nil {
 			c.Logger.Errorf("Failed to create tracker for TaskRuns for PipelineRun %s: %v", pr.Name, err)
 			c.Recorder.Event(pr, corev1.EventTypeWarning, eventReasonFailed, "Failed to create tracker for TaskRuns for PipelineRun")
@@ -194,15 +286,26 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 		}
 	}
 
+	// The commit status is reported first so that the result of a previous report is
+	// recorded along with the rest of the status.
+	c.reportCommitStatus(pr)
+
 	if equality.Semantic.DeepEqual(original.Status, pr.Status) {
 		// If we didn't change anything then don't call updateStatus.
 		// This is important because the copy we loaded from the informer's
 		// cache may be stale and we don't want to overwrite a prior update
 		// to status with this stale state.
-	} else if _, err := c.updateStatus(pr); err != nil {
-		c.Logger.Warn("Failed to update PipelineRun status", zap.Error(err))
-		c.Recorder.Event(pr, corev1.EventTypeWarning, eventReasonFailed, "PipelineRun failed to update")
-		return err
+	} else {
+		// updateStatus writes the status to the informer's copy as well
+		before := original.DeepCopy()
+		if _, err := c.updateStatus(pr); err != nil {
+			c.Logger.Warn("Failed to update PipelineRun status", zap.Error(err))
+			c.Recorder.Event(pr, corev1.EventTypeWarning, eventReasonFailed, "PipelineRun failed to update")
+			return err
+		}
+		// Only notify the changes of state once they are stored, since the update
+		// could be rejected and the state computed again.
+		reconciler.EmitCloudEvent(c.CloudEvents, before, pr)
 	}
 	// Since we are using the status subresource, it is not possible to update
 	// the status and labels simultaneously.
@@ -218,7 +321,7 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 }
 
 func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) error {
-	p, err := c.pipelineLister.Pipelines(pr.Namespace).Get(pr.Spec.PipelineRef.Name)
+	p, err := c.getPipeline(pr)
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.SetCondition(&apis.Condition{
@@ -231,7 +334,32 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		return nil
 	}
 
-	p = p.DeepCopy()
+	// On its first reconcile, a resumed PipelineRun adopts the successful TaskRuns of
+	// the PipelineRun it resumes from, so that they aren't run again
+	if pr.IsResumed() && pr.Status.ArtifactStorage == "" {
+		adopted, err := c.adoptResumedTaskRuns(pr, p)
+		if err != nil {
+			// This Run has failed, so we need to mark it as failed and stop reconciling it
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: ReasonCouldntResume,
+				Message: fmt.Sprintf("PipelineRun %s can't resume from PipelineRun %s: %s",
+					fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), pr.Spec.ResumeFrom.Name, err),
+			})
+			return nil
+		}
+		// The adopted TaskRuns are owned by pr too, so that they aren't deleted with the
+		// PipelineRun it resumes from while pr still needs them
+		for _, tr := range adopted {
+			if !pr.AddSharedOwnerReference(tr) {
+				continue
+			}
+			if _, err := c.PipelineClientSet.TektonV1alpha1().TaskRuns(tr.Namespace).Update(tr); err != nil {
+				return fmt.Errorf("failed to add PipelineRun %s as an owner of TaskRun %s: %v", pr.Name, tr.Name, err)
+			}
+		}
+	}
 
 	d, err := v1alpha1.BuildDAG(p.Spec.Tasks)
 	if err != nil {
@@ -245,6 +373,37 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		})
 		return nil
 	}
+	dfinally, err := v1alpha1.BuildDAG(p.Spec.Finally)
+	if err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: ReasonInvalidGraph,
+			Message: fmt.Sprintf("PipelineRun %s's Pipeline finally Tasks are invalid: %s",
+				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), err),
+		})
+		return nil
+	}
+	// The Pipelines run by PipelineTasks can't end up running the Pipeline of pr again
+	pipelineName := ""
+	if pr.Spec.PipelineRef != nil {
+		pipelineName = pr.Spec.PipelineRef.Name
+	}
+	getPipeline := func(name string) (*v1alpha1.Pipeline, error) {
+		return c.pipelineLister.Pipelines(pr.Namespace).Get(name)
+	}
+	if err := v1alpha1.CheckPipelineRefCycles(pipelineName, append(append([]v1alpha1.PipelineTask{}, p.Spec.Tasks...), p.Spec.Finally...), getPipeline); err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: ReasonInvalidGraph,
+			Message: fmt.Sprintf("PipelineRun %s's nested Pipelines are invalid: %s",
+				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), err),
+		})
+		return nil
+	}
 	providedResources, err := resources.GetResourcesFromBindings(p, pr)
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
@@ -253,7 +412,19 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 			Status: corev1.ConditionFalse,
 			Reason: ReasonInvalidBindings,
 			Message: fmt.Sprintf("PipelineRun %s doesn't bind Pipeline %s's PipelineResources correctly: %s",
-				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), fmt.Sprintf("%s/%s", pr.Namespace, pr.Spec.PipelineRef.Name), err),
+				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), fmt.Sprintf("%s/%s", pr.Namespace, p.Name), err),
+		})
+		return nil
+	}
+
+	if err := v1alpha1.ValidatePipelineParams(p.Spec.Params, pr.Spec.Params); err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: ReasonInvalidParams,
+			Message: fmt.Sprintf("PipelineRun %s doesn't provide Pipeline %s's params correctly: %s",
+				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), fmt.Sprintf("%s/%s", pr.Namespace, p.Name), err),
 		})
 		return nil
 	}
@@ -279,7 +450,8 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 			return c.clusterTaskLister.Get(name)
 		},
 		c.resourceLister.PipelineResources(pr.Namespace).Get,
-		p.Spec.Tasks, providedResources,
+		c.conditionLister.Conditions(pr.Namespace).Get,
+		append(append([]v1alpha1.PipelineTask{}, p.Spec.Tasks...), p.Spec.Finally...), providedResources,
 	)
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
@@ -300,6 +472,14 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 				Message: fmt.Sprintf("PipelineRun %s can't be Run; it tries to bind Resources that don't exist: %s",
 					fmt.Sprintf("%s/%s", p.Namespace, pr.Name), err),
 			})
+		case *resources.ConditionNotFoundError:
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: ReasonCouldntGetCondition,
+				Message: fmt.Sprintf("PipelineRun %s can't be Run; it contains Conditions that don't exist: %s",
+					fmt.Sprintf("%s/%s", p.Namespace, pr.Name), err),
+			})
 		default:
 			pr.Status.SetCondition(&apis.Condition{
 				Type:   apis.ConditionSucceeded,
@@ -324,6 +504,11 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	}
 
 	for _, rprt := range pipelineState {
+		if rprt.IsNested() || rprt.IsApproval() || rprt.IsCustomTask() {
+			// The PipelineRun of a nested Pipeline validates it, an approval runs nothing and
+			// the controller of a custom task validates its Run
+			continue
+		}
 		err := taskrun.ValidateResolvedTaskResources(rprt.PipelineTask.Params, rprt.ResolvedTaskResources)
 		if err != nil {
 			c.Logger.Errorf("Failed to validate pipelinerun %q with error %v", pr.Name, err)
@@ -342,17 +527,81 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	if err != nil {
 		return fmt.Errorf("Error getting TaskRuns for Pipeline %s: %s", p.Name, err)
 	}
+	err = resources.ResolvePipelineRuns(c.pipelineRunLister.PipelineRuns(pr.Namespace).Get, pipelineState)
+	if err != nil {
+		return fmt.Errorf("Error getting PipelineRuns for Pipeline %s: %s", p.Name, err)
+	}
+	err = resources.ResolveApprovals(c.approvalLister.Approvals(pr.Namespace).Get, pipelineState)
+	if err != nil {
+		return fmt.Errorf("Error getting Approvals for Pipeline %s: %s", p.Name, err)
+	}
+	err = resources.ResolveRuns(c.runLister.Runs(pr.Namespace).Get, pipelineState)
+	if err != nil {
+		return fmt.Errorf("Error getting Runs for Pipeline %s: %s", p.Name, err)
+	}
 
 	// If the pipelinerun is cancelled, cancel tasks and update status
 	if pr.IsCancelled() {
 		return cancelPipelineRun(pr, pipelineState, c.PipelineClientSet)
 	}
 
-	candidateTasks, err := dag.GetSchedulable(d, pipelineState.SuccessfulPipelineTaskNames()...)
-	if err != nil {
-		c.Logger.Errorf("Error getting potential next tasks for valid pipelinerun %s: %v", pr.Name, err)
+	// If the pipelinerun is paused or was just resumed, stop or restart its clock
+	c.updatePausedStatus(pr)
+
+	// The Approvals waiting for a decision are done once an approver decided or they timed out
+	for _, rprt := range pipelineState {
+		if rprt.Approval != nil && !rprt.Approval.IsDone() {
+			if err := c.reconcileApproval(rprt); err != nil {
+				return fmt.Errorf("error updating the status of Approval %s for PipelineTask %s from PipelineRun %s: %s", rprt.ApprovalName, rprt.PipelineTask.Name, pr.Name, err)
+			}
+		}
+	}
+
+	// The Runs of custom tasks are timed out if their controller didn't complete them in time
+	for _, rprt := range pipelineState {
+		if rprt.Run == nil {
+			continue
+		}
+		if rprt.Run.IsDone() {
+			c.timeoutHandler.Release(rprt.Run)
+			continue
+		}
+		if err := c.reconcileRun(rprt); err != nil {
+			return fmt.Errorf("error updating the status of Run %s for PipelineTask %s from PipelineRun %s: %s", rprt.RunName, rprt.PipelineTask.Name, pr.Name, err)
+		}
+	}
+
+	// Failed TaskRuns with retries left are replaced by new ones before scheduling
+	for _, name := range resources.PrepareRetries(pr, pipelineState) {
+		c.Logger.Infof("TaskRun %s for PipelineRun %s has failed and will be retried", name, pr.Name)
+	}
+
+	// With the FailFast policy, the TaskRuns still running are cancelled as soon as one has failed
+	if failed := pipelineState.GetFailedTask(d); failed != nil && pr.Spec.FailurePolicy == v1alpha1.PipelineRunFailurePolicyFailFast {
+		if err := failFast(pr, pipelineState, d, c.PipelineClientSet); err != nil {
+			c.Recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCancellationFailed", "Failed to cancel TaskRuns after %s failed: %v", failed, err)
+			return err
+		}
 	}
-	rprts := pipelineState.GetNextTasks(candidateTasks)
+
+	var rprts []*resources.ResolvedPipelineRunTask
+	if pr.IsPaused() {
+		c.Logger.Infof("PipelineRun %s is paused, so no new TaskRuns will be created", pr.Name)
+	} else if pipelineState.IsDAGDone(d, pr.Spec.FailurePolicy) {
+		// The finally tasks don't depend on each other, so they are all scheduled at once
+		candidateTasks, err := dag.GetSchedulable(dfinally)
+		if err != nil {
+			c.Logger.Errorf("Error getting finally tasks for valid pipelinerun %s: %v", pr.Name, err)
+		}
+		rprts = pipelineState.GetNextTasks(candidateTasks)
+	} else if pipelineState.GetFailedTask(d) == nil || pr.Spec.FailurePolicy == v1alpha1.PipelineRunFailurePolicyContinueIndependent {
+		candidateTasks, err := dag.GetSchedulable(d, pipelineState.SuccessfulPipelineTaskNames()...)
+		if err != nil {
+			c.Logger.Errorf("Error getting potential next tasks for valid pipelinerun %s: %v", pr.Name, err)
+		}
+		rprts = pipelineState.GetNextTasks(candidateTasks)
+	}
+	rprts = pipelineState.LimitParallelTasks(rprts, pr.Spec.MaxParallelTasks)
 
 	var as artifacts.ArtifactStorageInterface
 	if as, err = artifacts.InitializeArtifactStorage(pr, c.KubeClientSet, c.Logger); err != nil {
@@ -361,7 +610,74 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	}
 
 	for _, rprt := range rprts {
-		if rprt != nil {
+		if rprt != nil && !rprt.ResolvedConditionChecks.IsSuccess() {
+			// The conditions need to be evaluated before the TaskRun can be created
+			for _, rcc := range rprt.ResolvedConditionChecks {
+				if rcc.ConditionCheck != nil {
+					continue
+				}
+				c.Logger.Infof("Creating a new ConditionCheck object %s", rcc.ConditionCheckName)
+				rcc.ConditionCheck, err = c.createConditionCheck(rcc, pr)
+				if err != nil {
+					c.Recorder.Eventf(pr, corev1.EventTypeWarning, "ConditionCheckCreationFailed", "Failed to create ConditionCheck %q: %v", rcc.ConditionCheckName, err)
+					return fmt.Errorf("error creating ConditionCheck called %s for PipelineTask %s from PipelineRun %s: %s", rcc.ConditionCheckName, rprt.PipelineTask.Name, pr.Name, err)
+				}
+			}
+		} else if rprt != nil {
+			if err := resources.ApplyTaskResults(rprt, pipelineState); err != nil {
+				// This Run has failed, so we need to mark it as failed and stop reconciling it
+				pr.Status.SetCondition(&apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: ReasonInvalidTaskResultReference,
+					Message: fmt.Sprintf("PipelineRun %s can't be Run; couldn't resolve task result references: %s",
+						fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), err),
+				})
+				return nil
+			}
+			if rprt.IsNested() {
+				c.Logger.Infof("Creating a new PipelineRun object %s", rprt.PipelineRunName)
+				rprt.PipelineRun, err = c.createChildPipelineRun(rprt, pr, providedResources)
+				if err != nil {
+					c.Recorder.Eventf(pr, corev1.EventTypeWarning, "PipelineRunCreationFailed", "Failed to create PipelineRun %q: %v", rprt.PipelineRunName, err)
+					return fmt.Errorf("error creating PipelineRun called %s for PipelineTask %s from PipelineRun %s: %s", rprt.PipelineRunName, rprt.PipelineTask.Name, pr.Name, err)
+				}
+				continue
+			}
+			if rprt.IsApproval() {
+				if !hasTimeLeft(pr, rprt.PipelineTask) {
+					// The gate times out along with pr rather than waiting for a decision
+					c.Logger.Infof("Not creating Approval %s since PipelineRun %s has no time left", rprt.ApprovalName, pr.Name)
+					continue
+				}
+				c.Logger.Infof("Creating a new Approval object %s", rprt.ApprovalName)
+				rprt.Approval, err = c.createApproval(rprt, pr)
+				if err != nil {
+					c.Recorder.Eventf(pr, corev1.EventTypeWarning, "ApprovalCreationFailed", "Failed to create Approval %q: %v", rprt.ApprovalName, err)
+					return fmt.Errorf("error creating Approval called %s for PipelineTask %s from PipelineRun %s: %s", rprt.ApprovalName, rprt.PipelineTask.Name, pr.Name, err)
+				}
+				if err := c.reconcileApproval(rprt); err != nil {
+					return fmt.Errorf("error updating the status of Approval %s for PipelineTask %s from PipelineRun %s: %s", rprt.ApprovalName, rprt.PipelineTask.Name, pr.Name, err)
+				}
+				continue
+			}
+			if rprt.IsCustomTask() {
+				if !hasTimeLeft(pr, rprt.PipelineTask) {
+					// The custom task times out along with pr rather than being run
+					c.Logger.Infof("Not creating Run %s since PipelineRun %s has no time left", rprt.RunName, pr.Name)
+					continue
+				}
+				c.Logger.Infof("Creating a new Run object %s", rprt.RunName)
+				rprt.Run, err = c.createRun(rprt, pr)
+				if err != nil {
+					c.Recorder.Eventf(pr, corev1.EventTypeWarning, "RunCreationFailed", "Failed to create Run %q: %v", rprt.RunName, err)
+					return fmt.Errorf("error creating Run called %s for PipelineTask %s from PipelineRun %s: %s", rprt.RunName, rprt.PipelineTask.Name, pr.Name, err)
+				}
+				if err := c.reconcileRun(rprt); err != nil {
+					return fmt.Errorf("error updating the status of Run %s for PipelineTask %s from PipelineRun %s: %s", rprt.RunName, rprt.PipelineTask.Name, pr.Name, err)
+				}
+				continue
+			}
 			c.Logger.Infof("Creating a new TaskRun object %s", rprt.TaskRunName)
 			rprt.TaskRun, err = c.createTaskRun(c.Logger, rprt, pr, as.StorageBasePath(pr))
 			if err != nil {
@@ -371,11 +687,29 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		}
 	}
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
-	after := resources.GetPipelineConditionStatus(pr.Name, pipelineState, c.Logger, pr.Status.StartTime, pr.Spec.Timeout)
+	after := resources.GetPipelineConditionStatus(pr.Name, pipelineState, c.Logger, pr.Status.StartTime, getPipelineRunTimeout(pr), d, dfinally, pr.Spec.FailurePolicy)
+	if pr.IsPaused() && after.IsUnknown() {
+		after.Reason = ReasonPaused
+		after.Message = fmt.Sprintf("PipelineRun %q is paused", pr.Name)
+	}
 	pr.Status.SetCondition(after)
 	reconciler.EmitEvent(c.Recorder, before, after, pr)
 
+	// The PipelineRuns of nested Pipelines, the Approvals and the Runs don't outlive pr
+	if after.Reason == resources.ReasonTimedOut {
+		if errs := cancelChildPipelineRuns(pipelineState, pr.Namespace, c.PipelineClientSet); len(errs) > 0 {
+			c.Logger.Errorf("Failed to cancel the PipelineRuns of PipelineRun %s after it timed out: %s", pr.Name, strings.Join(errs, "\n"))
+		}
+		if errs := cancelApprovals(pipelineState, pr.Namespace, c.PipelineClientSet); len(errs) > 0 {
+			c.Logger.Errorf("Failed to cancel the Approvals of PipelineRun %s after it timed out: %s", pr.Name, strings.Join(errs, "\n"))
+		}
+		if errs := cancelRuns(pipelineState, pr.Namespace, c.PipelineClientSet); len(errs) > 0 {
+			c.Logger.Errorf("Failed to cancel the Runs of PipelineRun %s after it timed out: %s", pr.Name, strings.Join(errs, "\n"))
+		}
+	}
+
 	updateTaskRunsStatus(pr, pipelineState)
+	pr.Status.SkippedTasks = pipelineState.GetSkippedTasks(d, pr.Spec.FailurePolicy)
 
 	c.Logger.Infof("PipelineRun %s status is being set to %s", pr.Name, pr.Status.GetCondition(apis.ConditionSucceeded))
 	return nil
@@ -383,16 +717,66 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 
 func updateTaskRunsStatus(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask) {
 	for _, rprt := range pipelineState {
+		if rprt.PipelineRun != nil {
+			if pr.Status.PipelineRuns == nil {
+				pr.Status.PipelineRuns = make(map[string]*v1alpha1.PipelineRunPipelineRunStatus)
+			}
+			pr.Status.PipelineRuns[rprt.PipelineRun.Name] = &v1alpha1.PipelineRunPipelineRunStatus{
+				PipelineTaskName: rprt.PipelineTask.Name,
+				Status:           &rprt.PipelineRun.Status,
+			}
+		}
+		if rprt.Approval != nil {
+			if pr.Status.Approvals == nil {
+				pr.Status.Approvals = make(map[string]*v1alpha1.PipelineRunApprovalStatus)
+			}
+			pr.Status.Approvals[rprt.Approval.Name] = &v1alpha1.PipelineRunApprovalStatus{
+				PipelineTaskName: rprt.PipelineTask.Name,
+				Decision:         rprt.Approval.Spec.Decision,
+				Approver:         rprt.Approval.Spec.Approver,
+				Status:           &rprt.Approval.Status,
+			}
+		}
+		if rprt.Run != nil {
+			if pr.Status.Runs == nil {
+				pr.Status.Runs = make(map[string]*v1alpha1.PipelineRunRunStatus)
+			}
+			pr.Status.Runs[rprt.Run.Name] = &v1alpha1.PipelineRunRunStatus{
+				PipelineTaskName: rprt.PipelineTask.Name,
+				Status:           &rprt.Run.Status,
+			}
+		}
 		if rprt.TaskRun != nil {
 			prtrs := pr.Status.TaskRuns[rprt.TaskRun.Name]
 			if prtrs == nil {
 				prtrs = &v1alpha1.PipelineRunTaskRunStatus{
 					PipelineTaskName: rprt.PipelineTask.Name,
+					MatrixParams:     rprt.MatrixParams,
 				}
 				pr.Status.TaskRuns[rprt.TaskRun.Name] = prtrs
 			}
 			prtrs.Status = &rprt.TaskRun.Status
 		}
+		for _, rcc := range rprt.ResolvedConditionChecks {
+			if rcc.ConditionCheck == nil {
+				continue
+			}
+			prtrs := pr.Status.TaskRuns[rprt.TaskRunName]
+			if prtrs == nil {
+				prtrs = &v1alpha1.PipelineRunTaskRunStatus{
+					PipelineTaskName: rprt.PipelineTask.Name,
+					MatrixParams:     rprt.MatrixParams,
+				}
+				pr.Status.TaskRuns[rprt.TaskRunName] = prtrs
+			}
+			if prtrs.ConditionChecks == nil {
+				prtrs.ConditionChecks = make(map[string]*v1alpha1.PipelineRunConditionCheckStatus)
+			}
+			prtrs.ConditionChecks[rcc.ConditionCheckName] = &v1alpha1.PipelineRunConditionCheckStatus{
+				ConditionName: rcc.Condition.Name,
+				Status:        &rcc.ConditionCheck.Status,
+			}
+		}
 	}
 }
 
@@ -408,62 +792,297 @@ func (c *Reconciler) updateTaskRunsStatusDirectly(pr *v1alpha1.PipelineRun) erro
 		} else {
 			prtrs.Status = &tr.Status
 		}
+		for name, cc := range prtrs.ConditionChecks {
+			cctr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(name)
+			if err != nil {
+				if !errors.IsNotFound(err) {
+					return fmt.Errorf("error retrieving ConditionCheck %s: %s", name, err)
+				}
+			} else {
+				cc.Status = &cctr.Status
+			}
+		}
+	}
+	for pipelineRunName, prprs := range pr.Status.PipelineRuns {
+		child, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pipelineRunName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving PipelineRun %s: %s", pipelineRunName, err)
+			}
+		} else {
+			prprs.Status = &child.Status
+		}
+	}
+	for approvalName, pras := range pr.Status.Approvals {
+		a, err := c.approvalLister.Approvals(pr.Namespace).Get(approvalName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving Approval %s: %s", approvalName, err)
+			}
+		} else {
+			pras.Decision = a.Spec.Decision
+			pras.Approver = a.Spec.Approver
+			pras.Status = &a.Status
+		}
+	}
+	for runName, prrs := range pr.Status.Runs {
+		r, err := c.runLister.Runs(pr.Namespace).Get(runName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving Run %s: %s", runName, err)
+			}
+		} else {
+			prrs.Status = &r.Status
+		}
 	}
 
 	return nil
 }
 
 func (c *Reconciler) createTaskRun(logger *zap.SugaredLogger, rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, storageBasePath string) (*v1alpha1.TaskRun, error) {
-	var taskRunTimeout = &metav1.Duration{Duration: 0 * time.Second}
-	if pr.Spec.Timeout != nil {
-		pTimeoutTime := pr.Status.StartTime.Add(pr.Spec.Timeout.Duration)
-		if time.Now().After(pTimeoutTime) {
-			// Just in case something goes awry and we're creating the TaskRun after it should have already timed out,
-			// set a timeout of 0.
-			taskRunTimeout := pTimeoutTime.Sub(time.Now())
-			if taskRunTimeout < 0 {
-				taskRunTimeout = 0
-			}
-		} else {
-			taskRunTimeout = pr.Spec.Timeout
+	tr := &v1alpha1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.TaskRunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: pr.GetOwnerReference(),
+			Labels:          getTaskRunLabels(pr),
+		},
+		Spec: v1alpha1.TaskRunSpec{
+			Inputs: v1alpha1.TaskRunInputs{
+				Params: rprt.PipelineTask.Params,
+			},
+			ServiceAccount: pr.Spec.ServiceAccount,
+			Timeout:        getTaskRunTimeout(pr, rprt.PipelineTask),
+			PendingTimeout: rprt.PipelineTask.PendingTimeout,
+			NodeSelector:   pr.Spec.NodeSelector,
+			Tolerations:    pr.Spec.Tolerations,
+			Affinity:       pr.Spec.Affinity,
+			CloudEventSink: pr.Spec.CloudEventSink,
+		}}
+
+	// The resolved Task is embedded in the TaskRun, so that it runs the Task as it was
+	// when the PipelineRun resolved it. A referenced Task is still recorded in the labels
+	// the TaskRun would have been given from it.
+	tr.Spec.TaskSpec = rprt.ResolvedTaskResources.TaskSpec
+	if rprt.PipelineTask.TaskSpec == nil {
+		for key, val := range rprt.TaskLabels {
+			tr.ObjectMeta.Labels[key] = val
 		}
-	} else {
-		taskRunTimeout = nil
+		tr.ObjectMeta.Labels[pipeline.GroupName+pipeline.TaskLabelKey] = rprt.ResolvedTaskResources.TaskName
 	}
 
-	// Propagate labels from PipelineRun to TaskRun.
-	labels := make(map[string]string, len(pr.ObjectMeta.Labels)+1)
-	for key, val := range pr.ObjectMeta.Labels {
-		labels[key] = val
+	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, storageBasePath)
+
+	return c.PipelineClientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Create(tr)
+}
+
+// createChildPipelineRun creates the PipelineRun running the Pipeline of the PipelineTask of rprt,
+// passing it the params of the PipelineTask and binding its resources to the ones of pr.
+func (c *Reconciler) createChildPipelineRun(rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, providedResources map[string]v1alpha1.PipelineResourceRef) (*v1alpha1.PipelineRun, error) {
+	child := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.PipelineRunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: pr.GetOwnerReference(),
+			Labels:          getTaskRunLabels(pr),
+		},
+		Spec: v1alpha1.PipelineRunSpec{
+			PipelineRef:    &v1alpha1.PipelineRef{Name: rprt.PipelineTask.PipelineRef.Name},
+			Params:         rprt.PipelineTask.Params,
+			Trigger:        pr.Spec.Trigger,
+			ServiceAccount: pr.Spec.ServiceAccount,
+			Timeout:        getTaskRunTimeout(pr, rprt.PipelineTask),
+			NodeSelector:   pr.Spec.NodeSelector,
+			Tolerations:    pr.Spec.Tolerations,
+			Affinity:       pr.Spec.Affinity,
+			CloudEventSink: pr.Spec.CloudEventSink,
+		},
 	}
-	labels[pipeline.GroupName+pipeline.PipelineRunLabelKey] = pr.Name
+	if res := rprt.PipelineTask.Resources; res != nil {
+		for _, input := range res.Inputs {
+			child.Spec.Resources = append(child.Spec.Resources, v1alpha1.PipelineResourceBinding{
+				Name:        input.Name,
+				ResourceRef: providedResources[input.Resource],
+			})
+		}
+		for _, output := range res.Outputs {
+			child.Spec.Resources = append(child.Spec.Resources, v1alpha1.PipelineResourceBinding{
+				Name:        output.Name,
+				ResourceRef: providedResources[output.Resource],
+			})
+		}
+	}
+
+	return c.PipelineClientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Create(child)
+}
+
+// createConditionCheck creates the TaskRun evaluating the condition rcc, which runs the
+// check of the Condition as its only step.
+func (c *Reconciler) createConditionCheck(rcc *resources.ResolvedConditionCheck, pr *v1alpha1.PipelineRun) (*v1alpha1.TaskRun, error) {
+	labels := getTaskRunLabels(pr)
+	labels[pipeline.GroupName+pipeline.ConditionCheckKey] = rcc.ConditionCheckName
+	labels[pipeline.GroupName+pipeline.ConditionNameKey] = rcc.Condition.Name
 
 	tr := &v1alpha1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            rprt.TaskRunName,
+			Name:            rcc.ConditionCheckName,
 			Namespace:       pr.Namespace,
 			OwnerReferences: pr.GetOwnerReference(),
 			Labels:          labels,
 		},
 		Spec: v1alpha1.TaskRunSpec{
-			TaskRef: &v1alpha1.TaskRef{
-				Name: rprt.ResolvedTaskResources.TaskName,
-			},
+			TaskSpec: rcc.Condition.Spec.TaskSpec(),
 			Inputs: v1alpha1.TaskRunInputs{
-				Params: rprt.PipelineTask.Params,
+				Params:    rcc.PipelineTaskCondition.Params,
+				Resources: rcc.Inputs,
 			},
 			ServiceAccount: pr.Spec.ServiceAccount,
-			Timeout:        taskRunTimeout,
+			Timeout:        getTaskRunTimeout(pr, nil),
 			NodeSelector:   pr.Spec.NodeSelector,
 			Tolerations:    pr.Spec.Tolerations,
 			Affinity:       pr.Spec.Affinity,
 		}}
 
-	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, storageBasePath)
-
 	return c.PipelineClientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Create(tr)
 }
 
+// getPipeline returns the Pipeline run by pr, which is either embedded in pr or
+// referenced by it. An embedded Pipeline is named after pr.
+func (c *Reconciler) getPipeline(pr *v1alpha1.PipelineRun) (*v1alpha1.Pipeline, error) {
+	if pr.Spec.PipelineSpec != nil {
+		return &v1alpha1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pr.Name,
+				Namespace: pr.Namespace,
+			},
+			Spec: *pr.Spec.PipelineSpec.DeepCopy(),
+		}, nil
+	}
+	p, err := c.pipelineLister.Pipelines(pr.Namespace).Get(pr.Spec.PipelineRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	return p.DeepCopy(), nil
+}
+
+// getTaskRunLabels returns the labels of the TaskRuns created for pr, which are
+// propagated from the PipelineRun.
+func getTaskRunLabels(pr *v1alpha1.PipelineRun) map[string]string {
+	labels := make(map[string]string, len(pr.ObjectMeta.Labels)+1)
+	for key, val := range pr.ObjectMeta.Labels {
+		labels[key] = val
+	}
+	labels[pipeline.GroupName+pipeline.PipelineRunLabelKey] = pr.Name
+	if pr.Status.ArtifactStorage != "" {
+		labels[pipeline.GroupName+pipeline.ArtifactStorageKey] = pr.Status.ArtifactStorage
+	}
+	return labels
+}
+
+// adoptResumedTaskRuns copies the TaskRuns of the PipelineTasks of p which succeeded in
+// the PipelineRun pr resumes from into the status of pr, and records that pr shares the
+// artifact storage of that PipelineRun. The finally tasks are always run again. It returns
+// copies of the TaskRuns adopted.
+func (c *Reconciler) adoptResumedTaskRuns(pr *v1alpha1.PipelineRun, p *v1alpha1.Pipeline) ([]*v1alpha1.TaskRun, error) {
+	previous, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Spec.ResumeFrom.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !equality.Semantic.DeepEqual(previous.Spec.PipelineRef, pr.Spec.PipelineRef) ||
+		!equality.Semantic.DeepEqual(previous.Spec.PipelineSpec, pr.Spec.PipelineSpec) {
+		return nil, fmt.Errorf("it doesn't run the same Pipeline")
+	}
+	if !previous.IsDone() {
+		return nil, fmt.Errorf("it is still running")
+	}
+
+	tasks := map[string]struct{}{}
+	for _, pt := range p.Spec.Tasks {
+		tasks[pt.Name] = struct{}{}
+	}
+	if pr.Status.TaskRuns == nil {
+		pr.Status.TaskRuns = make(map[string]*v1alpha1.PipelineRunTaskRunStatus)
+	}
+	var adopted []*v1alpha1.TaskRun
+	for name, prtrs := range previous.Status.TaskRuns {
+		if _, ok := tasks[prtrs.PipelineTaskName]; !ok || prtrs.Status == nil {
+			continue
+		}
+		if !prtrs.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+			continue
+		}
+		tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(name)
+		if errors.IsNotFound(err) {
+			c.Logger.Infof("PipelineRun %s runs TaskRun %s of PipelineRun %s again: it doesn't exist anymore", pr.Name, name, previous.Name)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		c.Logger.Infof("PipelineRun %s reuses TaskRun %s of PipelineRun %s", pr.Name, name, previous.Name)
+		pr.Status.TaskRuns[name] = prtrs.DeepCopy()
+		adopted = append(adopted, tr.DeepCopy())
+	}
+	pr.Status.ArtifactStorage = previous.GetArtifactStorageName()
+	return adopted, nil
+}
+
+// getPipelineRunTimeout returns the timeout of pr extended by the time it spent paused,
+// since its clock is stopped while it is paused.
+func getPipelineRunTimeout(pr *v1alpha1.PipelineRun) *metav1.Duration {
+	if pr.Spec.Timeout == nil {
+		return nil
+	}
+	return &metav1.Duration{Duration: pr.Spec.Timeout.Duration + pr.GetPausedDuration()}
+}
+
+// getTaskRunTimeout returns the timeout of the TaskRun created for the PipelineTask pt of pr,
+// or of the PipelineRun if pt runs a Pipeline: the timeout of pt if it has one, clamped to the
+// time pr has left so that the run doesn't outlive pr. pt is nil for the ConditionChecks.
+func getTaskRunTimeout(pr *v1alpha1.PipelineRun, pt *v1alpha1.PipelineTask) *metav1.Duration {
+	var timeout *metav1.Duration
+	if pt != nil && pt.Timeout != nil {
+		timeout = &metav1.Duration{Duration: pt.Timeout.Duration}
+	}
+	if pr.Spec.Timeout == nil {
+		return timeout
+	}
+	pTimeoutTime := pr.Status.StartTime.Add(pr.Spec.Timeout.Duration + pr.GetPausedDuration())
+	// Just in case something goes awry and we're creating the TaskRun after it should have already timed out,
+	// set a timeout of 0.
+	remaining := pTimeoutTime.Sub(time.Now())
+	if remaining < 0 {
+		remaining = 0
+	}
+	if timeout == nil || remaining < timeout.Duration {
+		return &metav1.Duration{Duration: remaining}
+	}
+	return timeout
+}
+
+// hasTimeLeft returns false if pr has timed out by the time the PipelineTask pt would start,
+// in which case pt isn't started, since its timeout would be 0.
+func hasTimeLeft(pr *v1alpha1.PipelineRun, pt *v1alpha1.PipelineTask) bool {
+	timeout := getTaskRunTimeout(pr, pt)
+	return timeout == nil || timeout.Duration > 0
+}
+
+// updatePausedStatus records when pr is paused and resumed. Its timeout timer is stopped
+// while it is paused, and started again with the time spent paused added to the timeout
+// once it is resumed.
+func (c *Reconciler) updatePausedStatus(pr *v1alpha1.PipelineRun) {
+	switch {
+	case pr.IsPaused() && pr.Status.PausedTime == nil:
+		c.Logger.Infof("PipelineRun %s has been paused", pr.Name)
+		pr.Status.PausedTime = &metav1.Time{Time: time.Now()}
+		c.timeoutHandler.Release(pr)
+	case !pr.IsPaused() && pr.Status.PausedTime != nil:
+		c.Logger.Infof("PipelineRun %s has been resumed", pr.Name)
+		pr.Status.PausedDuration = &metav1.Duration{Duration: pr.GetPausedDuration()}
+		pr.Status.PausedTime = nil
+		go c.timeoutHandler.WaitPipelineRun(pr.DeepCopy(), pr.Status.StartTime)
+	}
+}
+
 func (c *Reconciler) updateStatus(pr *v1alpha1.PipelineRun) (*v1alpha1.PipelineRun, error) {
 	newPr, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Name)
 	if err != nil {
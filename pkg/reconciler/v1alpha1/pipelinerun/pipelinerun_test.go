@@ -15,6 +15,7 @@ package pipelinerun
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -26,7 +27,9 @@ import (
 	"github.com/knative/pkg/configmap"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/commitstatus"
 	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun/config"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun/resources"
 	taskrunresources "github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/system"
@@ -63,6 +66,7 @@ func getPipelineRunController(d test.Data, recorder record.EventRecorder) test.T
 				PipelineClientSet: c.Pipeline,
 				Recorder:          recorder,
 				ConfigMapWatcher:  configMapWatcher,
+				CommitStatuses:    commitstatus.NewQueue(logger, stopCh),
 			},
 			i.PipelineRun,
 			i.Pipeline,
@@ -70,6 +74,9 @@ func getPipelineRunController(d test.Data, recorder record.EventRecorder) test.T
 			i.ClusterTask,
 			i.TaskRun,
 			i.PipelineResource,
+			i.Condition,
+			i.Approval,
+			i.Run,
 			th,
 		),
 		Logs:      logs,
@@ -101,6 +108,7 @@ func TestReconcile(t *testing.T) {
 				tb.PipelineDeclaredResource("best-image", "image"),
 				tb.PipelineParam("pipeline-param", tb.PipelineParamDefault("somethingdifferent")),
 				tb.PipelineParam("rev-param", tb.PipelineParamDefault("revision")),
+				tb.PipelineParam("bar"),
 				// unit-test-3 uses runAfter to indicate it should run last
 				tb.PipelineTask("unit-test-3", "unit-test-task",
 					funParam, moreFunParam, templatedParam,
@@ -210,8 +218,18 @@ func TestReconcile(t *testing.T) {
 		),
 		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
 		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-success"),
+		tb.TaskRunLabel("tekton.dev/task", "unit-test-task"),
 		tb.TaskRunSpec(
-			tb.TaskRunTaskRef("unit-test-task"),
+			tb.TaskRunTaskSpec(
+				tb.TaskInputs(
+					tb.InputsResource("workspace", v1alpha1.PipelineResourceTypeGit),
+					tb.InputsParam("foo"), tb.InputsParam("bar"), tb.InputsParam("templatedparam"),
+				),
+				tb.TaskOutputs(
+					tb.OutputsResource("image-to-use", v1alpha1.PipelineResourceTypeImage),
+					tb.OutputsResource("workspace", v1alpha1.PipelineResourceTypeGit),
+				),
+			),
 			tb.TaskRunServiceAccount("test-sa"),
 			tb.TaskRunInputs(
 				tb.TaskRunInputsParam("foo", "somethingfun"),
@@ -278,6 +296,9 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 		tb.Pipeline("a-pipeline-that-should-be-caught-by-admission-control", "foo", tb.PipelineSpec(
 			tb.PipelineTask("some-task", "a-task-that-exists",
 				tb.PipelineTaskInputResource("needed-resource", "a-resource")))),
+		tb.Pipeline("a-pipeline-with-params", "foo", tb.PipelineSpec(
+			tb.PipelineParam("level", tb.PipelineParamEnum("debug", "info")),
+			tb.PipelineTask("some-task", "a-task-that-exists"))),
 	}
 	prs := []*v1alpha1.PipelineRun{
 		tb.PipelineRun("invalid-pipeline", "foo", tb.PipelineRunSpec("pipeline-not-exist")),
@@ -287,6 +308,11 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 		tb.PipelineRun("pipeline-resources-dont-exist", "foo", tb.PipelineRunSpec("a-fine-pipeline",
 			tb.PipelineRunResourceBinding("a-resource", tb.PipelineResourceBindingRef("missing-resource")))),
 		tb.PipelineRun("pipeline-resources-not-declared", "foo", tb.PipelineRunSpec("a-pipeline-that-should-be-caught-by-admission-control")),
+		tb.PipelineRun("pipeline-params-missing", "foo", tb.PipelineRunSpec("a-pipeline-with-params")),
+		tb.PipelineRun("pipeline-params-not-declared", "foo", tb.PipelineRunSpec("a-pipeline-with-params",
+			tb.PipelineRunParam("level", "info"), tb.PipelineRunParam("unknown", "value"))),
+		tb.PipelineRun("pipeline-params-invalid", "foo", tb.PipelineRunSpec("a-pipeline-with-params",
+			tb.PipelineRunParam("level", "trace"))),
 	}
 	d := test.Data{
 		Tasks:        ts,
@@ -322,6 +348,18 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 			name:        "invalid-pipeline-missing-declared-resource-shd-stop-reconciling",
 			pipelineRun: prs[5],
 			reason:      ReasonFailedValidation,
+		}, {
+			name:        "invalid-pipeline-run-missing-params-shd-stop-reconciling",
+			pipelineRun: prs[6],
+			reason:      ReasonInvalidParams,
+		}, {
+			name:        "invalid-pipeline-run-params-not-declared-shd-stop-reconciling",
+			pipelineRun: prs[7],
+			reason:      ReasonInvalidParams,
+		}, {
+			name:        "invalid-pipeline-run-params-not-in-enum-shd-stop-reconciling",
+			pipelineRun: prs[8],
+			reason:      ReasonInvalidParams,
 		},
 	}
 
@@ -404,7 +442,7 @@ func TestUpdateTaskRunsState(t *testing.T) {
 	pr := tb.PipelineRun("test-pipeline-run", "foo", tb.PipelineRunSpec("test-pipeline"))
 	pipelineTask := v1alpha1.PipelineTask{
 		Name:    "unit-test-1",
-		TaskRef: v1alpha1.TaskRef{Name: "unit-test-task"},
+		TaskRef: &v1alpha1.TaskRef{Name: "unit-test-task"},
 	}
 	task := tb.Task("unit-test-task", "foo", tb.TaskSpec(
 		tb.TaskInputs(tb.InputsResource("workspace", v1alpha1.PipelineResourceTypeGit)),
@@ -680,6 +718,84 @@ func TestReconcileWithTimeout(t *testing.T) {
 	}
 }
 
+func TestGetTaskRunTimeout(t *testing.T) {
+	// All PipelineRuns started an hour ago.
+	startTime := time.Now().Add(-time.Hour)
+	pipelineRun := func(timeout *metav1.Duration) *v1alpha1.PipelineRun {
+		pr := tb.PipelineRun("test-pipeline-run", "foo",
+			tb.PipelineRunSpec("test-pipeline"),
+			tb.PipelineRunStatus(tb.PipelineRunStartTime(startTime)),
+		)
+		pr.Spec.Timeout = timeout
+		return pr
+	}
+	pipelineTask := func(timeout time.Duration) *v1alpha1.PipelineTask {
+		return &v1alpha1.PipelineTask{Name: "hello-world-1", Timeout: &metav1.Duration{Duration: timeout}}
+	}
+	tcs := []struct {
+		name     string
+		pr       *v1alpha1.PipelineRun
+		pt       *v1alpha1.PipelineTask
+		expected *metav1.Duration
+		// remaining is true if the expected timeout is the time left before the PipelineRun times out.
+		remaining bool
+	}{{
+		name:     "no timeouts",
+		pr:       pipelineRun(nil),
+		expected: nil,
+	}, {
+		name:     "pipeline task timeout only",
+		pr:       pipelineRun(nil),
+		pt:       pipelineTask(10 * time.Minute),
+		expected: &metav1.Duration{Duration: 10 * time.Minute},
+	}, {
+		name:      "pipeline run timeout only",
+		pr:        pipelineRun(&metav1.Duration{Duration: 2 * time.Hour}),
+		expected:  &metav1.Duration{Duration: time.Hour},
+		remaining: true,
+	}, {
+		name:     "pipeline task timeout shorter than remaining time",
+		pr:       pipelineRun(&metav1.Duration{Duration: 2 * time.Hour}),
+		pt:       pipelineTask(10 * time.Minute),
+		expected: &metav1.Duration{Duration: 10 * time.Minute},
+	}, {
+		name:      "pipeline task timeout longer than remaining time",
+		pr:        pipelineRun(&metav1.Duration{Duration: 2 * time.Hour}),
+		pt:        pipelineTask(3 * time.Hour),
+		expected:  &metav1.Duration{Duration: time.Hour},
+		remaining: true,
+	}, {
+		name:     "pipeline run already timed out",
+		pr:       pipelineRun(&metav1.Duration{Duration: 30 * time.Minute}),
+		pt:       pipelineTask(10 * time.Minute),
+		expected: &metav1.Duration{Duration: 0},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := getTaskRunTimeout(tc.pr, tc.pt)
+			if tc.expected == nil {
+				if actual != nil {
+					t.Fatalf("Expected no timeout but got %s", actual.Duration)
+				}
+				return
+			}
+			if actual == nil {
+				t.Fatalf("Expected timeout %s but got nil", tc.expected.Duration)
+			}
+			if !tc.remaining {
+				if actual.Duration != tc.expected.Duration {
+					t.Errorf("Expected timeout %s but got %s", tc.expected.Duration, actual.Duration)
+				}
+				return
+			}
+			// The remaining time shrinks while the test runs, so allow some slack.
+			if diff := tc.expected.Duration - actual.Duration; diff < 0 || diff > time.Minute {
+				t.Errorf("Expected timeout close to %s but got %s", tc.expected.Duration, actual.Duration)
+			}
+		})
+	}
+}
+
 func TestReconcilePropagateLabels(t *testing.T) {
 	names.TestingSeed()
 
@@ -693,7 +809,10 @@ func TestReconcilePropagateLabels(t *testing.T) {
 			tb.PipelineRunServiceAccount("test-sa"),
 		),
 	)}
-	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo",
+		tb.TaskLabel("TaskLabel", "TaskValue"),
+		tb.TaskSpec(tb.Step("echo", "ubuntu", tb.Command("echo"), tb.Args("hello"))),
+	)}
 
 	d := test.Data{
 		PipelineRuns: prs,
@@ -732,8 +851,10 @@ func TestReconcilePropagateLabels(t *testing.T) {
 		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
 		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-with-labels"),
 		tb.TaskRunLabel("PipelineRunLabel", "PipelineRunValue"),
+		tb.TaskRunLabel("TaskLabel", "TaskValue"),
+		tb.TaskRunLabel("tekton.dev/task", "hello-world"),
 		tb.TaskRunSpec(
-			tb.TaskRunTaskRef("hello-world"),
+			tb.TaskRunTaskSpec(tb.Step("echo", "ubuntu", tb.Command("echo"), tb.Args("hello"))),
 			tb.TaskRunServiceAccount("test-sa"),
 		),
 	)
@@ -742,3 +863,2319 @@ func TestReconcilePropagateLabels(t *testing.T) {
 		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, d)
 	}
 }
+
+func TestReconcilePropagateCloudEventSink(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-sink", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunCloudEventSink("http://sink.example.com"),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-sink"); err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Check that the TaskRun sends its CloudEvents to the sink of the PipelineRun
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if actual.Spec.CloudEventSink != "http://sink.example.com" {
+		t.Errorf("Expected the TaskRun to send its CloudEvents to http://sink.example.com but it sends them to %q", actual.Spec.CloudEventSink)
+	}
+}
+
+func TestReconcileWithTaskResults(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "build-task"),
+		tb.PipelineTask("deploy", "deploy-task",
+			tb.PipelineTaskParam("image", "gcr.io/foo@${tasks.build.results.digest}"),
+		),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-results", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"test-pipeline-run-results-build": {PipelineTaskName: "build"},
+		})),
+	)}
+	ts := []*v1alpha1.Task{
+		tb.Task("build-task", "foo", tb.TaskSpec(tb.TaskResult("digest", "the image digest"))),
+		tb.Task("deploy-task", "foo", tb.TaskSpec(tb.TaskInputs(tb.InputsParam("image")))),
+	}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-results-build", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-results"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("build-task")),
+			tb.TaskRunStatus(
+				tb.Condition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+				tb.TaskRunResult("digest", "sha256:abc"),
+			),
+		),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-results")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Check that the expected TaskRun was created with the result substituted
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if actual == nil {
+		t.Fatalf("Expected a TaskRun to be created, but it wasn't.")
+	}
+	expectedParams := []v1alpha1.Param{{Name: "image", Value: "gcr.io/foo@sha256:abc"}}
+	if d := cmp.Diff(expectedParams, actual.Spec.Inputs.Params); d != "" {
+		t.Errorf("expected TaskRun to be created with the task result as param. Diff %s", d)
+	}
+}
+
+func TestReconcileWithMissingTaskResult(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "build-task"),
+		tb.PipelineTask("deploy", "deploy-task",
+			tb.PipelineTaskParam("image", "gcr.io/foo@${tasks.build.results.digest}"),
+		),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-missing-result", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"test-pipeline-run-missing-result-build": {PipelineTaskName: "build"},
+		})),
+	)}
+	ts := []*v1alpha1.Task{
+		tb.Task("build-task", "foo", tb.TaskSpec(tb.TaskResult("digest", "the image digest"))),
+		tb.Task("deploy-task", "foo", tb.TaskSpec(tb.TaskInputs(tb.InputsParam("image")))),
+	}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-missing-result-build", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-missing-result"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("build-task")),
+			tb.TaskRunStatus(
+				tb.Condition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+		),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-missing-result")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-missing-result", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != ReasonInvalidTaskResultReference {
+		t.Errorf("Expected PipelineRun to fail with reason %s but condition was %v", ReasonInvalidTaskResultReference, condition)
+	}
+}
+
+func TestReconcileWithRetries(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("flaky", "flaky-task", tb.Retries(1)),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-retries", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"test-pipeline-run-retries-flaky": {PipelineTaskName: "flaky"},
+		})),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("flaky-task", "foo")}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-retries-flaky", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-retries"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("flaky-task")),
+			tb.TaskRunStatus(
+				tb.Condition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: "Failed",
+				}),
+			),
+		),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-retries")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Check that a new TaskRun was created for the retry
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if actual.Name != "test-pipeline-run-retries-flaky-9l9zj" {
+		t.Errorf("Expected a new TaskRun to be created for the retry but got %s", actual.Name)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-retries", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to still be running while retrying but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	prtrs, ok := reconciledRun.Status.TaskRuns[actual.Name]
+	if !ok {
+		t.Fatalf("Expected the retried TaskRun %s to be in the PipelineRun status but got %v", actual.Name, reconciledRun.Status.TaskRuns)
+	}
+	expectedAttempts := []v1alpha1.PipelineTaskAttempt{{TaskRunName: "test-pipeline-run-retries-flaky", Reason: "Failed"}}
+	if prtrs.Attempt != 1 {
+		t.Errorf("Expected the retried TaskRun to be attempt 1 but was %d", prtrs.Attempt)
+	}
+	if d := cmp.Diff(expectedAttempts, prtrs.PreviousAttempts); d != "" {
+		t.Errorf("Expected the previous attempt to be recorded. Diff -want, +got: %s", d)
+	}
+}
+
+func TestReconcileWithFinally(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("unit-test", "unit-test-task"),
+		tb.PipelineTask("build", "build-task", tb.RunAfter("unit-test")),
+		tb.PipelineFinally("cleanup", "cleanup-task"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-finally", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"test-pipeline-run-finally-unit-test": {PipelineTaskName: "unit-test"},
+		})),
+	)}
+	ts := []*v1alpha1.Task{
+		tb.Task("unit-test-task", "foo"),
+		tb.Task("build-task", "foo"),
+		tb.Task("cleanup-task", "foo"),
+	}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-finally-unit-test", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-finally"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("unit-test-task")),
+			tb.TaskRunStatus(
+				tb.Condition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: "Failed",
+				}),
+			),
+		),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-finally")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Only the finally task should have been started, since the DAG stopped at the failure
+	created := []string{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun).Name)
+		}
+	}
+	if d := cmp.Diff([]string{"test-pipeline-run-finally-cleanup-mz4c7"}, created); d != "" {
+		t.Errorf("Expected only the finally TaskRun to be created. Diff -want, +got: %s", d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-finally", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to still be running while the finally tasks run but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+func TestReconcileWithFailedFinally(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("unit-test", "unit-test-task"),
+		tb.PipelineFinally("cleanup", "cleanup-task"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-finally", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"test-pipeline-run-finally-unit-test": {PipelineTaskName: "unit-test"},
+			"test-pipeline-run-finally-cleanup":   {PipelineTaskName: "cleanup"},
+		})),
+	)}
+	ts := []*v1alpha1.Task{
+		tb.Task("unit-test-task", "foo"),
+		tb.Task("cleanup-task", "foo"),
+	}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-finally-unit-test", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-finally"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("unit-test-task")),
+			tb.TaskRunStatus(
+				tb.Condition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+		),
+		tb.TaskRun("test-pipeline-run-finally-cleanup", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-finally"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("cleanup-task")),
+			tb.TaskRunStatus(
+				tb.Condition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: "Failed",
+				}),
+			),
+		),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-finally")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-finally", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != resources.ReasonFinallyFailed {
+		t.Errorf("Expected PipelineRun to fail with reason %s but condition was %v", resources.ReasonFinallyFailed, condition)
+	}
+}
+
+func TestReconcileWithFailurePolicy(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("unit-test", "unit-test-task"),
+		tb.PipelineTask("build", "build-task", tb.RunAfter("unit-test")),
+		tb.PipelineTask("lint", "lint-task"),
+	))}
+	ts := []*v1alpha1.Task{
+		tb.Task("unit-test-task", "foo"),
+		tb.Task("build-task", "foo"),
+		tb.Task("lint-task", "foo"),
+	}
+	failedTaskRun := tb.TaskRun("test-pipeline-run-policy-unit-test", "foo",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-policy"),
+		tb.TaskRunSpec(tb.TaskRunTaskRef("unit-test-task")),
+		tb.TaskRunStatus(tb.Condition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: "Failed",
+		})),
+	)
+	runningTaskRun := tb.TaskRun("test-pipeline-run-policy-lint", "foo",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-policy"),
+		tb.TaskRunSpec(tb.TaskRunTaskRef("lint-task")),
+		tb.TaskRunStatus(tb.Condition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		})),
+	)
+
+	pipelineTaskNames := map[string]string{
+		failedTaskRun.Name:  "unit-test",
+		runningTaskRun.Name: "lint",
+	}
+
+	tcs := []struct {
+		name              string
+		policy            v1alpha1.PipelineRunFailurePolicy
+		taskRuns          []*v1alpha1.TaskRun
+		expectedCreated   []string
+		expectedCancelled []string
+		expectedStatus    corev1.ConditionStatus
+		expectedSkipped   []v1alpha1.SkippedTask
+	}{{
+		name:              "fail fast",
+		policy:            v1alpha1.PipelineRunFailurePolicyFailFast,
+		taskRuns:          []*v1alpha1.TaskRun{failedTaskRun, runningTaskRun},
+		expectedCreated:   []string{},
+		expectedCancelled: []string{"test-pipeline-run-policy-lint"},
+		expectedStatus:    corev1.ConditionFalse,
+		expectedSkipped: []v1alpha1.SkippedTask{{
+			Name:    "build",
+			Reason:  resources.ReasonParentTaskFailed,
+			Message: "PipelineTask unit-test has failed",
+		}},
+	}, {
+		name:              "continue independent",
+		policy:            v1alpha1.PipelineRunFailurePolicyContinueIndependent,
+		taskRuns:          []*v1alpha1.TaskRun{failedTaskRun},
+		expectedCreated:   []string{"test-pipeline-run-policy-lint-mz4c7"},
+		expectedCancelled: []string{},
+		expectedStatus:    corev1.ConditionUnknown,
+		expectedSkipped: []v1alpha1.SkippedTask{{
+			Name:    "build",
+			Reason:  resources.ReasonParentTaskFailed,
+			Message: "PipelineTask unit-test has failed",
+		}},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			taskRunsStatus := map[string]*v1alpha1.PipelineRunTaskRunStatus{}
+			for _, tr := range tc.taskRuns {
+				taskRunsStatus[tr.Name] = &v1alpha1.PipelineRunTaskRunStatus{PipelineTaskName: pipelineTaskNames[tr.Name]}
+			}
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-policy", "foo",
+				tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+					tb.PipelineRunFailurePolicy(tc.policy)),
+				tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(taskRunsStatus)),
+			)}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     tc.taskRuns,
+			}
+
+			testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-policy")
+			if err != nil {
+				t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+			}
+
+			created, cancelled := []string{}, []string{}
+			for _, a := range clients.Pipeline.Actions() {
+				if a.GetResource().Resource != "taskruns" {
+					continue
+				}
+				switch a.GetVerb() {
+				case "create":
+					created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun).Name)
+				case "update":
+					if a.GetSubresource() != "" {
+						continue
+					}
+					tr := a.(ktesting.UpdateAction).GetObject().(*v1alpha1.TaskRun)
+					if tr.IsCancelled() {
+						cancelled = append(cancelled, tr.Name)
+					}
+				}
+			}
+			if d := cmp.Diff(tc.expectedCreated, created); d != "" {
+				t.Errorf("Unexpected TaskRuns created. Diff -want, +got: %s", d)
+			}
+			if d := cmp.Diff(tc.expectedCancelled, cancelled); d != "" {
+				t.Errorf("Unexpected TaskRuns cancelled. Diff -want, +got: %s", d)
+			}
+
+			reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-policy", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+			}
+			if status := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Status; status != tc.expectedStatus {
+				t.Errorf("Expected PipelineRun status to be %s but was %s", tc.expectedStatus, status)
+			}
+			if d := cmp.Diff(tc.expectedSkipped, reconciledRun.Status.SkippedTasks); d != "" {
+				t.Errorf("Unexpected skipped tasks. Diff -want, +got: %s", d)
+			}
+		})
+	}
+}
+
+func TestReconcilePausedPipelineRun(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-paused", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunPaused,
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-paused")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling paused PipelineRun but saw %s", err)
+	}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRun to be created for a paused PipelineRun but saw %v", a)
+		}
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-paused", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != ReasonPaused {
+		t.Errorf("Expected PipelineRun to be paused but condition was %v", condition)
+	}
+	if reconciledRun.Status.PausedTime == nil {
+		t.Errorf("Expected the time the PipelineRun was paused to be recorded")
+	}
+}
+
+func TestReconcileResumedPipelineRun(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	// The PipelineRun would have timed out if the time it spent paused was counted
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-resumed", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunTimeout(&metav1.Duration{Duration: 1 * time.Hour}),
+		),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(time.Now().Add(-2*time.Hour)),
+			tb.PipelineRunPausedTime(time.Now().Add(-90*time.Minute)),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-resumed")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling resumed PipelineRun but saw %s", err)
+	}
+	created := []string{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun).Name)
+		}
+	}
+	if d := cmp.Diff([]string{"test-pipeline-run-resumed-hello-world-1-9l9zj"}, created); d != "" {
+		t.Errorf("Expected scheduling to resume. Diff -want, +got: %s", d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-resumed", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded); !condition.IsUnknown() {
+		t.Errorf("Expected resumed PipelineRun to be running but condition was %v", condition)
+	}
+	if reconciledRun.Status.PausedTime != nil {
+		t.Errorf("Expected the PipelineRun not to be paused anymore but it was paused at %v", reconciledRun.Status.PausedTime)
+	}
+	if reconciledRun.Status.PausedDuration == nil || reconciledRun.Status.PausedDuration.Duration < 90*time.Minute {
+		t.Errorf("Expected the time spent paused to be recorded but got %v", reconciledRun.Status.PausedDuration)
+	}
+}
+
+func TestReconcileResumeFromPipelineRun(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("unit-test", "unit-test-task"),
+		tb.PipelineTask("build", "build-task", tb.RunAfter("unit-test")),
+		tb.PipelineTask("lint", "lint-task"),
+	))}
+	ts := []*v1alpha1.Task{
+		tb.Task("unit-test-task", "foo"),
+		tb.Task("build-task", "foo"),
+		tb.Task("lint-task", "foo"),
+	}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-failed-unit-test", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-failed"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("unit-test-task")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			})),
+		),
+		tb.TaskRun("test-pipeline-run-failed-lint", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-failed"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("lint-task")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: "Failed",
+			})),
+		),
+	}
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("test-pipeline-run-failed", "foo",
+			tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+			tb.PipelineRunStatus(
+				tb.PipelineRunStatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionFalse,
+					Reason: resources.ReasonFailed,
+				}),
+				tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+					trs[0].Name: {PipelineTaskName: "unit-test", Status: &trs[0].Status},
+					trs[1].Name: {PipelineTaskName: "lint", Status: &trs[1].Status},
+				}),
+			),
+		),
+		tb.PipelineRun("test-pipeline-run-resumed", "foo",
+			tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+				tb.PipelineRunResumeFrom("test-pipeline-run-failed"),
+			),
+		),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+	// The artifacts of the first PipelineRun are stored in its PVC
+	if _, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Create(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-failed-pvc", Namespace: "foo"},
+	}); err != nil {
+		t.Fatalf("Failed to create PVC: %s", err)
+	}
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-resumed")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling resumed PipelineRun but saw %s", err)
+	}
+
+	// Only the PipelineTasks which failed or weren't run are scheduled
+	created := []string{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			tr := a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+			created = append(created, tr.Name)
+			if tr.Labels[pipeline.GroupName+pipeline.ArtifactStorageKey] != "test-pipeline-run-failed" {
+				t.Errorf("Expected TaskRun %s to use the artifact storage of the resumed PipelineRun but got labels %v", tr.Name, tr.Labels)
+			}
+		}
+	}
+	sort.Strings(created)
+	expectedCreated := []string{
+		"test-pipeline-run-resumed-build-9l9zj",
+		"test-pipeline-run-resumed-lint-mz4c7",
+	}
+	if d := cmp.Diff(expectedCreated, created); d != "" {
+		t.Errorf("Expected the failed and not run PipelineTasks to be scheduled. Diff -want, +got: %s", d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-resumed", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if reconciledRun.Status.ArtifactStorage != "test-pipeline-run-failed" {
+		t.Errorf("Expected the artifact storage of test-pipeline-run-failed to be reused but got %q", reconciledRun.Status.ArtifactStorage)
+	}
+	if prtrs, ok := reconciledRun.Status.TaskRuns["test-pipeline-run-failed-unit-test"]; !ok || prtrs.PipelineTaskName != "unit-test" {
+		t.Errorf("Expected the successful TaskRun to be adopted but got TaskRuns %v", reconciledRun.Status.TaskRuns)
+	}
+	if _, ok := reconciledRun.Status.TaskRuns["test-pipeline-run-failed-lint"]; ok {
+		t.Errorf("Expected the failed TaskRun not to be adopted but got TaskRuns %v", reconciledRun.Status.TaskRuns)
+	}
+
+	// The adopted TaskRun and the PVC are owned by the resumed PipelineRun too, so that
+	// they outlive the PipelineRun it resumes from
+	sharedOwner := metav1.OwnerReference{APIVersion: "tekton.dev/v1alpha1", Kind: "PipelineRun", Name: "test-pipeline-run-resumed"}
+	adopted, err := clients.Pipeline.Tekton().TaskRuns("foo").Get("test-pipeline-run-failed-unit-test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting adopted TaskRun out of fake client: %s", err)
+	}
+	expectedOwners := []metav1.OwnerReference{trs[0].OwnerReferences[0], sharedOwner}
+	if d := cmp.Diff(expectedOwners, adopted.OwnerReferences); d != "" {
+		t.Errorf("Expected the adopted TaskRun to be owned by both PipelineRuns. Diff -want, +got: %s", d)
+	}
+	pvc, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Get("test-pipeline-run-failed-pvc", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting PVC out of fake client: %s", err)
+	}
+	if d := cmp.Diff([]metav1.OwnerReference{sharedOwner}, pvc.OwnerReferences); d != "" {
+		t.Errorf("Expected the PVC to be owned by the resumed PipelineRun. Diff -want, +got: %s", d)
+	}
+}
+
+func TestReconcileResumeFromInvalidPipelineRun(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	tcs := []struct {
+		name     string
+		previous *v1alpha1.PipelineRun
+	}{{
+		name: "missing pipelinerun",
+	}, {
+		name: "different pipeline",
+		previous: tb.PipelineRun("test-pipeline-run-previous", "foo",
+			tb.PipelineRunSpec("other-pipeline"),
+			tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+			})),
+		),
+	}, {
+		name: "running pipelinerun",
+		previous: tb.PipelineRun("test-pipeline-run-previous", "foo",
+			tb.PipelineRunSpec("test-pipeline"),
+			tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+			})),
+		),
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-resumed", "foo",
+				tb.PipelineRunSpec("test-pipeline", tb.PipelineRunResumeFrom("test-pipeline-run-previous")),
+			)}
+			if tc.previous != nil {
+				prs = append(prs, tc.previous)
+			}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+			}
+
+			testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-resumed")
+			if err != nil {
+				t.Errorf("Did not expect to see error when reconciling resumed PipelineRun but saw %s", err)
+			}
+			for _, a := range clients.Pipeline.Actions() {
+				if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+					t.Errorf("Expected no TaskRun to be created but got %v", a)
+				}
+			}
+			reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-resumed", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+			}
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if !condition.IsFalse() || condition.Reason != ReasonCouldntResume {
+				t.Errorf("Expected PipelineRun to fail with reason %s but condition was %v", ReasonCouldntResume, condition)
+			}
+		})
+	}
+}
+
+func TestReconcileWithEmbeddedSpecs(t *testing.T) {
+	names.TestingSeed()
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-embedded", "foo",
+		tb.PipelineRunSpec("", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunPipelineSpec(
+				tb.PipelineTask("hello-world-1", "", tb.PipelineTaskSpec(
+					tb.Step("echo", "ubuntu", tb.Command("echo"), tb.Args("hello")),
+				)),
+				tb.PipelineTask("hello-world-2", "hello-world", tb.RunAfter("hello-world-1")),
+			),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	d := test.Data{
+		PipelineRuns: prs,
+		Tasks:        ts,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-embedded")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun with an embedded Pipeline but saw %s", err)
+	}
+
+	// The embedded Task is embedded in the TaskRun as well
+	expectedTaskRun := tb.TaskRun("test-pipeline-run-embedded-hello-world-1-9l9zj", "foo",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-embedded",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1alpha1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline-run-embedded"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-embedded"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskSpec(tb.Step("echo", "ubuntu", tb.Command("echo"), tb.Args("hello"))),
+			tb.TaskRunServiceAccount("test-sa"),
+		),
+	)
+	created := []*v1alpha1.TaskRun{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+		}
+	}
+	if d := cmp.Diff([]*v1alpha1.TaskRun{expectedTaskRun}, created); d != "" {
+		t.Errorf("Expected the first Task to be run with its embedded spec. Diff -want, +got: %s", d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-embedded", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded); !condition.IsUnknown() {
+		t.Errorf("Expected PipelineRun with an embedded Pipeline to be running but condition was %v", condition)
+	}
+}
+
+func TestReconcileWithMatrix(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("test", "test-task",
+			tb.PipelineTaskParam("version", "1"),
+			tb.PipelineTaskMatrixParam("platform", "linux", "windows"),
+		),
+		tb.PipelineTask("publish", "publish-task", tb.RunAfter("test")),
+	))}
+	linux := []v1alpha1.Param{{Name: "platform", Value: "linux"}}
+	windows := []v1alpha1.Param{{Name: "platform", Value: "windows"}}
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("test-pipeline-run-matrix", "foo",
+			tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		),
+		tb.PipelineRun("test-pipeline-run-matrix-running", "foo",
+			tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+			tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+				"test-pipeline-run-matrix-running-test-0": {PipelineTaskName: "test", MatrixParams: linux},
+				"test-pipeline-run-matrix-running-test-1": {PipelineTaskName: "test", MatrixParams: windows},
+			})),
+		),
+	}
+	ts := []*v1alpha1.Task{
+		tb.Task("test-task", "foo", tb.TaskSpec(
+			tb.TaskInputs(tb.InputsParam("version"), tb.InputsParam("platform")),
+		)),
+		tb.Task("publish-task", "foo"),
+	}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-matrix-running-test-0", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-matrix-running"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("test-task")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})),
+		),
+		tb.TaskRun("test-pipeline-run-matrix-running-test-1", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-matrix-running"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("test-task")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown})),
+		),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	createdTaskRuns := func() []*v1alpha1.TaskRun {
+		created := []*v1alpha1.TaskRun{}
+		for _, a := range clients.Pipeline.Actions() {
+			if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+				created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+			}
+		}
+		return created
+	}
+
+	names.TestingSeed()
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-matrix")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Each combination of the matrix is run by its own TaskRun
+	expectedParams := map[string][]v1alpha1.Param{
+		"test-pipeline-run-matrix-test-9l9zj": {{Name: "version", Value: "1"}, {Name: "platform", Value: "linux"}},
+		"test-pipeline-run-matrix-test-mz4c7": {{Name: "version", Value: "1"}, {Name: "platform", Value: "windows"}},
+	}
+	actualParams := map[string][]v1alpha1.Param{}
+	for _, tr := range createdTaskRuns() {
+		actualParams[tr.Name] = tr.Spec.Inputs.Params
+	}
+	if d := cmp.Diff(expectedParams, actualParams); d != "" {
+		t.Errorf("Expected a TaskRun to be created for each combination of the matrix. Diff -want, +got: %s", d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-matrix", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	for name, params := range map[string][]v1alpha1.Param{"test-pipeline-run-matrix-test-9l9zj": linux, "test-pipeline-run-matrix-test-mz4c7": windows} {
+		prtrs, ok := reconciledRun.Status.TaskRuns[name]
+		if !ok {
+			t.Fatalf("Expected the TaskRun %s to be in the PipelineRun status but got %v", name, reconciledRun.Status.TaskRuns)
+		}
+		if prtrs.PipelineTaskName != "test" {
+			t.Errorf("Expected the TaskRun %s to belong to PipelineTask test but got %s", name, prtrs.PipelineTaskName)
+		}
+		if d := cmp.Diff(params, prtrs.MatrixParams); d != "" {
+			t.Errorf("Expected the matrix params of TaskRun %s to be recorded. Diff -want, +got: %s", name, d)
+		}
+	}
+
+	// The PipelineTasks running after the fanned out PipelineTask wait for all of its combinations
+	clients.Pipeline.ClearActions()
+	err = c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-matrix-running")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	if created := createdTaskRuns(); len(created) != 0 {
+		t.Errorf("Expected no TaskRun to be created while a combination of the matrix is running but got %v", created)
+	}
+}
+
+func TestReconcileWithNestedPipeline(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1alpha1.Pipeline{
+		tb.Pipeline("release-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineParam("revision"),
+			tb.PipelineDeclaredResource("source", v1alpha1.PipelineResourceTypeGit),
+			tb.PipelineTask("build", "", tb.PipelineTaskPipelineRef("build-pipeline"),
+				tb.PipelineTaskParam("revision", "${params.revision}"),
+				tb.PipelineTaskInputResource("workspace", "source"),
+			),
+			tb.PipelineTask("release", "release-task", tb.RunAfter("build")),
+		)),
+		tb.Pipeline("build-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineParam("revision"),
+			tb.PipelineDeclaredResource("workspace", v1alpha1.PipelineResourceTypeGit),
+			tb.PipelineTask("compile", "compile-task"),
+		)),
+	}
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("test-pipeline-run-nested", "foo",
+			tb.PipelineRunSpec("release-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+				tb.PipelineRunParam("revision", "v1"),
+				tb.PipelineRunResourceBinding("source", tb.PipelineResourceBindingRef("some-repo")),
+			),
+		),
+		tb.PipelineRun("test-pipeline-run-nested-done", "foo",
+			tb.PipelineRunSpec("release-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+				tb.PipelineRunParam("revision", "v1"),
+				tb.PipelineRunResourceBinding("source", tb.PipelineResourceBindingRef("some-repo")),
+			),
+			tb.PipelineRunStatus(tb.PipelineRunPipelineRunsStatus(map[string]*v1alpha1.PipelineRunPipelineRunStatus{
+				"test-pipeline-run-nested-done-build": {PipelineTaskName: "build"},
+			})),
+		),
+		tb.PipelineRun("test-pipeline-run-nested-done-build", "foo",
+			tb.PipelineRunSpec("build-pipeline"),
+			tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			})),
+		),
+	}
+	ts := []*v1alpha1.Task{tb.Task("compile-task", "foo"), tb.Task("release-task", "foo")}
+	rs := []*v1alpha1.PipelineResource{tb.PipelineResource("some-repo", "foo", tb.PipelineResourceSpec(
+		v1alpha1.PipelineResourceTypeGit, tb.PipelineResourceSpecParam("url", "https://github.com/tektoncd/pipeline"),
+	))}
+	d := test.Data{
+		PipelineRuns:      prs,
+		Pipelines:         ps,
+		Tasks:             ts,
+		PipelineResources: rs,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-nested")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The nested Pipeline is run by a PipelineRun owned by the PipelineRun
+	var child *v1alpha1.PipelineRun
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "pipelineruns" {
+			child = a.(ktesting.CreateAction).GetObject().(*v1alpha1.PipelineRun)
+		}
+	}
+	if child == nil {
+		t.Fatalf("Expected a PipelineRun to be created for the nested Pipeline but got none")
+	}
+	if child.Name != "test-pipeline-run-nested-build-9l9zj" {
+		t.Errorf("Expected the PipelineRun of the nested Pipeline to be named after the PipelineTask but got %s", child.Name)
+	}
+	if len(child.OwnerReferences) != 1 || child.OwnerReferences[0].Name != "test-pipeline-run-nested" {
+		t.Errorf("Expected the PipelineRun of the nested Pipeline to be owned by the PipelineRun but got %v", child.OwnerReferences)
+	}
+	expectedSpec := v1alpha1.PipelineRunSpec{
+		PipelineRef: &v1alpha1.PipelineRef{Name: "build-pipeline"},
+		Trigger:     v1alpha1.PipelineTrigger{Type: v1alpha1.PipelineTriggerTypeManual},
+		Params:      []v1alpha1.Param{{Name: "revision", Value: "v1"}},
+		Resources: []v1alpha1.PipelineResourceBinding{{
+			Name:        "workspace",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "some-repo"},
+		}},
+		ServiceAccount: "test-sa",
+	}
+	if d := cmp.Diff(expectedSpec, child.Spec); d != "" {
+		t.Errorf("Expected the params and resources to be passed to the nested Pipeline. Diff -want, +got: %s", d)
+	}
+
+	// Once the nested Pipeline has succeeded, the PipelineTasks running after it are started
+	clients.Pipeline.ClearActions()
+	err = c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-nested-done")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	created := []string{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(metav1.Object).GetName())
+		}
+	}
+	if d := cmp.Diff([]string{"test-pipeline-run-nested-done-release-mssqb"}, created); d != "" {
+		t.Errorf("Expected the PipelineTask after the nested Pipeline to be run. Diff -want, +got: %s", d)
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-nested-done", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	prprs, ok := reconciledRun.Status.PipelineRuns["test-pipeline-run-nested-done-build"]
+	if !ok || prprs.PipelineTaskName != "build" || !prprs.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("Expected the status of the nested PipelineRun to be recorded but got %v", reconciledRun.Status.PipelineRuns)
+	}
+}
+
+func TestReconcileWithNestedPipelineCycle(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{
+		tb.Pipeline("outer-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("inner", "", tb.PipelineTaskPipelineRef("inner-pipeline")),
+		)),
+		tb.Pipeline("inner-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("outer", "", tb.PipelineTaskPipelineRef("outer-pipeline")),
+		)),
+	}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-cycle", "foo",
+		tb.PipelineRunSpec("outer-pipeline"),
+	)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-cycle")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-cycle", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != ReasonInvalidGraph {
+		t.Errorf("Expected PipelineRun to fail with reason %s but condition was %v", ReasonInvalidGraph, condition)
+	}
+	if !strings.Contains(condition.Message, "outer-pipeline -> inner-pipeline -> outer-pipeline") {
+		t.Errorf("Expected the cycle to be reported but message was %q", condition.Message)
+	}
+}
+
+func TestReconcileCancelledPipelineRunWithNestedPipeline(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{
+		tb.Pipeline("outer-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("inner", "", tb.PipelineTaskPipelineRef("inner-pipeline")),
+		)),
+		tb.Pipeline("inner-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("hello", "hello-world"),
+		)),
+	}
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("test-pipeline-run-cancelled", "foo",
+			tb.PipelineRunSpec("outer-pipeline", tb.PipelineRunCancelled),
+			tb.PipelineRunStatus(tb.PipelineRunPipelineRunsStatus(map[string]*v1alpha1.PipelineRunPipelineRunStatus{
+				"test-pipeline-run-cancelled-inner": {PipelineTaskName: "inner"},
+			})),
+		),
+		tb.PipelineRun("test-pipeline-run-cancelled-inner", "foo",
+			tb.PipelineRunSpec("inner-pipeline"),
+			tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+			})),
+		),
+	}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-cancelled")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	child, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-cancelled-inner", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting nested run out of fake client: %s", err)
+	}
+	if !child.IsCancelled() {
+		t.Errorf("Expected the PipelineRun of the nested Pipeline to be cancelled along with its parent but spec status was %q", child.Spec.Status)
+	}
+}
+
+func TestReconcileWithApproval(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("release-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "build-task"),
+		tb.PipelineTask("approve", "", tb.RunAfter("build"), tb.PipelineTaskTimeout(time.Hour),
+			tb.PipelineTaskApproval(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers"), tb.ApprovalMessage("Release?"))),
+		tb.PipelineTask("release", "release-task", tb.RunAfter("approve")),
+	))}
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("test-pipeline-run-approval", "foo",
+			tb.PipelineRunSpec("release-pipeline"),
+			tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+				"test-pipeline-run-approval-build": {PipelineTaskName: "build"},
+			})),
+		),
+		tb.PipelineRun("test-pipeline-run-approved", "foo",
+			tb.PipelineRunSpec("release-pipeline"),
+			tb.PipelineRunStatus(
+				tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+					"test-pipeline-run-approved-build": {PipelineTaskName: "build"},
+				}),
+				tb.PipelineRunApprovalsStatus(map[string]*v1alpha1.PipelineRunApprovalStatus{
+					"test-pipeline-run-approved-approve": {PipelineTaskName: "approve"},
+				}),
+			),
+		),
+	}
+	ts := []*v1alpha1.Task{tb.Task("build-task", "foo"), tb.Task("release-task", "foo")}
+	succeeded := tb.TaskRunStatus(tb.Condition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+	}))
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-approval-build", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef("build-task")), succeeded),
+		tb.TaskRun("test-pipeline-run-approved-build", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef("build-task")), succeeded),
+	}
+	as := []*v1alpha1.Approval{tb.Approval("test-pipeline-run-approved-approve", "foo",
+		tb.ApprovalApprovers(tb.ApprovalUsers("alice"), tb.ApprovalGroups("releasers"), tb.ApprovalMessage("Release?")),
+		tb.ApprovalTimeout(time.Hour),
+		tb.ApprovalDecision(v1alpha1.ApprovalDecisionApproved, "bob"),
+		tb.ApprovalStatus(tb.ApprovalStartTime(time.Now()), tb.ApprovalStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+			Reason: v1alpha1.ApprovalReasonWaiting,
+		})),
+	)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		Approvals:    as,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-approval")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The approval gate creates an Approval owned by the PipelineRun instead of a TaskRun
+	var approval *v1alpha1.Approval
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRun to be created while waiting for the approval but got %s", a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun).Name)
+		}
+		if a.GetVerb() == "create" && a.GetResource().Resource == "approvals" {
+			approval = a.(ktesting.CreateAction).GetObject().(*v1alpha1.Approval)
+		}
+	}
+	if approval == nil {
+		t.Fatalf("Expected an Approval to be created for the approval gate but got none")
+	}
+	if len(approval.OwnerReferences) != 1 || approval.OwnerReferences[0].Name != "test-pipeline-run-approval" {
+		t.Errorf("Expected the Approval to be owned by the PipelineRun but got %v", approval.OwnerReferences)
+	}
+	expectedSpec := v1alpha1.ApprovalSpec{
+		ApprovalGate: v1alpha1.ApprovalGate{
+			Users:   []string{"alice"},
+			Groups:  []string{"releasers"},
+			Message: "Release?",
+		},
+		Timeout: &metav1.Duration{Duration: time.Hour},
+	}
+	if d := cmp.Diff(expectedSpec, approval.Spec); d != "" {
+		t.Errorf("Expected the approvers and timeout of the PipelineTask to be passed to the Approval. Diff -want, +got: %s", d)
+	}
+	approval, err = clients.Pipeline.Tekton().Approvals("foo").Get(approval.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting the Approval out of fake client: %s", err)
+	}
+	if !approval.HasStarted() || approval.Status.GetCondition(apis.ConditionSucceeded).Reason != v1alpha1.ApprovalReasonWaiting {
+		t.Errorf("Expected the Approval to wait for a decision but status was %v", approval.Status)
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-approval", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running while waiting for the approval but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if pras, ok := reconciledRun.Status.Approvals[approval.Name]; !ok || pras.PipelineTaskName != "approve" {
+		t.Errorf("Expected the Approval to be recorded in the status of the PipelineRun but got %v", reconciledRun.Status.Approvals)
+	}
+
+	// Once approved, the Approval succeeds and the PipelineTasks running after it are started
+	clients.Pipeline.ClearActions()
+	err = c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-approved")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	created := []string{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(metav1.Object).GetName())
+		}
+	}
+	if d := cmp.Diff([]string{"test-pipeline-run-approved-release-mssqb"}, created); d != "" {
+		t.Errorf("Expected the PipelineTask after the approval to be run. Diff -want, +got: %s", d)
+	}
+	approval, err = clients.Pipeline.Tekton().Approvals("foo").Get("test-pipeline-run-approved-approve", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting the Approval out of fake client: %s", err)
+	}
+	if condition := approval.Status.GetCondition(apis.ConditionSucceeded); !condition.IsTrue() || condition.Reason != v1alpha1.ApprovalReasonApproved {
+		t.Errorf("Expected the Approval to succeed once approved but condition was %v", condition)
+	}
+	reconciledRun, err = clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-approved", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	pras, ok := reconciledRun.Status.Approvals["test-pipeline-run-approved-approve"]
+	if !ok || pras.Decision != v1alpha1.ApprovalDecisionApproved || pras.Approver != "bob" || !pras.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("Expected the decision and the approver to be recorded in the status of the PipelineRun but got %v", reconciledRun.Status.Approvals)
+	}
+}
+
+func TestReconcileWithFailedApproval(t *testing.T) {
+	tcs := []struct {
+		name           string
+		approval       tb.ApprovalOp
+		startTime      time.Time
+		expectedReason string
+	}{{
+		name:           "rejected",
+		approval:       tb.ApprovalDecision(v1alpha1.ApprovalDecisionRejected, "alice"),
+		startTime:      time.Now(),
+		expectedReason: v1alpha1.ApprovalReasonRejected,
+	}, {
+		name:           "timed out",
+		approval:       tb.ApprovalTimeout(time.Hour),
+		startTime:      time.Now().Add(-2 * time.Hour),
+		expectedReason: v1alpha1.ApprovalReasonTimedOut,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1alpha1.Pipeline{tb.Pipeline("release-pipeline", "foo", tb.PipelineSpec(
+				tb.PipelineTask("approve", "", tb.PipelineTaskApproval(tb.ApprovalUsers("alice"))),
+				tb.PipelineTask("release", "release-task", tb.RunAfter("approve")),
+			))}
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-approval", "foo",
+				tb.PipelineRunSpec("release-pipeline"),
+				tb.PipelineRunStatus(tb.PipelineRunApprovalsStatus(map[string]*v1alpha1.PipelineRunApprovalStatus{
+					"test-pipeline-run-approval-approve": {PipelineTaskName: "approve"},
+				})),
+			)}
+			as := []*v1alpha1.Approval{tb.Approval("test-pipeline-run-approval-approve", "foo",
+				tb.ApprovalApprovers(tb.ApprovalUsers("alice")),
+				tc.approval,
+				tb.ApprovalStatus(tb.ApprovalStartTime(tc.startTime), tb.ApprovalStatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionUnknown,
+					Reason: v1alpha1.ApprovalReasonWaiting,
+				})),
+			)}
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        []*v1alpha1.Task{tb.Task("release-task", "foo")},
+				Approvals:    as,
+			}
+
+			testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-approval")
+			if err != nil {
+				t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+			}
+			for _, a := range clients.Pipeline.Actions() {
+				if a.GetVerb() == "create" {
+					t.Errorf("Expected nothing to run after the failed approval but got %s", a.(ktesting.CreateAction).GetObject().(metav1.Object).GetName())
+				}
+			}
+			approval, err := clients.Pipeline.Tekton().Approvals("foo").Get("test-pipeline-run-approval-approve", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting the Approval out of fake client: %s", err)
+			}
+			if condition := approval.Status.GetCondition(apis.ConditionSucceeded); !condition.IsFalse() || condition.Reason != tc.expectedReason {
+				t.Errorf("Expected the Approval to fail with reason %s but condition was %v", tc.expectedReason, condition)
+			}
+			reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-approval", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+			}
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if !condition.IsFalse() || condition.Message != "Approval test-pipeline-run-approval-approve has failed" {
+				t.Errorf("Expected PipelineRun to fail because of the approval but condition was %v", condition)
+			}
+		})
+	}
+}
+
+func TestReconcileCancelledPipelineRunWithApproval(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("release-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("approve", "", tb.PipelineTaskApproval(tb.ApprovalUsers("alice"))),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-cancelled", "foo",
+		tb.PipelineRunSpec("release-pipeline", tb.PipelineRunCancelled),
+		tb.PipelineRunStatus(tb.PipelineRunApprovalsStatus(map[string]*v1alpha1.PipelineRunApprovalStatus{
+			"test-pipeline-run-cancelled-approve": {PipelineTaskName: "approve"},
+		})),
+	)}
+	as := []*v1alpha1.Approval{tb.Approval("test-pipeline-run-cancelled-approve", "foo",
+		tb.ApprovalApprovers(tb.ApprovalUsers("alice")),
+		tb.ApprovalStatus(tb.ApprovalStartTime(time.Now()), tb.ApprovalStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+			Reason: v1alpha1.ApprovalReasonWaiting,
+		})),
+	)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Approvals:    as,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-cancelled")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	approval, err := clients.Pipeline.Tekton().Approvals("foo").Get("test-pipeline-run-cancelled-approve", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting the Approval out of fake client: %s", err)
+	}
+	if condition := approval.Status.GetCondition(apis.ConditionSucceeded); !condition.IsFalse() || condition.Reason != v1alpha1.ApprovalReasonCancelled {
+		t.Errorf("Expected the Approval to be cancelled along with the PipelineRun but condition was %v", condition)
+	}
+}
+
+func TestReconcileWithCustomTask(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("wait-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineParam("duration"),
+		tb.PipelineTask("build", "build-task"),
+		tb.PipelineTask("wait", "", tb.RunAfter("build"), tb.PipelineTaskTimeout(time.Hour),
+			tb.PipelineTaskCustomTask("example.dev/v1", "Wait"),
+			tb.PipelineTaskParam("duration", "${params.duration}")),
+		tb.PipelineTask("release", "release-task", tb.PipelineTaskParam("waited", "${tasks.wait.results.waited}")),
+	))}
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("test-pipeline-run-custom", "foo",
+			tb.PipelineRunSpec("wait-pipeline", tb.PipelineRunParam("duration", "1m")),
+			tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+				"test-pipeline-run-custom-build": {PipelineTaskName: "build"},
+			})),
+		),
+		tb.PipelineRun("test-pipeline-run-waited", "foo",
+			tb.PipelineRunSpec("wait-pipeline", tb.PipelineRunParam("duration", "1m")),
+			tb.PipelineRunStatus(
+				tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+					"test-pipeline-run-waited-build": {PipelineTaskName: "build"},
+				}),
+				tb.PipelineRunRunsStatus(map[string]*v1alpha1.PipelineRunRunStatus{
+					"test-pipeline-run-waited-wait": {PipelineTaskName: "wait"},
+				}),
+			),
+		),
+	}
+	ts := []*v1alpha1.Task{tb.Task("build-task", "foo"), tb.Task("release-task", "foo", tb.TaskSpec(tb.TaskInputs(tb.InputsParam("waited"))))}
+	succeeded := tb.TaskRunStatus(tb.Condition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+	}))
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-custom-build", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef("build-task")), succeeded),
+		tb.TaskRun("test-pipeline-run-waited-build", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef("build-task")), succeeded),
+	}
+	runs := []*v1alpha1.Run{tb.Run("test-pipeline-run-waited-wait", "foo",
+		tb.RunRef("example.dev/v1", "Wait", ""),
+		tb.RunParam("duration", "1m"),
+		tb.RunTimeout(time.Hour),
+		tb.RunStatus(tb.RunStartTime(time.Now()), tb.RunResult("waited", "60s"), tb.RunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+		})),
+	)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		Runs:         runs,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-custom")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The custom task is run by a Run owned by the PipelineRun instead of a TaskRun
+	var run *v1alpha1.Run
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRun to be created for the custom task but got %s", a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun).Name)
+		}
+		if a.GetVerb() == "create" && a.GetResource().Resource == "runs" {
+			run = a.(ktesting.CreateAction).GetObject().(*v1alpha1.Run)
+		}
+	}
+	if run == nil {
+		t.Fatalf("Expected a Run to be created for the custom task but got none")
+	}
+	if len(run.OwnerReferences) != 1 || run.OwnerReferences[0].Name != "test-pipeline-run-custom" {
+		t.Errorf("Expected the Run to be owned by the PipelineRun but got %v", run.OwnerReferences)
+	}
+	expectedSpec := v1alpha1.RunSpec{
+		Ref: &v1alpha1.TaskRef{
+			APIVersion: "example.dev/v1",
+			Kind:       "Wait",
+		},
+		Params:  []v1alpha1.Param{{Name: "duration", Value: "1m"}},
+		Timeout: &metav1.Duration{Duration: time.Hour},
+	}
+	if d := cmp.Diff(expectedSpec, run.Spec); d != "" {
+		t.Errorf("Expected the custom task, params and timeout of the PipelineTask to be passed to the Run. Diff -want, +got: %s", d)
+	}
+	run, err = clients.Pipeline.Tekton().Runs("foo").Get(run.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting the Run out of fake client: %s", err)
+	}
+	if !run.HasStarted() || !run.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected the Run to be started but status was %v", run.Status)
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-custom", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running while the Run is but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if prrs, ok := reconciledRun.Status.Runs[run.Name]; !ok || prrs.PipelineTaskName != "wait" {
+		t.Errorf("Expected the Run to be recorded in the status of the PipelineRun but got %v", reconciledRun.Status.Runs)
+	}
+
+	// Once the Run succeeded, the PipelineTasks using its results are started
+	clients.Pipeline.ClearActions()
+	err = c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-waited")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	var tr *v1alpha1.TaskRun
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			tr = a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+		}
+	}
+	if tr == nil {
+		t.Fatalf("Expected the PipelineTask using the results of the Run to be run but nothing was created")
+	}
+	if d := cmp.Diff([]v1alpha1.Param{{Name: "waited", Value: "60s"}}, tr.Spec.Inputs.Params); d != "" {
+		t.Errorf("Expected the result of the Run to be passed to the TaskRun. Diff -want, +got: %s", d)
+	}
+}
+
+func TestReconcileWithTimedOutRun(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("wait-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("wait", "", tb.PipelineTaskCustomTask("example.dev/v1", "Wait")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-custom", "foo",
+		tb.PipelineRunSpec("wait-pipeline"),
+		tb.PipelineRunStatus(tb.PipelineRunRunsStatus(map[string]*v1alpha1.PipelineRunRunStatus{
+			"test-pipeline-run-custom-wait": {PipelineTaskName: "wait"},
+		})),
+	)}
+	runs := []*v1alpha1.Run{tb.Run("test-pipeline-run-custom-wait", "foo",
+		tb.RunRef("example.dev/v1", "Wait", ""),
+		tb.RunTimeout(time.Hour),
+		tb.RunStatus(tb.RunStartTime(time.Now().Add(-2*time.Hour)), tb.RunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		})),
+	)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Runs:         runs,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-custom")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	run, err := clients.Pipeline.Tekton().Runs("foo").Get("test-pipeline-run-custom-wait", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting the Run out of fake client: %s", err)
+	}
+	if condition := run.Status.GetCondition(apis.ConditionSucceeded); !condition.IsFalse() || condition.Reason != v1alpha1.RunReasonTimedOut {
+		t.Errorf("Expected the Run to time out but condition was %v", condition)
+	}
+	if !run.IsCancelled() {
+		t.Errorf("Expected the Run to be cancelled once timed out but spec status was %q", run.Spec.Status)
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-custom", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Message != "Run test-pipeline-run-custom-wait has failed" {
+		t.Errorf("Expected PipelineRun to fail because of the Run but condition was %v", condition)
+	}
+}
+
+func TestReconcileCancelledPipelineRunWithRun(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("wait-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("wait", "", tb.PipelineTaskCustomTask("example.dev/v1", "Wait")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-cancelled", "foo",
+		tb.PipelineRunSpec("wait-pipeline", tb.PipelineRunCancelled),
+		tb.PipelineRunStatus(tb.PipelineRunRunsStatus(map[string]*v1alpha1.PipelineRunRunStatus{
+			"test-pipeline-run-cancelled-wait": {PipelineTaskName: "wait"},
+		})),
+	)}
+	runs := []*v1alpha1.Run{tb.Run("test-pipeline-run-cancelled-wait", "foo",
+		tb.RunRef("example.dev/v1", "Wait", ""),
+		tb.RunStatus(tb.RunStartTime(time.Now()), tb.RunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		})),
+	)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Runs:         runs,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-cancelled")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	run, err := clients.Pipeline.Tekton().Runs("foo").Get("test-pipeline-run-cancelled-wait", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting the Run out of fake client: %s", err)
+	}
+	if !run.IsCancelled() {
+		t.Errorf("Expected the Run to be cancelled along with the PipelineRun but spec status was %q", run.Spec.Status)
+	}
+}
+
+func TestReconcileWithConditionChecks(t *testing.T) {
+	names.TestingSeed()
+	prName := "test-pipeline-run"
+	conditions := []*v1alpha1.Condition{
+		tb.ConditionCRD("cond-1", "foo", tb.ConditionSpec(
+			tb.ConditionParam("env"),
+			tb.ConditionSpecCheck("check", "ubuntu", tb.Command("test"), tb.Args("${inputs.params.env}", "=", "prod")),
+		)),
+		tb.ConditionCRD("cond-2", "foo", tb.ConditionSpec(
+			tb.ConditionSpecCheck("check", "ubuntu", tb.Command("true")),
+		)),
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world",
+			tb.PipelineTaskCondition("cond-1", tb.PipelineTaskConditionParam("env", "prod")),
+			tb.PipelineTaskCondition("cond-2"),
+		),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun(prName, "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		Conditions:   conditions,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/"+prName)
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Check that the condition checks were created, but not the TaskRun
+	createdNames := []string{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			tr := a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+			createdNames = append(createdNames, tr.Name)
+			if tr.Spec.TaskSpec == nil || len(tr.Spec.TaskSpec.Steps) != 1 {
+				t.Errorf("Expected condition check %s to run the check as its only step but got %v", tr.Name, tr.Spec.TaskSpec)
+			}
+			if tr.Labels[pipeline.GroupName+pipeline.PipelineRunLabelKey] != prName {
+				t.Errorf("Expected condition check %s to be labelled with the PipelineRun but got labels %v", tr.Name, tr.Labels)
+			}
+		}
+	}
+	expectedNames := []string{
+		"test-pipeline-run-hello-world-1-9l9zj-cond-1-mz4c7",
+		"test-pipeline-run-hello-world-1-9l9zj-cond-2-mssqb",
+	}
+	if d := cmp.Diff(expectedNames, createdNames); d != "" {
+		t.Errorf("Expected only the condition checks to be created. Diff -want, +got: %s", d)
+	}
+
+	cc, err := clients.Pipeline.Tekton().TaskRuns("foo").Get(expectedNames[0], metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't get condition check %s: %s", expectedNames[0], err)
+	}
+	expectedParams := []v1alpha1.Param{{Name: "env", Value: "prod"}}
+	if d := cmp.Diff(expectedParams, cc.Spec.Inputs.Params); d != "" {
+		t.Errorf("Expected condition check to be created with the PipelineTask's condition params. Diff -want, +got: %s", d)
+	}
+	if cc.Labels[pipeline.GroupName+pipeline.ConditionNameKey] != "cond-1" {
+		t.Errorf("Expected condition check to be labelled with the name of the condition but got labels %v", cc.Labels)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get(prName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	prtrs := reconciledRun.Status.TaskRuns["test-pipeline-run-hello-world-1-9l9zj"]
+	if prtrs == nil || len(prtrs.ConditionChecks) != 2 {
+		t.Fatalf("Expected the condition checks to be recorded in the PipelineRun status but got %v", reconciledRun.Status.TaskRuns)
+	}
+	if prtrs.ConditionChecks[expectedNames[1]].ConditionName != "cond-2" {
+		t.Errorf("Expected condition check %s to be for cond-2 but got %v", expectedNames[1], prtrs.ConditionChecks[expectedNames[1]])
+	}
+}
+
+func TestReconcileWithFailingConditionChecks(t *testing.T) {
+	prName := "test-pipeline-run-with-failing-conditions"
+	conditions := []*v1alpha1.Condition{
+		tb.ConditionCRD("always-false", "foo", tb.ConditionSpec(
+			tb.ConditionSpecCheck("check", "ubuntu", tb.Command("false")),
+		)),
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("task-1", "hello-world", tb.PipelineTaskCondition("always-false")),
+		tb.PipelineTask("task-2", "hello-world", tb.RunAfter("task-1")),
+		tb.PipelineTask("task-3", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun(prName, "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			prName + "-task-1": {
+				PipelineTaskName: "task-1",
+				ConditionChecks: map[string]*v1alpha1.PipelineRunConditionCheckStatus{
+					prName + "-task-1-always-false": {ConditionName: "always-false"},
+				},
+			},
+			prName + "-task-3": {PipelineTaskName: "task-3"},
+		})),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun(prName+"-task-1-always-false", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", prName),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+			})),
+		),
+		tb.TaskRun(prName+"-task-3", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", prName),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			})),
+		),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		Conditions:   conditions,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/"+prName)
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+			t.Errorf("Expected no TaskRuns to be created for skipped tasks but %s was", a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun).Name)
+		}
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get(prName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("Expected PipelineRun to succeed once the remaining tasks are done but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	expectedSkipped := []v1alpha1.SkippedTask{{
+		Name:    "task-1",
+		Reason:  resources.ReasonConditionCheckFailed,
+		Message: "Condition always-false evaluated by " + prName + "-task-1-always-false was false",
+	}, {
+		Name:    "task-2",
+		Reason:  resources.ReasonParentTaskSkipped,
+		Message: "PipelineTask task-1 was skipped",
+	}}
+	if d := cmp.Diff(expectedSkipped, reconciledRun.Status.SkippedTasks); d != "" {
+		t.Errorf("Expected the skipped tasks to be recorded in the status. Diff -want, +got: %s", d)
+	}
+}
+
+func TestReconcileWithPassingConditionChecks(t *testing.T) {
+	prName := "test-pipeline-run-with-passing-conditions"
+	conditions := []*v1alpha1.Condition{
+		tb.ConditionCRD("always-true", "foo", tb.ConditionSpec(
+			tb.ConditionSpecCheck("check", "ubuntu", tb.Command("true")),
+		)),
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("task-1", "hello-world", tb.PipelineTaskCondition("always-true")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun(prName, "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			prName + "-task-1": {
+				PipelineTaskName: "task-1",
+				ConditionChecks: map[string]*v1alpha1.PipelineRunConditionCheckStatus{
+					prName + "-task-1-always-true": {ConditionName: "always-true"},
+				},
+			},
+		})),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun(prName+"-task-1-always-true", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", prName),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			})),
+		),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		Conditions:   conditions,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/"+prName)
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if actual.Name != prName+"-task-1" || actual.Spec.TaskSpec == nil || actual.Labels["tekton.dev/task"] != "hello-world" {
+		t.Errorf("Expected the TaskRun of the PipelineTask to be created once its conditions passed but got %v", actual)
+	}
+}
+
+func TestReconcileWithMaxParallelTasks(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("a", "hello-world"),
+		tb.PipelineTask("b", "hello-world"),
+		tb.PipelineTask("c", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{
+		tb.PipelineRun("test-pipeline-run-limited", "foo",
+			tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"), tb.PipelineRunMaxParallelTasks(2)),
+		),
+		tb.PipelineRun("test-pipeline-run-limited-running", "foo",
+			tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"), tb.PipelineRunMaxParallelTasks(2)),
+			tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+				"test-pipeline-run-limited-running-a": {PipelineTaskName: "a"},
+				"test-pipeline-run-limited-running-b": {PipelineTaskName: "b"},
+			})),
+		),
+	}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-limited-running-a", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-limited-running"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})),
+		),
+		tb.TaskRun("test-pipeline-run-limited-running-b", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-limited-running"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown})),
+		),
+	}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	// The TaskRuns created are named after their PipelineTask, followed by a random suffix
+	createdTaskRuns := func() []string {
+		created := []string{}
+		for _, a := range clients.Pipeline.Actions() {
+			if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+				tr := a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+				created = append(created, tr.Name[:strings.LastIndex(tr.Name, "-")])
+			}
+		}
+		return created
+	}
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-limited")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	if d := cmp.Diff([]string{"test-pipeline-run-limited-a", "test-pipeline-run-limited-b"}, createdTaskRuns()); d != "" {
+		t.Errorf("Expected only two PipelineTasks to be started at once. Diff -want, +got: %s", d)
+	}
+
+	// Once a PipelineTask has finished, another one can start
+	clients.Pipeline.ClearActions()
+	err = c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-limited-running")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	if d := cmp.Diff([]string{"test-pipeline-run-limited-running-c"}, createdTaskRuns()); d != "" {
+		t.Errorf("Expected the last PipelineTask to be started. Diff -want, +got: %s", d)
+	}
+}
+
+func TestReconcileQueuedPipelineRun(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	running := tb.PipelineRun("test-pipeline-run-running", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(time.Now()),
+			tb.PipelineRunStatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}),
+		),
+	)
+	prs := []*v1alpha1.PipelineRun{
+		running,
+		tb.PipelineRun("test-pipeline-run-queued", "foo",
+			tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		),
+	}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	// Only one PipelineRun can run at once in namespace foo
+	c.Reconciler.(*Reconciler).configStore.(*config.Store).OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.ConcurrencyConfigName, Namespace: system.GetNamespace()},
+		Data:       map[string]string{"foo": "1"},
+	})
+
+	createdTaskRuns := func() []*v1alpha1.TaskRun {
+		created := []*v1alpha1.TaskRun{}
+		for _, a := range clients.Pipeline.Actions() {
+			if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+				created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+			}
+		}
+		return created
+	}
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-queued")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	if created := createdTaskRuns(); len(created) != 0 {
+		t.Errorf("Expected no TaskRun to be created for a queued PipelineRun but got %v", created)
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-queued", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionUnknown || condition.Reason != ReasonQueued {
+		t.Errorf("Expected PipelineRun to be queued but condition was %v", condition)
+	}
+	if reconciledRun.HasStarted() {
+		t.Errorf("Expected a queued PipelineRun not to have started but it started at %v", reconciledRun.Status.StartTime)
+	}
+
+	// The queued PipelineRun starts once the running one is done
+	done := running.DeepCopy()
+	done.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	if err := testAssets.Informers.PipelineRun.Informer().GetIndexer().Update(done); err != nil {
+		t.Fatalf("Failed to update the PipelineRun informer: %s", err)
+	}
+	queued, err := testAssets.Informers.PipelineRun.Lister().PipelineRuns("foo").Get("test-pipeline-run-queued")
+	if err != nil {
+		t.Fatalf("Failed to get the queued PipelineRun: %s", err)
+	}
+	if err := testAssets.Informers.PipelineRun.Informer().GetIndexer().Update(reconciledRun); err != nil {
+		t.Fatalf("Failed to update the PipelineRun informer: %s", err)
+	}
+	clients.Pipeline.ClearActions()
+	err = c.Reconciler.Reconcile(context.Background(), getRunName(queued))
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	if created := createdTaskRuns(); len(created) != 1 {
+		t.Errorf("Expected the dequeued PipelineRun to create a TaskRun but got %v", created)
+	}
+	reconciledRun, err = clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-queued", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.HasStarted() {
+		t.Error("Expected the dequeued PipelineRun to have started")
+	}
+	if condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded); condition.Reason == ReasonQueued {
+		t.Errorf("Expected the dequeued PipelineRun not to be queued anymore but condition was %v", condition)
+	}
+}
+
+func TestReconcileWithConcurrencyGroup(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name            string
+		policy          v1alpha1.PipelineRunConcurrencyPolicy
+		newStarted      bool
+		reconciled      string
+		expectedReason  string
+		expectCancelled bool
+	}{{
+		name:            "cancel in progress",
+		policy:          v1alpha1.PipelineRunConcurrencyPolicyCancelInProgress,
+		reconciled:      "test-pipeline-run-new",
+		expectCancelled: true,
+	}, {
+		name:           "superseded",
+		policy:         v1alpha1.PipelineRunConcurrencyPolicyCancelInProgress,
+		newStarted:     true,
+		reconciled:     "test-pipeline-run-old",
+		expectedReason: ReasonSuperseded,
+	}, {
+		name:           "queue",
+		policy:         v1alpha1.PipelineRunConcurrencyPolicyQueue,
+		reconciled:     "test-pipeline-run-new",
+		expectedReason: ReasonQueued,
+	}, {
+		name:           "skip",
+		policy:         v1alpha1.PipelineRunConcurrencyPolicySkip,
+		reconciled:     "test-pipeline-run-new",
+		expectedReason: ReasonSkipped,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+				tb.PipelineParam("branch"),
+				tb.PipelineTask("hello-world-1", "hello-world"),
+			))}
+			// The old PipelineRun is running unless the new one is
+			groupLabel := tb.PipelineRunLabel(pipeline.GroupName+pipeline.ConcurrencyGroupLabelKey, hashConcurrencyGroup("ci-master"))
+			oldRun := tb.PipelineRun("test-pipeline-run-old", "foo", groupLabel,
+				tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+					tb.PipelineRunParam("branch", "master"),
+					tb.PipelineRunConcurrency("ci-${params.branch}", tc.policy),
+				),
+			)
+			oldRun.CreationTimestamp = metav1.Time{Time: now.Add(-time.Minute)}
+			newRun := tb.PipelineRun("test-pipeline-run-new", "foo",
+				tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+					tb.PipelineRunParam("branch", "master"),
+					tb.PipelineRunConcurrency("ci-${params.branch}", tc.policy),
+				),
+			)
+			newRun.CreationTimestamp = metav1.Time{Time: now}
+			started := oldRun
+			if tc.newStarted {
+				started = newRun
+				groupLabel(newRun)
+			}
+			started.Status.StartTime = &metav1.Time{Time: now}
+			started.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown})
+			// A PipelineRun of another group isn't affected
+			other := tb.PipelineRun("test-pipeline-run-other", "foo",
+				tb.PipelineRunLabel(pipeline.GroupName+pipeline.ConcurrencyGroupLabelKey, hashConcurrencyGroup("ci-release")),
+				tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+					tb.PipelineRunParam("branch", "release"),
+					tb.PipelineRunConcurrency("ci-${params.branch}", tc.policy),
+				),
+				tb.PipelineRunStatus(
+					tb.PipelineRunStartTime(now),
+					tb.PipelineRunStatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}),
+				),
+			)
+			d := test.Data{
+				PipelineRuns: []*v1alpha1.PipelineRun{oldRun, newRun, other},
+				Pipelines:    ps,
+				Tasks:        []*v1alpha1.Task{tb.Task("hello-world", "foo")},
+			}
+
+			testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/"+tc.reconciled); err != nil {
+				t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+			}
+
+			createdTaskRuns := 0
+			cancelled := []string{}
+			for _, a := range clients.Pipeline.Actions() {
+				if a.GetVerb() == "create" && a.GetResource().Resource == "taskruns" {
+					createdTaskRuns++
+				}
+				if a.GetVerb() == "update" && a.GetResource().Resource == "pipelineruns" {
+					if pr := a.(ktesting.UpdateAction).GetObject().(*v1alpha1.PipelineRun); pr.IsCancelled() {
+						cancelled = append(cancelled, pr.Name)
+					}
+				}
+			}
+			if tc.expectCancelled {
+				if d := cmp.Diff([]string{"test-pipeline-run-old"}, cancelled); d != "" {
+					t.Errorf("Expected the old PipelineRun of the group to be cancelled. Diff -want, +got: %s", d)
+				}
+			} else if len(cancelled) != 0 {
+				t.Errorf("Expected no PipelineRun to be cancelled but got %v", cancelled)
+			}
+
+			reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get(tc.reconciled, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+			}
+			if label := reconciledRun.Labels[pipeline.GroupName+pipeline.ConcurrencyGroupLabelKey]; label != hashConcurrencyGroup("ci-master") {
+				t.Errorf("Expected the PipelineRun to be labelled with the hash of its concurrency group but got %q", label)
+			}
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			if tc.expectedReason == "" {
+				if createdTaskRuns != 1 || !reconciledRun.HasStarted() {
+					t.Errorf("Expected the PipelineRun to start but it created %d TaskRuns, with condition %v", createdTaskRuns, condition)
+				}
+			} else {
+				if createdTaskRuns != 0 || reconciledRun.HasStarted() {
+					t.Errorf("Expected the PipelineRun not to start but it created %d TaskRuns", createdTaskRuns)
+				}
+				if condition == nil || condition.Reason != tc.expectedReason {
+					t.Errorf("Expected the PipelineRun condition to have reason %s but got %v", tc.expectedReason, condition)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileWithConcurrencyGroup_NotCachedYet(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name           string
+		policy         v1alpha1.PipelineRunConcurrencyPolicy
+		first, second  string
+		expectedReason string
+	}{{
+		name:           "queue",
+		policy:         v1alpha1.PipelineRunConcurrencyPolicyQueue,
+		first:          "test-pipeline-run-old",
+		second:         "test-pipeline-run-new",
+		expectedReason: ReasonQueued,
+	}, {
+		name:           "superseded",
+		policy:         v1alpha1.PipelineRunConcurrencyPolicyCancelInProgress,
+		first:          "test-pipeline-run-new",
+		second:         "test-pipeline-run-old",
+		expectedReason: ReasonSuperseded,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Neither PipelineRun was labelled with its concurrency group, nor started, yet
+			newRun := func(name string, created time.Time) *v1alpha1.PipelineRun {
+				pr := tb.PipelineRun(name, "foo",
+					tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+						tb.PipelineRunParam("branch", "master"),
+						tb.PipelineRunConcurrency("ci-${params.branch}", tc.policy),
+					),
+				)
+				pr.CreationTimestamp = metav1.Time{Time: created}
+				return pr
+			}
+			prs := []*v1alpha1.PipelineRun{
+				newRun("test-pipeline-run-old", now.Add(-time.Minute)),
+				newRun("test-pipeline-run-new", now),
+			}
+			d := test.Data{
+				PipelineRuns: []*v1alpha1.PipelineRun{prs[0].DeepCopy(), prs[1].DeepCopy()},
+				Pipelines: []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+					tb.PipelineParam("branch"),
+					tb.PipelineTask("hello-world-1", "hello-world"),
+				))},
+				Tasks: []*v1alpha1.Task{tb.Task("hello-world", "foo")},
+			}
+			testAssets := getPipelineRunController(d, record.NewFakeRecorder(4))
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			// The informer's cache doesn't have the updates of the first PipelineRun yet when
+			// the second one is reconciled, as when they are reconciled right after each other
+			for _, name := range []string{tc.first, tc.second} {
+				for _, pr := range prs {
+					if err := testAssets.Informers.PipelineRun.Informer().GetIndexer().Update(pr.DeepCopy()); err != nil {
+						t.Fatalf("Failed to update the PipelineRun informer: %s", err)
+					}
+				}
+				if err := c.Reconciler.Reconcile(context.Background(), "foo/"+name); err != nil {
+					t.Errorf("Did not expect to see error when reconciling PipelineRun %s but saw %s", name, err)
+				}
+			}
+
+			first, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get(tc.first, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+			}
+			if !first.HasStarted() {
+				t.Errorf("Expected PipelineRun %s to start but its condition is %v", tc.first, first.Status.GetCondition(apis.ConditionSucceeded))
+			}
+			second, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get(tc.second, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+			}
+			if second.HasStarted() {
+				t.Errorf("Expected PipelineRun %s not to start", tc.second)
+			}
+			if condition := second.Status.GetCondition(apis.ConditionSucceeded); condition == nil || condition.Reason != tc.expectedReason {
+				t.Errorf("Expected PipelineRun %s condition to have reason %s but got %v", tc.second, tc.expectedReason, condition)
+			}
+		})
+	}
+}
+
+func TestReconcileWithApproval_NoTimeLeft(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("release-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("approve", "", tb.PipelineTaskApproval(tb.ApprovalUsers("alice"))),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-approval", "foo",
+		tb.PipelineRunSpec("release-pipeline", tb.PipelineRunTimeout(&metav1.Duration{Duration: time.Minute})),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(time.Now().Add(-time.Minute)),
+			tb.PipelineRunStatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}),
+		),
+	)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	// The PipelineRun has no time left to wait for a decision, so it times out rather than
+	// creating an Approval with a timeout of 0, which isn't valid
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-approval"); err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "approvals" {
+			t.Errorf("Expected no Approval to be created but got %v", a.(ktesting.CreateAction).GetObject())
+		}
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-approval", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded); !condition.IsFalse() || condition.Reason != resources.ReasonTimedOut {
+		t.Errorf("Expected the PipelineRun to time out but condition was %v", condition)
+	}
+}
+
+func TestReconcileWithCustomTask_NoTimeLeft(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("wait-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("wait", "", tb.PipelineTaskCustomTask("example.dev/v1", "Wait")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-custom", "foo",
+		tb.PipelineRunSpec("wait-pipeline", tb.PipelineRunTimeout(&metav1.Duration{Duration: time.Minute})),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(time.Now().Add(-time.Minute)),
+			tb.PipelineRunStatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}),
+		),
+	)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+	}
+
+	testAssets := getPipelineRunController(d, record.NewFakeRecorder(2))
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	// The PipelineRun has no time left to run the custom task, so it times out rather than
+	// creating a Run with a timeout of 0, which isn't valid
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-custom"); err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" && a.GetResource().Resource == "runs" {
+			t.Errorf("Expected no Run to be created but got %v", a.(ktesting.CreateAction).GetObject())
+		}
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-custom", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded); !condition.IsFalse() || condition.Reason != resources.ReasonTimedOut {
+		t.Errorf("Expected the PipelineRun to time out but condition was %v", condition)
+	}
+}
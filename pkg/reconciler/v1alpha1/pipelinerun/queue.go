@@ -0,0 +1,275 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/knative/pkg/controller"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun/config"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// checkConcurrency returns true if pr can't start now because of the other PipelineRuns
+// of its concurrency group or of its namespace, in which case the condition of pr says
+// whether it is queued or won't run at all. With the cancel-in-progress policy, the older
+// PipelineRuns of the concurrency group of pr are cancelled instead.
+func (c *Reconciler) checkConcurrency(ctx context.Context, pr *v1alpha1.PipelineRun) (bool, error) {
+	// The PipelineRuns are checked one at a time, so that each one counts the ones
+	// allowed to start before it
+	c.concurrencyMu.Lock()
+	defer c.concurrencyMu.Unlock()
+	key := pr.Namespace + "/" + pr.Name
+	delete(c.startingPipelineRuns, key)
+
+	prs, err := c.listPipelineRuns(pr.Namespace)
+	if err != nil {
+		return false, err
+	}
+	if waiting, err := c.checkConcurrencyGroup(pr, prs); err != nil || waiting {
+		return waiting, err
+	}
+	if queued := getQueuedMessage(pr, prs, config.FromContext(ctx).Concurrency.Limit(pr.Namespace)); queued != "" {
+		pr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonQueued,
+			Message: queued,
+		})
+		return true, nil
+	}
+	c.startingPipelineRuns[key] = struct{}{}
+	return false, nil
+}
+
+// listPipelineRuns returns the PipelineRuns of namespace, where the ones allowed to start
+// whose start isn't in the informer's cache yet are marked as started.
+func (c *Reconciler) listPipelineRuns(namespace string) ([]*v1alpha1.PipelineRun, error) {
+	prs, err := c.pipelineRunLister.PipelineRuns(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	listed := map[string]struct{}{}
+	for i, pr := range prs {
+		key := pr.Namespace + "/" + pr.Name
+		listed[key] = struct{}{}
+		if _, ok := c.startingPipelineRuns[key]; !ok {
+			continue
+		}
+		if pr.HasStarted() || pr.IsDone() {
+			delete(c.startingPipelineRuns, key)
+			continue
+		}
+		pr = pr.DeepCopy()
+		pr.Status.StartTime = &metav1.Time{Time: time.Now()}
+		prs[i] = pr
+	}
+	// Forget the PipelineRuns deleted before their start was seen
+	for key := range c.startingPipelineRuns {
+		if _, ok := listed[key]; !ok && strings.HasPrefix(key, namespace+"/") {
+			delete(c.startingPipelineRuns, key)
+		}
+	}
+	return prs, nil
+}
+
+// checkConcurrencyGroup labels pr with the hash of its concurrency group and applies its
+// concurrency policy to the other PipelineRuns of the group which are in progress, among
+// prs, returning true if pr has to wait for them, or was skipped or superseded by them.
+// The groups are computed from the specs of the PipelineRuns rather than from their
+// labels, which the PipelineRuns created recently don't have yet.
+func (c *Reconciler) checkConcurrencyGroup(pr *v1alpha1.PipelineRun, prs []*v1alpha1.PipelineRun) (bool, error) {
+	if pr.Spec.Concurrency == nil || isChildPipelineRun(pr) {
+		return false, nil
+	}
+	group := c.getConcurrencyGroup(pr)
+	labelKey := pipeline.GroupName + pipeline.ConcurrencyGroupLabelKey
+	if pr.ObjectMeta.Labels == nil {
+		pr.ObjectMeta.Labels = make(map[string]string)
+	}
+	pr.ObjectMeta.Labels[labelKey] = hashConcurrencyGroup(group)
+
+	// The PipelineRuns of the group which are in progress, split between the ones created
+	// before pr and the ones created after it
+	var older, newer []*v1alpha1.PipelineRun
+	for _, other := range prs {
+		if other.Name == pr.Name || other.Spec.Concurrency == nil || other.IsDone() || other.IsCancelled() || isChildPipelineRun(other) {
+			continue
+		}
+		if c.getConcurrencyGroup(other) != group {
+			continue
+		}
+		if createdBefore(other, pr) {
+			older = append(older, other)
+		} else if other.HasStarted() {
+			newer = append(newer, other)
+		}
+	}
+
+	switch pr.Spec.Concurrency.Policy {
+	case v1alpha1.PipelineRunConcurrencyPolicyCancelInProgress:
+		if len(newer) > 0 {
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: ReasonSuperseded,
+				Message: fmt.Sprintf("PipelineRun %s was superseded by PipelineRun %s of concurrency group %q",
+					fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), newer[0].Name, group),
+			})
+			return true, nil
+		}
+		errs := []string{}
+		for _, other := range older {
+			c.Logger.Infof("Cancelling PipelineRun %s superseded by PipelineRun %s of concurrency group %q", other.Name, pr.Name, group)
+			errs = append(errs, requestPipelineRunCancellation(other, pr.Namespace, c.PipelineClientSet)...)
+		}
+		if len(errs) > 0 {
+			return false, fmt.Errorf("error(s) cancelling the PipelineRuns superseded by PipelineRun %s: %s", pr.Name, strings.Join(errs, "\n"))
+		}
+	case v1alpha1.PipelineRunConcurrencyPolicySkip:
+		if blocking := append(older, newer...); len(blocking) > 0 {
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: ReasonSkipped,
+				Message: fmt.Sprintf("PipelineRun %s was skipped because PipelineRun %s of concurrency group %q is in progress",
+					fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), blocking[0].Name, group),
+			})
+			return true, nil
+		}
+	default:
+		if blocking := append(older, newer...); len(blocking) > 0 {
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: ReasonQueued,
+				Message: fmt.Sprintf("PipelineRun %s is queued behind PipelineRun %s of concurrency group %q",
+					fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), blocking[0].Name, group),
+			})
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getConcurrencyGroup returns the concurrency group of pr.
+func (c *Reconciler) getConcurrencyGroup(pr *v1alpha1.PipelineRun) string {
+	// The Pipeline declares the default values of the params the group can refer to
+	p, err := c.getPipeline(pr)
+	if err != nil {
+		p = nil
+	}
+	return resources.GetConcurrencyGroup(p, pr)
+}
+
+// hashConcurrencyGroup returns a hash of group which can be used as a label value.
+func hashConcurrencyGroup(group string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(group)))[:32]
+}
+
+// createdBefore returns true if pr was created before other, using their names to order
+// the PipelineRuns created at the same time.
+func createdBefore(pr, other *v1alpha1.PipelineRun) bool {
+	if pr.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return pr.Name < other.Name
+	}
+	return pr.CreationTimestamp.Before(&other.CreationTimestamp)
+}
+
+// getQueuedMessage returns why pr has to wait before starting when limit PipelineRuns
+// at most can run at once in its namespace, where prs are all the PipelineRuns of
+// the namespace, or an empty string if pr can start. The PipelineRuns waiting to
+// start are started in the order they were created, and the ones run by the
+// PipelineTasks of another PipelineRun are never queued, nor counted.
+func getQueuedMessage(pr *v1alpha1.PipelineRun, prs []*v1alpha1.PipelineRun, limit int) string {
+	if limit <= 0 || isChildPipelineRun(pr) {
+		return ""
+	}
+	running := 0
+	queue := []*v1alpha1.PipelineRun{}
+	for _, other := range prs {
+		if other.IsDone() || isChildPipelineRun(other) {
+			continue
+		}
+		if other.HasStarted() {
+			running++
+		} else if !other.IsCancelled() {
+			queue = append(queue, other)
+		}
+	}
+	sort.Slice(queue, func(i, j int) bool {
+		return createdBefore(queue[i], queue[j])
+	})
+	ahead := 0
+	for _, other := range queue {
+		if other.Name == pr.Name {
+			break
+		}
+		ahead++
+	}
+	if running+ahead < limit {
+		return ""
+	}
+	return fmt.Sprintf("PipelineRun %s is queued behind %d other PipelineRuns: %d PipelineRuns are running in namespace %s, which allows at most %d",
+		fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), ahead, running, pr.Namespace, limit)
+}
+
+// isChildPipelineRun returns true if pr was created by a PipelineTask of another PipelineRun.
+func isChildPipelineRun(pr *v1alpha1.PipelineRun) bool {
+	owner := metav1.GetControllerOf(pr)
+	return owner != nil && owner.Kind == pipelineRunControllerName
+}
+
+// enqueueQueuedPipelineRuns returns a handler enqueueing the PipelineRuns waiting to
+// start in the namespace of the PipelineRun it is called with, since one of them may
+// start once that one has finished or was deleted.
+func (c *Reconciler) enqueueQueuedPipelineRuns(impl *controller.Impl) func(obj interface{}) {
+	return func(obj interface{}) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			c.Logger.Errorf("Couldn't get the key of PipelineRun %v: %v", obj, err)
+			return
+		}
+		namespace, _, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			c.Logger.Errorf("Couldn't get the namespace of PipelineRun %s: %v", key, err)
+			return
+		}
+		prs, err := c.pipelineRunLister.PipelineRuns(namespace).List(labels.Everything())
+		if err != nil {
+			c.Logger.Errorf("Couldn't list the PipelineRuns of namespace %s: %v", namespace, err)
+			return
+		}
+		for _, pr := range prs {
+			if !pr.HasStarted() && !pr.IsDone() {
+				impl.Enqueue(pr)
+			}
+		}
+	}
+}
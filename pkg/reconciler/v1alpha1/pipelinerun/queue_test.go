@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"testing"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetQueuedMessage(t *testing.T) {
+	now := time.Now()
+	created := func(pr *v1alpha1.PipelineRun, at time.Time) *v1alpha1.PipelineRun {
+		pr.CreationTimestamp = metav1.Time{Time: at}
+		return pr
+	}
+	running := tb.PipelineRun("running", "foo", tb.PipelineRunSpec("test-pipeline"),
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(now)),
+	)
+	done := tb.PipelineRun("done", "foo", tb.PipelineRunSpec("test-pipeline"),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(now),
+			tb.PipelineRunStatusCondition(apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}),
+		),
+	)
+	child := tb.PipelineRun("child", "foo", tb.PipelineRunSpec("test-pipeline"),
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(now)),
+	)
+	child.OwnerReferences = []metav1.OwnerReference{{Kind: "PipelineRun", Name: "running", Controller: &[]bool{true}[0]}}
+	first := created(tb.PipelineRun("first", "foo", tb.PipelineRunSpec("test-pipeline")), now)
+	second := created(tb.PipelineRun("second", "foo", tb.PipelineRunSpec("test-pipeline")), now.Add(time.Minute))
+	cancelled := created(tb.PipelineRun("cancelled", "foo", tb.PipelineRunSpec("test-pipeline", tb.PipelineRunCancelled)), now.Add(-time.Minute))
+	newChild := created(tb.PipelineRun("new-child", "foo", tb.PipelineRunSpec("test-pipeline")), now)
+	newChild.OwnerReferences = child.OwnerReferences
+	prs := []*v1alpha1.PipelineRun{second, running, child, done, cancelled, first, newChild}
+
+	for _, tc := range []struct {
+		name   string
+		pr     *v1alpha1.PipelineRun
+		limit  int
+		queued bool
+	}{{
+		name:   "no limit",
+		pr:     second,
+		limit:  0,
+		queued: false,
+	}, {
+		name:   "limit reached",
+		pr:     first,
+		limit:  1,
+		queued: true,
+	}, {
+		name:   "first in queue",
+		pr:     first,
+		limit:  2,
+		queued: false,
+	}, {
+		name:   "behind the first in queue",
+		pr:     second,
+		limit:  2,
+		queued: true,
+	}, {
+		name:   "room for the whole queue",
+		pr:     second,
+		limit:  3,
+		queued: false,
+	}, {
+		name:   "nested PipelineRun",
+		pr:     newChild,
+		limit:  1,
+		queued: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := getQueuedMessage(tc.pr, prs, tc.limit)
+			if queued := msg != ""; queued != tc.queued {
+				t.Errorf("Expected PipelineRun %s to be queued: %t, but got message %q", tc.pr.Name, tc.queued, msg)
+			}
+		})
+	}
+}
@@ -22,37 +22,132 @@ import (
 
 // ApplyParameters applies the params from a PipelineRun.Params to a PipelineSpec.
 func ApplyParameters(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) *v1alpha1.Pipeline {
+	stringReplacements, arrayReplacements := getParamReplacements(p, pr)
+	return ApplyReplacements(p, stringReplacements, arrayReplacements)
+}
+
+// GetConcurrencyGroup returns the concurrency group of pr, where the params of pr,
+// or the default values declared by p if they aren't set, are replaced. p can be nil
+// if it couldn't be retrieved, in which case only the params of pr are replaced.
+func GetConcurrencyGroup(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) string {
+	if pr.Spec.Concurrency == nil {
+		return ""
+	}
+	if p == nil {
+		p = &v1alpha1.Pipeline{}
+	}
+	replacements, _ := getParamReplacements(p, pr)
+	return templating.ApplyReplacements(pr.Spec.Concurrency.Group, replacements)
+}
+
+func getParamReplacements(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) (map[string]string, map[string][]string) {
 	// This assumes that the PipelineRun inputs have been validated against what the Pipeline requests.
-	replacements := map[string]string{}
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
 	// Set all the default replacements
 	for _, p := range p.Spec.Params {
-		if p.Default != "" {
-			replacements[fmt.Sprintf("params.%s", p.Name)] = p.Default
+		if p.ArrayDefault != nil {
+			arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.ArrayDefault
+		} else if p.Default != "" {
+			stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Default
 		}
 	}
 	// Set and overwrite params with the ones from the PipelineRun
 	for _, p := range pr.Spec.Params {
-		replacements[fmt.Sprintf("params.%s", p.Name)] = p.Value
+		if p.ArrayValue != nil {
+			arrayReplacements[fmt.Sprintf("params.%s", p.Name)] = p.ArrayValue
+		} else {
+			stringReplacements[fmt.Sprintf("params.%s", p.Name)] = p.Value
+		}
 	}
-
-	return ApplyReplacements(p, replacements)
+	return stringReplacements, arrayReplacements
 }
 
-// ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
-func ApplyReplacements(p *v1alpha1.Pipeline, replacements map[string]string) *v1alpha1.Pipeline {
+// ApplyReplacements replaces placeholders for declared parameters with the specified
+// replacements. The params of the PipelineTasks whose value is made of a reference to one
+// of arrayReplacements only are given the items of that array.
+func ApplyReplacements(p *v1alpha1.Pipeline, replacements map[string]string, arrayReplacements map[string][]string) *v1alpha1.Pipeline {
 	p = p.DeepCopy()
+	applyReplacementsToTasks(p.Spec.Tasks, replacements, arrayReplacements)
+	applyReplacementsToTasks(p.Spec.Finally, replacements, arrayReplacements)
+	return p
+}
 
-	tasks := p.Spec.Tasks
-
+func applyReplacementsToTasks(tasks []v1alpha1.PipelineTask, replacements map[string]string, arrayReplacements map[string][]string) {
 	for i := range tasks {
 		params := tasks[i].Params
 
 		for j := range params {
-			params[j].Value = templating.ApplyReplacements(params[j].Value, replacements)
+			applyReplacementsToParam(&params[j], replacements, arrayReplacements)
 		}
 
 		tasks[i].Params = params
+
+		for j := range tasks[i].Conditions {
+			c := &tasks[i].Conditions[j]
+			for k := range c.Params {
+				applyReplacementsToParam(&c.Params[k], replacements, arrayReplacements)
+			}
+		}
+
+		for j := range tasks[i].Matrix {
+			values := tasks[i].Matrix[j].Values
+			for k := range values {
+				values[k] = templating.ApplyReplacements(values[k], replacements)
+			}
+		}
 	}
+}
 
-	return p
+// applyReplacementsToParam replaces the placeholders in the value of p. If it is made
+// of a reference to an array only, p is given the items of that array instead.
+func applyReplacementsToParam(p *v1alpha1.Param, replacements map[string]string, arrayReplacements map[string][]string) {
+	if p.ArrayValue != nil {
+		for i := range p.ArrayValue {
+			p.ArrayValue[i] = templating.ApplyReplacements(p.ArrayValue[i], replacements)
+		}
+		return
+	}
+	for k, v := range arrayReplacements {
+		if p.Value == fmt.Sprintf("${%s}", k) {
+			p.Value = ""
+			p.ArrayValue = append([]string{}, v...)
+			return
+		}
+	}
+	p.Value = templating.ApplyReplacements(p.Value, replacements)
+}
+
+// ApplyTaskResults replaces the references to the results of other PipelineTasks
+// in the params of rprt with the values emitted by their TaskRuns or Runs in state. It
+// returns an error if any of the referenced results hasn't been emitted.
+func ApplyTaskResults(rprt *ResolvedPipelineRunTask, state PipelineRunState) error {
+	replacements := map[string]string{}
+	for _, ref := range v1alpha1.GetPipelineTaskResultRefs(*rprt.PipelineTask) {
+		depTask := findReferencedTask(ref.PipelineTask, state)
+		if depTask == nil || (depTask.TaskRun == nil && depTask.Run == nil) {
+			return fmt.Errorf("PipelineTask %q refers to %s but PipelineTask %q hasn't run", rprt.PipelineTask.Name, ref, ref.PipelineTask)
+		}
+		var value string
+		var ok bool
+		if depTask.Run != nil {
+			value, ok = depTask.Run.Status.GetResult(ref.Result)
+		} else {
+			value, ok = depTask.TaskRun.Status.GetTaskResult(ref.Result)
+		}
+		if !ok {
+			return fmt.Errorf("PipelineTask %q refers to %s but %s didn't emit it", rprt.PipelineTask.Name, ref, depTask)
+		}
+		replacements[fmt.Sprintf("tasks.%s.results.%s", ref.PipelineTask, ref.Result)] = value
+	}
+	if len(replacements) == 0 {
+		return nil
+	}
+
+	pt := rprt.PipelineTask.DeepCopy()
+	for i := range pt.Params {
+		applyReplacementsToParam(&pt.Params[i], replacements, nil)
+	}
+	rprt.PipelineTask = pt
+	return nil
 }
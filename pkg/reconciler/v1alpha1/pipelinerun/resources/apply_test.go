@@ -69,6 +69,52 @@ func TestApplyParameters(t *testing.T) {
 						tb.PipelineTaskParam("first-task-first-param", "${input.workspace.default-value}"),
 					))),
 		},
+		{
+			name: "parameter in finally task",
+			original: tb.Pipeline("test-pipeline", "foo",
+				tb.PipelineSpec(
+					tb.PipelineParam("first-param", tb.PipelineParamDefault("default-value")),
+					tb.PipelineTask("first-task-1", "first-task"),
+					tb.PipelineFinally("final-task-1", "final-task",
+						tb.PipelineTaskParam("final-task-first-param", "${params.first-param}"),
+					))),
+			run: tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline",
+					tb.PipelineRunParam("first-param", "first-value"))),
+			expected: tb.Pipeline("test-pipeline", "foo",
+				tb.PipelineSpec(
+					tb.PipelineParam("first-param", tb.PipelineParamDefault("default-value")),
+					tb.PipelineTask("first-task-1", "first-task"),
+					tb.PipelineFinally("final-task-1", "final-task",
+						tb.PipelineTaskParam("final-task-first-param", "first-value"),
+					))),
+		},
+		{
+			name: "array parameters",
+			original: tb.Pipeline("test-pipeline", "foo",
+				tb.PipelineSpec(
+					tb.PipelineParam("first-param", tb.PipelineParamType(v1alpha1.ParamTypeArray), tb.PipelineParamArrayDefault("-v")),
+					tb.PipelineParam("second-param", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+					tb.PipelineParam("third-param", tb.PipelineParamDefault("debug")),
+					tb.PipelineTask("first-task-1", "first-task",
+						tb.PipelineTaskParam("first-task-first-param", "${params.first-param}"),
+						tb.PipelineTaskParam("first-task-second-param", "${params.second-param}"),
+						tb.PipelineTaskArrayParam("first-task-third-param", "--level=${params.third-param}", "-q"),
+					))),
+			run: tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline",
+					tb.PipelineRunArrayParam("second-param", "a", "b"))),
+			expected: tb.Pipeline("test-pipeline", "foo",
+				tb.PipelineSpec(
+					tb.PipelineParam("first-param", tb.PipelineParamType(v1alpha1.ParamTypeArray), tb.PipelineParamArrayDefault("-v")),
+					tb.PipelineParam("second-param", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+					tb.PipelineParam("third-param", tb.PipelineParamDefault("debug")),
+					tb.PipelineTask("first-task-1", "first-task",
+						tb.PipelineTaskArrayParam("first-task-first-param", "-v"),
+						tb.PipelineTaskArrayParam("first-task-second-param", "a", "b"),
+						tb.PipelineTaskArrayParam("first-task-third-param", "--level=debug", "-q"),
+					))),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -79,3 +125,117 @@ func TestApplyParameters(t *testing.T) {
 		})
 	}
 }
+
+func TestGetConcurrencyGroup(t *testing.T) {
+	p := tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineParam("repo", tb.PipelineParamDefault("pipeline")),
+		tb.PipelineParam("branch"),
+	))
+	tests := []struct {
+		name     string
+		pipeline *v1alpha1.Pipeline
+		run      *v1alpha1.PipelineRun
+		expected string
+	}{{
+		name:     "no concurrency",
+		pipeline: p,
+		run:      tb.PipelineRun("test-pipeline-run", "foo", tb.PipelineRunSpec("test-pipeline")),
+		expected: "",
+	}, {
+		name:     "params and defaults",
+		pipeline: p,
+		run: tb.PipelineRun("test-pipeline-run", "foo", tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunParam("branch", "master"),
+			tb.PipelineRunConcurrency("${params.repo}-${params.branch}", v1alpha1.PipelineRunConcurrencyPolicyQueue),
+		)),
+		expected: "pipeline-master",
+	}, {
+		name: "missing pipeline",
+		run: tb.PipelineRun("test-pipeline-run", "foo", tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunParam("branch", "master"),
+			tb.PipelineRunConcurrency("${params.repo}-${params.branch}", v1alpha1.PipelineRunConcurrencyPolicyQueue),
+		)),
+		expected: "${params.repo}-master",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetConcurrencyGroup(tt.pipeline, tt.run); got != tt.expected {
+				t.Errorf("Expected concurrency group %q but got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplyTaskResults(t *testing.T) {
+	state := PipelineRunState{{
+		PipelineTask: &v1alpha1.PipelineTask{Name: "build"},
+		TaskRun: tb.TaskRun("pipelinerun-build", "foo", tb.TaskRunStatus(
+			tb.TaskRunResult("digest", "sha256:abc"),
+			tb.TaskRunResult("version", "v0.1"),
+		)),
+	}, {
+		PipelineTask: &v1alpha1.PipelineTask{Name: "not-run"},
+	}}
+	tests := []struct {
+		name     string
+		original v1alpha1.PipelineTask
+		expected v1alpha1.PipelineTask
+	}{{
+		name: "no references",
+		original: v1alpha1.PipelineTask{Name: "deploy", Params: []v1alpha1.Param{
+			{Name: "image", Value: "static value"},
+		}},
+		expected: v1alpha1.PipelineTask{Name: "deploy", Params: []v1alpha1.Param{
+			{Name: "image", Value: "static value"},
+		}},
+	}, {
+		name: "multiple references",
+		original: v1alpha1.PipelineTask{Name: "deploy", Params: []v1alpha1.Param{
+			{Name: "image", Value: "gcr.io/foo@${tasks.build.results.digest}"},
+			{Name: "version", Value: "${tasks.build.results.version}"},
+		}},
+		expected: v1alpha1.PipelineTask{Name: "deploy", Params: []v1alpha1.Param{
+			{Name: "image", Value: "gcr.io/foo@sha256:abc"},
+			{Name: "version", Value: "v0.1"},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.original.DeepCopy()
+			rprt := &ResolvedPipelineRunTask{PipelineTask: original}
+			if err := ApplyTaskResults(rprt, state); err != nil {
+				t.Fatalf("Didn't expect error applying task results but got %v", err)
+			}
+			if d := cmp.Diff(tt.expected, *rprt.PipelineTask); d != "" {
+				t.Errorf("ApplyTaskResults() got diff %s", d)
+			}
+			if d := cmp.Diff(tt.original, *original); d != "" {
+				t.Errorf("ApplyTaskResults() modified the original PipelineTask: %s", d)
+			}
+		})
+	}
+}
+
+func TestApplyTaskResults_Invalid(t *testing.T) {
+	state := PipelineRunState{{
+		PipelineTask: &v1alpha1.PipelineTask{Name: "build"},
+		TaskRun:      tb.TaskRun("pipelinerun-build", "foo", tb.TaskRunStatus(tb.TaskRunResult("digest", "sha256:abc"))),
+	}, {
+		PipelineTask: &v1alpha1.PipelineTask{Name: "not-run"},
+	}}
+	for _, value := range []string{
+		"${tasks.build.results.missing}",
+		"${tasks.not-run.results.digest}",
+		"${tasks.nonexistent.results.digest}",
+	} {
+		t.Run(value, func(t *testing.T) {
+			rprt := &ResolvedPipelineRunTask{PipelineTask: &v1alpha1.PipelineTask{
+				Name:   "deploy",
+				Params: []v1alpha1.Param{{Name: "foo", Value: value}},
+			}}
+			if err := ApplyTaskResults(rprt, state); err == nil {
+				t.Errorf("Expected error applying task results for %q but got none", value)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
+)
+
+const (
+	// ReasonConditionCheckFailed indicates that the PipelineTask was skipped because
+	// one of its conditions wasn't true
+	ReasonConditionCheckFailed = "ConditionCheckFailed"
+
+	// ReasonParentTaskSkipped indicates that the PipelineTask was skipped because it
+	// depends on a PipelineTask which was skipped
+	ReasonParentTaskSkipped = "ParentTaskSkipped"
+)
+
+// GetCondition is a function used to retrieve Conditions.
+type GetCondition func(name string) (*v1alpha1.Condition, error)
+
+// ConditionNotFoundError indicates that the resolution failed because a referenced Condition couldn't be retrieved
+type ConditionNotFoundError struct {
+	Name string
+	Msg  string
+}
+
+func (e *ConditionNotFoundError) Error() string {
+	return fmt.Sprintf("Couldn't retrieve Condition %q: %s", e.Name, e.Msg)
+}
+
+// ResolvedConditionCheck contains a Condition and its associated condition check,
+// which is the TaskRun evaluating the Condition. ConditionCheck can be nil to
+// represent the check not having been started yet.
+type ResolvedConditionCheck struct {
+	ConditionCheckName    string
+	Condition             *v1alpha1.Condition
+	ConditionCheck        *v1alpha1.TaskRun
+	PipelineTaskCondition *v1alpha1.PipelineTaskCondition
+	Inputs                []v1alpha1.TaskResourceBinding
+}
+
+// TaskConditionCheckState is a slice of ResolvedConditionChecks which represents
+// the current state of the conditions of a PipelineTask.
+type TaskConditionCheckState []*ResolvedConditionCheck
+
+// IsDone returns true if all of the condition checks have finished.
+func (state TaskConditionCheckState) IsDone() bool {
+	for _, rcc := range state {
+		if rcc.ConditionCheck == nil || !rcc.ConditionCheck.IsDone() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuccess returns true if all of the condition checks have succeeded, which
+// is the case when there are no conditions.
+func (state TaskConditionCheckState) IsSuccess() bool {
+	for _, rcc := range state {
+		if rcc.ConditionCheck == nil {
+			return false
+		}
+		if !rcc.ConditionCheck.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+			return false
+		}
+	}
+	return true
+}
+
+// GetFailed returns the condition check which has failed, if any.
+func (state TaskConditionCheckState) GetFailed() *ResolvedConditionCheck {
+	for _, rcc := range state {
+		if rcc.ConditionCheck != nil && rcc.ConditionCheck.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+			return rcc
+		}
+	}
+	return nil
+}
+
+// resolveConditionChecks retrieves the Conditions of the PipelineTask pt using getCondition,
+// and names the condition checks evaluating them, reusing the names from the status of the
+// PipelineRun if they were already started.
+func resolveConditionChecks(pt *v1alpha1.PipelineTask, taskRunStatus *v1alpha1.PipelineRunTaskRunStatus, taskRunName string,
+	getCondition GetCondition, providedResources map[string]v1alpha1.PipelineResourceRef) (TaskConditionCheckState, error) {
+	state := TaskConditionCheckState{}
+	used := map[string]struct{}{}
+	for i := range pt.Conditions {
+		ptc := pt.Conditions[i]
+		c, err := getCondition(ptc.ConditionRef)
+		if err != nil {
+			return nil, &ConditionNotFoundError{
+				Name: ptc.ConditionRef,
+				Msg:  err.Error(),
+			}
+		}
+
+		inputs := []v1alpha1.TaskResourceBinding{}
+		for _, cr := range ptc.Resources {
+			resource, ok := providedResources[cr.Resource]
+			if !ok {
+				return nil, fmt.Errorf("condition %s of pipelineTask %s tried to use resource %s not present in declared resources", ptc.ConditionRef, pt.Name, cr.Resource)
+			}
+			inputs = append(inputs, v1alpha1.TaskResourceBinding{
+				Name:        cr.Name,
+				ResourceRef: resource,
+			})
+		}
+
+		name := getConditionCheckName(taskRunStatus, used, taskRunName, ptc.ConditionRef)
+		used[name] = struct{}{}
+		state = append(state, &ResolvedConditionCheck{
+			ConditionCheckName:    name,
+			Condition:             c,
+			PipelineTaskCondition: &ptc,
+			Inputs:                inputs,
+		})
+	}
+	return state, nil
+}
+
+// getConditionCheckName returns the name of an existing condition check for the Condition
+// conditionName which isn't in used yet, and a new unique name otherwise.
+func getConditionCheckName(taskRunStatus *v1alpha1.PipelineRunTaskRunStatus, used map[string]struct{}, taskRunName, conditionName string) string {
+	if taskRunStatus != nil {
+		for k, v := range taskRunStatus.ConditionChecks {
+			if _, ok := used[k]; !ok && v.ConditionName == conditionName {
+				return k
+			}
+		}
+	}
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", taskRunName, conditionName))
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+var condition = tb.ConditionCRD("always-true", namespace, tb.ConditionSpec(
+	tb.ConditionSpecCheck("check", "ubuntu", tb.Command("true")),
+))
+
+func makeConditionCheck(name string, status corev1.ConditionStatus) *v1alpha1.TaskRun {
+	return tb.TaskRun(name, namespace, tb.TaskRunStatus(tb.Condition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: status,
+	})))
+}
+
+func TestTaskConditionCheckState(t *testing.T) {
+	tcs := []struct {
+		name            string
+		state           TaskConditionCheckState
+		expectedDone    bool
+		expectedSuccess bool
+		expectedFailed  string
+	}{{
+		name:            "no-conditions",
+		state:           TaskConditionCheckState{},
+		expectedDone:    true,
+		expectedSuccess: true,
+	}, {
+		name: "not-started",
+		state: TaskConditionCheckState{{
+			ConditionCheckName: "cc-1",
+		}},
+	}, {
+		name: "running",
+		state: TaskConditionCheckState{{
+			ConditionCheckName: "cc-1",
+			ConditionCheck:     makeConditionCheck("cc-1", corev1.ConditionTrue),
+		}, {
+			ConditionCheckName: "cc-2",
+			ConditionCheck:     makeConditionCheck("cc-2", corev1.ConditionUnknown),
+		}},
+	}, {
+		name: "succeeded",
+		state: TaskConditionCheckState{{
+			ConditionCheckName: "cc-1",
+			ConditionCheck:     makeConditionCheck("cc-1", corev1.ConditionTrue),
+		}, {
+			ConditionCheckName: "cc-2",
+			ConditionCheck:     makeConditionCheck("cc-2", corev1.ConditionTrue),
+		}},
+		expectedDone:    true,
+		expectedSuccess: true,
+	}, {
+		name: "one-failed",
+		state: TaskConditionCheckState{{
+			ConditionCheckName: "cc-1",
+			ConditionCheck:     makeConditionCheck("cc-1", corev1.ConditionTrue),
+		}, {
+			ConditionCheckName: "cc-2",
+			ConditionCheck:     makeConditionCheck("cc-2", corev1.ConditionFalse),
+		}},
+		expectedDone:   true,
+		expectedFailed: "cc-2",
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if done := tc.state.IsDone(); done != tc.expectedDone {
+				t.Errorf("expected IsDone to be %t but was %t", tc.expectedDone, done)
+			}
+			if success := tc.state.IsSuccess(); success != tc.expectedSuccess {
+				t.Errorf("expected IsSuccess to be %t but was %t", tc.expectedSuccess, success)
+			}
+			failed := ""
+			if rcc := tc.state.GetFailed(); rcc != nil {
+				failed = rcc.ConditionCheckName
+			}
+			if failed != tc.expectedFailed {
+				t.Errorf("expected GetFailed to return %q but got %q", tc.expectedFailed, failed)
+			}
+		})
+	}
+}
+
+func TestResolvePipelineRun_Conditions(t *testing.T) {
+	names.TestingSeed()
+	p := tb.Pipeline("pipelines", namespace, tb.PipelineSpec(
+		tb.PipelineDeclaredResource("git-resource", v1alpha1.PipelineResourceTypeGit),
+		tb.PipelineTask("mytask1", "task",
+			tb.PipelineTaskCondition("always-true",
+				tb.PipelineTaskConditionParam("foo", "bar"),
+				tb.PipelineTaskConditionResource("workspace", "git-resource"),
+			),
+		),
+		tb.PipelineTask("mytask2", "task",
+			tb.PipelineTaskCondition("always-true"),
+		),
+	))
+	pr := tb.PipelineRun("pipelinerun", namespace, tb.PipelineRunStatus(
+		tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"pipelinerun-mytask2": {
+				PipelineTaskName: "mytask2",
+				ConditionChecks: map[string]*v1alpha1.PipelineRunConditionCheckStatus{
+					"pipelinerun-mytask2-always-true-abcde": {ConditionName: "always-true"},
+				},
+			},
+		}),
+	))
+	providedResources := map[string]v1alpha1.PipelineResourceRef{
+		"git-resource": {Name: "someresource"},
+	}
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return task, nil }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return clustertask, nil }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) {
+		return tb.PipelineResource(name, namespace), nil
+	}
+	getCondition := func(name string) (*v1alpha1.Condition, error) { return condition, nil }
+
+	state, err := ResolvePipelineRun(*pr, getTask, getClusterTask, getResource, getCondition, p.Spec.Tasks, providedResources)
+	if err != nil {
+		t.Fatalf("Error resolving PipelineRun with conditions: %s", err)
+	}
+	expected := []TaskConditionCheckState{{{
+		ConditionCheckName:    "pipelinerun-mytask1-9l9zj-always-true-mz4c7",
+		Condition:             condition,
+		PipelineTaskCondition: &p.Spec.Tasks[0].Conditions[0],
+		Inputs: []v1alpha1.TaskResourceBinding{{
+			Name:        "workspace",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "someresource"},
+		}},
+	}}, {{
+		ConditionCheckName:    "pipelinerun-mytask2-always-true-abcde",
+		Condition:             condition,
+		PipelineTaskCondition: &p.Spec.Tasks[1].Conditions[0],
+		Inputs:                []v1alpha1.TaskResourceBinding{},
+	}}}
+	for i, rprt := range state {
+		if d := cmp.Diff(expected[i], rprt.ResolvedConditionChecks); d != "" {
+			t.Errorf("Unexpected condition checks for %s. Diff -want, +got: %s", rprt.PipelineTask.Name, d)
+		}
+	}
+
+	getTaskRun := func(name string) (*v1alpha1.TaskRun, error) {
+		if name == "pipelinerun-mytask2-always-true-abcde" {
+			return makeConditionCheck(name, corev1.ConditionTrue), nil
+		}
+		return nil, errors.NewNotFound(v1alpha1.Resource("taskrun"), name)
+	}
+	if err := ResolveTaskRuns(getTaskRun, state); err != nil {
+		t.Fatalf("Error resolving TaskRuns: %s", err)
+	}
+	if state[0].ResolvedConditionChecks[0].ConditionCheck != nil {
+		t.Errorf("Expected the condition check of mytask1 not to be started but got %v", state[0].ResolvedConditionChecks[0].ConditionCheck)
+	}
+	if !state[1].ResolvedConditionChecks.IsSuccess() {
+		t.Errorf("Expected the condition check of mytask2 to be resolved and successful")
+	}
+}
+
+func TestResolvePipelineRun_ConditionDoesntExist(t *testing.T) {
+	p := tb.Pipeline("pipelines", namespace, tb.PipelineSpec(
+		tb.PipelineTask("mytask1", "task", tb.PipelineTaskCondition("does-not-exist")),
+	))
+	pr := tb.PipelineRun("pipelinerun", namespace)
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return task, nil }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return clustertask, nil }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return nil, nil }
+
+	_, err := ResolvePipelineRun(*pr, getTask, getClusterTask, getResource, getNoCondition, p.Spec.Tasks, nil)
+	switch err := err.(type) {
+	case nil:
+		t.Fatalf("Expected error getting non-existent Conditions for Pipeline %s but got none", p.Name)
+	case *ConditionNotFoundError:
+		// expected error
+	default:
+		t.Fatalf("Expected specific error type returned by func for non-existent Condition got %s", err)
+	}
+}
+
+func TestGetSkippedTasks(t *testing.T) {
+	tasks := []v1alpha1.PipelineTask{{
+		Name: "mytask1",
+	}, {
+		Name:     "mytask2",
+		RunAfter: []string{"mytask1"},
+	}, {
+		Name: "mytask3",
+	}}
+	d, err := v1alpha1.BuildDAG(tasks)
+	if err != nil {
+		t.Fatalf("Unexpected error building DAG: %v", err)
+	}
+	state := PipelineRunState{{
+		PipelineTask: &tasks[0],
+		TaskRunName:  "pipelinerun-mytask1",
+		ResolvedConditionChecks: TaskConditionCheckState{{
+			ConditionCheckName: "pipelinerun-mytask1-always-false",
+			Condition:          condition,
+			ConditionCheck:     makeConditionCheck("pipelinerun-mytask1-always-false", corev1.ConditionFalse),
+		}},
+	}, {
+		PipelineTask: &tasks[1],
+		TaskRunName:  "pipelinerun-mytask2",
+	}, {
+		PipelineTask: &tasks[2],
+		TaskRunName:  "pipelinerun-mytask3",
+		TaskRun:      makeSucceeded(trs[0]),
+	}}
+
+	expected := []v1alpha1.SkippedTask{{
+		Name:    "mytask1",
+		Reason:  ReasonConditionCheckFailed,
+		Message: "Condition always-true evaluated by pipelinerun-mytask1-always-false was false",
+	}, {
+		Name:    "mytask2",
+		Reason:  ReasonParentTaskSkipped,
+		Message: "PipelineTask mytask1 was skipped",
+	}}
+	if d := cmp.Diff(expected, state.GetSkippedTasks(d, "")); d != "" {
+		t.Errorf("Unexpected skipped tasks. Diff -want, +got: %s", d)
+	}
+	if next := state.GetNextTasks(map[string]v1alpha1.PipelineTask{"mytask1": tasks[0]}); len(next) != 0 {
+		t.Errorf("Expected a PipelineTask whose condition failed not to be scheduled but got %v", next)
+	}
+}
@@ -24,11 +24,13 @@ import (
 	"github.com/tektoncd/pipeline/pkg/names"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/list"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/resources"
 )
 
@@ -44,6 +46,19 @@ const (
 	// completed successfully
 	ReasonSucceeded = "Succeeded"
 
+	// ReasonFinallyFailed indicates that the reason for the failure status is that one of the
+	// TaskRuns of the finally tasks failed, while all of the other TaskRuns completed successfully
+	ReasonFinallyFailed = "FinallyFailed"
+
+	// ReasonParentTaskFailed indicates that the PipelineTask was never started because
+	// a PipelineTask it depends on has failed
+	ReasonParentTaskFailed = "ParentTaskFailed"
+
+	// ReasonStoppedOnFailure indicates that the PipelineTask was never started because
+	// one of the PipelineTasks has failed, and the PipelineRun doesn't start any more
+	// PipelineTasks after a failure
+	ReasonStoppedOnFailure = "StoppedOnFailure"
+
 	// ReasonTimedOut indicates that the PipelineRun has taken longer than its configured
 	// timeout
 	ReasonTimedOut = "PipelineRunTimeout"
@@ -56,6 +71,107 @@ type ResolvedPipelineRunTask struct {
 	TaskRun               *v1alpha1.TaskRun
 	PipelineTask          *v1alpha1.PipelineTask
 	ResolvedTaskResources *resources.ResolvedTaskResources
+	// TaskLabels are the labels of the Task the PipelineTask refers to, if any.
+	TaskLabels map[string]string
+	// ResolvedConditionChecks represents all of the conditions that need to
+	// be true for the TaskRun to be created.
+	ResolvedConditionChecks TaskConditionCheckState
+	// MatrixParams are the values of the matrix params the TaskRun is run with,
+	// when the PipelineTask fans out over a matrix. PipelineTask then already
+	// includes them in its params.
+	MatrixParams []v1alpha1.Param
+	// MatrixIndex is the position of MatrixParams among the combinations of the
+	// matrix of the PipelineTask.
+	MatrixIndex int
+	// PipelineRunName is the name of the PipelineRun created for a PipelineTask
+	// running a Pipeline, instead of a TaskRun.
+	PipelineRunName string
+	PipelineRun     *v1alpha1.PipelineRun
+	// ApprovalName is the name of the Approval created for a PipelineTask which
+	// is an approval gate, instead of a TaskRun.
+	ApprovalName string
+	Approval     *v1alpha1.Approval
+	// RunName is the name of the Run created for a PipelineTask referring to a
+	// custom task, instead of a TaskRun.
+	RunName string
+	Run     *v1alpha1.Run
+}
+
+// IsNested returns true if t runs a Pipeline with a PipelineRun instead of a Task.
+func (t ResolvedPipelineRunTask) IsNested() bool {
+	return t.PipelineTask != nil && t.PipelineTask.PipelineRef != nil
+}
+
+// IsApproval returns true if t waits for a decision on an Approval instead of running a Task.
+func (t ResolvedPipelineRunTask) IsApproval() bool {
+	return t.PipelineTask != nil && t.PipelineTask.Approval != nil
+}
+
+// IsCustomTask returns true if t runs a custom task with a Run instead of a Task.
+func (t ResolvedPipelineRunTask) IsCustomTask() bool {
+	return t.PipelineTask != nil && t.PipelineTask.TaskRef.IsCustomTask()
+}
+
+// IsStarted returns true if the TaskRun, the PipelineRun, the Approval or the Run of t has
+// been created.
+func (t ResolvedPipelineRunTask) IsStarted() bool {
+	return t.TaskRun != nil || t.PipelineRun != nil || t.Approval != nil || t.Run != nil
+}
+
+// IsDone returns true if the TaskRun, the PipelineRun or the Run of t has finished executing,
+// successfully or not, or if its Approval was decided or timed out.
+func (t ResolvedPipelineRunTask) IsDone() bool {
+	switch {
+	case t.PipelineRun != nil:
+		return t.PipelineRun.IsDone()
+	case t.Approval != nil:
+		return t.Approval.IsDone()
+	case t.Run != nil:
+		return t.Run.IsDone()
+	}
+	return t.TaskRun != nil && t.TaskRun.IsDone()
+}
+
+// IsFailed returns true if the TaskRun, the PipelineRun or the Run of t has failed, or if its
+// Approval was rejected or timed out.
+func (t ResolvedPipelineRunTask) IsFailed() bool {
+	c := t.getSucceededCondition()
+	return c != nil && c.IsFalse()
+}
+
+// IsSuccessful returns true if the TaskRun, the PipelineRun or the Run of t has succeeded, or if
+// its Approval was approved.
+func (t ResolvedPipelineRunTask) IsSuccessful() bool {
+	c := t.getSucceededCondition()
+	return c != nil && c.IsTrue()
+}
+
+func (t ResolvedPipelineRunTask) getSucceededCondition() *apis.Condition {
+	switch {
+	case t.PipelineRun != nil:
+		return t.PipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+	case t.Approval != nil:
+		return t.Approval.Status.GetCondition(apis.ConditionSucceeded)
+	case t.Run != nil:
+		return t.Run.Status.GetCondition(apis.ConditionSucceeded)
+	case t.TaskRun != nil:
+		return t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
+	}
+	return nil
+}
+
+// String describes the run of t, either its TaskRun, its PipelineRun, its Approval or its Run.
+func (t ResolvedPipelineRunTask) String() string {
+	if t.IsNested() {
+		return fmt.Sprintf("PipelineRun %s", t.PipelineRunName)
+	}
+	if t.IsApproval() {
+		return fmt.Sprintf("Approval %s", t.ApprovalName)
+	}
+	if t.IsCustomTask() {
+		return fmt.Sprintf("Run %s", t.RunName)
+	}
+	return fmt.Sprintf("TaskRun %s", t.TaskRunName)
 }
 
 // PipelineRunState is a slice of ResolvedPipelineRunTasks the represents the current execution
@@ -63,27 +179,186 @@ type ResolvedPipelineRunTask struct {
 type PipelineRunState []*ResolvedPipelineRunTask
 
 // GetNextTasks will return the next ResolvedPipelineRunTasks to execute, which are the ones in the
-// list of candidateTasks which aren't yet indicated in state to be running.
+// list of candidateTasks which aren't yet indicated in state to be running, and which weren't
+// skipped because of a failed condition.
 func (state PipelineRunState) GetNextTasks(candidateTasks map[string]v1alpha1.PipelineTask) []*ResolvedPipelineRunTask {
 	tasks := []*ResolvedPipelineRunTask{}
 	for _, t := range state {
-		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.TaskRun == nil {
+		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && !t.IsStarted() && t.ResolvedConditionChecks.GetFailed() == nil {
 			tasks = append(tasks, t)
 		}
 	}
 	return tasks
 }
 
+// isRunning returns true if the TaskRun or the PipelineRun of t is executing, or if the
+// ConditionChecks deciding whether it will be created have been started.
+func (t ResolvedPipelineRunTask) isRunning() bool {
+	if t.IsStarted() {
+		return !t.IsDone()
+	}
+	if t.ResolvedConditionChecks.GetFailed() != nil {
+		return false
+	}
+	for _, rcc := range t.ResolvedConditionChecks {
+		if rcc.ConditionCheck != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// LimitParallelTasks returns the ResolvedPipelineRunTasks of next which can be started
+// without more than max PipelineTasks of state running at once. The ones whose
+// ConditionChecks were already started are always returned, so that they can go on. A
+// max of 0 means that the number of PipelineTasks running at once isn't limited.
+func (state PipelineRunState) LimitParallelTasks(next []*ResolvedPipelineRunTask, max int) []*ResolvedPipelineRunTask {
+	if max <= 0 {
+		return next
+	}
+	running := 0
+	for _, t := range state {
+		if t.isRunning() {
+			running++
+		}
+	}
+	tasks := []*ResolvedPipelineRunTask{}
+	for _, t := range next {
+		if t.isRunning() {
+			tasks = append(tasks, t)
+		} else if running < max {
+			tasks = append(tasks, t)
+			running++
+		}
+	}
+	return tasks
+}
+
+// GetSkippedTasks returns the PipelineTasks in state which won't be run, along with the
+// reason why: either one of their conditions has failed, or they depend on a PipelineTask
+// which was skipped, or they weren't started because of the failure of another PipelineTask.
+// d is the graph of the PipelineTasks in state and policy decides which PipelineTasks are
+// still started after a failure.
+func (state PipelineRunState) GetSkippedTasks(d *v1alpha1.DAG, policy v1alpha1.PipelineRunFailurePolicy) []v1alpha1.SkippedTask {
+	failed := map[string]*ResolvedConditionCheck{}
+	conditionFailed := []string{}
+	for _, t := range state {
+		if rcc := t.ResolvedConditionChecks.GetFailed(); rcc != nil {
+			failed[t.PipelineTask.Name] = rcc
+			conditionFailed = append(conditionFailed, t.PipelineTask.Name)
+		}
+	}
+	skipped := dag.GetSkipped(d, conditionFailed...)
+
+	var firstFailed string
+	taskFailed := []string{}
+	for _, t := range state {
+		if _, ok := d.Nodes[t.PipelineTask.Name]; ok && t.IsFailed() {
+			if firstFailed == "" {
+				firstFailed = t.PipelineTask.Name
+			}
+			taskFailed = append(taskFailed, t.PipelineTask.Name)
+		}
+	}
+	blocked := dag.GetSkipped(d, taskFailed...)
+
+	skippedTasks := []v1alpha1.SkippedTask{}
+	seen := map[string]struct{}{}
+	for _, t := range state {
+		// The combinations of a PipelineTask fanning out over a matrix are skipped together
+		if _, ok := seen[t.PipelineTask.Name]; ok {
+			continue
+		}
+		if cause, ok := skipped[t.PipelineTask.Name]; ok {
+			seen[t.PipelineTask.Name] = struct{}{}
+			if rcc, ok := failed[t.PipelineTask.Name]; ok {
+				skippedTasks = append(skippedTasks, v1alpha1.SkippedTask{
+					Name:    t.PipelineTask.Name,
+					Reason:  ReasonConditionCheckFailed,
+					Message: fmt.Sprintf("Condition %s evaluated by %s was false", rcc.Condition.Name, rcc.ConditionCheckName),
+				})
+			} else {
+				skippedTasks = append(skippedTasks, v1alpha1.SkippedTask{
+					Name:    t.PipelineTask.Name,
+					Reason:  ReasonParentTaskSkipped,
+					Message: fmt.Sprintf("PipelineTask %s was skipped", cause),
+				})
+			}
+			continue
+		}
+		if _, ok := d.Nodes[t.PipelineTask.Name]; !ok || firstFailed == "" || t.IsStarted() {
+			continue
+		}
+		seen[t.PipelineTask.Name] = struct{}{}
+		if cause, ok := blocked[t.PipelineTask.Name]; ok {
+			skippedTasks = append(skippedTasks, v1alpha1.SkippedTask{
+				Name:    t.PipelineTask.Name,
+				Reason:  ReasonParentTaskFailed,
+				Message: fmt.Sprintf("PipelineTask %s has failed", cause),
+			})
+		} else if policy != v1alpha1.PipelineRunFailurePolicyContinueIndependent {
+			skippedTasks = append(skippedTasks, v1alpha1.SkippedTask{
+				Name:    t.PipelineTask.Name,
+				Reason:  ReasonStoppedOnFailure,
+				Message: fmt.Sprintf("PipelineTask %s has failed", firstFailed),
+			})
+		}
+	}
+	return skippedTasks
+}
+
+// GetFailedTask returns the first PipelineTask of the graph d in state whose TaskRun has
+// failed, or nil if none of them has.
+func (state PipelineRunState) GetFailedTask(d *v1alpha1.DAG) *ResolvedPipelineRunTask {
+	for _, t := range state {
+		if _, ok := d.Nodes[t.PipelineTask.Name]; ok && t.IsFailed() {
+			return t
+		}
+	}
+	return nil
+}
+
+// IsDAGDone returns true if none of the PipelineTasks of the graph d in state will run
+// anymore: all of them have either finished or been skipped, including the ones which
+// won't be started because of a failure according to policy.
+func (state PipelineRunState) IsDAGDone(d *v1alpha1.DAG, policy v1alpha1.PipelineRunFailurePolicy) bool {
+	skipped := map[string]struct{}{}
+	for _, t := range state.GetSkippedTasks(d, policy) {
+		skipped[t.Name] = struct{}{}
+	}
+	for _, t := range state {
+		if _, ok := d.Nodes[t.PipelineTask.Name]; !ok {
+			continue
+		}
+		if _, ok := skipped[t.PipelineTask.Name]; ok {
+			continue
+		}
+		if !t.IsDone() {
+			return false
+		}
+	}
+	return true
+}
+
 // SuccessfulPipelineTaskNames returns a list of the names of all of the PipelineTasks in state
-// which have successfully completed.
+// which have successfully completed. A PipelineTask fanning out over a matrix has only
+// completed once the TaskRuns of all of its combinations have.
 func (state PipelineRunState) SuccessfulPipelineTaskNames() []string {
-	done := []string{}
+	successful := map[string]bool{}
+	ptNames := []string{}
 	for _, t := range state {
-		if t.TaskRun != nil {
-			c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-			if c.IsTrue() {
-				done = append(done, t.PipelineTask.Name)
-			}
+		succeeded := t.IsSuccessful()
+		if previous, ok := successful[t.PipelineTask.Name]; ok {
+			successful[t.PipelineTask.Name] = previous && succeeded
+			continue
+		}
+		successful[t.PipelineTask.Name] = succeeded
+		ptNames = append(ptNames, t.PipelineTask.Name)
+	}
+	done := []string{}
+	for _, name := range ptNames {
+		if successful[name] {
+			done = append(done, name)
 		}
 	}
 	return done
@@ -92,6 +367,15 @@ func (state PipelineRunState) SuccessfulPipelineTaskNames() []string {
 // GetTaskRun is a function that will retrieve the TaskRun name.
 type GetTaskRun func(name string) (*v1alpha1.TaskRun, error)
 
+// GetPipelineRun is a function that will retrieve the PipelineRun name.
+type GetPipelineRun func(name string) (*v1alpha1.PipelineRun, error)
+
+// GetApproval is a function that will retrieve the Approval name.
+type GetApproval func(name string) (*v1alpha1.Approval, error)
+
+// GetRun is a function that will retrieve the Run name.
+type GetRun func(name string) (*v1alpha1.Run, error)
+
 // GetResourcesFromBindings will validate that all PipelineResources declared in Pipeline p are bound in PipelineRun pr
 // and if so, will return a map from the declared name of the PipelineResource (which is how the PipelineResource will
 // be referred to in the PipelineRun) to the ResourceRef.
@@ -173,6 +457,7 @@ func ResolvePipelineRun(
 	getTask resources.GetTask,
 	getClusterTask resources.GetClusterTask,
 	getResource resources.GetResource,
+	getCondition GetCondition,
 	tasks []v1alpha1.PipelineTask,
 	providedResources map[string]v1alpha1.PipelineResourceRef,
 ) (PipelineRunState, error) {
@@ -181,24 +466,60 @@ func ResolvePipelineRun(
 	for i := range tasks {
 		pt := tasks[i]
 
-		rprt := ResolvedPipelineRunTask{
-			PipelineTask: &pt,
-			TaskRunName:  getTaskRunName(pipelineRun.Status.TaskRuns, pt.Name, pipelineRun.Name),
+		// A nested Pipeline is run by its own PipelineRun, which resolves it
+		if pt.PipelineRef != nil {
+			state = append(state, &ResolvedPipelineRunTask{
+				PipelineTask:    &pt,
+				PipelineRunName: getPipelineRunName(pipelineRun.Status.PipelineRuns, pt.Name, pipelineRun.Name),
+			})
+			continue
 		}
 
-		// Find the Task that this task in the Pipeline this PipelineTask is using
-		var t v1alpha1.TaskInterface
-		var err error
-		if pt.TaskRef.Kind == v1alpha1.ClusterTaskKind {
-			t, err = getClusterTask(pt.TaskRef.Name)
-		} else {
-			t, err = getTask(pt.TaskRef.Name)
+		// An approval gate only waits for a decision on its Approval
+		if pt.Approval != nil {
+			state = append(state, &ResolvedPipelineRunTask{
+				PipelineTask: &pt,
+				ApprovalName: getApprovalName(pipelineRun.Status.Approvals, pt.Name, pipelineRun.Name),
+			})
+			continue
 		}
-		if err != nil {
-			return nil, &TaskNotFoundError{
-				Name: pt.TaskRef.Name,
-				Msg:  err.Error(),
+
+		// A custom task is run by an external controller, so there is no Task to find
+		if pt.TaskRef.IsCustomTask() {
+			state = append(state, &ResolvedPipelineRunTask{
+				PipelineTask: &pt,
+				RunName:      getRunName(pipelineRun.Status.Runs, pt.Name, pipelineRun.Name),
+			})
+			continue
+		}
+
+		// Find the Task that this task in the Pipeline this PipelineTask is using,
+		// unless the Task is embedded in the PipelineTask
+		var spec v1alpha1.TaskSpec
+		var taskName string
+		var taskLabels map[string]string
+		switch {
+		case pt.TaskSpec != nil:
+			spec = *pt.TaskSpec
+		case pt.TaskRef != nil:
+			var t v1alpha1.TaskInterface
+			var err error
+			if pt.TaskRef.Kind == v1alpha1.ClusterTaskKind {
+				t, err = getClusterTask(pt.TaskRef.Name)
+			} else {
+				t, err = getTask(pt.TaskRef.Name)
 			}
+			if err != nil {
+				return nil, &TaskNotFoundError{
+					Name: pt.TaskRef.Name,
+					Msg:  err.Error(),
+				}
+			}
+			spec = t.TaskSpec()
+			taskName = t.TaskMetadata().Name
+			taskLabels = t.TaskMetadata().Labels
+		default:
+			return nil, fmt.Errorf("PipelineTask %s neither refers to a Task nor embeds one", pt.Name)
 		}
 
 		// Get all the resources that this task will be using, if any
@@ -207,15 +528,49 @@ func ResolvePipelineRun(
 			return nil, fmt.Errorf("unexpected error which should have been caught by Pipeline webhook: %v", err)
 		}
 
-		spec := t.TaskSpec()
-		rtr, err := resources.ResolveTaskResources(&spec, t.TaskMetadata().Name, inputs, outputs, getResource)
+		rtr, err := resources.ResolveTaskResources(&spec, taskName, inputs, outputs, getResource)
 		if err != nil {
 			return nil, &ResourceNotFoundError{Msg: err.Error()}
 		}
-		rprt.ResolvedTaskResources = rtr
 
-		// Add this task to the state of the PipelineRun
-		state = append(state, &rprt)
+		rprts := []*ResolvedPipelineRunTask{}
+		if combinations := pt.GetMatrixCombinations(); combinations != nil {
+			// Each combination of the matrix params is run by its own TaskRun, with
+			// the values of the combination passed as regular params
+			for j, params := range combinations {
+				mpt := pt.DeepCopy()
+				mpt.Params = append(mpt.Params, params...)
+				mpt.Matrix = nil
+				rprts = append(rprts, &ResolvedPipelineRunTask{
+					PipelineTask: mpt,
+					TaskRunName:  getMatrixTaskRunName(pipelineRun.Status.TaskRuns, pt.Name, pipelineRun.Name, params),
+					MatrixParams: params,
+					MatrixIndex:  j,
+				})
+			}
+		} else {
+			rprts = append(rprts, &ResolvedPipelineRunTask{
+				PipelineTask: &pt,
+				TaskRunName:  getTaskRunName(pipelineRun.Status.TaskRuns, pt.Name, pipelineRun.Name),
+			})
+		}
+
+		for _, rprt := range rprts {
+			rprt.ResolvedTaskResources = rtr
+			rprt.TaskLabels = taskLabels
+
+			// Get the conditions that this task depends on, if any
+			if len(pt.Conditions) > 0 {
+				rcc, err := resolveConditionChecks(rprt.PipelineTask, pipelineRun.Status.TaskRuns[rprt.TaskRunName], rprt.TaskRunName, getCondition, providedResources)
+				if err != nil {
+					return nil, err
+				}
+				rprt.ResolvedConditionChecks = rcc
+			}
+
+			// Add this task to the state of the PipelineRun
+			state = append(state, rprt)
+		}
 	}
 	return state, nil
 }
@@ -224,6 +579,9 @@ func ResolvePipelineRun(
 // for each of them by calling getTaskRun.
 func ResolveTaskRuns(getTaskRun GetTaskRun, state PipelineRunState) error {
 	for _, rprt := range state {
+		if rprt.IsNested() || rprt.IsApproval() || rprt.IsCustomTask() {
+			continue
+		}
 		// Check if we have already started a TaskRun for this task
 		taskRun, err := getTaskRun(rprt.TaskRunName)
 		if err != nil {
@@ -234,10 +592,118 @@ func ResolveTaskRuns(getTaskRun GetTaskRun, state PipelineRunState) error {
 		} else {
 			rprt.TaskRun = taskRun
 		}
+
+		// Check if we have already started the condition checks for this task
+		for _, rcc := range rprt.ResolvedConditionChecks {
+			cctr, err := getTaskRun(rcc.ConditionCheckName)
+			if err != nil {
+				if !errors.IsNotFound(err) {
+					return fmt.Errorf("error retrieving ConditionCheck %s: %s", rcc.ConditionCheckName, err)
+				}
+			} else {
+				rcc.ConditionCheck = cctr
+			}
+		}
+	}
+	return nil
+}
+
+// ResolvePipelineRuns will go through all tasks in state running a Pipeline and check if
+// there are existing PipelineRuns for each of them by calling getPipelineRun.
+func ResolvePipelineRuns(getPipelineRun GetPipelineRun, state PipelineRunState) error {
+	for _, rprt := range state {
+		if !rprt.IsNested() {
+			continue
+		}
+		pr, err := getPipelineRun(rprt.PipelineRunName)
+		if err != nil {
+			// If the PipelineRun isn't found, it just means it hasn't been run yet
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving PipelineRun %s: %s", rprt.PipelineRunName, err)
+			}
+		} else {
+			rprt.PipelineRun = pr
+		}
 	}
 	return nil
 }
 
+// ResolveApprovals will go through all tasks in state which are approval gates and check if
+// there are existing Approvals for each of them by calling getApproval.
+func ResolveApprovals(getApproval GetApproval, state PipelineRunState) error {
+	for _, rprt := range state {
+		if !rprt.IsApproval() {
+			continue
+		}
+		a, err := getApproval(rprt.ApprovalName)
+		if err != nil {
+			// If the Approval isn't found, it just means it hasn't been created yet
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving Approval %s: %s", rprt.ApprovalName, err)
+			}
+		} else {
+			rprt.Approval = a
+		}
+	}
+	return nil
+}
+
+// ResolveRuns will go through all tasks in state referring to a custom task and check if
+// there are existing Runs for each of them by calling getRun.
+func ResolveRuns(getRun GetRun, state PipelineRunState) error {
+	for _, rprt := range state {
+		if !rprt.IsCustomTask() {
+			continue
+		}
+		r, err := getRun(rprt.RunName)
+		if err != nil {
+			// If the Run isn't found, it just means it hasn't been created yet
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving Run %s: %s", rprt.RunName, err)
+			}
+		} else {
+			rprt.Run = r
+		}
+	}
+	return nil
+}
+
+// getPipelineRunName returns a unique name for the `PipelineRun` of a PipelineTask running a Pipeline
+// if one has not already been defined, and the existing one otherwise.
+func getPipelineRunName(pipelineRunsStatus map[string]*v1alpha1.PipelineRunPipelineRunStatus, ptName, prName string) string {
+	for k, v := range pipelineRunsStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
+
+// getApprovalName returns a unique name for the `Approval` of a PipelineTask which is an approval
+// gate if one has not already been defined, and the existing one otherwise.
+func getApprovalName(approvalsStatus map[string]*v1alpha1.PipelineRunApprovalStatus, ptName, prName string) string {
+	for k, v := range approvalsStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
+
+// getRunName returns a unique name for the `Run` of a PipelineTask referring to a custom task
+// if one has not already been defined, and the existing one otherwise.
+func getRunName(runsStatus map[string]*v1alpha1.PipelineRunRunStatus, ptName, prName string) string {
+	for k, v := range runsStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
+
 // getTaskRunName should return a unique name for a `TaskRun` if one has not already been defined, and the existing one otherwise.
 func getTaskRunName(taskRunsStatus map[string]*v1alpha1.PipelineRunTaskRunStatus, ptName, prName string) string {
 	for k, v := range taskRunsStatus {
@@ -249,11 +715,78 @@ func getTaskRunName(taskRunsStatus map[string]*v1alpha1.PipelineRunTaskRunStatus
 	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
 }
 
+// getMatrixTaskRunName returns the name of the existing `TaskRun` of the combination params of the
+// matrix of a PipelineTask, recorded in the status of the PipelineRun, and otherwise a unique name.
+func getMatrixTaskRunName(taskRunsStatus map[string]*v1alpha1.PipelineRunTaskRunStatus, ptName, prName string, params []v1alpha1.Param) string {
+	for k, v := range taskRunsStatus {
+		if v.PipelineTaskName == ptName && equality.Semantic.DeepEqual(v.MatrixParams, params) {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
+
+// PrepareRetries will look for the TaskRuns in state which have failed while their
+// PipelineTask still has retries left. Each of these is replaced in state by a new TaskRun
+// name, so that the PipelineTask is scheduled again, and the failed attempt is recorded in
+// the status of the PipelineRun pr. It returns the names of the TaskRuns being retried.
+func PrepareRetries(pr *v1alpha1.PipelineRun, state PipelineRunState) []string {
+	retried := []string{}
+	if pr.Status.TaskRuns == nil {
+		pr.Status.TaskRuns = make(map[string]*v1alpha1.PipelineRunTaskRunStatus)
+	}
+	for _, rprt := range state {
+		if rprt.TaskRun == nil || rprt.TaskRun.IsCancelled() {
+			continue
+		}
+		c := rprt.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
+		if !c.IsFalse() {
+			continue
+		}
+		prtrs, ok := pr.Status.TaskRuns[rprt.TaskRunName]
+		if !ok {
+			prtrs = &v1alpha1.PipelineRunTaskRunStatus{PipelineTaskName: rprt.PipelineTask.Name, MatrixParams: rprt.MatrixParams}
+		}
+		if prtrs.Attempt >= rprt.PipelineTask.Retries {
+			continue
+		}
+
+		attempts := make([]v1alpha1.PipelineTaskAttempt, len(prtrs.PreviousAttempts), len(prtrs.PreviousAttempts)+1)
+		copy(attempts, prtrs.PreviousAttempts)
+		attempts = append(attempts, v1alpha1.PipelineTaskAttempt{
+			TaskRunName: rprt.TaskRunName,
+			Reason:      c.Reason,
+			Message:     c.Message,
+		})
+		delete(pr.Status.TaskRuns, rprt.TaskRunName)
+		retried = append(retried, rprt.TaskRunName)
+
+		if rprt.MatrixParams != nil {
+			rprt.TaskRunName = names.SimpleNameGenerator.RestrictLengthWithSuffix(fmt.Sprintf("%s-%s", pr.Name, rprt.PipelineTask.Name), fmt.Sprintf("-%d-retry%d", rprt.MatrixIndex, prtrs.Attempt+1))
+		} else {
+			rprt.TaskRunName = getTaskRunName(pr.Status.TaskRuns, rprt.PipelineTask.Name, pr.Name)
+		}
+		rprt.TaskRun = nil
+		pr.Status.TaskRuns[rprt.TaskRunName] = &v1alpha1.PipelineRunTaskRunStatus{
+			PipelineTaskName: rprt.PipelineTask.Name,
+			MatrixParams:     rprt.MatrixParams,
+			Attempt:          prtrs.Attempt + 1,
+			PreviousAttempts: attempts,
+			ConditionChecks:  prtrs.ConditionChecks,
+		}
+	}
+	return retried
+}
+
 // GetPipelineConditionStatus will return the Condition that the PipelineRun prName should be
-// updated with, based on the status of the TaskRuns in state.
+// updated with, based on the status of the TaskRuns in state. PipelineTasks which were skipped
+// are considered to be finished. d is the graph of the PipelineTasks in state and dfinally
+// the graph of its finally tasks, which are only considered once all of the other
+// PipelineTasks are done. If there are no finally tasks, the PipelineRun fails as soon as
+// one of its TaskRuns fails, unless policy is to continue running the independent tasks.
 func GetPipelineConditionStatus(prName string, state PipelineRunState, logger *zap.SugaredLogger, startTime *metav1.Time,
-	pipelineTimeout *metav1.Duration) *apis.Condition {
-	allFinished := true
+	pipelineTimeout *metav1.Duration, d *v1alpha1.DAG, dfinally *v1alpha1.DAG, policy v1alpha1.PipelineRunFailurePolicy) *apis.Condition {
 	if !startTime.IsZero() && pipelineTimeout != nil {
 		timeout := pipelineTimeout.Duration
 		runtime := time.Since(startTime.Time)
@@ -269,41 +802,68 @@ func GetPipelineConditionStatus(prName string, state PipelineRunState, logger *z
 			}
 		}
 	}
-	for _, rprt := range state {
-		if rprt.TaskRun == nil {
-			logger.Infof("TaskRun %s doesn't have a Status, so PipelineRun %s isn't finished", rprt.TaskRunName, prName)
-			allFinished = false
-			continue
+
+	// If any TaskRuns have failed and there is nothing left to run, we should halt
+	// execution and consider the run failed
+	failed := state.GetFailedTask(d)
+	if failed != nil && len(dfinally.Nodes) == 0 && policy != v1alpha1.PipelineRunFailurePolicyContinueIndependent {
+		logger.Infof("%s has failed, so PipelineRun %s has failed", failed, prName)
+		return &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonFailed,
+			Message: fmt.Sprintf("%s has failed", failed),
 		}
-		c := rprt.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-		if c == nil {
-			logger.Infof("TaskRun %s doesn't have a condition, so PipelineRun %s isn't finished", rprt.TaskRunName, prName)
-			allFinished = false
+	}
+	if !state.IsDAGDone(d, policy) {
+		logger.Infof("PipelineRun %s still has running TaskRuns so it isn't yet done", prName)
+		return &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonRunning,
+			Message: "Not all Tasks in the Pipeline have finished executing",
+		}
+	}
+
+	var finallyFailed *ResolvedPipelineRunTask
+	for _, rprt := range state {
+		if _, ok := dfinally.Nodes[rprt.PipelineTask.Name]; !ok {
 			continue
 		}
-		logger.Infof("TaskRun %s status : %v", rprt.TaskRunName, c.Status)
-		// If any TaskRuns have failed, we should halt execution and consider the run failed
-		if c.Status == corev1.ConditionFalse {
-			logger.Infof("TaskRun %s has failed, so PipelineRun %s has failed", rprt.TaskRunName, prName)
+		if !rprt.IsDone() {
+			logger.Infof("Finally %s isn't done, so PipelineRun %s isn't finished", rprt, prName)
 			return &apis.Condition{
 				Type:    apis.ConditionSucceeded,
-				Status:  corev1.ConditionFalse,
-				Reason:  ReasonFailed,
-				Message: fmt.Sprintf("TaskRun %s has failed", rprt.TaskRun.Name),
+				Status:  corev1.ConditionUnknown,
+				Reason:  ReasonRunning,
+				Message: "Not all finally Tasks in the Pipeline have finished executing",
 			}
 		}
-		if c.Status != corev1.ConditionTrue {
-			logger.Infof("TaskRun %s is still running so PipelineRun %s is still running", rprt.TaskRunName, prName)
-			allFinished = false
+		if rprt.IsFailed() && finallyFailed == nil {
+			finallyFailed = rprt
 		}
 	}
-	if !allFinished {
-		logger.Infof("PipelineRun %s still has running TaskRuns so it isn't yet done", prName)
+
+	if failed != nil {
+		logger.Infof("%s has failed, so PipelineRun %s has failed", failed, prName)
+		msg := fmt.Sprintf("%s has failed", failed)
+		if finallyFailed != nil {
+			msg = fmt.Sprintf("%s; finally %s has failed", msg, finallyFailed)
+		}
 		return &apis.Condition{
 			Type:    apis.ConditionSucceeded,
-			Status:  corev1.ConditionUnknown,
-			Reason:  ReasonRunning,
-			Message: "Not all Tasks in the Pipeline have finished executing",
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonFailed,
+			Message: msg,
+		}
+	}
+	if finallyFailed != nil {
+		logger.Infof("Finally %s has failed, so PipelineRun %s has failed", finallyFailed, prName)
+		return &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonFinallyFailed,
+			Message: fmt.Sprintf("Finally %s has failed", finallyFailed),
 		}
 	}
 	logger.Infof("All TaskRuns have finished for PipelineRun %s so it has finished", prName)
@@ -331,7 +891,7 @@ func findReferencedTask(pb string, state []*ResolvedPipelineRunTask) *ResolvedPi
 // not valid, it will return an error.
 func ValidateFrom(state PipelineRunState) error {
 	for _, rprt := range state {
-		if rprt.PipelineTask.Resources != nil {
+		if rprt.PipelineTask.Resources != nil && !rprt.IsNested() {
 			for _, dep := range rprt.PipelineTask.Resources.Inputs {
 				inputBinding := rprt.ResolvedTaskResources.Inputs[dep.Name]
 				for _, pb := range dep.From {
@@ -339,7 +899,7 @@ func ValidateFrom(state PipelineRunState) error {
 						return fmt.Errorf("PipelineTask %s is trying to depend on a PipelineResource from itself", pb)
 					}
 					depTask := findReferencedTask(pb, state)
-					if depTask == nil {
+					if depTask == nil || depTask.IsNested() || depTask.IsApproval() || depTask.IsCustomTask() {
 						return fmt.Errorf("pipelineTask %s is trying to depend on previous Task %q but it does not exist", rprt.PipelineTask.Name, pb)
 					}
 
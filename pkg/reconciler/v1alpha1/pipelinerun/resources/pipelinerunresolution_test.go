@@ -42,13 +42,13 @@ const (
 
 var pts = []v1alpha1.PipelineTask{{
 	Name:    "mytask1",
-	TaskRef: v1alpha1.TaskRef{Name: "task"},
+	TaskRef: &v1alpha1.TaskRef{Name: "task"},
 }, {
 	Name:    "mytask2",
-	TaskRef: v1alpha1.TaskRef{Name: "task"},
+	TaskRef: &v1alpha1.TaskRef{Name: "task"},
 }, {
 	Name:    "mytask3",
-	TaskRef: v1alpha1.TaskRef{Name: "clustertask"},
+	TaskRef: &v1alpha1.TaskRef{Name: "clustertask"},
 }}
 
 var p = &v1alpha1.Pipeline{
@@ -66,9 +66,9 @@ var task = &v1alpha1.Task{
 		Name: "task",
 	},
 	Spec: v1alpha1.TaskSpec{
-		Steps: []corev1.Container{{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
 			Name: "step1",
-		}},
+		}}},
 	},
 }
 
@@ -77,9 +77,9 @@ var clustertask = &v1alpha1.ClusterTask{
 		Name: "clustertask",
 	},
 	Spec: v1alpha1.TaskSpec{
-		Steps: []corev1.Container{{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
 			Name: "step1",
-		}},
+		}}},
 	},
 }
 
@@ -97,6 +97,11 @@ var trs = []v1alpha1.TaskRun{{
 	Spec: v1alpha1.TaskRunSpec{},
 }}
 
+// getNoCondition is used to resolve PipelineRuns whose PipelineTasks don't have conditions.
+func getNoCondition(name string) (*v1alpha1.Condition, error) {
+	return nil, errors.NewNotFound(v1alpha1.Resource("condition"), name)
+}
+
 func makeStarted(tr v1alpha1.TaskRun) *v1alpha1.TaskRun {
 	newTr := newTaskRun(tr)
 	newTr.Status.Conditions[0].Status = corev1.ConditionUnknown
@@ -206,6 +211,34 @@ var allFinishedState = PipelineRunState{{
 	},
 }}
 
+// matrixPartlyFinishedState is the state of a PipelineTask fanning out over a matrix,
+// where only one of its combinations has finished.
+var matrixPartlyFinishedState = PipelineRunState{{
+	PipelineTask: &pts[0],
+	TaskRunName:  "pipelinerun-mytask1-0",
+	TaskRun:      makeSucceeded(trs[0]),
+	MatrixParams: []v1alpha1.Param{{Name: "platform", Value: "linux"}},
+}, {
+	PipelineTask: &pts[0],
+	TaskRunName:  "pipelinerun-mytask1-1",
+	TaskRun:      makeStarted(trs[0]),
+	MatrixParams: []v1alpha1.Param{{Name: "platform", Value: "windows"}},
+	MatrixIndex:  1,
+}}
+
+var matrixFinishedState = PipelineRunState{{
+	PipelineTask: &pts[0],
+	TaskRunName:  "pipelinerun-mytask1-0",
+	TaskRun:      makeSucceeded(trs[0]),
+	MatrixParams: []v1alpha1.Param{{Name: "platform", Value: "linux"}},
+}, {
+	PipelineTask: &pts[0],
+	TaskRunName:  "pipelinerun-mytask1-1",
+	TaskRun:      makeSucceeded(trs[0]),
+	MatrixParams: []v1alpha1.Param{{Name: "platform", Value: "windows"}},
+	MatrixIndex:  1,
+}}
+
 func TestGetNextTasks(t *testing.T) {
 	tcs := []struct {
 		name         string
@@ -379,6 +412,69 @@ func TestGetNextTasks(t *testing.T) {
 	}
 }
 
+func TestLimitParallelTasks(t *testing.T) {
+	// mytask2 is waiting for its ConditionCheck while mytask1 is running
+	checkingState := PipelineRunState{oneStartedState[0], {
+		PipelineTask: &pts[1],
+		TaskRunName:  "pipelinerun-mytask2",
+		ResolvedConditionChecks: TaskConditionCheckState{{
+			ConditionCheckName: "pipelinerun-mytask2-always-true",
+			ConditionCheck:     makeStarted(trs[1]),
+		}},
+	}}
+	tcs := []struct {
+		name         string
+		state        PipelineRunState
+		next         []*ResolvedPipelineRunTask
+		max          int
+		expectedNext []*ResolvedPipelineRunTask
+	}{{
+		name:         "no-limit",
+		state:        noneStartedState,
+		next:         noneStartedState,
+		max:          0,
+		expectedNext: noneStartedState,
+	}, {
+		name:         "no-tasks-started-limit-one",
+		state:        noneStartedState,
+		next:         noneStartedState,
+		max:          1,
+		expectedNext: []*ResolvedPipelineRunTask{noneStartedState[0]},
+	}, {
+		name:         "one-task-started-limit-one",
+		state:        oneStartedState,
+		next:         oneStartedState[1:],
+		max:          1,
+		expectedNext: []*ResolvedPipelineRunTask{},
+	}, {
+		name:         "one-task-started-limit-two",
+		state:        oneStartedState,
+		next:         oneStartedState[1:],
+		max:          2,
+		expectedNext: oneStartedState[1:],
+	}, {
+		name:         "one-task-finished-limit-one",
+		state:        oneFinishedState,
+		next:         oneFinishedState[1:],
+		max:          1,
+		expectedNext: oneFinishedState[1:],
+	}, {
+		name:         "condition-check-started-limit-one",
+		state:        checkingState,
+		next:         checkingState[1:],
+		max:          1,
+		expectedNext: checkingState[1:],
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			next := tc.state.LimitParallelTasks(tc.next, tc.max)
+			if d := cmp.Diff(tc.expectedNext, next); d != "" {
+				t.Errorf("Didn't get expected next Tasks: %v", d)
+			}
+		})
+	}
+}
+
 func TestSuccessfulPipelineTaskNames(t *testing.T) {
 	tcs := []struct {
 		name          string
@@ -410,6 +506,16 @@ func TestSuccessfulPipelineTaskNames(t *testing.T) {
 			state:         allFinishedState,
 			expectedNames: []string{"mytask1", "mytask2"},
 		},
+		{
+			name:          "matrix-partly-finished",
+			state:         matrixPartlyFinishedState,
+			expectedNames: []string{},
+		},
+		{
+			name:          "matrix-finished",
+			state:         matrixFinishedState,
+			expectedNames: []string{"mytask1"},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -455,8 +561,16 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
+			d, err := v1alpha1.BuildDAG(getPipelineTasks(tc.state))
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", tc.state, err)
+			}
+			dfinally, err := v1alpha1.BuildDAG(nil)
+			if err != nil {
+				t.Fatalf("Unexpected error while building empty DAG: %v", err)
+			}
 			c := GetPipelineConditionStatus("somepipelinerun", tc.state, zap.NewNop().Sugar(), &metav1.Time{time.Now()},
-				nil)
+				nil, d, dfinally, "")
 			if c.Status != tc.expectedStatus {
 				t.Fatalf("Expected to get status %s but got %s for state %v", tc.expectedStatus, c.Status, tc.state)
 			}
@@ -464,6 +578,433 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 	}
 }
 
+func TestGetPipelineConditionStatus_Finally(t *testing.T) {
+	finallyTask := v1alpha1.PipelineTask{
+		Name:    "myfinallytask",
+		TaskRef: &v1alpha1.TaskRef{Name: "task"},
+	}
+	finallyTaskRun := v1alpha1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "namespace",
+			Name:      "pipelinerun-myfinallytask",
+		},
+	}
+	withFinally := func(state PipelineRunState, tr *v1alpha1.TaskRun) PipelineRunState {
+		return append(append(PipelineRunState{}, state...), &ResolvedPipelineRunTask{
+			PipelineTask: &finallyTask,
+			TaskRunName:  finallyTaskRun.Name,
+			TaskRun:      tr,
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		})
+	}
+	oneFailedOneRunningState := PipelineRunState{{
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1",
+		TaskRun:      makeFailed(trs[0]),
+	}, {
+		PipelineTask: &pts[1],
+		TaskRunName:  "pipelinerun-mytask2",
+		TaskRun:      makeStarted(trs[1]),
+	}}
+
+	tcs := []struct {
+		name           string
+		state          PipelineRunState
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{{
+		name:           "tasks-running",
+		state:          withFinally(oneStartedState, nil),
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: ReasonRunning,
+	}, {
+		name:           "task-failed-other-task-running",
+		state:          withFinally(oneFailedOneRunningState, nil),
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: ReasonRunning,
+	}, {
+		name:           "task-failed-finally-not-started",
+		state:          withFinally(oneFailedState, nil),
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: ReasonRunning,
+	}, {
+		name:           "task-failed-finally-succeeded",
+		state:          withFinally(oneFailedState, makeSucceeded(finallyTaskRun)),
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: ReasonFailed,
+	}, {
+		name:           "tasks-succeeded-finally-running",
+		state:          withFinally(allFinishedState, makeStarted(finallyTaskRun)),
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: ReasonRunning,
+	}, {
+		name:           "tasks-succeeded-finally-failed",
+		state:          withFinally(allFinishedState, makeFailed(finallyTaskRun)),
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: ReasonFinallyFailed,
+	}, {
+		name:           "all-succeeded",
+		state:          withFinally(allFinishedState, makeSucceeded(finallyTaskRun)),
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: ReasonSucceeded,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := v1alpha1.BuildDAG(pts[:2])
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG: %v", err)
+			}
+			dfinally, err := v1alpha1.BuildDAG([]v1alpha1.PipelineTask{finallyTask})
+			if err != nil {
+				t.Fatalf("Unexpected error while building finally DAG: %v", err)
+			}
+			c := GetPipelineConditionStatus("somepipelinerun", tc.state, zap.NewNop().Sugar(), &metav1.Time{Time: time.Now()},
+				nil, d, dfinally, "")
+			if c.Status != tc.expectedStatus || c.Reason != tc.expectedReason {
+				t.Fatalf("Expected to get status %s with reason %s but got %s with reason %s", tc.expectedStatus, tc.expectedReason, c.Status, c.Reason)
+			}
+		})
+	}
+}
+
+func TestIsDAGDone(t *testing.T) {
+	d, err := v1alpha1.BuildDAG(pts[:2])
+	if err != nil {
+		t.Fatalf("Unexpected error while building DAG: %v", err)
+	}
+	tcs := []struct {
+		name     string
+		state    PipelineRunState
+		expected bool
+	}{{
+		name:     "no-tasks-started",
+		state:    noneStartedState,
+		expected: false,
+	}, {
+		name:     "one-task-finished",
+		state:    oneFinishedState,
+		expected: false,
+	}, {
+		name:     "one-task-failed",
+		state:    oneFailedState,
+		expected: true,
+	}, {
+		name:     "all-finished",
+		state:    allFinishedState,
+		expected: true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if done := tc.state.IsDAGDone(d, ""); done != tc.expected {
+				t.Errorf("expected IsDAGDone to be %t but was %t", tc.expected, done)
+			}
+		})
+	}
+}
+
+func TestGetSkippedTasks_FailurePolicy(t *testing.T) {
+	tasks := []v1alpha1.PipelineTask{{
+		Name: "mytask1",
+	}, {
+		Name:     "mytask2",
+		RunAfter: []string{"mytask1"},
+	}, {
+		Name: "mytask3",
+	}}
+	d, err := v1alpha1.BuildDAG(tasks)
+	if err != nil {
+		t.Fatalf("Unexpected error building DAG: %v", err)
+	}
+	state := PipelineRunState{{
+		PipelineTask: &tasks[0],
+		TaskRunName:  "pipelinerun-mytask1",
+		TaskRun:      makeFailed(trs[0]),
+	}, {
+		PipelineTask: &tasks[1],
+		TaskRunName:  "pipelinerun-mytask2",
+	}, {
+		PipelineTask: &tasks[2],
+		TaskRunName:  "pipelinerun-mytask3",
+	}}
+
+	tcs := []struct {
+		name     string
+		policy   v1alpha1.PipelineRunFailurePolicy
+		expected []v1alpha1.SkippedTask
+		done     bool
+	}{{
+		name:   "default",
+		policy: "",
+		expected: []v1alpha1.SkippedTask{{
+			Name:    "mytask2",
+			Reason:  ReasonParentTaskFailed,
+			Message: "PipelineTask mytask1 has failed",
+		}, {
+			Name:    "mytask3",
+			Reason:  ReasonStoppedOnFailure,
+			Message: "PipelineTask mytask1 has failed",
+		}},
+		done: true,
+	}, {
+		name:   "fail fast",
+		policy: v1alpha1.PipelineRunFailurePolicyFailFast,
+		expected: []v1alpha1.SkippedTask{{
+			Name:    "mytask2",
+			Reason:  ReasonParentTaskFailed,
+			Message: "PipelineTask mytask1 has failed",
+		}, {
+			Name:    "mytask3",
+			Reason:  ReasonStoppedOnFailure,
+			Message: "PipelineTask mytask1 has failed",
+		}},
+		done: true,
+	}, {
+		name:   "continue independent",
+		policy: v1alpha1.PipelineRunFailurePolicyContinueIndependent,
+		expected: []v1alpha1.SkippedTask{{
+			Name:    "mytask2",
+			Reason:  ReasonParentTaskFailed,
+			Message: "PipelineTask mytask1 has failed",
+		}},
+		done: false,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.expected, state.GetSkippedTasks(d, tc.policy)); d != "" {
+				t.Errorf("Unexpected skipped tasks. Diff -want, +got: %s", d)
+			}
+			if done := state.IsDAGDone(d, tc.policy); done != tc.done {
+				t.Errorf("expected IsDAGDone to be %t but was %t", tc.done, done)
+			}
+		})
+	}
+}
+
+func TestGetPipelineConditionStatus_ContinueIndependent(t *testing.T) {
+	d, err := v1alpha1.BuildDAG(pts[:2])
+	if err != nil {
+		t.Fatalf("Unexpected error while building DAG: %v", err)
+	}
+	dfinally, err := v1alpha1.BuildDAG(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error while building empty DAG: %v", err)
+	}
+	oneFailedOneSucceededState := PipelineRunState{{
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1",
+		TaskRun:      makeFailed(trs[0]),
+	}, {
+		PipelineTask: &pts[1],
+		TaskRunName:  "pipelinerun-mytask2",
+		TaskRun:      makeSucceeded(trs[1]),
+	}}
+
+	tcs := []struct {
+		name           string
+		state          PipelineRunState
+		expectedStatus corev1.ConditionStatus
+	}{{
+		name:           "independent-task-not-started",
+		state:          oneFailedState,
+		expectedStatus: corev1.ConditionUnknown,
+	}, {
+		name:           "independent-task-finished",
+		state:          oneFailedOneSucceededState,
+		expectedStatus: corev1.ConditionFalse,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			c := GetPipelineConditionStatus("somepipelinerun", tc.state, zap.NewNop().Sugar(), &metav1.Time{Time: time.Now()},
+				nil, d, dfinally, v1alpha1.PipelineRunFailurePolicyContinueIndependent)
+			if c.Status != tc.expectedStatus {
+				t.Fatalf("Expected to get status %s but got %s", tc.expectedStatus, c.Status)
+			}
+		})
+	}
+}
+
+func TestGetPipelineConditionStatus_NestedPipeline(t *testing.T) {
+	nested := []v1alpha1.PipelineTask{{
+		Name:        "build",
+		PipelineRef: &v1alpha1.PipelineRef{Name: "build-pipeline"},
+	}}
+	d, err := v1alpha1.BuildDAG(nested)
+	if err != nil {
+		t.Fatalf("Unexpected error while building DAG: %v", err)
+	}
+	dfinally, err := v1alpha1.BuildDAG(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error while building empty DAG: %v", err)
+	}
+	child := tb.PipelineRun("pipelinerun-build", namespace, tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionFalse,
+	})))
+	state := PipelineRunState{{
+		PipelineTask:    &nested[0],
+		PipelineRunName: "pipelinerun-build",
+		PipelineRun:     child,
+	}}
+
+	c := GetPipelineConditionStatus("pipelinerun", state, zap.NewNop().Sugar(), &metav1.Time{Time: time.Now()},
+		nil, d, dfinally, "")
+	if !c.IsFalse() || c.Message != "PipelineRun pipelinerun-build has failed" {
+		t.Errorf("Expected the PipelineRun to fail because of its nested PipelineRun but got %v", c)
+	}
+}
+
+func TestGetPipelineConditionStatus_Approval(t *testing.T) {
+	approval := []v1alpha1.PipelineTask{{
+		Name:     "approve",
+		Approval: &v1alpha1.ApprovalGate{Users: []string{"alice"}},
+	}}
+	d, err := v1alpha1.BuildDAG(approval)
+	if err != nil {
+		t.Fatalf("Unexpected error while building DAG: %v", err)
+	}
+	dfinally, err := v1alpha1.BuildDAG(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error while building empty DAG: %v", err)
+	}
+	tcs := []struct {
+		name           string
+		status         corev1.ConditionStatus
+		expectedStatus corev1.ConditionStatus
+	}{{
+		name:           "waiting",
+		status:         corev1.ConditionUnknown,
+		expectedStatus: corev1.ConditionUnknown,
+	}, {
+		name:           "approved",
+		status:         corev1.ConditionTrue,
+		expectedStatus: corev1.ConditionTrue,
+	}, {
+		name:           "rejected",
+		status:         corev1.ConditionFalse,
+		expectedStatus: corev1.ConditionFalse,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			a := tb.Approval("pipelinerun-approve", namespace, tb.ApprovalStatus(tb.ApprovalStatusCondition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: tc.status,
+			})))
+			state := PipelineRunState{{
+				PipelineTask: &approval[0],
+				ApprovalName: "pipelinerun-approve",
+				Approval:     a,
+			}}
+			c := GetPipelineConditionStatus("pipelinerun", state, zap.NewNop().Sugar(), &metav1.Time{Time: time.Now()},
+				nil, d, dfinally, "")
+			if c.Status != tc.expectedStatus {
+				t.Errorf("Expected to get status %s but got %s", tc.expectedStatus, c.Status)
+			}
+		})
+	}
+}
+
+func TestPrepareRetries(t *testing.T) {
+	names.TestingSeed()
+	retried := pts[0]
+	retried.Retries = 2
+	failed := makeFailed(trs[0])
+	failed.Status.Conditions[0].Reason = "Failed"
+	failed.Status.Conditions[0].Message = "step build failed"
+	state := PipelineRunState{{
+		PipelineTask: &retried,
+		TaskRunName:  "pipelinerun-mytask1",
+		TaskRun:      failed,
+	}, {
+		PipelineTask: &pts[1],
+		TaskRunName:  "pipelinerun-mytask2",
+		TaskRun:      makeFailed(trs[1]),
+	}}
+	pr := tb.PipelineRun("pipelinerun", namespace, tb.PipelineRunStatus(
+		tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"pipelinerun-mytask1": {
+				PipelineTaskName: "mytask1",
+				Attempt:          1,
+				PreviousAttempts: []v1alpha1.PipelineTaskAttempt{{
+					TaskRunName: "pipelinerun-mytask1-abcde",
+					Reason:      "TaskRunTimeout",
+				}},
+			},
+			"pipelinerun-mytask2": {PipelineTaskName: "mytask2"},
+		}),
+	))
+
+	got := PrepareRetries(pr, state)
+	if d := cmp.Diff([]string{"pipelinerun-mytask1"}, got); d != "" {
+		t.Errorf("expected only the TaskRun with retries left to be retried. Diff -want, +got: %s", d)
+	}
+	if state[0].TaskRunName != "pipelinerun-mytask1-9l9zj" || state[0].TaskRun != nil {
+		t.Errorf("expected the retried PipelineTask to get a new TaskRun but got %q (%v)", state[0].TaskRunName, state[0].TaskRun)
+	}
+	if state[1].TaskRunName != "pipelinerun-mytask2" || state[1].TaskRun == nil {
+		t.Errorf("expected the PipelineTask without retries to keep its TaskRun but got %q (%v)", state[1].TaskRunName, state[1].TaskRun)
+	}
+	expectedStatus := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"pipelinerun-mytask1-9l9zj": {
+			PipelineTaskName: "mytask1",
+			Attempt:          2,
+			PreviousAttempts: []v1alpha1.PipelineTaskAttempt{{
+				TaskRunName: "pipelinerun-mytask1-abcde",
+				Reason:      "TaskRunTimeout",
+			}, {
+				TaskRunName: "pipelinerun-mytask1",
+				Reason:      "Failed",
+				Message:     "step build failed",
+			}},
+		},
+		"pipelinerun-mytask2": {PipelineTaskName: "mytask2"},
+	}
+	if d := cmp.Diff(expectedStatus, pr.Status.TaskRuns); d != "" {
+		t.Errorf("expected the failed attempt to be recorded in the status. Diff -want, +got: %s", d)
+	}
+
+	// Once the retries are exhausted, the TaskRun isn't retried anymore
+	state[0].TaskRun = failed
+	if got := PrepareRetries(pr, state); len(got) != 0 {
+		t.Errorf("expected no TaskRuns to be retried once retries were exhausted but got %v", got)
+	}
+}
+
+func TestPrepareRetries_Matrix(t *testing.T) {
+	retried := pts[0]
+	retried.Retries = 1
+	params := []v1alpha1.Param{{Name: "platform", Value: "windows"}}
+	state := PipelineRunState{{
+		PipelineTask: &retried,
+		TaskRunName:  "pipelinerun-mytask1-1",
+		TaskRun:      makeFailed(trs[0]),
+		MatrixParams: params,
+		MatrixIndex:  1,
+	}}
+	pr := tb.PipelineRun("pipelinerun", namespace, tb.PipelineRunStatus(
+		tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"pipelinerun-mytask1-1": {PipelineTaskName: "mytask1", MatrixParams: params},
+		}),
+	))
+
+	got := PrepareRetries(pr, state)
+	if d := cmp.Diff([]string{"pipelinerun-mytask1-1"}, got); d != "" {
+		t.Errorf("expected the TaskRun of the combination to be retried. Diff -want, +got: %s", d)
+	}
+	expectedStatus := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"pipelinerun-mytask1-1-retry1": {
+			PipelineTaskName: "mytask1",
+			MatrixParams:     params,
+			Attempt:          1,
+			PreviousAttempts: []v1alpha1.PipelineTaskAttempt{{TaskRunName: "pipelinerun-mytask1-1"}},
+		},
+	}
+	if d := cmp.Diff(expectedStatus, pr.Status.TaskRuns); d != "" {
+		t.Errorf("expected the retry to keep the params of the combination. Diff -want, +got: %s", d)
+	}
+}
+
 func TestGetResourcesFromBindings(t *testing.T) {
 	p := tb.Pipeline("pipelines", "namespace", tb.PipelineSpec(
 		tb.PipelineDeclaredResource("git-resource", "git"),
@@ -552,7 +1093,7 @@ func TestResolvePipelineRun(t *testing.T) {
 	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, nil }
 	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return r, nil }
 
-	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, p.Spec.Tasks, providedResources)
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, getNoCondition, p.Spec.Tasks, providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -602,13 +1143,13 @@ func TestResolvePipelineRun(t *testing.T) {
 func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	pts := []v1alpha1.PipelineTask{{
 		Name:    "mytask1",
-		TaskRef: v1alpha1.TaskRef{Name: "task"},
+		TaskRef: &v1alpha1.TaskRef{Name: "task"},
 	}, {
 		Name:    "mytask2",
-		TaskRef: v1alpha1.TaskRef{Name: "task"},
+		TaskRef: &v1alpha1.TaskRef{Name: "task"},
 	}, {
 		Name:    "mytask3",
-		TaskRef: v1alpha1.TaskRef{Name: "task"},
+		TaskRef: &v1alpha1.TaskRef{Name: "task"},
 	}}
 	providedResources := map[string]v1alpha1.PipelineResourceRef{}
 
@@ -620,7 +1161,7 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, pts, providedResources)
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, getNoCondition, pts, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Resources: %v", err)
 	}
@@ -641,10 +1182,212 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineRun_EmbeddedTaskSpec(t *testing.T) {
+	names.TestingSeed()
+	taskSpec := v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{Name: "step1", Image: "ubuntu"}}},
+	}
+	pts := []v1alpha1.PipelineTask{{
+		Name:     "mytask1",
+		TaskSpec: &taskSpec,
+	}}
+	providedResources := map[string]v1alpha1.PipelineResourceRef{}
+
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return nil, fmt.Errorf("should not get called") }
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun",
+		},
+	}
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, getNoCondition, pts, providedResources)
+	if err != nil {
+		t.Fatalf("Did not expect error when resolving PipelineRun with an embedded Task: %v", err)
+	}
+	expectedState := PipelineRunState{{
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1-9l9zj",
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskSpec: &taskSpec,
+			Inputs:   map[string]*v1alpha1.PipelineResource{},
+			Outputs:  map[string]*v1alpha1.PipelineResource{},
+		},
+	}}
+	if d := cmp.Diff(expectedState, pipelineState, cmpopts.IgnoreUnexported(v1alpha1.TaskRunSpec{})); d != "" {
+		t.Errorf("Expected the embedded Task to be resolved but actual differed: %s", d)
+	}
+}
+
+func TestResolvePipelineRun_Matrix(t *testing.T) {
+	names.TestingSeed()
+	pts := []v1alpha1.PipelineTask{{
+		Name:    "mytask1",
+		TaskRef: &v1alpha1.TaskRef{Name: "task"},
+		Params:  []v1alpha1.Param{{Name: "version", Value: "1"}},
+		Matrix:  []v1alpha1.MatrixParam{{Name: "platform", Values: []string{"linux", "windows"}}},
+	}}
+	providedResources := map[string]v1alpha1.PipelineResourceRef{}
+
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return task, nil }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return nil, fmt.Errorf("should not get called") }
+	pr := tb.PipelineRun("pipelinerun", namespace, tb.PipelineRunStatus(
+		tb.PipelineRunTaskRunsStatus(map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"pipelinerun-mytask1-1-retry1": {
+				PipelineTaskName: "mytask1",
+				MatrixParams:     []v1alpha1.Param{{Name: "platform", Value: "windows"}},
+			},
+		}),
+	))
+	pipelineState, err := ResolvePipelineRun(*pr, getTask, getClusterTask, getResource, getNoCondition, pts, providedResources)
+	if err != nil {
+		t.Fatalf("Did not expect error when resolving PipelineRun with a matrix: %v", err)
+	}
+	rtr := &resources.ResolvedTaskResources{
+		TaskName: "task",
+		TaskSpec: &task.Spec,
+		Inputs:   map[string]*v1alpha1.PipelineResource{},
+		Outputs:  map[string]*v1alpha1.PipelineResource{},
+	}
+	expectedState := PipelineRunState{{
+		PipelineTask: &v1alpha1.PipelineTask{
+			Name:    "mytask1",
+			TaskRef: &v1alpha1.TaskRef{Name: "task"},
+			Params:  []v1alpha1.Param{{Name: "version", Value: "1"}, {Name: "platform", Value: "linux"}},
+		},
+		TaskRunName:           "pipelinerun-mytask1-9l9zj",
+		ResolvedTaskResources: rtr,
+		MatrixParams:          []v1alpha1.Param{{Name: "platform", Value: "linux"}},
+	}, {
+		PipelineTask: &v1alpha1.PipelineTask{
+			Name:    "mytask1",
+			TaskRef: &v1alpha1.TaskRef{Name: "task"},
+			Params:  []v1alpha1.Param{{Name: "version", Value: "1"}, {Name: "platform", Value: "windows"}},
+		},
+		TaskRunName:           "pipelinerun-mytask1-1-retry1",
+		ResolvedTaskResources: rtr,
+		MatrixParams:          []v1alpha1.Param{{Name: "platform", Value: "windows"}},
+		MatrixIndex:           1,
+	}}
+	if d := cmp.Diff(expectedState, pipelineState, cmpopts.IgnoreUnexported(v1alpha1.TaskRunSpec{})); d != "" {
+		t.Errorf("Expected a TaskRun for each combination of the matrix but actual differed: %s", d)
+	}
+}
+
+func TestResolvePipelineRun_NestedPipeline(t *testing.T) {
+	names.TestingSeed()
+	pts := []v1alpha1.PipelineTask{{
+		Name:        "build",
+		PipelineRef: &v1alpha1.PipelineRef{Name: "build-pipeline"},
+		Params:      []v1alpha1.Param{{Name: "revision", Value: "v1"}},
+	}}
+	providedResources := map[string]v1alpha1.PipelineResourceRef{}
+
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return nil, fmt.Errorf("should not get called") }
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun",
+		},
+	}
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, getNoCondition, pts, providedResources)
+	if err != nil {
+		t.Fatalf("Did not expect error when resolving PipelineRun with a nested Pipeline: %v", err)
+	}
+	expectedState := PipelineRunState{{
+		PipelineTask:    &pts[0],
+		PipelineRunName: "pipelinerun-build-9l9zj",
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Expected the nested Pipeline to be run by a PipelineRun but actual differed: %s", d)
+	}
+}
+
+func TestResolvePipelineRun_Approval(t *testing.T) {
+	pts := []v1alpha1.PipelineTask{{
+		Name:     "approve",
+		Approval: &v1alpha1.ApprovalGate{Users: []string{"alice"}},
+	}}
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return nil, fmt.Errorf("should not get called") }
+	pr := tb.PipelineRun("pipelinerun", namespace, tb.PipelineRunStatus(tb.PipelineRunApprovalsStatus(map[string]*v1alpha1.PipelineRunApprovalStatus{
+		"pipelinerun-approve-abcde": {PipelineTaskName: "approve"},
+	})))
+	pipelineState, err := ResolvePipelineRun(*pr, getTask, getClusterTask, getResource, getNoCondition, pts, map[string]v1alpha1.PipelineResourceRef{})
+	if err != nil {
+		t.Fatalf("Did not expect error when resolving PipelineRun with an approval: %v", err)
+	}
+	expectedState := PipelineRunState{{
+		PipelineTask: &pts[0],
+		ApprovalName: "pipelinerun-approve-abcde",
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Expected the approval to keep the Approval recorded in the status but actual differed: %s", d)
+	}
+
+	a := tb.Approval("pipelinerun-approve-abcde", namespace)
+	getApproval := func(name string) (*v1alpha1.Approval, error) {
+		if name == a.Name {
+			return a, nil
+		}
+		return nil, errors.NewNotFound(v1alpha1.Resource("approval"), name)
+	}
+	if err := ResolveApprovals(getApproval, pipelineState); err != nil {
+		t.Fatalf("Didn't expect error resolving approvals but got %v", err)
+	}
+	if pipelineState[0].Approval != a || !pipelineState[0].IsStarted() {
+		t.Errorf("Expected the approval to resolve to its Approval but was %v", pipelineState[0].Approval)
+	}
+}
+
+func TestResolvePipelineRun_CustomTask(t *testing.T) {
+	pts := []v1alpha1.PipelineTask{{
+		Name:    "wait",
+		TaskRef: &v1alpha1.TaskRef{APIVersion: "example.dev/v1", Kind: "Wait"},
+	}}
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return nil, fmt.Errorf("should not get called") }
+	pr := tb.PipelineRun("pipelinerun", namespace, tb.PipelineRunStatus(tb.PipelineRunRunsStatus(map[string]*v1alpha1.PipelineRunRunStatus{
+		"pipelinerun-wait-abcde": {PipelineTaskName: "wait"},
+	})))
+	pipelineState, err := ResolvePipelineRun(*pr, getTask, getClusterTask, getResource, getNoCondition, pts, map[string]v1alpha1.PipelineResourceRef{})
+	if err != nil {
+		t.Fatalf("Did not expect error when resolving PipelineRun with a custom task: %v", err)
+	}
+	expectedState := PipelineRunState{{
+		PipelineTask: &pts[0],
+		RunName:      "pipelinerun-wait-abcde",
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Expected the custom task to keep the Run recorded in the status but actual differed: %s", d)
+	}
+
+	r := tb.Run("pipelinerun-wait-abcde", namespace, tb.RunStatus(tb.RunStatusCondition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+	})))
+	getRun := func(name string) (*v1alpha1.Run, error) {
+		if name == r.Name {
+			return r, nil
+		}
+		return nil, errors.NewNotFound(v1alpha1.Resource("run"), name)
+	}
+	if err := ResolveRuns(getRun, pipelineState); err != nil {
+		t.Fatalf("Didn't expect error resolving runs but got %v", err)
+	}
+	if pipelineState[0].Run != r || !pipelineState[0].IsDone() || !pipelineState[0].IsSuccessful() {
+		t.Errorf("Expected the custom task to resolve to its successful Run but was %v", pipelineState[0].Run)
+	}
+}
+
 func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 	pts := []v1alpha1.PipelineTask{{
 		Name:    "mytask1",
-		TaskRef: v1alpha1.TaskRef{Name: "task"},
+		TaskRef: &v1alpha1.TaskRef{Name: "task"},
 	}}
 	providedResources := map[string]v1alpha1.PipelineResourceRef{}
 
@@ -661,7 +1404,7 @@ func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, pts, providedResources)
+	_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, getNoCondition, pts, providedResources)
 	switch err := err.(type) {
 	case nil:
 		t.Fatalf("Expected error getting non-existent Tasks for Pipeline %s but got none", p.Name)
@@ -707,7 +1450,7 @@ func TestResolvePipelineRun_ResourceBindingsDontExist(t *testing.T) {
 					Name: "pipelinerun",
 				},
 			}
-			_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, tt.p.Spec.Tasks, providedResources)
+			_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, getNoCondition, tt.p.Spec.Tasks, providedResources)
 			if err == nil {
 				t.Fatalf("Expected error when bindings are in incorrect state for Pipeline %s but got none", p.Name)
 			}
@@ -756,7 +1499,7 @@ func TestResolvePipelineRun_ResourcesDontExist(t *testing.T) {
 					Name: "pipelinerun",
 				},
 			}
-			_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, tt.p.Spec.Tasks, providedResources)
+			_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, getNoCondition, tt.p.Spec.Tasks, providedResources)
 			switch err := err.(type) {
 			case nil:
 				t.Fatalf("Expected error getting non-existent Resources for Pipeline %s but got none", p.Name)
@@ -821,6 +1564,33 @@ func TestResolveTaskRuns_SomeStarted(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineRuns(t *testing.T) {
+	nested := v1alpha1.PipelineTask{Name: "build", PipelineRef: &v1alpha1.PipelineRef{Name: "build-pipeline"}}
+	child := tb.PipelineRun("pipelinerun-build", namespace)
+	state := []*ResolvedPipelineRunTask{{
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1",
+	}, {
+		PipelineTask:    &nested,
+		PipelineRunName: "pipelinerun-build",
+	}}
+	getPipelineRun := func(name string) (*v1alpha1.PipelineRun, error) {
+		if name == "pipelinerun-build" {
+			return child, nil
+		}
+		return nil, errors.NewNotFound(v1alpha1.Resource("pipelinerun"), name)
+	}
+	if err := ResolvePipelineRuns(getPipelineRun, state); err != nil {
+		t.Fatalf("Didn't expect error resolving pipelineruns but got %v", err)
+	}
+	if state[0].PipelineRun != nil {
+		t.Errorf("Expected the task running a Task not to resolve to a pipelinerun but was %v", state[0].PipelineRun)
+	}
+	if state[1].PipelineRun != child {
+		t.Errorf("Expected the task running a Pipeline to resolve to its pipelinerun but was %v", state[1].PipelineRun)
+	}
+}
+
 func TestResolveTaskRuns_NoneStarted(t *testing.T) {
 	state := []*ResolvedPipelineRunTask{{
 		TaskRunName: "pipelinerun-mytask1",
@@ -1074,7 +1844,7 @@ func TestResolvePipelineRun_withExistingTaskRuns(t *testing.T) {
 	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, nil }
 	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return r, nil }
 
-	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, p.Spec.Tasks, providedResources)
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, getNoCondition, p.Spec.Tasks, providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -1096,3 +1866,11 @@ func TestResolvePipelineRun_withExistingTaskRuns(t *testing.T) {
 		t.Fatalf("Expected to get current pipeline state %v, but actual differed: %s", expectedState, d)
 	}
 }
+
+func getPipelineTasks(state PipelineRunState) []v1alpha1.PipelineTask {
+	tasks := []v1alpha1.PipelineTask{}
+	for _, t := range state {
+		tasks = append(tasks, *t.PipelineTask)
+	}
+	return tasks
+}
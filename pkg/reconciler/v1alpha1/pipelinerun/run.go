@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// createRun creates the Run of the PipelineTask of rprt referring to a custom task, with the
+// params and the timeout of the PipelineTask, for the controller of the custom task to fulfil.
+func (c *Reconciler) createRun(rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun) (*v1alpha1.Run, error) {
+	r := &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.RunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: pr.GetOwnerReference(),
+			Labels:          getTaskRunLabels(pr),
+		},
+		Spec: v1alpha1.RunSpec{
+			Ref:     rprt.PipelineTask.TaskRef.DeepCopy(),
+			Params:  rprt.PipelineTask.Params,
+			Timeout: getTaskRunTimeout(pr, rprt.PipelineTask),
+		},
+	}
+	return c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Create(r)
+}
+
+// reconcileRun records when the Run of rprt started, like the TaskRun reconciler does for the
+// TaskRuns, and times it out since the controller fulfilling it may not: a Run which times out
+// is marked as failed, and cancelled so that its controller stops it.
+func (c *Reconciler) reconcileRun(rprt *resources.ResolvedPipelineRunTask) error {
+	r := rprt.Run.DeepCopy()
+	if !r.HasStarted() {
+		r.Status.InitializeConditions()
+		if r.Spec.Timeout != nil {
+			go c.timeoutHandler.WaitRun(r, r.Status.StartTime)
+		}
+	}
+	timedOut := r.Spec.Timeout != nil && time.Since(r.Status.StartTime.Time) > r.Spec.Timeout.Duration
+	if timedOut {
+		c.Logger.Infof("Run %s has timed out (running for %s over %s)", r.Name, time.Since(r.Status.StartTime.Time), r.Spec.Timeout.Duration)
+		r.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1alpha1.RunReasonTimedOut,
+			Message: fmt.Sprintf("Run %q failed to finish within %q", r.Name, r.Spec.Timeout.Duration.String()),
+		})
+		r.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+		c.timeoutHandler.Release(r)
+	}
+	if !equality.Semantic.DeepEqual(r.Status, rprt.Run.Status) {
+		updated, err := c.PipelineClientSet.TektonV1alpha1().Runs(r.Namespace).UpdateStatus(r)
+		if err != nil {
+			return err
+		}
+		rprt.Run = updated
+	}
+	if timedOut && !rprt.Run.IsCancelled() {
+		if errs := requestRunCancellation(rprt.Run, r.Namespace, c.PipelineClientSet); len(errs) > 0 {
+			return fmt.Errorf("error cancelling Run %s after it timed out: %s", r.Name, errs[0])
+		}
+	}
+	return nil
+}
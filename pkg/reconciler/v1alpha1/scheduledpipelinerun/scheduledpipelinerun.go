@@ -0,0 +1,391 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduledpipelinerun
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/knative/pkg/controller"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	informers "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1alpha1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/cron"
+	"github.com/tektoncd/pipeline/pkg/names"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// scheduledPipelineRunAgentName defines logging agent name for ScheduledPipelineRun Controller
+	scheduledPipelineRunAgentName = "scheduled-pipeline-run-controller"
+	// scheduledPipelineRunControllerName defines name for ScheduledPipelineRun Controller
+	scheduledPipelineRunControllerName = "ScheduledPipelineRun"
+
+	// Event reasons
+	eventReasonCreated = "PipelineRunCreated"
+	eventReasonMissed  = "PipelineRunMissed"
+	eventReasonFailed  = "ScheduledPipelineRunFailed"
+
+	// maxMissedRuns bounds how many of the PipelineRuns which were due while the controller
+	// wasn't running are walked through, like CronJobs do
+	maxMissedRuns = 100
+)
+
+// Reconciler implements controller.Reconciler for ScheduledPipelineRun resources.
+type Reconciler struct {
+	*reconciler.Base
+
+	// listers index properties about resources
+	scheduledPipelineRunLister listers.ScheduledPipelineRunLister
+	pipelineRunLister          listers.PipelineRunLister
+
+	// now returns the current time, and is replaced in tests
+	now func() time.Time
+
+	// timers enqueue the ScheduledPipelineRuns again when their next PipelineRun is due
+	timersMu sync.Mutex
+	timers   map[string]*time.Timer
+	enqueue  func(key string)
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// NewController creates a new ScheduledPipelineRun controller
+func NewController(
+	opt reconciler.Options,
+	scheduledPipelineRunInformer informers.ScheduledPipelineRunInformer,
+	pipelineRunInformer informers.PipelineRunInformer,
+) *controller.Impl {
+
+	r := &Reconciler{
+		Base:                       reconciler.NewBase(opt, scheduledPipelineRunAgentName),
+		scheduledPipelineRunLister: scheduledPipelineRunInformer.Lister(),
+		pipelineRunLister:          pipelineRunInformer.Lister(),
+		now:                        time.Now,
+		timers:                     map[string]*time.Timer{},
+	}
+
+	impl := controller.NewImpl(r, r.Logger, scheduledPipelineRunControllerName, reconciler.MustNewStatsReporter(scheduledPipelineRunControllerName, r.Logger))
+	r.enqueue = impl.EnqueueKey
+
+	r.Logger.Info("Setting up event handlers")
+	scheduledPipelineRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    impl.Enqueue,
+		UpdateFunc: controller.PassNew(impl.Enqueue),
+	})
+	// The ScheduledPipelineRuns keep track of the PipelineRuns they created which are
+	// still running, and may create the next one once they are done
+	pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind(scheduledPipelineRunControllerName)),
+		Handler: cache.ResourceEventHandlerFuncs{
+			UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
+			DeleteFunc: impl.EnqueueControllerOf,
+		},
+	})
+	return impl
+}
+
+// Reconcile creates the PipelineRuns of the ScheduledPipelineRun which are due, deletes
+// the oldest finished ones, and updates its Status block with the ones still running.
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		c.Logger.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+
+	original, err := c.scheduledPipelineRunLister.ScheduledPipelineRuns(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		// The resource no longer exists, in which case we stop processing.
+		c.Logger.Infof("scheduled pipeline run %q in work queue no longer exists", key)
+		c.stopTimer(key)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Don't modify the informer's copy.
+	spr := original.DeepCopy()
+	next, err := c.reconcile(spr)
+	if err != nil {
+		c.Logger.Errorf("Reconcile error: %v", err.Error())
+		c.Recorder.Event(spr, corev1.EventTypeWarning, eventReasonFailed, err.Error())
+	}
+	if !next.IsZero() {
+		c.startTimer(key, next.Sub(c.now()))
+	} else {
+		c.stopTimer(key)
+	}
+
+	if equality.Semantic.DeepEqual(original.Status, spr.Status) {
+		// If we didn't change anything then don't call updateStatus.
+	} else if _, updateErr := c.PipelineClientSet.TektonV1alpha1().ScheduledPipelineRuns(namespace).UpdateStatus(spr); updateErr != nil {
+		c.Logger.Warn("Failed to update ScheduledPipelineRun status", zap.Error(updateErr))
+		return updateErr
+	}
+	return err
+}
+
+// reconcile creates the PipelineRun of spr which is due, if any, and returns when the next
+// one is due, or the zero time if it doesn't need to be reconciled on schedule.
+func (c *Reconciler) reconcile(spr *v1alpha1.ScheduledPipelineRun) (time.Time, error) {
+	active, err := c.syncPipelineRuns(spr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if spr.Spec.Suspend {
+		return time.Time{}, nil
+	}
+
+	schedule, err := cron.Parse(spr.Spec.Schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid schedule %q: %v", spr.Spec.Schedule, err)
+	}
+	loc, err := spr.Spec.GetLocation()
+	if err != nil {
+		return time.Time{}, err
+	}
+	now := c.now().In(loc)
+	next := schedule.Next(now)
+
+	last := spr.CreationTimestamp.Time
+	if last.IsZero() {
+		last = now
+	}
+	if spr.Status.LastScheduleTime != nil {
+		last = spr.Status.LastScheduleTime.Time
+	}
+	// Only the latest of the PipelineRuns which became due since the last one is
+	// created, the earlier ones were missed while the controller wasn't running
+	due, lastMissed, missed, tooMany := dueTimes(schedule, last.In(loc), now)
+	if missed > 0 {
+		count := fmt.Sprint(missed)
+		if tooMany {
+			count = "more than " + count
+		}
+		spr.Status.MissedRuns += int64(missed)
+		spr.Status.LastMissedTime = &metav1.Time{Time: lastMissed}
+		c.Recorder.Eventf(spr, corev1.EventTypeWarning, eventReasonMissed, "Missed %s PipelineRuns due until %s: the controller wasn't running",
+			count, lastMissed.Format(time.RFC3339))
+	}
+	if due.IsZero() {
+		return next, nil
+	}
+	spr.Status.LastScheduleTime = &metav1.Time{Time: due}
+
+	if len(active) > 0 {
+		switch spr.Spec.ConcurrencyPolicy {
+		case v1alpha1.ScheduledPipelineRunConcurrencyPolicyForbid:
+			c.missed(spr, due, fmt.Sprintf("PipelineRuns %v are still running", spr.Status.Active))
+			return next, nil
+		case v1alpha1.ScheduledPipelineRunConcurrencyPolicyReplace:
+			for _, pr := range active {
+				if err := c.cancelPipelineRun(pr); err != nil {
+					return next, err
+				}
+			}
+		}
+	}
+
+	pr, err := c.createPipelineRun(spr, due)
+	if errors.IsAlreadyExists(err) {
+		// The PipelineRun was created by a previous reconcile whose status update failed
+		c.Logger.Infof("PipelineRun %s of ScheduledPipelineRun %s already exists", pr.Name, spr.Name)
+	} else if err != nil {
+		return next, fmt.Errorf("failed to create PipelineRun %s: %v", pr.Name, err)
+	} else {
+		c.Recorder.Eventf(spr, corev1.EventTypeNormal, eventReasonCreated, "Created PipelineRun %s", pr.Name)
+	}
+	spr.Status.Active = append(spr.Status.Active, pr.Name)
+	return next, nil
+}
+
+// dueTimes returns the latest time after last and until now at which a PipelineRun is due,
+// if any, together with the previous one and how many earlier ones were missed. At most
+// maxMissedRuns are counted, tooMany reports if there were more.
+func dueTimes(schedule *cron.Schedule, last, now time.Time) (due, lastMissed time.Time, missed int, tooMany bool) {
+	for t := schedule.Next(last); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+		if !due.IsZero() {
+			if missed == maxMissedRuns {
+				tooMany = true
+				break
+			}
+			missed++
+			lastMissed = due
+		}
+		due = t
+	}
+	if !tooMany {
+		return due, lastMissed, missed, false
+	}
+
+	// Rather than walking through all of the missed times, look for the latest two in a
+	// window before now which is doubled until it contains them
+	for d := time.Minute; ; d *= 2 {
+		from := now.Add(-d)
+		if !from.After(due) {
+			from = due
+		}
+		first := schedule.Next(from)
+		if first.IsZero() || first.After(now) {
+			if from.Equal(due) {
+				return due, lastMissed, missed, true
+			}
+			continue
+		}
+		if second := schedule.Next(first); second.IsZero() || second.After(now) {
+			if from.Equal(due) {
+				return first, due, missed, true
+			}
+			continue
+		}
+		for t := first; !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+			lastMissed, due = due, t
+		}
+		return due, lastMissed, missed, true
+	}
+}
+
+// syncPipelineRuns records the PipelineRuns of spr which are still running in its status,
+// deleting the oldest finished ones beyond its history limit, and returns the running ones.
+func (c *Reconciler) syncPipelineRuns(spr *v1alpha1.ScheduledPipelineRun) ([]*v1alpha1.PipelineRun, error) {
+	selector := labels.SelectorFromSet(labels.Set{pipeline.GroupName + pipeline.ScheduledPipelineRunLabelKey: spr.Name})
+	prs, err := c.pipelineRunLister.PipelineRuns(spr.Namespace).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the PipelineRuns of ScheduledPipelineRun %s: %v", spr.Name, err)
+	}
+	sort.Slice(prs, func(i, j int) bool {
+		if prs[i].CreationTimestamp.Equal(&prs[j].CreationTimestamp) {
+			return prs[i].Name < prs[j].Name
+		}
+		return prs[i].CreationTimestamp.Before(&prs[j].CreationTimestamp)
+	})
+
+	var active, finished []*v1alpha1.PipelineRun
+	for _, pr := range prs {
+		if !metav1.IsControlledBy(pr, spr) {
+			continue
+		}
+		if pr.IsDone() {
+			finished = append(finished, pr)
+		} else {
+			active = append(active, pr)
+		}
+	}
+
+	for i := 0; i < len(finished)-spr.Spec.GetHistoryLimit(); i++ {
+		pr := finished[i]
+		if err := c.PipelineClientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Delete(pr.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete PipelineRun %s: %v", pr.Name, err)
+		}
+	}
+
+	spr.Status.Active = nil
+	for _, pr := range active {
+		spr.Status.Active = append(spr.Status.Active, pr.Name)
+	}
+	return active, nil
+}
+
+// missed records that the PipelineRun of spr due at t wasn't created, for the given reason.
+func (c *Reconciler) missed(spr *v1alpha1.ScheduledPipelineRun, t time.Time, reason string) {
+	spr.Status.MissedRuns++
+	spr.Status.LastMissedTime = &metav1.Time{Time: t}
+	c.Recorder.Eventf(spr, corev1.EventTypeWarning, eventReasonMissed, "Missed the PipelineRun due at %s: %s", t.Format(time.RFC3339), reason)
+}
+
+// createPipelineRun creates the PipelineRun of spr which is due at t from its template. The
+// name of the PipelineRun depends on t, so that it isn't created twice.
+func (c *Reconciler) createPipelineRun(spr *v1alpha1.ScheduledPipelineRun, t time.Time) (*v1alpha1.PipelineRun, error) {
+	template := spr.Spec.PipelineRunTemplate.DeepCopy()
+	labels := template.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[pipeline.GroupName+pipeline.ScheduledPipelineRunLabelKey] = spr.Name
+
+	pr := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            names.SimpleNameGenerator.RestrictLengthWithSuffix(spr.Name, fmt.Sprintf("-%d", t.Unix()/60)),
+			Namespace:       spr.Namespace,
+			OwnerReferences: spr.GetOwnerReference(),
+			Labels:          labels,
+			Annotations:     template.Annotations,
+		},
+		Spec: template.Spec,
+	}
+	pr.Spec.Trigger = v1alpha1.PipelineTrigger{
+		Type: v1alpha1.PipelineTriggerTypeScheduled,
+		Name: spr.Name,
+	}
+
+	created, err := c.PipelineClientSet.TektonV1alpha1().PipelineRuns(spr.Namespace).Create(pr)
+	if err != nil {
+		return pr, err
+	}
+	return created, nil
+}
+
+// cancelPipelineRun marks the spec of pr as cancelled, so that the PipelineRun controller
+// stops its TaskRuns.
+func (c *Reconciler) cancelPipelineRun(pr *v1alpha1.PipelineRun) error {
+	if pr.IsCancelled() {
+		return nil
+	}
+	pr = pr.DeepCopy()
+	pr.Spec.Status = v1alpha1.PipelineRunSpecStatusCancelled
+	if _, err := c.PipelineClientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Update(pr); err != nil {
+		return fmt.Errorf("failed to cancel PipelineRun %s: %v", pr.Name, err)
+	}
+	return nil
+}
+
+// startTimer enqueues the ScheduledPipelineRun with the given key after d, replacing the
+// timer previously started for it.
+func (c *Reconciler) startTimer(key string, d time.Duration) {
+	c.timersMu.Lock()
+	defer c.timersMu.Unlock()
+	if timer, ok := c.timers[key]; ok {
+		timer.Stop()
+	}
+	c.timers[key] = time.AfterFunc(d, func() {
+		c.enqueue(key)
+	})
+}
+
+// stopTimer stops the timer started for the ScheduledPipelineRun with the given key, if any.
+func (c *Reconciler) stopTimer(key string) {
+	c.timersMu.Lock()
+	defer c.timersMu.Unlock()
+	if timer, ok := c.timers[key]; ok {
+		timer.Stop()
+		delete(c.timers, key)
+	}
+}
@@ -0,0 +1,401 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduledpipelinerun
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/test"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var (
+	created  = time.Date(2019, time.March, 4, 1, 30, 0, 0, time.UTC)
+	nightly  = time.Date(2019, time.March, 4, 2, 0, 0, 0, time.UTC)
+	finished = tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+	}))
+	running = tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+	}))
+)
+
+// getScheduledPipelineRunController returns an instance of the ScheduledPipelineRun controller/reconciler
+// that has been seeded with d, and for which the current time is now.
+func getScheduledPipelineRunController(d test.Data, now time.Time) test.TestAssets {
+	c, i := test.SeedTestData(d)
+	observer, logs := observer.New(zap.InfoLevel)
+	impl := NewController(
+		reconciler.Options{
+			Logger:            zap.New(observer).Sugar(),
+			KubeClientSet:     c.Kube,
+			PipelineClientSet: c.Pipeline,
+			Recorder:          record.NewFakeRecorder(10),
+		},
+		i.ScheduledPipelineRun,
+		i.PipelineRun,
+	)
+	impl.Reconciler.(*Reconciler).now = func() time.Time { return now }
+	return test.TestAssets{
+		Controller: impl,
+		Logs:       logs,
+		Clients:    c,
+		Informers:  i,
+	}
+}
+
+func scheduledPipelineRun(name string, ops ...tb.PipelineRunOp) *v1alpha1.PipelineRun {
+	ops = append([]tb.PipelineRunOp{
+		tb.PipelineRunSpec("nightly-pipeline"),
+		tb.PipelineRunLabel("tekton.dev/scheduledPipelineRun", "nightly"),
+		tb.PipelineRunOwnerReference("ScheduledPipelineRun", "nightly", tb.Controller),
+	}, ops...)
+	return tb.PipelineRun(name, "foo", ops...)
+}
+
+func TestReconcile(t *testing.T) {
+	spr := tb.ScheduledPipelineRun("nightly", "foo", "0 2 * * *", "nightly-pipeline",
+		tb.ScheduledPipelineRunCreationTimestamp(created),
+		tb.ScheduledPipelineRunTemplateLabel("team", "security"),
+		tb.ScheduledPipelineRunTemplateSpec(tb.PipelineRunServiceAccount("scanner")),
+	)
+	d := test.Data{ScheduledPipelineRuns: []*v1alpha1.ScheduledPipelineRun{spr}}
+
+	testAssets := getScheduledPipelineRunController(d, nightly.Add(30*time.Second))
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/nightly"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling ScheduledPipelineRun but saw %s", err)
+	}
+	clients := testAssets.Clients
+
+	name := "nightly-25861080"
+	pr, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get(name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected PipelineRun %s to be created: %s", name, err)
+	}
+	expected := tb.PipelineRun(name, "foo",
+		tb.PipelineRunLabel("team", "security"),
+		tb.PipelineRunLabel("tekton.dev/scheduledPipelineRun", "nightly"),
+		tb.PipelineRunOwnerReference("ScheduledPipelineRun", "nightly",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1alpha1"), tb.Controller, tb.BlockOwnerDeletion),
+		tb.PipelineRunSpec("nightly-pipeline", tb.PipelineRunServiceAccount("scanner")),
+	)
+	expected.Spec.Trigger = v1alpha1.PipelineTrigger{Type: v1alpha1.PipelineTriggerTypeScheduled, Name: "nightly"}
+	if d := cmp.Diff(expected, pr); d != "" {
+		t.Errorf("PipelineRun created didn't match, diff: %s", d)
+	}
+
+	reconciled, err := clients.Pipeline.Tekton().ScheduledPipelineRuns("foo").Get("nightly", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled ScheduledPipelineRun out of fake client: %s", err)
+	}
+	expectedStatus := v1alpha1.ScheduledPipelineRunStatus{
+		LastScheduleTime: &metav1.Time{Time: nightly},
+		Active:           []string{name},
+	}
+	if d := cmp.Diff(expectedStatus, reconciled.Status); d != "" {
+		t.Errorf("ScheduledPipelineRun status didn't match, diff: %s", d)
+	}
+}
+
+func TestReconcile_NotDue(t *testing.T) {
+	tests := []struct {
+		name string
+		spr  *v1alpha1.ScheduledPipelineRun
+	}{{
+		name: "before the first schedule",
+		spr: tb.ScheduledPipelineRun("nightly", "foo", "0 2 * * *", "nightly-pipeline",
+			tb.ScheduledPipelineRunCreationTimestamp(nightly.Add(10*time.Second)),
+		),
+	}, {
+		name: "already created",
+		spr: tb.ScheduledPipelineRun("nightly", "foo", "0 2 * * *", "nightly-pipeline",
+			tb.ScheduledPipelineRunCreationTimestamp(created),
+			tb.ScheduledPipelineRunStatus(tb.ScheduledPipelineRunLastScheduleTime(nightly)),
+		),
+	}, {
+		name: "suspended",
+		spr: tb.ScheduledPipelineRun("nightly", "foo", "0 2 * * *", "nightly-pipeline",
+			tb.ScheduledPipelineRunCreationTimestamp(created),
+			tb.ScheduledPipelineRunSuspend,
+		),
+	}, {
+		name: "in another time zone",
+		spr: tb.ScheduledPipelineRun("nightly", "foo", "0 2 * * *", "nightly-pipeline",
+			tb.ScheduledPipelineRunCreationTimestamp(created),
+			tb.ScheduledPipelineRunTimeZone("America/New_York"),
+		),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{ScheduledPipelineRuns: []*v1alpha1.ScheduledPipelineRun{tc.spr}}
+			testAssets := getScheduledPipelineRunController(d, nightly.Add(30*time.Second))
+			if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/nightly"); err != nil {
+				t.Fatalf("Did not expect to see error when reconciling ScheduledPipelineRun but saw %s", err)
+			}
+			prs, err := testAssets.Clients.Pipeline.Tekton().PipelineRuns("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error listing PipelineRuns out of fake client: %s", err)
+			}
+			if len(prs.Items) != 0 {
+				t.Errorf("Expected no PipelineRun to be created but got %v", prs.Items)
+			}
+		})
+	}
+}
+
+func TestReconcile_TimeZone(t *testing.T) {
+	// 2am in Paris is 1am UTC in the winter
+	spr := tb.ScheduledPipelineRun("nightly", "foo", "0 2 * * *", "nightly-pipeline",
+		tb.ScheduledPipelineRunCreationTimestamp(created.Add(-time.Hour)),
+		tb.ScheduledPipelineRunTimeZone("Europe/Paris"),
+	)
+	d := test.Data{ScheduledPipelineRuns: []*v1alpha1.ScheduledPipelineRun{spr}}
+	testAssets := getScheduledPipelineRunController(d, nightly.Add(-time.Hour))
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/nightly"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling ScheduledPipelineRun but saw %s", err)
+	}
+	if _, err := testAssets.Clients.Pipeline.Tekton().PipelineRuns("foo").Get("nightly-25861020", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected PipelineRun to be created at 2am in Paris: %s", err)
+	}
+}
+
+func TestReconcile_MissedRuns(t *testing.T) {
+	// The controller wasn't running for the last three nights
+	spr := tb.ScheduledPipelineRun("nightly", "foo", "0 2 * * *", "nightly-pipeline",
+		tb.ScheduledPipelineRunCreationTimestamp(created.Add(-7*24*time.Hour)),
+		tb.ScheduledPipelineRunStatus(tb.ScheduledPipelineRunLastScheduleTime(nightly.Add(-3*24*time.Hour))),
+	)
+	d := test.Data{ScheduledPipelineRuns: []*v1alpha1.ScheduledPipelineRun{spr}}
+	testAssets := getScheduledPipelineRunController(d, nightly.Add(10*time.Minute))
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/nightly"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling ScheduledPipelineRun but saw %s", err)
+	}
+	clients := testAssets.Clients
+
+	prs, err := clients.Pipeline.Tekton().PipelineRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error listing PipelineRuns out of fake client: %s", err)
+	}
+	if len(prs.Items) != 1 || prs.Items[0].Name != "nightly-25861080" {
+		t.Errorf("Expected only the latest PipelineRun to be created but got %v", prs.Items)
+	}
+	reconciled, err := clients.Pipeline.Tekton().ScheduledPipelineRuns("foo").Get("nightly", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled ScheduledPipelineRun out of fake client: %s", err)
+	}
+	if reconciled.Status.MissedRuns != 2 {
+		t.Errorf("Expected 2 missed runs but got %d", reconciled.Status.MissedRuns)
+	}
+	if lastMissed := reconciled.Status.LastMissedTime; lastMissed == nil || !lastMissed.Time.Equal(nightly.Add(-24*time.Hour)) {
+		t.Errorf("Expected last missed time to be %s but got %v", nightly.Add(-24*time.Hour), lastMissed)
+	}
+}
+
+func TestReconcile_MissedRunsLongDowntime(t *testing.T) {
+	// The controller wasn't running for a week, during which a PipelineRun was due every minute
+	spr := tb.ScheduledPipelineRun("nightly", "foo", "* * * * *", "nightly-pipeline",
+		tb.ScheduledPipelineRunCreationTimestamp(created.Add(-14*24*time.Hour)),
+		tb.ScheduledPipelineRunStatus(tb.ScheduledPipelineRunLastScheduleTime(nightly.Add(-7*24*time.Hour))),
+	)
+	d := test.Data{ScheduledPipelineRuns: []*v1alpha1.ScheduledPipelineRun{spr}}
+	testAssets := getScheduledPipelineRunController(d, nightly.Add(30*time.Second))
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/nightly"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling ScheduledPipelineRun but saw %s", err)
+	}
+	clients := testAssets.Clients
+
+	prs, err := clients.Pipeline.Tekton().PipelineRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error listing PipelineRuns out of fake client: %s", err)
+	}
+	if len(prs.Items) != 1 || prs.Items[0].Name != "nightly-25861080" {
+		t.Errorf("Expected only the latest PipelineRun to be created but got %v", prs.Items)
+	}
+	reconciled, err := clients.Pipeline.Tekton().ScheduledPipelineRuns("foo").Get("nightly", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled ScheduledPipelineRun out of fake client: %s", err)
+	}
+	if reconciled.Status.MissedRuns != maxMissedRuns {
+		t.Errorf("Expected %d missed runs but got %d", maxMissedRuns, reconciled.Status.MissedRuns)
+	}
+	if lastMissed := reconciled.Status.LastMissedTime; lastMissed == nil || !lastMissed.Time.Equal(nightly.Add(-time.Minute)) {
+		t.Errorf("Expected last missed time to be %s but got %v", nightly.Add(-time.Minute), lastMissed)
+	}
+
+	recorder := testAssets.Controller.Reconciler.(*Reconciler).Recorder.(*record.FakeRecorder)
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	expected := []string{
+		"Warning PipelineRunMissed Missed more than 100 PipelineRuns due until 2019-03-04T01:59:00Z: the controller wasn't running",
+		"Normal PipelineRunCreated Created PipelineRun nightly-25861080",
+	}
+	if d := cmp.Diff(expected, events); d != "" {
+		t.Errorf("Events didn't match, diff: %s", d)
+	}
+}
+
+func TestReconcile_LongName(t *testing.T) {
+	name := strings.Repeat("a", 63)
+	spr := tb.ScheduledPipelineRun(name, "foo", "0 2 * * *", "nightly-pipeline",
+		tb.ScheduledPipelineRunCreationTimestamp(created),
+	)
+	d := test.Data{ScheduledPipelineRuns: []*v1alpha1.ScheduledPipelineRun{spr}}
+	testAssets := getScheduledPipelineRunController(d, nightly.Add(30*time.Second))
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/"+name); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling ScheduledPipelineRun but saw %s", err)
+	}
+
+	prName := strings.Repeat("a", 54) + "-25861080"
+	if _, err := testAssets.Clients.Pipeline.Tekton().PipelineRuns("foo").Get(prName, metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected PipelineRun %s to be created: %s", prName, err)
+	}
+}
+
+func TestReconcile_ConcurrencyPolicy(t *testing.T) {
+	tests := []struct {
+		name              string
+		policy            v1alpha1.ScheduledPipelineRunConcurrencyPolicy
+		expectedCreated   bool
+		expectedCancelled bool
+		expectedMissed    int64
+		expectedActive    []string
+	}{{
+		name:            "allow",
+		policy:          v1alpha1.ScheduledPipelineRunConcurrencyPolicyAllow,
+		expectedCreated: true,
+		expectedActive:  []string{"nightly-25859640", "nightly-25861080"},
+	}, {
+		name:           "forbid",
+		policy:         v1alpha1.ScheduledPipelineRunConcurrencyPolicyForbid,
+		expectedMissed: 1,
+		expectedActive: []string{"nightly-25859640"},
+	}, {
+		name:              "replace",
+		policy:            v1alpha1.ScheduledPipelineRunConcurrencyPolicyReplace,
+		expectedCreated:   true,
+		expectedCancelled: true,
+		expectedActive:    []string{"nightly-25859640", "nightly-25861080"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				ScheduledPipelineRuns: []*v1alpha1.ScheduledPipelineRun{tb.ScheduledPipelineRun("nightly", "foo", "0 2 * * *", "nightly-pipeline",
+					tb.ScheduledPipelineRunCreationTimestamp(created.Add(-7*24*time.Hour)),
+					tb.ScheduledPipelineRunConcurrencyPolicy(tc.policy),
+					tb.ScheduledPipelineRunStatus(tb.ScheduledPipelineRunLastScheduleTime(nightly.Add(-24*time.Hour))),
+				)},
+				PipelineRuns: []*v1alpha1.PipelineRun{scheduledPipelineRun("nightly-25859640", running)},
+			}
+			testAssets := getScheduledPipelineRunController(d, nightly)
+			if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/nightly"); err != nil {
+				t.Fatalf("Did not expect to see error when reconciling ScheduledPipelineRun but saw %s", err)
+			}
+			clients := testAssets.Clients
+
+			_, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("nightly-25861080", metav1.GetOptions{})
+			if created := err == nil; created != tc.expectedCreated {
+				t.Errorf("Expected PipelineRun to be created: %t, but got error %v", tc.expectedCreated, err)
+			}
+			previous, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("nightly-25859640", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting PipelineRun out of fake client: %s", err)
+			}
+			if previous.IsCancelled() != tc.expectedCancelled {
+				t.Errorf("Expected previous PipelineRun to be cancelled: %t, but spec status was %q", tc.expectedCancelled, previous.Spec.Status)
+			}
+			reconciled, err := clients.Pipeline.Tekton().ScheduledPipelineRuns("foo").Get("nightly", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled ScheduledPipelineRun out of fake client: %s", err)
+			}
+			if reconciled.Status.MissedRuns != tc.expectedMissed {
+				t.Errorf("Expected %d missed runs but got %d", tc.expectedMissed, reconciled.Status.MissedRuns)
+			}
+			if d := cmp.Diff(tc.expectedActive, reconciled.Status.Active); d != "" {
+				t.Errorf("Active PipelineRuns didn't match, diff: %s", d)
+			}
+			if !reconciled.Status.LastScheduleTime.Time.Equal(nightly) {
+				t.Errorf("Expected last schedule time to be %s but got %s", nightly, reconciled.Status.LastScheduleTime)
+			}
+		})
+	}
+}
+
+func TestReconcile_HistoryLimit(t *testing.T) {
+	day := func(n int) func(*v1alpha1.PipelineRun) {
+		return func(pr *v1alpha1.PipelineRun) {
+			pr.CreationTimestamp = metav1.Time{Time: nightly.Add(time.Duration(n-4) * 24 * time.Hour)}
+		}
+	}
+	d := test.Data{
+		ScheduledPipelineRuns: []*v1alpha1.ScheduledPipelineRun{tb.ScheduledPipelineRun("nightly", "foo", "0 2 * * *", "nightly-pipeline",
+			tb.ScheduledPipelineRunCreationTimestamp(created.Add(-7*24*time.Hour)),
+			tb.ScheduledPipelineRunHistoryLimit(2),
+			tb.ScheduledPipelineRunStatus(tb.ScheduledPipelineRunLastScheduleTime(nightly)),
+		)},
+		PipelineRuns: []*v1alpha1.PipelineRun{
+			scheduledPipelineRun("nightly-1", day(1), finished),
+			scheduledPipelineRun("nightly-2", day(2), finished),
+			scheduledPipelineRun("nightly-3", day(3), finished),
+			scheduledPipelineRun("nightly-4", day(4), running),
+			// PipelineRuns which aren't controlled by the ScheduledPipelineRun are never deleted
+			tb.PipelineRun("nightly-0", "foo", day(0), finished,
+				tb.PipelineRunLabel("tekton.dev/scheduledPipelineRun", "nightly")),
+		},
+	}
+	testAssets := getScheduledPipelineRunController(d, nightly.Add(time.Hour))
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), "foo/nightly"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling ScheduledPipelineRun but saw %s", err)
+	}
+	clients := testAssets.Clients
+
+	prs, err := clients.Pipeline.Tekton().PipelineRuns("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error listing PipelineRuns out of fake client: %s", err)
+	}
+	names := []string{}
+	for _, pr := range prs.Items {
+		names = append(names, pr.Name)
+	}
+	sort.Strings(names)
+	if d := cmp.Diff([]string{"nightly-0", "nightly-2", "nightly-3", "nightly-4"}, names); d != "" {
+		t.Errorf("Remaining PipelineRuns didn't match, diff: %s", d)
+	}
+	reconciled, err := clients.Pipeline.Tekton().ScheduledPipelineRuns("foo").Get("nightly", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled ScheduledPipelineRun out of fake client: %s", err)
+	}
+	if d := cmp.Diff([]string{"nightly-4"}, reconciled.Status.Active); d != "" {
+		t.Errorf("Active PipelineRuns didn't match, diff: %s", d)
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -43,12 +44,40 @@ const (
 	InitContainerName = "place-tools"
 	digestSeparator   = "@"
 	cacheSize         = 1024
+
+	// ResultsMountName is the name of the volume the steps write their
+	// results to, and ResultsDir is where it is mounted.
+	ResultsMountName = "results"
+	ResultsDir       = "/builder/results"
+
+	// ScriptsMountName is the name of the volume the scripts of the steps
+	// are written to, and ScriptsDir is where it is mounted.
+	ScriptsMountName = "scripts"
+	ScriptsDir       = "/builder/scripts"
+
+	// DownwardMountName is the name of the volume exposing the ReadyAnnotation
+	// of the pod to its first step, as the file ReadyFile.
+	DownwardMountName  = "downward"
+	DownwardMountPoint = "/builder/downward"
+	ReadyFile          = DownwardMountPoint + "/ready"
+	// ReadyAnnotation is set to ReadyAnnotationValue on the pod once its
+	// sidecars are ready, letting its first step start.
+	ReadyAnnotation      = "tekton.dev/ready"
+	ReadyAnnotationValue = "READY"
 )
 
 var toolsMount = corev1.VolumeMount{
 	Name:      MountName,
 	MountPath: MountPoint,
 }
+var resultsMount = corev1.VolumeMount{
+	Name:      ResultsMountName,
+	MountPath: ResultsDir,
+}
+var downwardMount = corev1.VolumeMount{
+	Name:      DownwardMountName,
+	MountPath: DownwardMountPoint,
+}
 var (
 	entrypointImage = flag.String("entrypoint-image", "override-with-entrypoint:latest",
 		"The container image containing our entrypoint binary.")
@@ -98,7 +127,7 @@ func AddCopyStep(spec *v1alpha1.TaskSpec) {
 		Args:         []string{"-c", fmt.Sprintf("cp /ko-app/entrypoint %s", BinaryLocation)},
 		VolumeMounts: []corev1.VolumeMount{toolsMount},
 	}
-	spec.Steps = append([]corev1.Container{cp}, spec.Steps...)
+	spec.Steps = append([]v1alpha1.Step{{Container: cp}}, spec.Steps...)
 
 }
 
@@ -106,9 +135,15 @@ func AddCopyStep(spec *v1alpha1.TaskSpec) {
 // the binary being run is no longer the one specified by the Command
 // and the Args, but is instead the entrypoint binary, which will
 // itself invoke the Command and Args, but also capture logs.
-func RedirectSteps(cache *Cache, steps []corev1.Container, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun, logger *zap.SugaredLogger) error {
+//
+// Steps with a script run the file their script is written to when the pod
+// is created, at ScriptPath.
+func RedirectSteps(cache *Cache, steps []v1alpha1.Step, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun, logger *zap.SugaredLogger) error {
 	for i := range steps {
 		step := &steps[i]
+		if step.Script != "" {
+			step.Command = []string{ScriptPath(i)}
+		}
 		if err := RedirectStep(cache, i, step, kubeclient, taskRun, logger); err != nil {
 			return err
 		}
@@ -121,7 +156,7 @@ func RedirectSteps(cache *Cache, steps []corev1.Container, kubeclient kubernetes
 // the binary being run is no longer the one specified by the Command
 // and the Args, but is instead the entrypoint binary, which will
 // itself invoke the Command and Args, but also capture logs.
-func RedirectStep(cache *Cache, stepNum int, step *corev1.Container, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun, logger *zap.SugaredLogger) error {
+func RedirectStep(cache *Cache, stepNum int, step *v1alpha1.Step, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun, logger *zap.SugaredLogger) error {
 	if len(step.Command) == 0 {
 		logger.Infof("Getting Cmd from remote entrypoint for step: %s", step.Name)
 		var err error
@@ -132,15 +167,75 @@ func RedirectStep(cache *Cache, stepNum int, step *corev1.Container, kubeclient
 		}
 	}
 
-	step.Args = GetArgs(stepNum, step.Command, step.Args)
+	step.Args = GetArgs(stepNum, step.Command, step.Args, step.Timeout, step.OnError)
 	step.Command = []string{BinaryLocation}
 	step.VolumeMounts = append(step.VolumeMounts, toolsMount)
 	return nil
 }
 
+// AddResultsDir will give each of the (already redirected) steps access to
+// the directory the Task's results are written to, and will make the
+// entrypoint report the results found there as the step's termination
+// message. It does nothing if the Task doesn't declare any results.
+func AddResultsDir(spec *v1alpha1.TaskSpec) {
+	if len(spec.Results) == 0 {
+		return
+	}
+	for i := range spec.Steps {
+		step := &spec.Steps[i]
+		step.Args = append([]string{"-results_dir", ResultsDir}, step.Args...)
+		step.VolumeMounts = append(step.VolumeMounts, resultsMount)
+	}
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: ResultsMountName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+}
+
+// AddReadyWait will make the first of the (already redirected) steps wait for
+// the pod to be marked as ready with the ReadyAnnotation, once its sidecars
+// are, before starting. It does nothing if the Task doesn't have sidecars.
+func AddReadyWait(spec *v1alpha1.TaskSpec) {
+	if len(spec.Sidecars) == 0 || len(spec.Steps) == 0 {
+		return
+	}
+	step := &spec.Steps[0]
+	for i := 0; i < len(step.Args)-1; i++ {
+		if step.Args[i] == "-wait_file" {
+			step.Args[i+1] = ReadyFile
+			break
+		}
+	}
+	// The file exists as soon as the pod starts, but is empty until the
+	// annotation is set.
+	step.Args = append([]string{"-wait_file_content"}, step.Args...)
+	step.VolumeMounts = append(step.VolumeMounts, downwardMount)
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: DownwardMountName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path: "ready",
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: fmt.Sprintf("metadata.annotations['%s']", ReadyAnnotation),
+					},
+				}},
+			},
+		},
+	})
+}
+
+// ScriptPath returns where the script of the step stepNum is written to.
+func ScriptPath(stepNum int) string {
+	return fmt.Sprintf("%s/script-%d", ScriptsDir, stepNum)
+}
+
 // GetArgs returns the arguments that should be specified for the step which has been wrapped
-// such that it will execute our custom entrypoint instead of the user provided Command and Args.
-func GetArgs(stepNum int, commands, args []string) []string {
+// such that it will execute our custom entrypoint instead of the user provided Command and Args,
+// killing it after timeout and going on with the next steps if it fails, according to onError.
+func GetArgs(stepNum int, commands, args []string, timeout *metav1.Duration, onError v1alpha1.StepOnError) []string {
 	waitFile := fmt.Sprintf("%s/%s", MountPoint, strconv.Itoa(stepNum-1))
 	if stepNum == 0 {
 		waitFile = ""
@@ -152,12 +247,18 @@ func GetArgs(stepNum int, commands, args []string) []string {
 		args = append(commands[1:], args...)
 		commands = commands[:1]
 	}
-	argsForEntrypoint := append([]string{
+	argsForEntrypoint := []string{
 		"-wait_file", waitFile,
 		"-post_file", fmt.Sprintf("%s/%s", MountPoint, strconv.Itoa(stepNum)),
-		"-entrypoint"},
-		commands...,
-	)
+	}
+	if timeout != nil && timeout.Duration > 0 {
+		argsForEntrypoint = append(argsForEntrypoint, "-timeout", timeout.Duration.String())
+	}
+	if onError != "" {
+		argsForEntrypoint = append(argsForEntrypoint, "-on_error", string(onError))
+	}
+	argsForEntrypoint = append(argsForEntrypoint, "-entrypoint")
+	argsForEntrypoint = append(argsForEntrypoint, commands...)
 	// TODO: what if Command has multiple elements, do we need "--" between command and args?
 	argsForEntrypoint = append(argsForEntrypoint, "--")
 	return append(argsForEntrypoint, args...)
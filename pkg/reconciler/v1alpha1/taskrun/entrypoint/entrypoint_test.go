@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
@@ -26,17 +27,18 @@ const (
 )
 
 func TestRewriteSteps(t *testing.T) {
-	inputs := []corev1.Container{
-		{
+	inputs := []v1alpha1.Step{{
+		Container: corev1.Container{
 			Image:   "image",
 			Command: []string{"abcd"},
 		},
-		{
+	}, {
+		Container: corev1.Container{
 			Image:   "my.registry.svc/image:tag",
 			Command: []string{"abcd"},
 			Args:    []string{"efgh"},
 		},
-	}
+	}}
 	taskRun := &v1alpha1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "foo",
@@ -44,11 +46,11 @@ func TestRewriteSteps(t *testing.T) {
 		},
 		Spec: v1alpha1.TaskRunSpec{
 			TaskSpec: &v1alpha1.TaskSpec{
-				Steps: []corev1.Container{{
+				Steps: []v1alpha1.Step{{Container: corev1.Container{
 					Image:   "ubuntu",
 					Command: []string{"echo"},
 					Args:    []string{"hello"},
-				}},
+				}}},
 			},
 		},
 	}
@@ -76,6 +78,35 @@ func TestRewriteSteps(t *testing.T) {
 	}
 }
 
+func TestRewriteSteps_Script(t *testing.T) {
+	steps := []v1alpha1.Step{{
+		Container: corev1.Container{Image: "image", Command: []string{"abcd"}},
+	}, {
+		Container: corev1.Container{Image: "ubuntu", Args: []string{"efgh"}},
+		Script:    "echo hello",
+	}}
+	observer, _ := observer.New(zap.InfoLevel)
+	entrypointCache, _ := NewCache()
+	c := fakekubeclientset.NewSimpleClientset()
+	// The script is run as-is, so the entrypoint of the image isn't looked up
+	if err := RedirectSteps(entrypointCache, steps, c, &v1alpha1.TaskRun{}, zap.New(observer).Sugar()); err != nil {
+		t.Fatalf("failed to redirect steps: %v", err)
+	}
+	wantArgs := []string{
+		"-wait_file", "/builder/tools/0",
+		"-post_file", "/builder/tools/1",
+		"-entrypoint", "/builder/scripts/script-1",
+		"--",
+		"efgh",
+	}
+	if d := cmp.Diff(wantArgs, steps[1].Args); d != "" {
+		t.Errorf("Script step args diff -want, +got: %v", d)
+	}
+	if d := cmp.Diff([]string{BinaryLocation}, steps[1].Command); d != "" {
+		t.Errorf("Script step command diff -want, +got: %v", d)
+	}
+}
+
 func TestGetArgs(t *testing.T) {
 	// first step
 	// multiple commands
@@ -85,6 +116,8 @@ func TestGetArgs(t *testing.T) {
 		stepNum      int
 		commands     []string
 		args         []string
+		timeout      *metav1.Duration
+		onError      v1alpha1.StepOnError
 		expectedArgs []string
 	}{{
 		desc:     "Args for first step",
@@ -121,9 +154,25 @@ func TestGetArgs(t *testing.T) {
 			"-entrypoint", "ls",
 			"--",
 		},
+	}, {
+		desc:     "Timeout and on error",
+		stepNum:  1,
+		commands: []string{"golint"},
+		args:     []string{"./..."},
+		timeout:  &metav1.Duration{Duration: 90 * time.Second},
+		onError:  v1alpha1.StepOnErrorContinue,
+		expectedArgs: []string{
+			"-wait_file", "/builder/tools/0",
+			"-post_file", "/builder/tools/1",
+			"-timeout", "1m30s",
+			"-on_error", "continue",
+			"-entrypoint", "golint",
+			"--",
+			"./...",
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
-			a := GetArgs(c.stepNum, c.commands, c.args)
+			a := GetArgs(c.stepNum, c.commands, c.args, c.timeout, c.onError)
 			if d := cmp.Diff(a, c.expectedArgs); d != "" {
 				t.Errorf("Didn't get expected arguments, difference: %s", d)
 			}
@@ -241,11 +290,11 @@ func TestGetRemoteEntrypoint(t *testing.T) {
 		Spec: v1alpha1.TaskRunSpec{
 			ServiceAccount: "default",
 			TaskSpec: &v1alpha1.TaskSpec{
-				Steps: []corev1.Container{{
+				Steps: []v1alpha1.Step{{Container: corev1.Container{
 					Image:   "ubuntu",
 					Command: []string{"echo"},
 					Args:    []string{"hello"},
-				}},
+				}}},
 			},
 		},
 	}
@@ -310,11 +359,11 @@ func TestGetRemoteEntrypointWithNonDefaultSA(t *testing.T) {
 		Spec: v1alpha1.TaskRunSpec{
 			ServiceAccount: "some-other-sa",
 			TaskSpec: &v1alpha1.TaskSpec{
-				Steps: []corev1.Container{{
+				Steps: []v1alpha1.Step{{Container: corev1.Container{
 					Image:   "ubuntu",
 					Command: []string{"echo"},
 					Args:    []string{"hello"},
-				}},
+				}}},
 			},
 		},
 	}
@@ -360,11 +409,11 @@ func TestEntrypointCacheLRU(t *testing.T) {
 
 func TestAddCopyStep(t *testing.T) {
 	ts := &v1alpha1.TaskSpec{
-		Steps: []corev1.Container{{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
 			Name: "test",
-		}, {
+		}}, {Container: corev1.Container{
 			Name: "test",
-		}},
+		}}},
 	}
 
 	expectedSteps := len(ts.Steps) + 1
@@ -376,3 +425,86 @@ func TestAddCopyStep(t *testing.T) {
 		t.Errorf("entrypoint is incorrect: %s should be %s", ts.Steps[0].Name, InitContainerName)
 	}
 }
+
+func TestAddResultsDir(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name: "test",
+			Args: []string{"-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--"},
+		}}},
+		Results: []v1alpha1.TaskResult{{Name: "digest"}},
+	}
+	AddResultsDir(ts)
+
+	expectedSteps := []v1alpha1.Step{{Container: corev1.Container{
+		Name:         "test",
+		Args:         []string{"-results_dir", ResultsDir, "-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--"},
+		VolumeMounts: []corev1.VolumeMount{{Name: ResultsMountName, MountPath: ResultsDir}},
+	}}}
+	if d := cmp.Diff(expectedSteps, ts.Steps); d != "" {
+		t.Errorf("steps diff -want, +got: %v", d)
+	}
+	if len(ts.Volumes) != 1 || ts.Volumes[0].Name != ResultsMountName {
+		t.Errorf("expected results volume to be added but got %v", ts.Volumes)
+	}
+}
+
+func TestAddResultsDir_NoResults(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{Name: "test"}}},
+	}
+	AddResultsDir(ts)
+	if d := cmp.Diff(&v1alpha1.TaskSpec{Steps: []v1alpha1.Step{{Container: corev1.Container{Name: "test"}}}}, ts); d != "" {
+		t.Errorf("expected TaskSpec without results to be unchanged, diff -want, +got: %v", d)
+	}
+}
+
+func TestAddReadyWait(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name: "first",
+			Args: []string{"-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--"},
+		}}, {Container: corev1.Container{
+			Name: "second",
+			Args: []string{"-wait_file", "/builder/tools/0", "-post_file", "/builder/tools/1", "-entrypoint", "echo", "--"},
+		}}},
+		Sidecars: []corev1.Container{{Name: "db", Image: "postgres"}},
+	}
+	AddReadyWait(ts)
+
+	expectedSteps := []v1alpha1.Step{{Container: corev1.Container{
+		Name:         "first",
+		Args:         []string{"-wait_file_content", "-wait_file", ReadyFile, "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--"},
+		VolumeMounts: []corev1.VolumeMount{{Name: DownwardMountName, MountPath: DownwardMountPoint}},
+	}}, {Container: corev1.Container{
+		Name: "second",
+		Args: []string{"-wait_file", "/builder/tools/0", "-post_file", "/builder/tools/1", "-entrypoint", "echo", "--"},
+	}}}
+	if d := cmp.Diff(expectedSteps, ts.Steps); d != "" {
+		t.Errorf("steps diff -want, +got: %v", d)
+	}
+	expectedVolumes := []corev1.Volume{{
+		Name: DownwardMountName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path:     "ready",
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['tekton.dev/ready']"},
+				}},
+			},
+		},
+	}}
+	if d := cmp.Diff(expectedVolumes, ts.Volumes); d != "" {
+		t.Errorf("volumes diff -want, +got: %v", d)
+	}
+}
+
+func TestAddReadyWait_NoSidecars(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{Name: "test", Args: []string{"-wait_file", ""}}}},
+	}
+	AddReadyWait(ts)
+	if d := cmp.Diff(&v1alpha1.TaskSpec{Steps: []v1alpha1.Step{{Container: corev1.Container{Name: "test", Args: []string{"-wait_file", ""}}}}}, ts); d != "" {
+		t.Errorf("expected TaskSpec without sidecars to be unchanged, diff -want, +got: %v", d)
+	}
+}
@@ -18,26 +18,45 @@ package resources
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/templating"
 )
 
 // ApplyParameters applies the params from a TaskRun.Input.Parameters to a TaskSpec
 func ApplyParameters(spec *v1alpha1.TaskSpec, tr *v1alpha1.TaskRun, defaults ...v1alpha1.TaskParam) *v1alpha1.TaskSpec {
 	// This assumes that the TaskRun inputs have been validated against what the Task requests.
-	replacements := map[string]string{}
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
 	// Set all the default replacements
 	for _, p := range defaults {
-		if p.Default != "" {
-			replacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default
+		if p.ArrayDefault != nil {
+			arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.ArrayDefault
+		} else if p.Default != "" {
+			stringReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default
 		}
 	}
 	// Set and overwrite params with the ones from the TaskRun
 	for _, p := range tr.Spec.Inputs.Params {
-		replacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value
+		if p.ArrayValue != nil {
+			arrayReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.ArrayValue
+		} else {
+			stringReplacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value
+		}
 	}
 
+	return ApplyArrayReplacements(spec, stringReplacements, arrayReplacements)
+}
+
+// ApplyResults replaces the placeholders for the paths of the results declared
+// in spec with the location the steps should write them to.
+func ApplyResults(spec *v1alpha1.TaskSpec) *v1alpha1.TaskSpec {
+	replacements := map[string]string{}
+	for _, r := range spec.Results {
+		replacements[fmt.Sprintf("results.%s.path", r.Name)] = filepath.Join(entrypoint.ResultsDir, r.Name)
+	}
 	return ApplyReplacements(spec, replacements)
 }
 
@@ -65,6 +84,13 @@ func ApplyResources(spec *v1alpha1.TaskSpec, resources []v1alpha1.TaskResourceBi
 
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
 func ApplyReplacements(spec *v1alpha1.TaskSpec, replacements map[string]string) *v1alpha1.TaskSpec {
+	return ApplyArrayReplacements(spec, replacements, map[string][]string{})
+}
+
+// ApplyArrayReplacements replaces placeholders for declared parameters with the specified
+// replacements. The items of the command and args of the steps which are made of a reference
+// to one of arrayReplacements only are replaced with the items of that array.
+func ApplyArrayReplacements(spec *v1alpha1.TaskSpec, replacements map[string]string, arrayReplacements map[string][]string) *v1alpha1.TaskSpec {
 	spec = spec.DeepCopy()
 
 	// Apply variable expansion to steps fields.
@@ -72,15 +98,24 @@ func ApplyReplacements(spec *v1alpha1.TaskSpec, replacements map[string]string)
 	for i := range steps {
 		steps[i].Name = templating.ApplyReplacements(steps[i].Name, replacements)
 		steps[i].Image = templating.ApplyReplacements(steps[i].Image, replacements)
-		for ia, a := range steps[i].Args {
-			steps[i].Args[ia] = templating.ApplyReplacements(a, replacements)
+		if steps[i].Args != nil {
+			args := []string{}
+			for _, a := range steps[i].Args {
+				args = append(args, templating.ApplyArrayReplacements(a, replacements, arrayReplacements)...)
+			}
+			steps[i].Args = args
 		}
 		for ie, e := range steps[i].Env {
 			steps[i].Env[ie].Value = templating.ApplyReplacements(e.Value, replacements)
 		}
 		steps[i].WorkingDir = templating.ApplyReplacements(steps[i].WorkingDir, replacements)
-		for ic, c := range steps[i].Command {
-			steps[i].Command[ic] = templating.ApplyReplacements(c, replacements)
+		steps[i].Script = templating.ApplyReplacements(steps[i].Script, replacements)
+		if steps[i].Command != nil {
+			command := []string{}
+			for _, c := range steps[i].Command {
+				command = append(command, templating.ApplyArrayReplacements(c, replacements, arrayReplacements)...)
+			}
+			steps[i].Command = command
 		}
 		for iv, v := range steps[i].VolumeMounts {
 			steps[i].VolumeMounts[iv].Name = templating.ApplyReplacements(v.Name, replacements)
@@ -27,22 +27,22 @@ import (
 )
 
 var simpleTaskSpec = &v1alpha1.TaskSpec{
-	Steps: []corev1.Container{{
+	Steps: []v1alpha1.Step{{Container: corev1.Container{
 		Name:  "foo",
 		Image: "${inputs.params.myimage}",
-	}, {
+	}}, {Container: corev1.Container{
 		Name:  "baz",
 		Image: "bat",
 		Args:  []string{"${inputs.resources.workspace.url}"},
-	}, {
+	}}, {Container: corev1.Container{
 		Name:  "qux",
 		Image: "quux",
 		Args:  []string{"${outputs.resources.imageToUse.url}"},
-	}},
+	}}},
 }
 
 var volumeMountTaskSpec = &v1alpha1.TaskSpec{
-	Steps: []corev1.Container{{
+	Steps: []v1alpha1.Step{{Container: corev1.Container{
 		Name:  "foo",
 		Image: "busybox:${inputs.params.FOO}",
 		VolumeMounts: []corev1.VolumeMount{{
@@ -50,6 +50,25 @@ var volumeMountTaskSpec = &v1alpha1.TaskSpec{
 			MountPath: "path/to/${inputs.params.FOO}",
 			SubPath:   "sub/${inputs.params.FOO}/path",
 		}},
+	}}},
+}
+
+var arrayParamTaskSpec = &v1alpha1.TaskSpec{
+	Steps: []v1alpha1.Step{{Container: corev1.Container{
+		Name:    "foo",
+		Image:   "bar",
+		Command: []string{"cmd", "${inputs.params.flags}"},
+		Args:    []string{"first", "${inputs.params.flags}", "--level=${inputs.params.level}", "last"},
+	}}},
+}
+
+var scriptTaskSpec = &v1alpha1.TaskSpec{
+	Steps: []v1alpha1.Step{{
+		Container: corev1.Container{
+			Name:  "foo",
+			Image: "bar",
+		},
+		Script: "#!/bin/bash\necho ${inputs.params.myimage} ${HOME}",
 	}},
 }
 
@@ -135,6 +154,15 @@ func TestApplyParameters(t *testing.T) {
 		want: applyMutation(simpleTaskSpec, func(spec *v1alpha1.TaskSpec) {
 			spec.Steps[0].Image = "bar"
 		}),
+	}, {
+		name: "script parameter",
+		args: args{
+			ts: scriptTaskSpec,
+			tr: paramTaskRun,
+		},
+		want: applyMutation(scriptTaskSpec, func(spec *v1alpha1.TaskSpec) {
+			spec.Steps[0].Script = "#!/bin/bash\necho bar ${HOME}"
+		}),
 	}, {
 		name: "volume mount parameter",
 		args: args{
@@ -171,6 +199,69 @@ func TestApplyParameters(t *testing.T) {
 		want: applyMutation(simpleTaskSpec, func(spec *v1alpha1.TaskSpec) {
 			spec.Steps[0].Image = "mydefault"
 		}),
+	}, {
+		name: "array parameter",
+		args: args{
+			ts: arrayParamTaskSpec,
+			tr: &v1alpha1.TaskRun{
+				Spec: v1alpha1.TaskRunSpec{
+					Inputs: v1alpha1.TaskRunInputs{
+						Params: []v1alpha1.Param{{
+							Name:       "flags",
+							ArrayValue: []string{"-v", "-q"},
+						}, {
+							Name:  "level",
+							Value: "debug",
+						}},
+					},
+				},
+			},
+		},
+		want: applyMutation(arrayParamTaskSpec, func(spec *v1alpha1.TaskSpec) {
+			spec.Steps[0].Command = []string{"cmd", "-v", "-q"}
+			spec.Steps[0].Args = []string{"first", "-v", "-q", "--level=debug", "last"}
+		}),
+	}, {
+		name: "empty array parameter",
+		args: args{
+			ts: arrayParamTaskSpec,
+			tr: &v1alpha1.TaskRun{
+				Spec: v1alpha1.TaskRunSpec{
+					Inputs: v1alpha1.TaskRunInputs{
+						Params: []v1alpha1.Param{{
+							Name:       "flags",
+							ArrayValue: []string{},
+						}},
+					},
+				},
+			},
+			dp: []v1alpha1.TaskParam{{
+				Name:    "level",
+				Default: "info",
+			}},
+		},
+		want: applyMutation(arrayParamTaskSpec, func(spec *v1alpha1.TaskSpec) {
+			spec.Steps[0].Command = []string{"cmd"}
+			spec.Steps[0].Args = []string{"first", "--level=info", "last"}
+		}),
+	}, {
+		name: "with default array parameter",
+		args: args{
+			ts: arrayParamTaskSpec,
+			tr: &v1alpha1.TaskRun{},
+			dp: []v1alpha1.TaskParam{{
+				Name:         "flags",
+				Type:         v1alpha1.ParamTypeArray,
+				ArrayDefault: []string{"--default"},
+			}, {
+				Name:    "level",
+				Default: "info",
+			}},
+		},
+		want: applyMutation(arrayParamTaskSpec, func(spec *v1alpha1.TaskSpec) {
+			spec.Steps[0].Command = []string{"cmd", "--default"}
+			spec.Steps[0].Args = []string{"first", "--default", "--level=info", "last"}
+		}),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -182,6 +273,32 @@ func TestApplyParameters(t *testing.T) {
 	}
 }
 
+func TestApplyResults(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name:    "foo",
+			Image:   "busybox",
+			Command: []string{"/bin/sh"},
+			Args:    []string{"-c", "git rev-parse HEAD > ${results.commit.path}"},
+		}}, {Container: corev1.Container{
+			Name:  "bar",
+			Image: "busybox",
+			Env: []corev1.EnvVar{{
+				Name:  "OUT",
+				Value: "${results.version.path}",
+			}},
+		}}},
+		Results: []v1alpha1.TaskResult{{Name: "commit"}, {Name: "version"}},
+	}
+	want := applyMutation(ts, func(spec *v1alpha1.TaskSpec) {
+		spec.Steps[0].Args[1] = "git rev-parse HEAD > /builder/results/commit"
+		spec.Steps[1].Env[0].Value = "/builder/results/version"
+	})
+	if d := cmp.Diff(want, ApplyResults(ts)); d != "" {
+		t.Errorf("ApplyResults() got diff %s", d)
+	}
+}
+
 type rg struct {
 	resources map[string]*v1alpha1.PipelineResource
 }
@@ -62,7 +62,10 @@ func AddInputResource(
 	pvcName := taskRun.GetPipelineRunPVCName()
 	mountPVC := false
 
-	prNameFromLabel := taskRun.Labels[pipeline.GroupName+pipeline.PipelineRunLabelKey]
+	prNameFromLabel := taskRun.Labels[pipeline.GroupName+pipeline.ArtifactStorageKey]
+	if prNameFromLabel == "" {
+		prNameFromLabel = taskRun.Labels[pipeline.GroupName+pipeline.PipelineRunLabelKey]
+	}
 	if prNameFromLabel == "" {
 		prNameFromLabel = pvcName
 	}
@@ -108,7 +111,7 @@ func AddInputResource(
 		}
 		// source is copied from previous task so skip fetching download container definition
 		if len(copyStepsFromPrevTasks) > 0 {
-			taskSpec.Steps = append(copyStepsFromPrevTasks, taskSpec.Steps...)
+			taskSpec.Steps = append(containersToSteps(copyStepsFromPrevTasks), taskSpec.Steps...)
 			taskSpec.Volumes = append(taskSpec.Volumes, as.GetSecretsVolumes()...)
 		} else {
 			switch resource.Spec.Type {
@@ -137,7 +140,7 @@ func AddInputResource(
 				}
 			}
 
-			taskSpec.Steps = append(resourceContainers, taskSpec.Steps...)
+			taskSpec.Steps = append(containersToSteps(resourceContainers), taskSpec.Steps...)
 			taskSpec.Volumes = append(taskSpec.Volumes, resourceVolumes...)
 		}
 	}
@@ -209,3 +212,12 @@ func destinationPath(name, path string) string {
 	}
 	return filepath.Join(workspaceDir, path)
 }
+
+// containersToSteps returns the steps running containers.
+func containersToSteps(containers []corev1.Container) []v1alpha1.Step {
+	steps := make([]v1alpha1.Step, 0, len(containers))
+	for _, c := range containers {
+		steps = append(steps, v1alpha1.Step{Container: c})
+	}
+	return steps
+}
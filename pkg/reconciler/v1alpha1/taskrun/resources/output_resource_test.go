@@ -109,7 +109,7 @@ func TestValidOutputResources(t *testing.T) {
 		desc        string
 		task        *v1alpha1.Task
 		taskRun     *v1alpha1.TaskRun
-		wantSteps   []corev1.Container
+		wantSteps   []v1alpha1.Step
 		wantVolumes []corev1.Volume
 	}{{
 		name: "git resource in input and output",
@@ -163,7 +163,7 @@ func TestValidOutputResources(t *testing.T) {
 				},
 			},
 		},
-		wantSteps: []corev1.Container{{
+		wantSteps: []v1alpha1.Step{{Container: corev1.Container{
 			Name:    "source-mkdir-source-git-9l9zj",
 			Image:   "override-with-bash-noop:latest",
 			Command: []string{"/ko-app/bash"},
@@ -172,7 +172,7 @@ func TestValidOutputResources(t *testing.T) {
 				Name:      "pipelinerun-pvc",
 				MountPath: "/pvc",
 			}},
-		}, {
+		}}, {Container: corev1.Container{
 			Name:    "source-copy-source-git-mz4c7",
 			Image:   "override-with-bash-noop:latest",
 			Command: []string{"/ko-app/bash"},
@@ -181,7 +181,7 @@ func TestValidOutputResources(t *testing.T) {
 				Name:      "pipelinerun-pvc",
 				MountPath: "/pvc",
 			}},
-		}},
+		}}},
 	}, {
 		name: "git resource in output only",
 		desc: "git resource declared as output with pipelinerun owner reference",
@@ -220,7 +220,7 @@ func TestValidOutputResources(t *testing.T) {
 				},
 			},
 		},
-		wantSteps: []corev1.Container{{
+		wantSteps: []v1alpha1.Step{{Container: corev1.Container{
 			Name:    "source-mkdir-source-git-9l9zj",
 			Image:   "override-with-bash-noop:latest",
 			Command: []string{"/ko-app/bash"},
@@ -229,7 +229,7 @@ func TestValidOutputResources(t *testing.T) {
 				Name:      "pipelinerun-pvc",
 				MountPath: "/pvc",
 			}},
-		}, {
+		}}, {Container: corev1.Container{
 			Name:    "source-copy-source-git-mz4c7",
 			Image:   "override-with-bash-noop:latest",
 			Command: []string{"/ko-app/bash"},
@@ -238,7 +238,7 @@ func TestValidOutputResources(t *testing.T) {
 				Name:      "pipelinerun-pvc",
 				MountPath: "/pvc",
 			}},
-		}},
+		}}},
 	}, {
 		name: "image resource in output with pipelinerun with owner",
 		desc: "image resource declared as output with pipelinerun owner reference should not generate any steps",
@@ -365,7 +365,7 @@ func TestValidOutputResources(t *testing.T) {
 				},
 			},
 		},
-		wantSteps: []corev1.Container{{
+		wantSteps: []v1alpha1.Step{{Container: corev1.Container{
 			Name:  "upload-source-gcs-9l9zj",
 			Image: "override-with-gsutil-image:latest",
 			VolumeMounts: []corev1.VolumeMount{{
@@ -377,19 +377,19 @@ func TestValidOutputResources(t *testing.T) {
 			Env: []corev1.EnvVar{{
 				Name: "GOOGLE_APPLICATION_CREDENTIALS", Value: "/var/secret/sname/key.json",
 			}},
-		}, {
+		}}, {Container: corev1.Container{
 			Name:         "source-mkdir-source-gcs-mz4c7",
 			Image:        "override-with-bash-noop:latest",
 			Command:      []string{"/ko-app/bash"},
 			Args:         []string{"-args", "mkdir -p pipeline-task-path"},
 			VolumeMounts: []corev1.VolumeMount{{Name: "pipelinerun-parent-pvc", MountPath: "/pvc"}},
-		}, {
+		}}, {Container: corev1.Container{
 			Name:         "source-copy-source-gcs-mssqb",
 			Image:        "override-with-bash-noop:latest",
 			Command:      []string{"/ko-app/bash"},
 			Args:         []string{"-args", "cp -r /workspace/faraway-disk/. pipeline-task-path"},
 			VolumeMounts: []corev1.VolumeMount{{Name: "pipelinerun-parent-pvc", MountPath: "/pvc"}},
-		}},
+		}}},
 		wantVolumes: []corev1.Volume{{
 			Name: "volume-source-gcs-sname",
 			VolumeSource: corev1.VolumeSource{
@@ -434,7 +434,7 @@ func TestValidOutputResources(t *testing.T) {
 				},
 			},
 		},
-		wantSteps: []corev1.Container{{
+		wantSteps: []v1alpha1.Step{{Container: corev1.Container{
 			Name:  "upload-source-gcs-9l9zj",
 			Image: "override-with-gsutil-image:latest",
 			VolumeMounts: []corev1.VolumeMount{{
@@ -445,19 +445,19 @@ func TestValidOutputResources(t *testing.T) {
 			}},
 			Command: []string{"/ko-app/gsutil"},
 			Args:    []string{"-args", "rsync -d -r /workspace/output/source-workspace gs://some-bucket"},
-		}, {
+		}}, {Container: corev1.Container{
 			Name:         "source-mkdir-source-gcs-mz4c7",
 			Image:        "override-with-bash-noop:latest",
 			Command:      []string{"/ko-app/bash"},
 			Args:         []string{"-args", "mkdir -p pipeline-task-path"},
 			VolumeMounts: []corev1.VolumeMount{{Name: "pipelinerun-pvc", MountPath: "/pvc"}},
-		}, {
+		}}, {Container: corev1.Container{
 			Name:         "source-copy-source-gcs-mssqb",
 			Image:        "override-with-bash-noop:latest",
 			Command:      []string{"/ko-app/bash"},
 			Args:         []string{"-args", "cp -r /workspace/output/source-workspace/. pipeline-task-path"},
 			VolumeMounts: []corev1.VolumeMount{{Name: "pipelinerun-pvc", MountPath: "/pvc"}},
-		}},
+		}}},
 		wantVolumes: []corev1.Volume{{
 			Name: "volume-source-gcs-sname",
 			VolumeSource: corev1.VolumeSource{
@@ -498,7 +498,7 @@ func TestValidOutputResources(t *testing.T) {
 				},
 			},
 		},
-		wantSteps: []corev1.Container{{
+		wantSteps: []v1alpha1.Step{{Container: corev1.Container{
 			Name:  "upload-source-gcs-9l9zj",
 			Image: "override-with-gsutil-image:latest",
 			VolumeMounts: []corev1.VolumeMount{{
@@ -509,7 +509,7 @@ func TestValidOutputResources(t *testing.T) {
 			}},
 			Command: []string{"/ko-app/gsutil"},
 			Args:    []string{"-args", "rsync -d -r /workspace/output/source-workspace gs://some-bucket"},
-		}},
+		}}},
 		wantVolumes: []corev1.Volume{{
 			Name: "volume-source-gcs-sname",
 			VolumeSource: corev1.VolumeSource{
@@ -549,7 +549,7 @@ func TestValidOutputResources(t *testing.T) {
 				},
 			},
 		},
-		wantSteps: []corev1.Container{{
+		wantSteps: []v1alpha1.Step{{Container: corev1.Container{
 			Name:  "upload-source-gcs-9l9zj",
 			Image: "override-with-gsutil-image:latest",
 			VolumeMounts: []corev1.VolumeMount{{
@@ -560,7 +560,7 @@ func TestValidOutputResources(t *testing.T) {
 			}},
 			Command: []string{"/ko-app/gsutil"},
 			Args:    []string{"-args", "rsync -d -r /workspace/output/source-workspace gs://some-bucket"},
-		}},
+		}}},
 		wantVolumes: []corev1.Volume{{
 			Name: "volume-source-gcs-sname",
 			VolumeSource: corev1.VolumeSource{
@@ -678,7 +678,7 @@ func TestValidOutputResourcesWithBucketStorage(t *testing.T) {
 		desc        string
 		task        *v1alpha1.Task
 		taskRun     *v1alpha1.TaskRun
-		wantSteps   []corev1.Container
+		wantSteps   []v1alpha1.Step
 		wantVolumes []corev1.Volume
 	}{{
 		name: "git resource in input and output with bucket storage",
@@ -732,12 +732,12 @@ func TestValidOutputResourcesWithBucketStorage(t *testing.T) {
 				},
 			},
 		},
-		wantSteps: []corev1.Container{{
+		wantSteps: []v1alpha1.Step{{Container: corev1.Container{
 			Name:    "artifact-copy-to-source-git-9l9zj",
 			Image:   "override-with-gsutil-image:latest",
 			Command: []string{"/ko-app/gsutil"},
 			Args:    []string{"-args", "cp -r /workspace/source-workspace gs://fake-bucket/pipeline-task-name"},
-		}},
+		}}},
 	}, {
 		name: "git resource in output only with bucket storage",
 		desc: "git resource declared as output with pipelinerun owner reference",
@@ -776,12 +776,12 @@ func TestValidOutputResourcesWithBucketStorage(t *testing.T) {
 				},
 			},
 		},
-		wantSteps: []corev1.Container{{
+		wantSteps: []v1alpha1.Step{{Container: corev1.Container{
 			Name:    "artifact-copy-to-source-git-9l9zj",
 			Image:   "override-with-gsutil-image:latest",
 			Command: []string{"/ko-app/gsutil"},
 			Args:    []string{"-args", "cp -r /workspace/output/source-workspace gs://fake-bucket/pipeline-task-name"},
-		}},
+		}}},
 	}, {
 		name: "git resource in output",
 		desc: "git resource declared in output without pipelinerun owner reference",
@@ -847,7 +847,7 @@ func TestInValidOutputResources(t *testing.T) {
 		desc      string
 		task      *v1alpha1.Task
 		taskRun   *v1alpha1.TaskRun
-		wantSteps []corev1.Container
+		wantSteps []v1alpha1.Step
 		wantErr   bool
 	}{{
 		desc: "git declared in both resource spec and resource ref",
@@ -26,6 +26,7 @@ import (
 	"io"
 	"io/ioutil"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"go.uber.org/zap"
@@ -90,9 +91,14 @@ const (
 	// IMPORTANT: Changing these values without changing fluentd collection configuration
 	// will break log collection for init containers.
 	containerPrefix            = "build-step-"
+	sidecarPrefix              = "sidecar-"
 	unnamedInitContainerPrefix = "build-step-unnamed-"
 	// Name of the credential initialization container.
 	credsInit = "credential-initializer"
+	// Name of the container writing the scripts of the steps.
+	scriptsInit = "place-scripts"
+	// Interpreter of the scripts which don't start with a shebang line.
+	defaultScriptShebang = "#!/bin/sh\nset -e\n"
 )
 
 var (
@@ -102,6 +108,14 @@ var (
 	// The container that just prints build successful.
 	nopImage = flag.String("nop-image", "override-with-nop:latest",
 		"The container image run at the end of the build to log build success")
+	// The container used to write the scripts of the steps.
+	shellImage = flag.String("shell-image", "override-with-shell:latest",
+		"The container image with a shell used to write the scripts of the steps")
+
+	scriptsMount = corev1.VolumeMount{
+		Name:      entrypoint.ScriptsMountName,
+		MountPath: entrypoint.ScriptsDir,
+	}
 )
 
 func makeCredentialInitializer(serviceAccountName, namespace string, kubeclient kubernetes.Interface) (*corev1.Container, []corev1.Volume, error) {
@@ -197,10 +211,16 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 	initContainers := []corev1.Container{*cred}
 	podContainers := []corev1.Container{}
 
-	maxIndicesByResource := findMaxResourceRequest(taskSpec.Steps, corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage)
+	stepContainers := make([]corev1.Container, 0, len(taskSpec.Steps))
+	for _, s := range taskSpec.Steps {
+		stepContainers = append(stepContainers, s.Container)
+	}
+	maxIndicesByResource := findMaxResourceRequest(stepContainers, corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage)
 
+	// The scripts of the steps, by the path they are written to.
+	scripts := map[string]string{}
 	for i := range taskSpec.Steps {
-		step := &taskSpec.Steps[i]
+		step := &taskSpec.Steps[i].Container
 		step.Env = append(implicitEnvVars, step.Env...)
 		// TODO(mattmoor): Check that volumeMounts match volumes.
 
@@ -229,6 +249,12 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 			initContainers = append(initContainers, *step)
 		} else {
 			zeroNonMaxResourceRequests(step, i, maxIndicesByResource)
+			if script := taskSpec.Steps[i].Script; script != "" {
+				// The step was redirected to run the file at the
+				// path for its position among the containers.
+				scripts[entrypoint.ScriptPath(len(podContainers))] = script
+				step.VolumeMounts = append(step.VolumeMounts, scriptsMount)
+			}
 			podContainers = append(podContainers, *step)
 		}
 	}
@@ -236,6 +262,13 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 	// declared user volumes.
 	volumes := append(taskSpec.Volumes, implicitVolumes...)
 	volumes = append(volumes, secrets...)
+	if len(scripts) > 0 {
+		initContainers = append(initContainers, makeScriptsInitializer(scripts))
+		volumes = append(volumes, corev1.Volume{
+			Name:         entrypoint.ScriptsMountName,
+			VolumeSource: emptyVolumeSource,
+		})
+	}
 	if err := v1alpha1.ValidateVolumes(volumes); err != nil {
 		return nil, err
 	}
@@ -247,9 +280,9 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 	}
 	gibberish := hex.EncodeToString(b)
 
-	nopContainer := &corev1.Container{Name: "nop", Image: *nopImage, Command: []string{"/ko-app/nop"}}
-	entrypoint.RedirectStep(cache, len(podContainers), nopContainer, kubeclient, taskRun, logger)
-	podContainers = append(podContainers, *nopContainer)
+	nopStep := &v1alpha1.Step{Container: corev1.Container{Name: "nop", Image: *nopImage, Command: []string{"/ko-app/nop"}}}
+	entrypoint.RedirectStep(cache, len(podContainers), nopStep, kubeclient, taskRun, logger)
+	podContainers = append(podContainers, nopStep.Container)
 
 	mergedInitContainers, err := merge.CombineStepsWithContainerTemplate(taskSpec.ContainerTemplate, initContainers)
 	if err != nil {
@@ -259,6 +292,12 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 	if err != nil {
 		return nil, err
 	}
+	// The sidecars aren't redirected, so that they start with the pod, nor
+	// based on the container template of the steps.
+	for _, sidecar := range taskSpec.Sidecars {
+		sidecar.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sidecar.Name))
+		mergedPodContainers = append(mergedPodContainers, sidecar)
+	}
 
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -290,6 +329,36 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 	}, nil
 }
 
+// makeScriptsInitializer returns the container writing each of scripts as an
+// executable file at the path it's keyed by, run with /bin/sh unless it starts
+// with a shebang line.
+func makeScriptsInitializer(scripts map[string]string) corev1.Container {
+	paths := make([]string, 0, len(scripts))
+	for path := range scripts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	for _, path := range paths {
+		script := scripts[path]
+		if !strings.HasPrefix(script, "#!") {
+			script = defaultScriptShebang + script
+		}
+		// The delimiter is quoted so that the script is written as-is,
+		// and random so that it doesn't appear in the script.
+		delimiter := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("script-heredoc")
+		fmt.Fprintf(&b, "cat > %s << '%s'\n%s\n%s\nchmod +x %s\n", path, delimiter, script, delimiter, path)
+	}
+	return corev1.Container{
+		Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(containerPrefix + scriptsInit),
+		Image:        *shellImage,
+		Command:      []string{"sh"},
+		Args:         []string{"-c", b.String()},
+		VolumeMounts: []corev1.VolumeMount{scriptsMount},
+	}
+}
+
 // makeLabels constructs the labels we will propagate from TaskRuns to Pods.
 func makeLabels(s *v1alpha1.TaskRun) map[string]string {
 	labels := make(map[string]string, len(s.ObjectMeta.Labels)+1)
@@ -151,10 +151,10 @@ func TestMakePod(t *testing.T) {
 	}{{
 		desc: "simple",
 		ts: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:  "name",
 				Image: "image",
-			}},
+			}}},
 		},
 		bAnnotations: map[string]string{
 			"simple-annotation-key": "simple-annotation-val",
@@ -191,10 +191,10 @@ func TestMakePod(t *testing.T) {
 	}, {
 		desc: "with-service-account",
 		ts: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:  "name",
 				Image: "image",
-			}},
+			}}},
 		},
 		trs: v1alpha1.TaskRunSpec{
 			ServiceAccount: "service-account",
@@ -237,10 +237,10 @@ func TestMakePod(t *testing.T) {
 	}, {
 		desc: "very-long-step-name",
 		ts: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:  "a-very-long-character-step-name-to-trigger-max-len----and-invalid-characters",
 				Image: "image",
-			}},
+			}}},
 		},
 		bAnnotations: map[string]string{
 			"simple-annotation-key": "simple-annotation-val",
@@ -277,10 +277,10 @@ func TestMakePod(t *testing.T) {
 	}, {
 		desc: "step-name-ends-with-non-alphanumeric",
 		ts: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:  "ends-with-invalid-%%__$$",
 				Image: "image",
-			}},
+			}}},
 		},
 		bAnnotations: map[string]string{
 			"simple-annotation-key": "simple-annotation-val",
@@ -314,6 +314,159 @@ func TestMakePod(t *testing.T) {
 			},
 			Volumes: implicitVolumes,
 		},
+	}, {
+		desc: "with-scripts",
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{
+				Container: corev1.Container{
+					Name:  "one",
+					Image: "image",
+				},
+				Script: "echo hello",
+			}, {
+				Container: corev1.Container{
+					Name:    "two",
+					Image:   "image",
+					Command: []string{"echo"},
+				},
+			}, {
+				Container: corev1.Container{
+					Name:  "three",
+					Image: "image",
+				},
+				Script: "#!/usr/bin/env python\nprint('hello')",
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{{
+				Name:         containerPrefix + credsInit + "-9l9zj",
+				Image:        *credsImage,
+				Command:      []string{"/ko-app/creds-init"},
+				Args:         []string{},
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
+			}, {
+				Name:    containerPrefix + scriptsInit + "-78c5n",
+				Image:   *shellImage,
+				Command: []string{"sh"},
+				Args: []string{"-c", `cat > /builder/scripts/script-0 << 'script-heredoc-mz4c7'
+#!/bin/sh
+set -e
+echo hello
+script-heredoc-mz4c7
+chmod +x /builder/scripts/script-0
+cat > /builder/scripts/script-2 << 'script-heredoc-mssqb'
+#!/usr/bin/env python
+print('hello')
+script-heredoc-mssqb
+chmod +x /builder/scripts/script-2
+`},
+				VolumeMounts: []corev1.VolumeMount{scriptsMount},
+			}},
+			Containers: []corev1.Container{{
+				Name:         "build-step-one",
+				Image:        "image",
+				Env:          implicitEnvVars,
+				VolumeMounts: append(implicitVolumeMounts, scriptsMount),
+				WorkingDir:   workspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("0"),
+						corev1.ResourceMemory:           resource.MustParse("0"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("0"),
+					},
+				},
+			}, {
+				Name:         "build-step-two",
+				Image:        "image",
+				Command:      []string{"echo"},
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("0"),
+						corev1.ResourceMemory:           resource.MustParse("0"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("0"),
+					},
+				},
+			}, {
+				Name:         "build-step-three",
+				Image:        "image",
+				Env:          implicitEnvVars,
+				VolumeMounts: append(implicitVolumeMounts, scriptsMount),
+				WorkingDir:   workspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("0"),
+						corev1.ResourceMemory:           resource.MustParse("0"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("0"),
+					},
+				},
+			}, {
+				Name:    "nop",
+				Image:   *nopImage,
+				Command: []string{"/builder/tools/entrypoint"},
+				Args:    []string{"-wait_file", "/builder/tools/2", "-post_file", "/builder/tools/3", "-entrypoint", "/ko-app/nop", "--"},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      entrypoint.MountName,
+					MountPath: entrypoint.MountPoint,
+				}},
+			}},
+			Volumes: append(implicitVolumes, corev1.Volume{
+				Name:         entrypoint.ScriptsMountName,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}),
+		},
+	}, {
+		desc: "with-sidecars",
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "name",
+				Image: "image",
+			}}},
+			Sidecars: []corev1.Container{{
+				Name:  "db",
+				Image: "postgres",
+				Env:   []corev1.EnvVar{{Name: "POSTGRES_PASSWORD", Value: "secret"}},
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{{
+				Name:         containerPrefix + credsInit + "-9l9zj",
+				Image:        *credsImage,
+				Command:      []string{"/ko-app/creds-init"},
+				Args:         []string{},
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
+			}},
+			Containers: []corev1.Container{{
+				Name:         "build-step-name",
+				Image:        "image",
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("0"),
+						corev1.ResourceMemory:           resource.MustParse("0"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("0"),
+					},
+				},
+			},
+				nopContainer,
+				{
+					Name:  "sidecar-db",
+					Image: "postgres",
+					Env:   []corev1.EnvVar{{Name: "POSTGRES_PASSWORD", Value: "secret"}},
+				},
+			},
+			Volumes: implicitVolumes,
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			names.TestingSeed()
@@ -0,0 +1,115 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/entrypoint"
+)
+
+// IsSidecarContainer returns whether the container called name runs a sidecar
+// rather than a step.
+func IsSidecarContainer(name string) bool {
+	return strings.HasPrefix(name, sidecarPrefix)
+}
+
+// TrimSidecarNamePrefix trims the container name prefix to get the corresponding sidecar name
+func TrimSidecarNamePrefix(containerName string) string {
+	return strings.TrimPrefix(containerName, sidecarPrefix)
+}
+
+// SidecarsReady returns whether pod has sidecars, all of which are ready.
+func SidecarsReady(pod *corev1.Pod) bool {
+	found := false
+	for _, s := range pod.Status.ContainerStatuses {
+		if !IsSidecarContainer(s.Name) {
+			continue
+		}
+		if !s.Ready {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// StepsDone returns whether all of the steps of pod, which has sidecars, have
+// terminated, in which case the sidecars should be stopped.
+func StepsDone(pod *corev1.Pod) bool {
+	found := false
+	for _, s := range pod.Status.ContainerStatuses {
+		if IsSidecarContainer(s.Name) {
+			found = true
+		} else if s.State.Terminated == nil {
+			return false
+		}
+	}
+	return found
+}
+
+// UpdateReady sets the ReadyAnnotation on pod, letting its first step start.
+func UpdateReady(kubeclient kubernetes.Interface, pod *corev1.Pod) error {
+	if pod.Annotations[entrypoint.ReadyAnnotation] == entrypoint.ReadyAnnotationValue {
+		return nil
+	}
+	newPod, err := kubeclient.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if newPod.Annotations == nil {
+		newPod.Annotations = map[string]string{}
+	}
+	newPod.Annotations[entrypoint.ReadyAnnotation] = entrypoint.ReadyAnnotationValue
+	_, err = kubeclient.CoreV1().Pods(pod.Namespace).Update(newPod)
+	return err
+}
+
+// StopSidecars stops the sidecars of pod which are still running by replacing
+// their image with the nop image, which exits right away, so that the pod can
+// complete.
+func StopSidecars(kubeclient kubernetes.Interface, pod *corev1.Pod) error {
+	running := map[string]bool{}
+	for _, s := range pod.Status.ContainerStatuses {
+		if IsSidecarContainer(s.Name) && s.State.Running != nil {
+			running[s.Name] = true
+		}
+	}
+	if len(running) == 0 {
+		return nil
+	}
+	newPod, err := kubeclient.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	updated := false
+	for i, c := range newPod.Spec.Containers {
+		if running[c.Name] && c.Image != *nopImage {
+			newPod.Spec.Containers[i].Image = *nopImage
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	_, err = kubeclient.CoreV1().Pods(pod.Namespace).Update(newPod)
+	return err
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSidecarsReady(t *testing.T) {
+	for _, c := range []struct {
+		desc     string
+		statuses []corev1.ContainerStatus
+		want     bool
+	}{{
+		desc:     "no-sidecars",
+		statuses: []corev1.ContainerStatus{{Name: "build-step-step", Ready: true}},
+		want:     false,
+	}, {
+		desc: "sidecar-not-ready",
+		statuses: []corev1.ContainerStatus{
+			{Name: "build-step-step"},
+			{Name: "sidecar-db", Ready: true},
+			{Name: "sidecar-registry"},
+		},
+		want: false,
+	}, {
+		desc: "sidecars-ready",
+		statuses: []corev1.ContainerStatus{
+			{Name: "build-step-step"},
+			{Name: "sidecar-db", Ready: true},
+			{Name: "sidecar-registry", Ready: true},
+		},
+		want: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: c.statuses}}
+			if got := SidecarsReady(pod); got != c.want {
+				t.Errorf("SidecarsReady() = %t, want %t", got, c.want)
+			}
+		})
+	}
+}
+
+func TestStepsDone(t *testing.T) {
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	for _, c := range []struct {
+		desc     string
+		statuses []corev1.ContainerStatus
+		want     bool
+	}{{
+		desc:     "no-sidecars",
+		statuses: []corev1.ContainerStatus{{Name: "build-step-step", State: terminated}},
+		want:     false,
+	}, {
+		desc: "step-running",
+		statuses: []corev1.ContainerStatus{
+			{Name: "build-step-step", State: running},
+			{Name: "sidecar-db", State: running},
+		},
+		want: false,
+	}, {
+		desc: "steps-done",
+		statuses: []corev1.ContainerStatus{
+			{Name: "build-step-step", State: terminated},
+			{Name: "nop", State: terminated},
+			{Name: "sidecar-db", State: running},
+		},
+		want: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: c.statuses}}
+			if got := StepsDone(pod); got != c.want {
+				t.Errorf("StepsDone() = %t, want %t", got, c.want)
+			}
+		})
+	}
+}
+
+func TestUpdateReady(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+	}
+	kubeclient := fakek8s.NewSimpleClientset(pod)
+	if err := UpdateReady(kubeclient, pod); err != nil {
+		t.Fatalf("UpdateReady: %v", err)
+	}
+	got, err := kubeclient.CoreV1().Pods("foo").Get("pod", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Getting pod: %v", err)
+	}
+	if v := got.Annotations["tekton.dev/ready"]; v != "READY" {
+		t.Errorf("Expected ready annotation to be set, got %q", v)
+	}
+}
+
+func TestStopSidecars(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "build-step-step", Image: "step-image"},
+				{Name: "sidecar-db", Image: "postgres"},
+				{Name: "sidecar-registry", Image: "registry"},
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "build-step-step", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}},
+				{Name: "sidecar-db", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				{Name: "sidecar-registry", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}},
+			},
+		},
+	}
+	kubeclient := fakek8s.NewSimpleClientset(pod)
+	if err := StopSidecars(kubeclient, pod); err != nil {
+		t.Fatalf("StopSidecars: %v", err)
+	}
+	got, err := kubeclient.CoreV1().Pods("foo").Get("pod", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Getting pod: %v", err)
+	}
+	want := map[string]string{
+		"build-step-step":  "step-image",
+		"sidecar-db":       *nopImage,
+		"sidecar-registry": "registry",
+	}
+	for _, c := range got.Spec.Containers {
+		if c.Image != want[c.Name] {
+			t.Errorf("Expected container %s to run image %s, got %s", c.Name, want[c.Name], c.Image)
+		}
+	}
+}
@@ -18,8 +18,11 @@ package taskrun
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/knative/pkg/apis"
@@ -59,9 +62,26 @@ const (
 	// is just starting to be reconciled
 	reasonRunning = "Running"
 
+	// reasonBuilding indicates that the reason for the inprogress status is that the pod of
+	// the TaskRun is running
+	reasonBuilding = "Building"
+
 	// reasonTimedOut indicates that the TaskRun has taken longer than its configured timeout
 	reasonTimedOut = "TaskRunTimeout"
 
+	// reasonStepTimedOut indicates that a step of the TaskRun has taken longer than its
+	// configured timeout
+	reasonStepTimedOut = "StepTimeout"
+
+	// reasonPendingTimedOut indicates that the pod of the TaskRun has been pending for longer
+	// than its configured pending timeout
+	reasonPendingTimedOut = "PendingTimeout"
+
+	// reasonInvalidTerminationMessage indicates that a step of the TaskRun reported how it
+	// went with a termination message which couldn't be parsed, such as one truncated
+	// because the results of the step were too large
+	reasonInvalidTerminationMessage = "InvalidTerminationMessage"
+
 	// taskRunAgentName defines logging agent name for TaskRun Controller
 	taskRunAgentName = "taskrun-controller"
 	// taskRunControllerName defines name for TaskRun Controller
@@ -174,9 +194,16 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 		// This is important because the copy we loaded from the informer's
 		// cache may be stale and we don't want to overwrite a prior update
 		// to status with this stale state.
-	} else if _, err := c.updateStatus(tr); err != nil {
-		c.Logger.Warn("Failed to update taskRun status", zap.Error(err))
-		return err
+	} else {
+		// updateStatus writes the status to the informer's copy as well
+		before := original.DeepCopy()
+		if _, err := c.updateStatus(tr); err != nil {
+			c.Logger.Warn("Failed to update taskRun status", zap.Error(err))
+			return err
+		}
+		// Only notify the changes of state once they are stored, since the update
+		// could be rejected and the state computed again.
+		reconciler.EmitCloudEvent(c.CloudEvents, before, tr)
 	}
 	// Since we are using the status subresource, it is not possible to update
 	// the status and labels simultaneously.
@@ -311,7 +338,25 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 		return err
 	}
 
+	// Let the steps start once the sidecars are ready, and stop the sidecars
+	// once the steps are done so that the pod can complete.
+	if pod.Status.Phase == corev1.PodRunning {
+		if resources.SidecarsReady(pod) {
+			if err := resources.UpdateReady(c.KubeClientSet, pod); err != nil {
+				c.Logger.Errorf("Failed to mark pod %q of taskrun %q as ready: %v", pod.Name, tr.Name, err)
+				return err
+			}
+		}
+		if resources.StepsDone(pod) {
+			if err := resources.StopSidecars(c.KubeClientSet, pod); err != nil {
+				c.Logger.Errorf("Failed to stop the sidecars of pod %q of taskrun %q: %v", pod.Name, tr.Name, err)
+				return err
+			}
+		}
+	}
+
 	before := tr.Status.GetCondition(apis.ConditionSucceeded)
+	wasRunning := tr.Status.RunningTime != nil
 
 	updateStatusFromPod(tr, pod)
 
@@ -319,6 +364,13 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 
 	reconciler.EmitEvent(c.Recorder, before, after, tr)
 
+	// The timeout starts once the pod is running, so the timer waiting for the
+	// pending timeout is replaced by one waiting for the timeout.
+	if !wasRunning && tr.Status.RunningTime != nil {
+		c.timeoutHandler.Release(tr)
+		go c.timeoutHandler.WaitTaskRun(tr.DeepCopy(), tr.Status.RunningTime)
+	}
+
 	c.Logger.Infof("Successfully reconciled taskrun %s/%s with status: %#v", tr.Name, tr.Namespace, after)
 
 	return nil
@@ -338,25 +390,88 @@ func updateStatusFromPod(taskRun *v1alpha1.TaskRun, pod *corev1.Pod) {
 	taskRun.Status.PodName = pod.Name
 
 	taskRun.Status.Steps = []v1alpha1.StepState{}
-	for _, s := range pod.Status.ContainerStatuses {
-		taskRun.Status.Steps = append(taskRun.Status.Steps, v1alpha1.StepState{
+	taskRun.Status.Sidecars = nil
+	// invalidStep says which step failed to report how it went, and its results
+	invalidStep := ""
+	for _, s := range sortContainerStatuses(pod) {
+		if resources.IsSidecarContainer(s.Name) {
+			taskRun.Status.Sidecars = append(taskRun.Status.Sidecars, v1alpha1.SidecarState{
+				ContainerState: *s.State.DeepCopy(),
+				Name:           resources.TrimSidecarNamePrefix(s.Name),
+				ImageID:        strings.TrimPrefix(s.ImageID, dockerPullablePrefix),
+			})
+			continue
+		}
+		step := v1alpha1.StepState{
 			ContainerState: *s.State.DeepCopy(),
 			Name:           resources.TrimContainerNamePrefix(s.Name),
-		})
+			ImageID:        strings.TrimPrefix(s.ImageID, dockerPullablePrefix),
+		}
+		if t := step.Terminated; t != nil {
+			m, err := parseTerminationMessage(t.Message)
+			if err != nil && invalidStep == "" {
+				invalidStep = fmt.Sprintf("%q failed: %v (image: %q); for logs run: kubectl -n %s logs %s -c %s",
+					s.Name, err, s.ImageID, pod.Namespace, pod.Name, s.Name)
+			}
+			if m != nil && !m.StartedAt.IsZero() {
+				// The container was started when the pod was, so use
+				// when the step actually ran, excluding its wait.
+				t.StartedAt = metav1.Time{Time: m.StartedAt}
+				t.FinishedAt = metav1.Time{Time: m.FinishedAt}
+				// Steps continuing on error exit with code 0
+				// whatever their command exited with.
+				t.ExitCode = int32(m.ExitCode)
+			}
+			switch {
+			case m != nil && m.Skipped:
+				step.Outcome = v1alpha1.StepOutcomeSkipped
+			case m != nil && m.TimedOut:
+				step.Outcome = v1alpha1.StepOutcomeTimedOut
+			case t.ExitCode != 0 || err != nil:
+				step.Outcome = v1alpha1.StepOutcomeFailed
+			default:
+				step.Outcome = v1alpha1.StepOutcomeSucceeded
+			}
+			if m != nil {
+				updateTaskResults(taskRun, m.Results)
+			}
+		}
+		taskRun.Status.Steps = append(taskRun.Status.Steps, step)
 	}
 
-	switch pod.Status.Phase {
+	phase := pod.Status.Phase
+	if phase == corev1.PodFailed && stepsSucceeded(pod) {
+		// Sidecars running a command fail to run the nop image they are
+		// stopped with, which doesn't make the TaskRun fail.
+		phase = corev1.PodSucceeded
+	}
+	if phase == corev1.PodSucceeded && invalidStep != "" {
+		// The results of the step are lost, so the TaskRun can't succeed
+		taskRun.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  reasonInvalidTerminationMessage,
+			Message: invalidStep,
+		})
+		taskRun.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+		return
+	}
+	switch phase {
 	case corev1.PodRunning:
 		taskRun.Status.SetCondition(&apis.Condition{
 			Type:   apis.ConditionSucceeded,
 			Status: corev1.ConditionUnknown,
-			Reason: "Building",
+			Reason: reasonBuilding,
 		})
+		if taskRun.Status.RunningTime == nil {
+			taskRun.Status.RunningTime = &metav1.Time{Time: time.Now()}
+		}
 	case corev1.PodFailed:
-		msg := getFailureMessage(pod)
+		reason, msg := getFailureReasonAndMessage(pod)
 		taskRun.Status.SetCondition(&apis.Condition{
 			Type:    apis.ConditionSucceeded,
 			Status:  corev1.ConditionFalse,
+			Reason:  reason,
 			Message: msg,
 		})
 		// update tr completed time
@@ -379,6 +494,93 @@ func updateStatusFromPod(taskRun *v1alpha1.TaskRun, pod *corev1.Pod) {
 	}
 }
 
+// stepsSucceeded returns whether pod has sidecars and all of its steps have
+// terminated successfully.
+func stepsSucceeded(pod *corev1.Pod) bool {
+	sidecars := false
+	for _, s := range pod.Status.ContainerStatuses {
+		if resources.IsSidecarContainer(s.Name) {
+			sidecars = true
+		} else if s.State.Terminated == nil || s.State.Terminated.ExitCode != 0 {
+			return false
+		}
+	}
+	return sidecars
+}
+
+// dockerPullablePrefix prefixes the image IDs reported by the Docker runtime.
+const dockerPullablePrefix = "docker-pullable://"
+
+// sortContainerStatuses returns the statuses of the containers of pod in the
+// order the containers, and so the steps, are declared in, rather than the
+// order the statuses are reported in.
+func sortContainerStatuses(pod *corev1.Pod) []corev1.ContainerStatus {
+	index := map[string]int{}
+	for i, c := range pod.Spec.Containers {
+		index[c.Name] = i
+	}
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.ContainerStatuses...)
+	position := func(name string) int {
+		if i, ok := index[name]; ok {
+			return i
+		}
+		return len(index)
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return position(statuses[i].Name) < position(statuses[j].Name)
+	})
+	return statuses
+}
+
+// terminationMessage is how the entrypoint reports how a step went as its
+// termination message, see pkg/entrypoint.
+type terminationMessage struct {
+	StartedAt  time.Time                `json:"startedAt"`
+	FinishedAt time.Time                `json:"finishedAt"`
+	ExitCode   int                      `json:"exitCode"`
+	TimedOut   bool                     `json:"timedOut,omitempty"`
+	Skipped    bool                     `json:"skipped,omitempty"`
+	Results    []v1alpha1.TaskRunResult `json:"results,omitempty"`
+	Error      string                   `json:"error,omitempty"`
+}
+
+// parseTerminationMessage parses the termination message msg of a step,
+// returning nil if it wasn't written by the entrypoint, and an error if it was
+// but can't be parsed, such as when the kubelet truncated it. Earlier versions
+// of the entrypoint only reported the results of the step, as a list.
+func parseTerminationMessage(msg string) (*terminationMessage, error) {
+	if !strings.HasPrefix(msg, "{") && !strings.HasPrefix(msg, "[") {
+		return nil, nil
+	}
+	m := &terminationMessage{}
+	err := json.Unmarshal([]byte(msg), m)
+	if err == nil {
+		return m, nil
+	}
+	if json.Unmarshal([]byte(msg), &m.Results) == nil {
+		return m, nil
+	}
+	return nil, fmt.Errorf("couldn't parse the termination message, which may have been truncated because the results of the step were too large: %v", err)
+}
+
+// updateTaskResults merges the results reported in the termination message of
+// a step into the status of taskRun. Steps report all of the results written
+// so far, so the values reported by later steps take precedence.
+func updateTaskResults(taskRun *v1alpha1.TaskRun, results []v1alpha1.TaskRunResult) {
+	for _, r := range results {
+		found := false
+		for i := range taskRun.Status.TaskResults {
+			if taskRun.Status.TaskResults[i].Name == r.Name {
+				taskRun.Status.TaskResults[i].Value = r.Value
+				found = true
+			}
+		}
+		if !found {
+			taskRun.Status.TaskResults = append(taskRun.Status.TaskResults, r)
+		}
+	}
+}
+
 func getWaitingMessage(pod *corev1.Pod) string {
 	// First, try to surface reason for pending/unknown about the actual build step.
 	for _, status := range pod.Status.ContainerStatuses {
@@ -406,22 +608,37 @@ func getWaitingMessage(pod *corev1.Pod) string {
 	return "Pending"
 }
 
-func getFailureMessage(pod *corev1.Pod) string {
+func getFailureReasonAndMessage(pod *corev1.Pod) (string, string) {
 	// First, try to surface an error about the actual build step that failed.
-	for _, status := range pod.Status.ContainerStatuses {
+	for _, status := range sortContainerStatuses(pod) {
+		if resources.IsSidecarContainer(status.Name) {
+			continue
+		}
 		term := status.State.Terminated
-		if term != nil && term.ExitCode != 0 {
-			return fmt.Sprintf("%q exited with code %d (image: %q); for logs run: kubectl -n %s logs %s -c %s",
-				status.Name, term.ExitCode, status.ImageID,
+		if term == nil || term.ExitCode == 0 {
+			continue
+		}
+		m, _ := parseTerminationMessage(term.Message)
+		if m != nil && m.TimedOut {
+			return reasonStepTimedOut, fmt.Sprintf("%q timed out (image: %q); for logs run: kubectl -n %s logs %s -c %s",
+				status.Name, status.ImageID,
 				pod.Namespace, pod.Name, status.Name)
 		}
+		if m != nil && m.Error != "" {
+			return "", fmt.Sprintf("%q failed: %s (image: %q); for logs run: kubectl -n %s logs %s -c %s",
+				status.Name, m.Error, status.ImageID,
+				pod.Namespace, pod.Name, status.Name)
+		}
+		return "", fmt.Sprintf("%q exited with code %d (image: %q); for logs run: kubectl -n %s logs %s -c %s",
+			status.Name, term.ExitCode, status.ImageID,
+			pod.Namespace, pod.Name, status.Name)
 	}
 	// Next, return the Pod's status message if it has one.
 	if pod.Status.Message != "" {
-		return pod.Status.Message
+		return "", pod.Status.Message
 	}
 	// Lastly fall back on a generic error message.
-	return "build failed for unspecified reasons."
+	return "", "build failed for unspecified reasons."
 }
 
 func (c *Reconciler) updateStatus(taskrun *v1alpha1.TaskRun) (*v1alpha1.TaskRun, error) {
@@ -476,6 +693,9 @@ func (c *Reconciler) createPod(tr *v1alpha1.TaskRun, ts *v1alpha1.TaskSpec, task
 	// Apply parameter templating from the taskrun.
 	ts = resources.ApplyParameters(ts, tr, defaults...)
 
+	// Apply the paths the results should be written to.
+	ts = resources.ApplyResults(ts)
+
 	// Apply bound resource templating from the taskrun.
 	ts, err = resources.ApplyResources(ts, tr.Spec.Inputs.Resources, c.resourceLister.PipelineResources(tr.Namespace).Get, "inputs")
 	if err != nil {
@@ -505,6 +725,12 @@ func createRedirectedTaskSpec(kubeclient kubernetes.Interface, ts *v1alpha1.Task
 	if err != nil {
 		return nil, fmt.Errorf("failed to add entrypoint to steps of TaskRun %s: %v", tr.Name, err)
 	}
+	// Have the first step wait for the sidecars to be ready.
+	entrypoint.AddReadyWait(ts)
+	// Give the steps somewhere to write their results to, and have the
+	// entrypoint report them.
+	entrypoint.AddResultsDir(ts)
+
 	// Add the step which will copy the entrypoint into the volume
 	// we are going to be using, so that all of the steps will have
 	// access to it.
@@ -530,30 +756,56 @@ func (c *Reconciler) checkTimeout(tr *v1alpha1.TaskRun, ts *v1alpha1.TaskSpec, d
 		return false, nil
 	}
 
+	// The time the pod spent pending doesn't count towards the timeout, which
+	// starts once it is running.
+	if isPending(tr) && tr.Spec.PendingTimeout != nil {
+		pendingTime := time.Since(tr.Status.StartTime.Time)
+		pendingTimeout := tr.Spec.PendingTimeout.Duration
+		if pendingTime > pendingTimeout {
+			c.Logger.Infof("TaskRun %q is pending timeout (pending for %s over %s), deleting pod", tr.Name, pendingTime, pendingTimeout)
+			return true, c.timeOut(tr, dp, reasonPendingTimedOut, fmt.Sprintf("TaskRun %q failed to start running within %q", tr.Name, pendingTimeout.String()))
+		}
+		return false, nil
+	}
+
 	if tr.Spec.Timeout != nil {
 		timeout := tr.Spec.Timeout.Duration
-		runtime := time.Since(tr.Status.StartTime.Time)
+		startTime := tr.Status.StartTime
+		if tr.Status.RunningTime != nil {
+			startTime = tr.Status.RunningTime
+		}
+		runtime := time.Since(startTime.Time)
 
-		c.Logger.Infof("Checking timeout for TaskRun %q (startTime %s, timeout %s, runtime %s)", tr.Name, tr.Status.StartTime, timeout, runtime)
+		c.Logger.Infof("Checking timeout for TaskRun %q (startTime %s, timeout %s, runtime %s)", tr.Name, startTime, timeout, runtime)
 		if runtime > timeout {
 			c.Logger.Infof("TaskRun %q is timeout (runtime %s over %s), deleting pod", tr.Name, runtime, timeout)
-			if err := dp(tr.Status.PodName, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
-				c.Logger.Errorf("Failed to terminate pod: %v", err)
-				return true, err
-			}
-
-			timeoutMsg := fmt.Sprintf("TaskRun %q failed to finish within %q", tr.Name, timeout.String())
-			tr.Status.SetCondition(&apis.Condition{
-				Type:    apis.ConditionSucceeded,
-				Status:  corev1.ConditionFalse,
-				Reason:  reasonTimedOut,
-				Message: timeoutMsg,
-			})
-			// update tr completed time
-			tr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
-
-			return true, nil
+			return true, c.timeOut(tr, dp, reasonTimedOut, fmt.Sprintf("TaskRun %q failed to finish within %q", tr.Name, timeout.String()))
 		}
 	}
 	return false, nil
 }
+
+// timeOut deletes the pod of tr and marks tr as failed with reason and message.
+func (c *Reconciler) timeOut(tr *v1alpha1.TaskRun, dp DeletePod, reason, message string) error {
+	if err := dp(tr.Status.PodName, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		c.Logger.Errorf("Failed to terminate pod: %v", err)
+		return err
+	}
+
+	tr.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	// update tr completed time
+	tr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	return nil
+}
+
+// isPending returns true if the pod of tr hasn't started running yet, either because it
+// wasn't created yet, or because it waits to be scheduled or for its images to be pulled.
+func isPending(tr *v1alpha1.TaskRun) bool {
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
+	return c == nil || (c.IsUnknown() && c.Reason != reasonBuilding)
+}
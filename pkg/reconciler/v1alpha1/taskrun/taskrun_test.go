@@ -16,6 +16,8 @@ package taskrun
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -1037,6 +1039,41 @@ func TestReconcile_SetsStartTime(t *testing.T) {
 	}
 }
 
+func TestReconcile_SendsCloudEvents(t *testing.T) {
+	received := make(chan string, 10)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("Ce-Type")
+	}))
+	defer sink.Close()
+	taskRun := tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef(simpleTask.Name),
+		tb.TaskRunCloudEventSink(sink.URL),
+	))
+	d := test.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+		Tasks:    []*v1alpha1.Task{simpleTask},
+	}
+	testAssets := getTaskRunController(d)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	cloudEvents := reconciler.NewCloudEventSender(zap.NewNop().Sugar(), stopCh)
+	cloudEvents.SetAllowedSinks(sink.URL)
+	testAssets.Controller.Reconciler.(*Reconciler).CloudEvents = cloudEvents
+
+	if err := testAssets.Controller.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+
+	select {
+	case eventType := <-received:
+		if eventType != "dev.tekton.event.taskrun.started.v1" {
+			t.Errorf("expected a CloudEvent notifying that the TaskRun started but got %s", eventType)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("expected a CloudEvent to be sent when the TaskRun started")
+	}
+}
+
 func TestReconcile_DoesntChangeStartTime(t *testing.T) {
 	startTime := time.Date(2000, 1, 1, 1, 1, 1, 1, time.UTC)
 	taskRun := tb.TaskRun("test-taskrun", "foo", tb.TaskRunSpec(
@@ -1225,6 +1262,93 @@ func TestReconcilePodUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestReconcileSidecars(t *testing.T) {
+	task := tb.Task("test-task-sidecars", "foo", tb.TaskSpec(
+		tb.Step("simple-step", "foo", tb.Command("/mycmd")),
+		tb.Sidecar("redis", "redis"),
+	))
+	taskRun := tb.TaskRun("test-taskrun-sidecars", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef(task.Name)))
+
+	logger, _ := logging.NewLogger("", "")
+	cache, _ := entrypoint.NewCache()
+	pod, err := resources.MakePod(taskRun, task.Spec, fakekubeclientset.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: taskRun.Namespace,
+		},
+	}), cache, logger)
+	if err != nil {
+		t.Fatalf("MakePod: %v", err)
+	}
+	// The sidecar is ready, so the step can start
+	pod.Status = corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  "build-step-simple-step",
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		}, {
+			Name:  "sidecar-redis",
+			Ready: true,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		}},
+	}
+	taskRun.Status = v1alpha1.TaskRunStatus{
+		PodName: pod.Name,
+	}
+	d := test.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+		Tasks:    []*v1alpha1.Task{task},
+		Pods:     []*corev1.Pod{pod},
+	}
+
+	testAssets := getTaskRunController(d)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), fmt.Sprintf("%s/%s", taskRun.Namespace, taskRun.Name)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile() : %v", err)
+	}
+	newPod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(pod.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected pod %s to exist but instead got error when getting it: %v", pod.Name, err)
+	}
+	if v := newPod.Annotations["tekton.dev/ready"]; v != "READY" {
+		t.Errorf("Expected the pod to be marked as ready, got annotation %q", v)
+	}
+	newTr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	wantSidecars := []v1alpha1.SidecarState{{
+		ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		Name:           "redis",
+	}}
+	if d := cmp.Diff(wantSidecars, newTr.Status.Sidecars); d != "" {
+		t.Errorf("TaskRun sidecars diff -want, +got: %v", d)
+	}
+	if len(newTr.Status.Steps) != 1 || newTr.Status.Steps[0].Name != "simple-step" {
+		t.Errorf("Expected the step only to be reported in the steps, got %v", newTr.Status.Steps)
+	}
+
+	// The step is done, so the sidecar is stopped
+	newPod.Status.ContainerStatuses[0].State = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	if _, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).UpdateStatus(newPod); err != nil {
+		t.Fatalf("Unexpected error while updating pod: %v", err)
+	}
+	if err := c.Reconciler.Reconcile(context.Background(), fmt.Sprintf("%s/%s", taskRun.Namespace, taskRun.Name)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile() : %v", err)
+	}
+	newPod, err = clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(pod.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected pod %s to exist but instead got error when getting it: %v", pod.Name, err)
+	}
+	for _, c := range newPod.Spec.Containers {
+		if c.Name == "sidecar-redis" && c.Image != "override-with-nop:latest" {
+			t.Errorf("Expected the sidecar to be stopped by running the nop image, got image %q", c.Image)
+		}
+	}
+}
+
 func TestCreateRedirectedTaskSpec(t *testing.T) {
 	tr := tb.TaskRun("tr", "tr", tb.TaskRunSpec(
 		tb.TaskRunServiceAccount("sa"),
@@ -1361,6 +1485,143 @@ func TestReconcileOnTimedOutTaskRun(t *testing.T) {
 	}
 }
 
+func TestReconcileOnPendingTimedOutTaskRun(t *testing.T) {
+	pending := apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: "Pending",
+	}
+	building := apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: reasonBuilding,
+	}
+	for _, tc := range []struct {
+		name           string
+		condition      apis.Condition
+		expectedReason string
+	}{{
+		name:           "pending",
+		condition:      pending,
+		expectedReason: "PendingTimeout",
+	}, {
+		name:      "running",
+		condition: building,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := tb.TaskRun("test-taskrun-pending-timeout", "foo",
+				tb.TaskRunSpec(
+					tb.TaskRunTaskRef(simpleTask.Name),
+					tb.TaskRunTimeout(time.Hour),
+					tb.TaskRunPendingTimeout(10*time.Second),
+				),
+				tb.TaskRunStatus(tb.Condition(tc.condition),
+					tb.TaskRunStartTime(time.Now().Add(-15*time.Second))))
+
+			d := test.Data{
+				TaskRuns: []*v1alpha1.TaskRun{taskRun},
+				Tasks:    []*v1alpha1.Task{simpleTask},
+			}
+
+			testAssets := getTaskRunController(d)
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), fmt.Sprintf("%s/%s", taskRun.Namespace, taskRun.Name)); err != nil {
+				t.Fatalf("Unexpected error when reconciling TaskRun : %v", err)
+			}
+			newTr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+
+			condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+			if tc.expectedReason == "" {
+				if condition.Reason == reasonPendingTimedOut {
+					t.Errorf("Expected the running TaskRun not to time out but condition was %v", condition)
+				}
+				return
+			}
+			expectedStatus := &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  tc.expectedReason,
+				Message: `TaskRun "test-taskrun-pending-timeout" failed to start running within "10s"`,
+			}
+			if d := cmp.Diff(condition, expectedStatus, ignoreLastTransitionTime); d != "" {
+				t.Fatalf("-want, +got: %v", d)
+			}
+		})
+	}
+}
+
+func TestReconcileOnTaskRunRunningAfterPending(t *testing.T) {
+	building := apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: reasonBuilding,
+	}
+	for _, tc := range []struct {
+		name           string
+		runningTime    time.Time
+		expectedReason string
+	}{{
+		// The pod was pending for longer than the timeout, which doesn't count
+		name:        "running within timeout",
+		runningTime: time.Now().Add(-1 * time.Second),
+	}, {
+		name:           "running for longer than timeout",
+		runningTime:    time.Now().Add(-6 * time.Second),
+		expectedReason: "TaskRunTimeout",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := tb.TaskRun("test-taskrun-running-after-pending", "foo",
+				tb.TaskRunSpec(
+					tb.TaskRunTaskRef(simpleTask.Name),
+					tb.TaskRunTimeout(5*time.Second),
+					tb.TaskRunPendingTimeout(time.Minute),
+				),
+				tb.TaskRunStatus(tb.Condition(building),
+					tb.TaskRunStartTime(time.Now().Add(-8*time.Second)),
+					tb.TaskRunRunningTime(tc.runningTime)))
+
+			d := test.Data{
+				TaskRuns: []*v1alpha1.TaskRun{taskRun},
+				Tasks:    []*v1alpha1.Task{simpleTask},
+			}
+
+			testAssets := getTaskRunController(d)
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), fmt.Sprintf("%s/%s", taskRun.Namespace, taskRun.Name)); err != nil {
+				t.Fatalf("Unexpected error when reconciling TaskRun : %v", err)
+			}
+			newTr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+			}
+
+			condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+			if tc.expectedReason == "" {
+				if condition.Reason == reasonTimedOut {
+					t.Errorf("Expected the TaskRun not to time out but condition was %v", condition)
+				}
+				return
+			}
+			expectedStatus := &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  tc.expectedReason,
+				Message: `TaskRun "test-taskrun-running-after-pending" failed to finish within "5s"`,
+			}
+			if d := cmp.Diff(condition, expectedStatus, ignoreLastTransitionTime); d != "" {
+				t.Fatalf("-want, +got: %v", d)
+			}
+		})
+	}
+}
+
 func TestUpdateStatusFromPod(t *testing.T) {
 	conditionRunning := apis.Condition{
 		Type:    apis.ConditionSucceeded,
@@ -1379,6 +1640,7 @@ func TestUpdateStatusFromPod(t *testing.T) {
 	}
 	for _, c := range []struct {
 		desc      string
+		podSpec   corev1.PodSpec
 		podStatus corev1.PodStatus
 		want      v1alpha1.TaskRunStatus
 	}{{
@@ -1415,7 +1677,8 @@ func TestUpdateStatusFromPod(t *testing.T) {
 					Terminated: &corev1.ContainerStateTerminated{
 						ExitCode: 123,
 					}},
-				Name: "state-name",
+				Name:    "state-name",
+				Outcome: v1alpha1.StepOutcomeFailed,
 			}},
 		},
 	}, {
@@ -1444,7 +1707,8 @@ func TestUpdateStatusFromPod(t *testing.T) {
 					Terminated: &corev1.ContainerStateTerminated{
 						ExitCode: 123,
 					}},
-				Name: "state-name",
+				Name:    "state-name",
+				Outcome: v1alpha1.StepOutcomeFailed,
 			}},
 		},
 	}, {
@@ -1469,7 +1733,138 @@ func TestUpdateStatusFromPod(t *testing.T) {
 					Terminated: &corev1.ContainerStateTerminated{
 						ExitCode: 0,
 					}},
-				Name: "build-step-push",
+				Name:    "build-step-push",
+				Outcome: v1alpha1.StepOutcomeSucceeded,
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
+	}, {
+		desc: "success-with-results",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "build-step-version",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"name":"version","value":"v0.1"}]`,
+					},
+				},
+			}, {
+				Name: "build-step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"name":"digest","value":"sha256:abc"},{"name":"version","value":"v0.2"}]`,
+					},
+				},
+			}, {
+				Name: "build-step-unrelated",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: "not a result",
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionTrue},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"name":"version","value":"v0.1"}]`,
+					}},
+				Name:    "version",
+				Outcome: v1alpha1.StepOutcomeSucceeded,
+			}, {
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"name":"digest","value":"sha256:abc"},{"name":"version","value":"v0.2"}]`,
+					}},
+				Name:    "build",
+				Outcome: v1alpha1.StepOutcomeSucceeded,
+			}, {
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: "not a result",
+					}},
+				Name:    "unrelated",
+				Outcome: v1alpha1.StepOutcomeSucceeded,
+			}},
+			TaskResults: []v1alpha1.TaskRunResult{{
+				Name:  "version",
+				Value: "v0.2",
+			}, {
+				Name:  "digest",
+				Value: "sha256:abc",
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
+	}, {
+		desc: "truncated-results",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "build-step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `{"exitCode":0,"results":[{"name":"manifest","value":"{\"layers\": [`,
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Reason:  reasonInvalidTerminationMessage,
+					Message: `"build-step-build" failed: couldn't parse the termination message, which may have been truncated because the results of the step were too large: unexpected end of JSON input (image: ""); for logs run: kubectl -n foo logs pod -c build-step-build`,
+				}},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `{"exitCode":0,"results":[{"name":"manifest","value":"{\"layers\": [`,
+					}},
+				Name:    "build",
+				Outcome: v1alpha1.StepOutcomeFailed,
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
+	}, {
+		desc: "results-too-large",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "build-step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  `{"exitCode":1,"error":"results too large"}`,
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Message: `"build-step-build" failed: results too large (image: ""); for logs run: kubectl -n foo logs pod -c build-step-build`,
+				}},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  `{"exitCode":1,"error":"results too large"}`,
+					}},
+				Name:    "build",
+				Outcome: v1alpha1.StepOutcomeFailed,
 			}},
 			// We don't actually care about the time, just that it's not nil
 			CompletionTime: &metav1.Time{Time: time.Now()},
@@ -1495,6 +1890,7 @@ func TestUpdateStatusFromPod(t *testing.T) {
 				},
 				Name: "running-step",
 			}},
+			RunningTime: &metav1.Time{},
 		},
 	}, {
 		desc: "failure-terminated",
@@ -1526,7 +1922,99 @@ func TestUpdateStatusFromPod(t *testing.T) {
 					Terminated: &corev1.ContainerStateTerminated{
 						ExitCode: 123,
 					}},
-				Name: "failure",
+				Name:    "failure",
+				Outcome: v1alpha1.StepOutcomeFailed,
+				ImageID: "image-id",
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
+	}, {
+		desc: "step-outcomes",
+		podSpec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "build-step-first",
+			}, {
+				Name: "build-step-second",
+			}, {
+				Name: "build-step-third",
+			}},
+		},
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			// Reported in a different order than the steps are declared in
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "build-step-third",
+				ImageID: "docker-pullable://gcr.io/foo/third@sha256:333",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `{"startedAt":"2019-08-01T10:00:20Z","finishedAt":"2019-08-01T10:00:20Z","exitCode":0,"skipped":true}`,
+					},
+				},
+			}, {
+				Name:    "build-step-first",
+				ImageID: "docker-pullable://gcr.io/foo/first@sha256:111",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						StartedAt:  metav1.Date(2019, 8, 1, 10, 0, 0, 0, time.UTC),
+						FinishedAt: metav1.Date(2019, 8, 1, 10, 0, 12, 0, time.UTC),
+						Message:    `{"startedAt":"2019-08-01T10:00:02Z","finishedAt":"2019-08-01T10:00:12Z","exitCode":0,"results":[{"name":"version","value":"v0.1"}]}`,
+					},
+				},
+			}, {
+				Name:    "build-step-second",
+				ImageID: "docker-pullable://gcr.io/foo/second@sha256:222",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 2,
+						Message:  `{"startedAt":"2019-08-01T10:00:13Z","finishedAt":"2019-08-01T10:00:19Z","exitCode":2}`,
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Message: `"build-step-second" exited with code 2 (image: "docker-pullable://gcr.io/foo/second@sha256:222"); for logs run: kubectl -n foo logs pod -c build-step-second`,
+				}},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						StartedAt:  metav1.Date(2019, 8, 1, 10, 0, 2, 0, time.UTC),
+						FinishedAt: metav1.Date(2019, 8, 1, 10, 0, 12, 0, time.UTC),
+						Message:    `{"startedAt":"2019-08-01T10:00:02Z","finishedAt":"2019-08-01T10:00:12Z","exitCode":0,"results":[{"name":"version","value":"v0.1"}]}`,
+					}},
+				Name:    "first",
+				Outcome: v1alpha1.StepOutcomeSucceeded,
+				ImageID: "gcr.io/foo/first@sha256:111",
+			}, {
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   2,
+						StartedAt:  metav1.Date(2019, 8, 1, 10, 0, 13, 0, time.UTC),
+						FinishedAt: metav1.Date(2019, 8, 1, 10, 0, 19, 0, time.UTC),
+						Message:    `{"startedAt":"2019-08-01T10:00:13Z","finishedAt":"2019-08-01T10:00:19Z","exitCode":2}`,
+					}},
+				Name:    "second",
+				Outcome: v1alpha1.StepOutcomeFailed,
+				ImageID: "gcr.io/foo/second@sha256:222",
+			}, {
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						StartedAt:  metav1.Date(2019, 8, 1, 10, 0, 20, 0, time.UTC),
+						FinishedAt: metav1.Date(2019, 8, 1, 10, 0, 20, 0, time.UTC),
+						Message:    `{"startedAt":"2019-08-01T10:00:20Z","finishedAt":"2019-08-01T10:00:20Z","exitCode":0,"skipped":true}`,
+					}},
+				Name:    "third",
+				Outcome: v1alpha1.StepOutcomeSkipped,
+				ImageID: "gcr.io/foo/third@sha256:333",
+			}},
+			TaskResults: []v1alpha1.TaskRunResult{{
+				Name:  "version",
+				Value: "v0.1",
 			}},
 			// We don't actually care about the time, just that it's not nil
 			CompletionTime: &metav1.Time{Time: time.Now()},
@@ -1636,6 +2124,115 @@ func TestUpdateStatusFromPod(t *testing.T) {
 			},
 			Steps: []v1alpha1.StepState{},
 		},
+	}, {
+		desc: "step-timed-out",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "build-step-slow",
+				ImageID: "docker-pullable://gcr.io/foo/slow@sha256:111",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 137,
+						Message:  `{"startedAt":"2019-08-01T10:00:02Z","finishedAt":"2019-08-01T10:01:02Z","exitCode":137,"timedOut":true}`,
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Reason:  reasonStepTimedOut,
+					Message: `"build-step-slow" timed out (image: "docker-pullable://gcr.io/foo/slow@sha256:111"); for logs run: kubectl -n foo logs pod -c build-step-slow`,
+				}},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   137,
+						StartedAt:  metav1.Date(2019, 8, 1, 10, 0, 2, 0, time.UTC),
+						FinishedAt: metav1.Date(2019, 8, 1, 10, 1, 2, 0, time.UTC),
+						Message:    `{"startedAt":"2019-08-01T10:00:02Z","finishedAt":"2019-08-01T10:01:02Z","exitCode":137,"timedOut":true}`,
+					}},
+				Name:    "slow",
+				Outcome: v1alpha1.StepOutcomeTimedOut,
+				ImageID: "gcr.io/foo/slow@sha256:111",
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
+	}, {
+		desc: "step-continued-on-error",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "build-step-lint",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `{"startedAt":"2019-08-01T10:00:02Z","finishedAt":"2019-08-01T10:00:12Z","exitCode":1}`,
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionTrue},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   1,
+						StartedAt:  metav1.Date(2019, 8, 1, 10, 0, 2, 0, time.UTC),
+						FinishedAt: metav1.Date(2019, 8, 1, 10, 0, 12, 0, time.UTC),
+						Message:    `{"startedAt":"2019-08-01T10:00:02Z","finishedAt":"2019-08-01T10:00:12Z","exitCode":1}`,
+					}},
+				Name:    "lint",
+				Outcome: v1alpha1.StepOutcomeFailed,
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
+	}, {
+		desc: "stopped-sidecar",
+		podStatus: corev1.PodStatus{
+			// The sidecar exited with an error after being stopped with the nop image.
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "build-step-step",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}, {
+				Name:    "sidecar-db",
+				ImageID: "docker-pullable://nop@sha256:111",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionTrue},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+				Name:    "step",
+				Outcome: v1alpha1.StepOutcomeSucceeded,
+			}},
+			Sidecars: []v1alpha1.SidecarState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1},
+				},
+				Name:    "db",
+				ImageID: "nop@sha256:111",
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
 	}, {
 		desc:      "pending-no-message",
 		podStatus: corev1.PodStatus{Phase: corev1.PodPending},
@@ -1659,6 +2256,7 @@ func TestUpdateStatusFromPod(t *testing.T) {
 					Namespace:         "foo",
 					CreationTimestamp: now,
 				},
+				Spec:   c.podSpec,
 				Status: c.podStatus,
 			}
 			startTime := time.Date(2010, 1, 1, 1, 1, 1, 1, time.UTC)
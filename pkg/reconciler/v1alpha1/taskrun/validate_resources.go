@@ -65,34 +65,11 @@ func validateResources(requiredResources []v1alpha1.TaskResource, providedResour
 }
 
 func validateParams(inputs *v1alpha1.Inputs, params []v1alpha1.Param) error {
-	neededParams := []string{}
+	var declared []v1alpha1.TaskParam
 	if inputs != nil {
-		neededParams = make([]string, 0, len(inputs.Params))
-		for _, inputResourceParam := range inputs.Params {
-			neededParams = append(neededParams, inputResourceParam.Name)
-		}
-	}
-	providedParams := make([]string, 0, len(params))
-	for _, param := range params {
-		providedParams = append(providedParams, param.Name)
-	}
-	missingParams := list.DiffLeft(neededParams, providedParams)
-	missingParamsNoDefaults := []string{}
-	for _, param := range missingParams {
-		for _, inputResourceParam := range inputs.Params {
-			if inputResourceParam.Name == param && inputResourceParam.Default == "" {
-				missingParamsNoDefaults = append(missingParamsNoDefaults, param)
-			}
-		}
+		declared = inputs.Params
 	}
-	if len(missingParamsNoDefaults) > 0 {
-		return fmt.Errorf("missing values for these params which have no default values: %s", missingParamsNoDefaults)
-	}
-	extraParams := list.DiffLeft(providedParams, neededParams)
-	if len(extraParams) != 0 {
-		return fmt.Errorf("didn't need these params but they were provided anyway: %s", extraParams)
-	}
-	return nil
+	return v1alpha1.ValidateTaskParams(declared, params)
 }
 
 // ValidateResolvedTaskResources validates task inputs, params and output matches taskrun
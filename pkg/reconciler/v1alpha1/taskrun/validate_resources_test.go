@@ -73,6 +73,36 @@ func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 			Name:  "extra",
 			Value: "i am an extra param",
 		}},
+	}, {
+		name: "value-not-in-enum",
+		rtr: tb.ResolvedTaskResources(tb.ResolvedTaskResourcesTaskSpec(
+			tb.Step("mystep", "myimage", tb.Command("mycmd")),
+			tb.TaskInputs(tb.InputsParam("level", tb.ParamEnum("debug", "info"))),
+		)),
+		params: []v1alpha1.Param{{
+			Name:  "level",
+			Value: "trace",
+		}},
+	}, {
+		name: "value-not-matching-pattern",
+		rtr: tb.ResolvedTaskResources(tb.ResolvedTaskResourcesTaskSpec(
+			tb.Step("mystep", "myimage", tb.Command("mycmd")),
+			tb.TaskInputs(tb.InputsParam("flags", tb.ParamType(v1alpha1.ParamTypeArray), tb.ParamPattern("^-"))),
+		)),
+		params: []v1alpha1.Param{{
+			Name:       "flags",
+			ArrayValue: []string{"-v", "q"},
+		}},
+	}, {
+		name: "string-for-array-param",
+		rtr: tb.ResolvedTaskResources(tb.ResolvedTaskResourcesTaskSpec(
+			tb.Step("mystep", "myimage", tb.Command("mycmd")),
+			tb.TaskInputs(tb.InputsParam("flags", tb.ParamType(v1alpha1.ParamTypeArray))),
+		)),
+		params: []v1alpha1.Param{{
+			Name:  "flags",
+			Value: "-v",
+		}},
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -37,6 +37,41 @@ func ValidateVariable(name, value, prefix, contextPrefix, locationName, path str
 	return nil
 }
 
+// ValidateVariableProhibited returns an error if value refers to one of vars,
+// which can't be used in the field called name.
+func ValidateVariableProhibited(name, value, prefix, contextPrefix, locationName, path string, vars map[string]struct{}) *apis.FieldError {
+	if vs, present := extractVariablesFromString(value, contextPrefix+prefix); present {
+		for _, v := range vs {
+			if _, ok := vars[v]; ok {
+				return &apis.FieldError{
+					Message: fmt.Sprintf("variable type invalid in %q for %s %s", value, locationName, name),
+					Paths:   []string{path + "." + name},
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateVariableIsolated returns an error if value refers to one of vars
+// without being made of that reference only, since the variables of vars expand
+// to several values.
+func ValidateVariableIsolated(name, value, prefix, contextPrefix, locationName, path string, vars map[string]struct{}) *apis.FieldError {
+	if vs, present := extractVariablesFromString(value, contextPrefix+prefix); present {
+		pattern := fmt.Sprintf("^\\$({%s.(?P<var>%s)})$", contextPrefix+prefix, parameterSubstitution)
+		isolated := regexp.MustCompile(pattern).MatchString(value)
+		for _, v := range vs {
+			if _, ok := vars[v]; ok && !isolated {
+				return &apis.FieldError{
+					Message: fmt.Sprintf("variable is not properly isolated in %q for %s %s", value, locationName, name),
+					Paths:   []string{path + "." + name},
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func extractVariablesFromString(s, prefix string) ([]string, bool) {
 	pattern := fmt.Sprintf("\\$({%s.(?P<var>%s)})", prefix, parameterSubstitution)
 	re := regexp.MustCompile(pattern)
@@ -69,3 +104,16 @@ func ApplyReplacements(in string, replacements map[string]string) string {
 	}
 	return in
 }
+
+// ApplyArrayReplacements returns the values in expands to. If in is made of a
+// reference to one of arrayReplacements only, it expands to the items of that
+// array. Otherwise it expands to in, with its references to stringReplacements
+// replaced.
+func ApplyArrayReplacements(in string, stringReplacements map[string]string, arrayReplacements map[string][]string) []string {
+	for k, v := range arrayReplacements {
+		if in == fmt.Sprintf("${%s}", k) {
+			return append([]string{}, v...)
+		}
+	}
+	return []string{ApplyReplacements(in, stringReplacements)}
+}
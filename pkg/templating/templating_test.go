@@ -123,3 +123,91 @@ func TestValidateVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateVariableProhibited(t *testing.T) {
+	vars := map[string]struct{}{"flags": {}}
+	for _, tc := range []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "no reference",
+		input: "--verbose",
+	}, {
+		name:  "reference to another variable",
+		input: "--level=${inputs.params.level}",
+	}, {
+		name:  "reference to a prohibited variable",
+		input: "${inputs.params.flags}",
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "${inputs.params.flags}" for step somefield`,
+			Paths:   []string{"taskspec.steps.somefield"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := templating.ValidateVariableProhibited("somefield", tc.input, "params", "inputs.", "step", "taskspec.steps", vars)
+			if d := cmp.Diff(got, tc.expectedError, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateVariableProhibited() error did not match expected error %s", d)
+			}
+		})
+	}
+}
+
+func TestValidateVariableIsolated(t *testing.T) {
+	vars := map[string]struct{}{"flags": {}}
+	for _, tc := range []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "isolated reference",
+		input: "${inputs.params.flags}",
+	}, {
+		name:  "reference to another variable",
+		input: "--level=${inputs.params.level}",
+	}, {
+		name:  "reference within a string",
+		input: "--flags=${inputs.params.flags}",
+		expectedError: &apis.FieldError{
+			Message: `variable is not properly isolated in "--flags=${inputs.params.flags}" for step somefield`,
+			Paths:   []string{"taskspec.steps.somefield"},
+		},
+	}, {
+		name:  "reference along with another variable",
+		input: "${inputs.params.flags}${inputs.params.level}",
+		expectedError: &apis.FieldError{
+			Message: `variable is not properly isolated in "${inputs.params.flags}${inputs.params.level}" for step somefield`,
+			Paths:   []string{"taskspec.steps.somefield"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := templating.ValidateVariableIsolated("somefield", tc.input, "params", "inputs.", "step", "taskspec.steps", vars)
+			if d := cmp.Diff(got, tc.expectedError, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateVariableIsolated() error did not match expected error %s", d)
+			}
+		})
+	}
+}
+
+func TestApplyArrayReplacements(t *testing.T) {
+	stringReplacements := map[string]string{"inputs.params.level": "debug"}
+	arrayReplacements := map[string][]string{"inputs.params.flags": {"-v", "-q"}}
+	for _, tc := range []struct {
+		input    string
+		expected []string
+	}{{
+		input:    "${inputs.params.flags}",
+		expected: []string{"-v", "-q"},
+	}, {
+		input:    "--level=${inputs.params.level}",
+		expected: []string{"--level=debug"},
+	}, {
+		input:    "--verbose",
+		expected: []string{"--verbose"},
+	}} {
+		got := templating.ApplyArrayReplacements(tc.input, stringReplacements, arrayReplacements)
+		if d := cmp.Diff(tc.expected, got); d != "" {
+			t.Errorf("ApplyArrayReplacements(%q) -want, +got: %v", tc.input, d)
+		}
+	}
+}
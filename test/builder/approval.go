@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Knative Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApprovalOp is an operation which modifies an Approval struct.
+type ApprovalOp func(*v1alpha1.Approval)
+
+// ApprovalGateOp is an operation which modifies an ApprovalGate struct.
+type ApprovalGateOp func(*v1alpha1.ApprovalGate)
+
+// ApprovalStatusOp is an operation which modifies an ApprovalStatus struct.
+type ApprovalStatusOp func(*v1alpha1.ApprovalStatus)
+
+// Approval creates an Approval with default values.
+// Any number of Approval modifier can be passed to transform it.
+func Approval(name, namespace string, ops ...ApprovalOp) *v1alpha1.Approval {
+	a := &v1alpha1.Approval{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	for _, op := range ops {
+		op(a)
+	}
+
+	return a
+}
+
+// ApprovalApprovers sets who may decide on the Approval.
+// Any number of ApprovalGate modifier can be passed to transform it.
+func ApprovalApprovers(ops ...ApprovalGateOp) ApprovalOp {
+	return func(a *v1alpha1.Approval) {
+		for _, op := range ops {
+			op(&a.Spec.ApprovalGate)
+		}
+	}
+}
+
+// ApprovalUsers adds users who may approve or reject to the ApprovalGate.
+func ApprovalUsers(users ...string) ApprovalGateOp {
+	return func(g *v1alpha1.ApprovalGate) {
+		g.Users = append(g.Users, users...)
+	}
+}
+
+// ApprovalGroups adds groups whose members may approve or reject to the ApprovalGate.
+func ApprovalGroups(groups ...string) ApprovalGateOp {
+	return func(g *v1alpha1.ApprovalGate) {
+		g.Groups = append(g.Groups, groups...)
+	}
+}
+
+// ApprovalMessage sets the message shown to the approvers to the ApprovalGate.
+func ApprovalMessage(message string) ApprovalGateOp {
+	return func(g *v1alpha1.ApprovalGate) {
+		g.Message = message
+	}
+}
+
+// ApprovalTimeout sets the timeout of the Approval.
+func ApprovalTimeout(d time.Duration) ApprovalOp {
+	return func(a *v1alpha1.Approval) {
+		a.Spec.Timeout = &metav1.Duration{Duration: d}
+	}
+}
+
+// ApprovalDecision sets the decision of the Approval and the approver who made it.
+func ApprovalDecision(decision v1alpha1.ApprovalDecision, approver string) ApprovalOp {
+	return func(a *v1alpha1.Approval) {
+		a.Spec.Decision = decision
+		a.Spec.Approver = approver
+	}
+}
+
+// ApprovalLabel adds a label, with specified key and value, to the Approval.
+func ApprovalLabel(key, value string) ApprovalOp {
+	return func(a *v1alpha1.Approval) {
+		if a.ObjectMeta.Labels == nil {
+			a.ObjectMeta.Labels = map[string]string{}
+		}
+		a.ObjectMeta.Labels[key] = value
+	}
+}
+
+// ApprovalOwnerReference sets the OwnerReference, with specified kind and name, to the Approval.
+func ApprovalOwnerReference(kind, name string, ops ...OwnerReferenceOp) ApprovalOp {
+	return func(a *v1alpha1.Approval) {
+		o := &metav1.OwnerReference{
+			Kind: kind,
+			Name: name,
+		}
+		for _, op := range ops {
+			op(o)
+		}
+		a.ObjectMeta.OwnerReferences = append(a.ObjectMeta.OwnerReferences, *o)
+	}
+}
+
+// ApprovalStatus sets the ApprovalStatus to the Approval.
+// Any number of ApprovalStatus modifier can be passed to transform it.
+func ApprovalStatus(ops ...ApprovalStatusOp) ApprovalOp {
+	return func(a *v1alpha1.Approval) {
+		status := &a.Status
+		for _, op := range ops {
+			op(status)
+		}
+		a.Status = *status
+	}
+}
+
+// ApprovalStartTime sets the start time to the ApprovalStatus.
+func ApprovalStartTime(startTime time.Time) ApprovalStatusOp {
+	return func(s *v1alpha1.ApprovalStatus) {
+		s.StartTime = &metav1.Time{Time: startTime}
+	}
+}
+
+// ApprovalStatusCondition adds an apis.Condition to the ApprovalStatus.
+func ApprovalStatusCondition(condition apis.Condition) ApprovalStatusOp {
+	return func(s *v1alpha1.ApprovalStatus) {
+		s.Conditions = append(s.Conditions, condition)
+	}
+}
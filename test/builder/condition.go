@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Knative Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionOp is an operation which modifies a Condition struct.
+type ConditionOp func(*v1alpha1.Condition)
+
+// ConditionSpecOp is an operation which modifies a ConditionSpec struct.
+type ConditionSpecOp func(*v1alpha1.ConditionSpec)
+
+// ConditionCRD creates a Condition with default values. It isn't called
+// Condition because that builder already sets the apis.Condition of a TaskRun.
+// Any number of Condition modifier can be passed to transform it.
+func ConditionCRD(name, namespace string, ops ...ConditionOp) *v1alpha1.Condition {
+	c := &v1alpha1.Condition{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	for _, op := range ops {
+		op(c)
+	}
+
+	return c
+}
+
+// ConditionSpec sets the specified spec of the Condition.
+// Any number of ConditionSpec modifier can be passed to create/modify it.
+func ConditionSpec(ops ...ConditionSpecOp) ConditionOp {
+	return func(c *v1alpha1.Condition) {
+		spec := &c.Spec
+		for _, op := range ops {
+			op(spec)
+		}
+		c.Spec = *spec
+	}
+}
+
+// ConditionSpecCheck sets the container used as the check of the Condition.
+// Any number of Container modifier can be passed to transform it.
+func ConditionSpecCheck(name, image string, ops ...ContainerOp) ConditionSpecOp {
+	return func(spec *v1alpha1.ConditionSpec) {
+		c := &corev1.Container{
+			Name:  name,
+			Image: image,
+		}
+		for _, op := range ops {
+			op(c)
+		}
+		spec.Check = *c
+	}
+}
+
+// ConditionParam adds a param, with specified name, to the ConditionSpec.
+// Any number of TaskParam modifier can be passed to transform it.
+func ConditionParam(name string, ops ...TaskParamOp) ConditionSpecOp {
+	return func(spec *v1alpha1.ConditionSpec) {
+		tp := &v1alpha1.TaskParam{Name: name}
+		for _, op := range ops {
+			op(tp)
+		}
+		spec.Params = append(spec.Params, *tp)
+	}
+}
+
+// ConditionResource adds a resource, with specified name and type, to the ConditionSpec.
+func ConditionResource(name string, resourceType v1alpha1.PipelineResourceType) ConditionSpecOp {
+	return func(spec *v1alpha1.ConditionSpec) {
+		spec.Resources = append(spec.Resources, v1alpha1.TaskResource{Name: name, Type: resourceType})
+	}
+}
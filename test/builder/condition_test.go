@@ -0,0 +1,54 @@
+/*
+Copyright 2019 The Knative Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCondition(t *testing.T) {
+	condition := tb.ConditionCRD("cond-name", "foo", tb.ConditionSpec(
+		tb.ConditionParam("path", tb.ParamDefault("README.md")),
+		tb.ConditionResource("workspace", v1alpha1.PipelineResourceTypeGit),
+		tb.ConditionSpecCheck("check", "ubuntu", tb.Command("test"), tb.Args("-f", "${inputs.params.path}")),
+	))
+	expected := &v1alpha1.Condition{
+		ObjectMeta: metav1.ObjectMeta{Name: "cond-name", Namespace: "foo"},
+		Spec: v1alpha1.ConditionSpec{
+			Check: corev1.Container{
+				Name:    "check",
+				Image:   "ubuntu",
+				Command: []string{"test"},
+				Args:    []string{"-f", "${inputs.params.path}"},
+			},
+			Params: []v1alpha1.TaskParam{{
+				Name:    "path",
+				Default: "README.md",
+			}},
+			Resources: []v1alpha1.TaskResource{{
+				Name: "workspace",
+				Type: v1alpha1.PipelineResourceTypeGit,
+			}},
+		},
+	}
+	if d := cmp.Diff(expected, condition); d != "" {
+		t.Fatalf("Condition diff -want, +got: %v", d)
+	}
+}
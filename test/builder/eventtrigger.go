@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EventTriggerOp is an operation which modifies an EventTrigger struct.
+type EventTriggerOp func(*v1alpha1.EventTrigger)
+
+// EventSignatureOp is an operation which modifies an EventSignature struct.
+type EventSignatureOp func(*v1alpha1.EventSignature)
+
+// EventTrigger creates an EventTrigger creating PipelineRuns of the Pipeline with the
+// specified name.
+// Any number of EventTrigger modifier can be passed to transform it.
+func EventTrigger(name, namespace, pipelineName string, ops ...EventTriggerOp) *v1alpha1.EventTrigger {
+	et := &v1alpha1.EventTrigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: v1alpha1.EventTriggerSpec{
+			PipelineRunTemplate: v1alpha1.PipelineRunTemplate{
+				Spec: v1alpha1.PipelineRunSpec{
+					PipelineRef: &v1alpha1.PipelineRef{Name: pipelineName},
+				},
+			},
+		},
+	}
+
+	for _, op := range ops {
+		op(et)
+	}
+
+	return et
+}
+
+// EventTriggerEventTypes sets the header holding the type of the events, and the types of the
+// events which trigger a PipelineRun.
+func EventTriggerEventTypes(header string, types ...string) EventTriggerOp {
+	return func(et *v1alpha1.EventTrigger) {
+		et.Spec.EventTypeHeader = header
+		et.Spec.EventTypes = types
+	}
+}
+
+// EventTriggerDeliveryIDHeader sets the header holding the ID of the delivery of the events.
+func EventTriggerDeliveryIDHeader(header string) EventTriggerOp {
+	return func(et *v1alpha1.EventTrigger) {
+		et.Spec.DeliveryIDHeader = header
+	}
+}
+
+// EventTriggerSignature sets the header holding the signature of the events, and the key of
+// the Secret they are signed with.
+// Any number of EventSignature modifier can be passed to transform it.
+func EventTriggerSignature(header, secretName, secretKey string, ops ...EventSignatureOp) EventTriggerOp {
+	return func(et *v1alpha1.EventTrigger) {
+		s := &v1alpha1.EventSignature{
+			Header: header,
+			SecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  secretKey,
+			},
+		}
+		for _, op := range ops {
+			op(s)
+		}
+		et.Spec.Signature = s
+	}
+}
+
+// EventTriggerInsecureSkipSignature accepts the events which aren't signed.
+func EventTriggerInsecureSkipSignature() EventTriggerOp {
+	return func(et *v1alpha1.EventTrigger) {
+		et.Spec.InsecureSkipSignature = true
+	}
+}
+
+// EventSignatureType sets how the events are signed to the EventSignature.
+func EventSignatureType(t v1alpha1.EventSignatureType) EventSignatureOp {
+	return func(s *v1alpha1.EventSignature) {
+		s.Type = t
+	}
+}
+
+// EventTriggerParam adds a param, whose value is extracted from the events with the specified
+// JSONPath template.
+func EventTriggerParam(name, value string) EventTriggerOp {
+	return func(et *v1alpha1.EventTrigger) {
+		et.Spec.Params = append(et.Spec.Params, v1alpha1.Param{
+			Name:  name,
+			Value: value,
+		})
+	}
+}
+
+// EventTriggerResource adds a PipelineResource created for each event, with the specified name
+// and type, and bound to the PipelineRun.
+// Any number of PipelineResourceSpec modifier can be passed to transform it.
+func EventTriggerResource(name string, resourceType v1alpha1.PipelineResourceType, ops ...PipelineResourceSpecOp) EventTriggerOp {
+	return func(et *v1alpha1.EventTrigger) {
+		r := v1alpha1.EventResource{
+			Name: name,
+			Spec: v1alpha1.PipelineResourceSpec{Type: resourceType},
+		}
+		for _, op := range ops {
+			op(&r.Spec)
+		}
+		et.Spec.Resources = append(et.Spec.Resources, r)
+	}
+}
+
+// EventTriggerTemplateSpec transforms the spec of the PipelineRuns created by the EventTrigger
+// with any number of PipelineRunSpec modifier.
+func EventTriggerTemplateSpec(ops ...PipelineRunSpecOp) EventTriggerOp {
+	return func(et *v1alpha1.EventTrigger) {
+		for _, op := range ops {
+			op(&et.Spec.PipelineRunTemplate.Spec)
+		}
+	}
+}
@@ -52,9 +52,15 @@ type PipelineResourceSpecOp func(*v1alpha1.PipelineResourceSpec)
 // PipelineTaskInputResourceOp is an operation which modifies a PipelineTaskInputResource.
 type PipelineTaskInputResourceOp func(*v1alpha1.PipelineTaskInputResource)
 
+// PipelineTaskConditionOp is an operation which modifies a PipelineTaskCondition.
+type PipelineTaskConditionOp func(*v1alpha1.PipelineTaskCondition)
+
 // PipelineRunStatusOp is an operation which modify a PipelineRunStatus
 type PipelineRunStatusOp func(*v1alpha1.PipelineRunStatus)
 
+// PipelineRunCommitStatusOp is an operation which modify a PipelineRunCommitStatus
+type PipelineRunCommitStatusOp func(*v1alpha1.PipelineRunCommitStatus)
+
 // Pipeline creates a Pipeline with default values.
 // Any number of Pipeline modifier can be passed to transform it.
 func Pipeline(name, namespace string, ops ...PipelineOp) *v1alpha1.Pipeline {
@@ -91,6 +97,11 @@ func PipelineRunCancelled(spec *v1alpha1.PipelineRunSpec) {
 	spec.Status = v1alpha1.PipelineRunSpecStatusCancelled
 }
 
+// PipelineRunPaused sets the status to pause to the PipelineRunSpec.
+func PipelineRunPaused(spec *v1alpha1.PipelineRunSpec) {
+	spec.Status = v1alpha1.PipelineRunSpecStatusPaused
+}
+
 // PipelineDeclaredResource adds a resource declaration to the Pipeline Spec,
 // with the specified name and type.
 func PipelineDeclaredResource(name string, t v1alpha1.PipelineResourceType) PipelineSpecOp {
@@ -129,13 +140,41 @@ func PipelineParamDefault(value string) PipelineParamOp {
 	}
 }
 
+// PipelineParamType sets the type to the PipelineParam.
+func PipelineParamType(paramType v1alpha1.ParamType) PipelineParamOp {
+	return func(pp *v1alpha1.PipelineParam) {
+		pp.Type = paramType
+	}
+}
+
+// PipelineParamArrayDefault sets the default value of an array PipelineParam.
+func PipelineParamArrayDefault(values ...string) PipelineParamOp {
+	return func(pp *v1alpha1.PipelineParam) {
+		pp.ArrayDefault = values
+	}
+}
+
+// PipelineParamEnum sets the values the PipelineParam may take.
+func PipelineParamEnum(values ...string) PipelineParamOp {
+	return func(pp *v1alpha1.PipelineParam) {
+		pp.Enum = values
+	}
+}
+
+// PipelineParamPattern sets the pattern the values of the PipelineParam must match.
+func PipelineParamPattern(pattern string) PipelineParamOp {
+	return func(pp *v1alpha1.PipelineParam) {
+		pp.Pattern = pattern
+	}
+}
+
 // PipelineTask adds a PipelineTask, with specified name and task name, to the PipelineSpec.
 // Any number of PipelineTask modifier can be passed to transform it.
 func PipelineTask(name, taskName string, ops ...PipelineTaskOp) PipelineSpecOp {
 	return func(ps *v1alpha1.PipelineSpec) {
 		pTask := &v1alpha1.PipelineTask{
 			Name: name,
-			TaskRef: v1alpha1.TaskRef{
+			TaskRef: &v1alpha1.TaskRef{
 				Name: taskName,
 			},
 		}
@@ -146,6 +185,23 @@ func PipelineTask(name, taskName string, ops ...PipelineTaskOp) PipelineSpecOp {
 	}
 }
 
+// PipelineFinally adds a finally Task, with specified name and task name, to the PipelineSpec.
+// Any number of PipelineTask modifier can be passed to transform it.
+func PipelineFinally(name, taskName string, ops ...PipelineTaskOp) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		pTask := &v1alpha1.PipelineTask{
+			Name: name,
+			TaskRef: &v1alpha1.TaskRef{
+				Name: taskName,
+			},
+		}
+		for _, op := range ops {
+			op(pTask)
+		}
+		ps.Finally = append(ps.Finally, *pTask)
+	}
+}
+
 // RunAfter will update the provided Pipeline Task to indicate that it
 // should be run after the provided list of Pipeline Task names.
 func RunAfter(tasks ...string) PipelineTaskOp {
@@ -154,6 +210,61 @@ func RunAfter(tasks ...string) PipelineTaskOp {
 	}
 }
 
+// Retries sets the number of retries on a PipelineTask.
+func Retries(retries int) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Retries = retries
+	}
+}
+
+// PipelineTaskTimeout sets the timeout of the TaskRun of the PipelineTask.
+func PipelineTaskTimeout(d time.Duration) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Timeout = &metav1.Duration{Duration: d}
+	}
+}
+
+// PipelineTaskPendingTimeout sets the time the pod of the TaskRun of the PipelineTask can be pending.
+func PipelineTaskPendingTimeout(d time.Duration) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.PendingTimeout = &metav1.Duration{Duration: d}
+	}
+}
+
+// PipelineTaskCondition adds a condition, referring to the Condition called
+// conditionRef, to the PipelineTask.
+// Any number of PipelineTaskCondition modifier can be passed to transform it.
+func PipelineTaskCondition(conditionRef string, ops ...PipelineTaskConditionOp) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		c := &v1alpha1.PipelineTaskCondition{ConditionRef: conditionRef}
+		for _, op := range ops {
+			op(c)
+		}
+		pt.Conditions = append(pt.Conditions, *c)
+	}
+}
+
+// PipelineTaskConditionParam adds a Param, with specified name and value, to the PipelineTaskCondition.
+func PipelineTaskConditionParam(name, value string) PipelineTaskConditionOp {
+	return func(c *v1alpha1.PipelineTaskCondition) {
+		c.Params = append(c.Params, v1alpha1.Param{
+			Name:  name,
+			Value: value,
+		})
+	}
+}
+
+// PipelineTaskConditionResource adds a resource, with specified name and the
+// name of the Pipeline's declared resource, to the PipelineTaskCondition.
+func PipelineTaskConditionResource(name, resource string) PipelineTaskConditionOp {
+	return func(c *v1alpha1.PipelineTaskCondition) {
+		c.Resources = append(c.Resources, v1alpha1.PipelineConditionResource{
+			Name:     name,
+			Resource: resource,
+		})
+	}
+}
+
 // PipelineTaskRefKind sets the TaskKind to the PipelineTaskRef.
 func PipelineTaskRefKind(kind v1alpha1.TaskKind) PipelineTaskOp {
 	return func(pt *v1alpha1.PipelineTask) {
@@ -161,6 +272,49 @@ func PipelineTaskRefKind(kind v1alpha1.TaskKind) PipelineTaskOp {
 	}
 }
 
+// PipelineTaskCustomTask makes the TaskRef of the PipelineTask refer to a custom task, with
+// specified apiVersion and kind, which is run by a Run instead of a TaskRun.
+func PipelineTaskCustomTask(apiVersion, kind string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.TaskRef.APIVersion = apiVersion
+		pt.TaskRef.Kind = v1alpha1.TaskKind(kind)
+	}
+}
+
+// PipelineTaskSpec embeds a TaskSpec in the PipelineTask instead of referencing a Task.
+// Any number of TaskSpec modifier can be passed to transform it.
+func PipelineTaskSpec(ops ...TaskSpecOp) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		spec := &v1alpha1.TaskSpec{}
+		for _, op := range ops {
+			op(spec)
+		}
+		pt.TaskRef = nil
+		pt.TaskSpec = spec
+	}
+}
+
+// PipelineTaskPipelineRef makes the PipelineTask run the Pipeline with the specified
+// name instead of a Task.
+func PipelineTaskPipelineRef(name string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.TaskRef = nil
+		pt.PipelineRef = &v1alpha1.PipelineRef{Name: name}
+	}
+}
+
+// PipelineTaskApproval makes the PipelineTask wait for a manual approval instead of
+// running a Task. Any number of ApprovalGate modifier can be passed to transform it.
+func PipelineTaskApproval(ops ...ApprovalGateOp) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.TaskRef = nil
+		pt.Approval = &v1alpha1.ApprovalGate{}
+		for _, op := range ops {
+			op(pt.Approval)
+		}
+	}
+}
+
 // PipelineTaskParam adds a Param, with specified name and value, to the PipelineTask.
 func PipelineTaskParam(name, value string) PipelineTaskOp {
 	return func(pt *v1alpha1.PipelineTask) {
@@ -171,6 +325,27 @@ func PipelineTaskParam(name, value string) PipelineTaskOp {
 	}
 }
 
+// PipelineTaskArrayParam adds an array Param, with specified name and values, to the PipelineTask.
+func PipelineTaskArrayParam(name string, values ...string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Params = append(pt.Params, v1alpha1.Param{
+			Name:       name,
+			ArrayValue: values,
+		})
+	}
+}
+
+// PipelineTaskMatrixParam adds a matrix param, with specified name and values, to the
+// PipelineTask, so that it is run once for each of the values.
+func PipelineTaskMatrixParam(name string, values ...string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Matrix = append(pt.Matrix, v1alpha1.MatrixParam{
+			Name:   name,
+			Values: values,
+		})
+	}
+}
+
 // From will update the provided PipelineTaskInputResource to indicate that it
 // should come from tasks.
 func From(tasks ...string) PipelineTaskInputResourceOp {
@@ -236,12 +411,15 @@ func PipelineRun(name, namespace string, ops ...PipelineRunOp) *v1alpha1.Pipelin
 }
 
 // PipelineRunSpec sets the PipelineRunSpec, references Pipeline with specified name, to the PipelineRun.
+// The Pipeline isn't referenced if name is empty, e.g. when it is embedded with PipelineRunPipelineSpec.
 // Any number of PipelineRunSpec modifier can be passed to transform it.
 func PipelineRunSpec(name string, ops ...PipelineRunSpecOp) PipelineRunOp {
 	return func(pr *v1alpha1.PipelineRun) {
 		prs := &pr.Spec
 
-		prs.PipelineRef.Name = name
+		if name != "" {
+			prs.PipelineRef = &v1alpha1.PipelineRef{Name: name}
+		}
 
 		for _, op := range ops {
 			op(prs)
@@ -261,6 +439,20 @@ func PipelineRunLabel(key, value string) PipelineRunOp {
 	}
 }
 
+// PipelineRunOwnerReference sets the OwnerReference, with specified kind and name, to the PipelineRun.
+func PipelineRunOwnerReference(kind, name string, ops ...OwnerReferenceOp) PipelineRunOp {
+	return func(pr *v1alpha1.PipelineRun) {
+		o := &metav1.OwnerReference{
+			Kind: kind,
+			Name: name,
+		}
+		for _, op := range ops {
+			op(o)
+		}
+		pr.ObjectMeta.OwnerReferences = append(pr.ObjectMeta.OwnerReferences, *o)
+	}
+}
+
 // PipelineRunResourceBinding adds bindings from actual instances to a Pipeline's declared resources.
 func PipelineRunResourceBinding(name string, ops ...PipelineResourceBindingOp) PipelineRunSpecOp {
 	return func(prs *v1alpha1.PipelineRunSpec) {
@@ -301,6 +493,16 @@ func PipelineRunParam(name, value string) PipelineRunSpecOp {
 	}
 }
 
+// PipelineRunArrayParam add an array param, with specified name and values, to the PipelineRunSpec.
+func PipelineRunArrayParam(name string, values ...string) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.Params = append(prs.Params, v1alpha1.Param{
+			Name:       name,
+			ArrayValue: values,
+		})
+	}
+}
+
 // PipelineRunTimeout sets the timeout to the PipelineSpec.
 func PipelineRunTimeout(duration *metav1.Duration) PipelineRunSpecOp {
 	return func(prs *v1alpha1.PipelineRunSpec) {
@@ -308,6 +510,46 @@ func PipelineRunTimeout(duration *metav1.Duration) PipelineRunSpecOp {
 	}
 }
 
+// PipelineRunFailurePolicy sets the failure policy to the PipelineRunSpec.
+func PipelineRunFailurePolicy(policy v1alpha1.PipelineRunFailurePolicy) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.FailurePolicy = policy
+	}
+}
+
+// PipelineRunMaxParallelTasks sets the maximum number of PipelineTasks which can run at once.
+func PipelineRunMaxParallelTasks(max int) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.MaxParallelTasks = max
+	}
+}
+
+// PipelineRunConcurrency sets the concurrency group of the PipelineRunSpec and its policy.
+func PipelineRunConcurrency(group string, policy v1alpha1.PipelineRunConcurrencyPolicy) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.Concurrency = &v1alpha1.PipelineRunConcurrency{Group: group, Policy: policy}
+	}
+}
+
+// PipelineRunPipelineSpec embeds a PipelineSpec in the PipelineRunSpec.
+// Any number of PipelineSpec modifier can be passed to transform it.
+func PipelineRunPipelineSpec(ops ...PipelineSpecOp) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		ps := &v1alpha1.PipelineSpec{}
+		for _, op := range ops {
+			op(ps)
+		}
+		prs.PipelineSpec = ps
+	}
+}
+
+// PipelineRunResumeFrom sets the PipelineRun the PipelineRunSpec resumes from.
+func PipelineRunResumeFrom(name string) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.ResumeFrom = &v1alpha1.PipelineRunRef{Name: name}
+	}
+}
+
 // PipelineRunNodeSelector sets the Node selector to the PipelineSpec.
 func PipelineRunNodeSelector(values map[string]string) PipelineRunSpecOp {
 	return func(prs *v1alpha1.PipelineRunSpec) {
@@ -329,6 +571,54 @@ func PipelineRunAffinity(affinity *corev1.Affinity) PipelineRunSpecOp {
 	}
 }
 
+// PipelineRunCloudEventSink sets the URL CloudEvents are sent to to the PipelineRunSpec.
+func PipelineRunCloudEventSink(sink string) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.CloudEventSink = sink
+	}
+}
+
+// PipelineRunCommitStatus sets the commit status reported on the revision of the git resource
+// to the PipelineRunSpec, authenticating with the token of the key of the Secret secretName.
+// Any number of PipelineRunCommitStatus modifier can be passed to transform it.
+func PipelineRunCommitStatus(resource string, provider v1alpha1.CommitStatusProvider, secretName, secretKey string, ops ...PipelineRunCommitStatusOp) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		cs := &v1alpha1.PipelineRunCommitStatus{
+			Resource: resource,
+			Provider: provider,
+			TokenSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  secretKey,
+			},
+		}
+		for _, op := range ops {
+			op(cs)
+		}
+		prs.CommitStatus = cs
+	}
+}
+
+// CommitStatusAPIURL sets the URL of the API of the provider to the PipelineRunCommitStatus.
+func CommitStatusAPIURL(url string) PipelineRunCommitStatusOp {
+	return func(cs *v1alpha1.PipelineRunCommitStatus) {
+		cs.APIURL = url
+	}
+}
+
+// CommitStatusContext sets the context to the PipelineRunCommitStatus.
+func CommitStatusContext(context string) PipelineRunCommitStatusOp {
+	return func(cs *v1alpha1.PipelineRunCommitStatus) {
+		cs.Context = context
+	}
+}
+
+// CommitStatusTargetURL sets the link of the status to the PipelineRunCommitStatus.
+func CommitStatusTargetURL(url string) PipelineRunCommitStatusOp {
+	return func(cs *v1alpha1.PipelineRunCommitStatus) {
+		cs.TargetURL = url
+	}
+}
+
 // PipelineRunStatus sets the PipelineRunStatus to the PipelineRun.
 // Any number of PipelineRunStatus modifier can be passed to transform it.
 func PipelineRunStatus(ops ...PipelineRunStatusOp) PipelineRunOp {
@@ -355,6 +645,28 @@ func PipelineRunStartTime(startTime time.Time) PipelineRunStatusOp {
 	}
 }
 
+// PipelineRunPausedTime sets the time the PipelineRun was paused to the PipelineRunStatus.
+func PipelineRunPausedTime(pausedTime time.Time) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.PausedTime = &metav1.Time{Time: pausedTime}
+	}
+}
+
+// PipelineRunPausedDuration sets the time the PipelineRun spent paused to the PipelineRunStatus.
+func PipelineRunPausedDuration(pausedDuration time.Duration) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.PausedDuration = &metav1.Duration{Duration: pausedDuration}
+	}
+}
+
+// PipelineRunArtifactStorage sets the name of the PipelineRun whose artifact storage
+// is used, to the PipelineRunStatus.
+func PipelineRunArtifactStorage(name string) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.ArtifactStorage = name
+	}
+}
+
 // PipelineRunTaskRunsStatus sets the TaskRuns of the PipelineRunStatus.
 func PipelineRunTaskRunsStatus(taskRuns map[string]*v1alpha1.PipelineRunTaskRunStatus) PipelineRunStatusOp {
 	return func(s *v1alpha1.PipelineRunStatus) {
@@ -362,6 +674,40 @@ func PipelineRunTaskRunsStatus(taskRuns map[string]*v1alpha1.PipelineRunTaskRunS
 	}
 }
 
+// PipelineRunPipelineRunsStatus sets the PipelineRuns of nested Pipelines of the PipelineRunStatus.
+func PipelineRunPipelineRunsStatus(pipelineRuns map[string]*v1alpha1.PipelineRunPipelineRunStatus) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.PipelineRuns = pipelineRuns
+	}
+}
+
+// PipelineRunApprovalsStatus sets the Approvals of the approval gates of the PipelineRunStatus.
+func PipelineRunApprovalsStatus(approvals map[string]*v1alpha1.PipelineRunApprovalStatus) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.Approvals = approvals
+	}
+}
+
+// PipelineRunRunsStatus sets the Runs of the PipelineTasks referring to custom tasks to the
+// PipelineRunStatus.
+func PipelineRunRunsStatus(runs map[string]*v1alpha1.PipelineRunRunStatus) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.Runs = runs
+	}
+}
+
+// PipelineRunSkippedTask adds a SkippedTask, with specified name, reason and
+// message, to the PipelineRunStatus.
+func PipelineRunSkippedTask(name, reason, message string) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.SkippedTasks = append(s.SkippedTasks, v1alpha1.SkippedTask{
+			Name:    name,
+			Reason:  reason,
+			Message: message,
+		})
+	}
+}
+
 // PipelineResource creates a PipelineResource with default values.
 // Any number of PipelineResource modifier can be passed to transform it.
 func PipelineResource(name, namespace string, ops ...PipelineResourceOp) *v1alpha1.PipelineResource {
@@ -22,6 +22,7 @@ import (
 	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,8 +31,12 @@ func TestPipeline(t *testing.T) {
 		tb.PipelineDeclaredResource("my-only-git-resource", "git"),
 		tb.PipelineDeclaredResource("my-only-image-resource", "image"),
 		tb.PipelineParam("first-param", tb.PipelineParamDefault("default-value"), tb.PipelineParamDescription("default description")),
+		tb.PipelineParam("second-param", tb.PipelineParamType(v1alpha1.ParamTypeArray), tb.PipelineParamArrayDefault("-v"),
+			tb.PipelineParamEnum("-v", "-q"), tb.PipelineParamPattern("^-")),
 		tb.PipelineTask("foo", "banana",
 			tb.PipelineTaskParam("name", "value"),
+			tb.PipelineTaskArrayParam("flags", "-v", "-q"),
+			tb.PipelineTaskMatrixParam("platform", "linux", "windows"),
 		),
 		tb.PipelineTask("bar", "chocolate",
 			tb.PipelineTaskRefKind(v1alpha1.ClusterTaskKind),
@@ -40,6 +45,17 @@ func TestPipeline(t *testing.T) {
 		),
 		tb.PipelineTask("never-gonna", "give-you-up",
 			tb.RunAfter("foo"),
+			tb.Retries(2),
+			tb.PipelineTaskTimeout(10*time.Minute),
+			tb.PipelineTaskPendingTimeout(time.Minute),
+			tb.PipelineTaskCondition("some-condition",
+				tb.PipelineTaskConditionParam("param-name", "param-value"),
+				tb.PipelineTaskConditionResource("workspace", "my-only-git-resource"),
+			),
+		),
+		tb.PipelineTask("nested", "", tb.PipelineTaskPipelineRef("other-pipeline")),
+		tb.PipelineFinally("cleanup", "tear-down",
+			tb.PipelineTaskParam("name", "value"),
 		),
 	))
 	expectedPipeline := &v1alpha1.Pipeline{
@@ -56,14 +72,21 @@ func TestPipeline(t *testing.T) {
 				Name:        "first-param",
 				Default:     "default-value",
 				Description: "default description",
+			}, {
+				Name:         "second-param",
+				Type:         v1alpha1.ParamTypeArray,
+				ArrayDefault: []string{"-v"},
+				Enum:         []string{"-v", "-q"},
+				Pattern:      "^-",
 			}},
 			Tasks: []v1alpha1.PipelineTask{{
 				Name:    "foo",
-				TaskRef: v1alpha1.TaskRef{Name: "banana"},
-				Params:  []v1alpha1.Param{{Name: "name", Value: "value"}},
+				TaskRef: &v1alpha1.TaskRef{Name: "banana"},
+				Params:  []v1alpha1.Param{{Name: "name", Value: "value"}, {Name: "flags", ArrayValue: []string{"-v", "-q"}}},
+				Matrix:  []v1alpha1.MatrixParam{{Name: "platform", Values: []string{"linux", "windows"}}},
 			}, {
 				Name:    "bar",
-				TaskRef: v1alpha1.TaskRef{Name: "chocolate", Kind: v1alpha1.ClusterTaskKind},
+				TaskRef: &v1alpha1.TaskRef{Name: "chocolate", Kind: v1alpha1.ClusterTaskKind},
 				Resources: &v1alpha1.PipelineTaskResources{
 					Inputs: []v1alpha1.PipelineTaskInputResource{{
 						Name:     "some-repo",
@@ -76,9 +99,28 @@ func TestPipeline(t *testing.T) {
 					}},
 				},
 			}, {
-				Name:     "never-gonna",
-				TaskRef:  v1alpha1.TaskRef{Name: "give-you-up"},
-				RunAfter: []string{"foo"},
+				Name:           "never-gonna",
+				TaskRef:        &v1alpha1.TaskRef{Name: "give-you-up"},
+				RunAfter:       []string{"foo"},
+				Retries:        2,
+				Timeout:        &metav1.Duration{Duration: 10 * time.Minute},
+				PendingTimeout: &metav1.Duration{Duration: time.Minute},
+				Conditions: []v1alpha1.PipelineTaskCondition{{
+					ConditionRef: "some-condition",
+					Params:       []v1alpha1.Param{{Name: "param-name", Value: "param-value"}},
+					Resources: []v1alpha1.PipelineConditionResource{{
+						Name:     "workspace",
+						Resource: "my-only-git-resource",
+					}},
+				}},
+			}, {
+				Name:        "nested",
+				PipelineRef: &v1alpha1.PipelineRef{Name: "other-pipeline"},
+			}},
+			Finally: []v1alpha1.PipelineTask{{
+				Name:    "cleanup",
+				TaskRef: &v1alpha1.TaskRef{Name: "tear-down"},
+				Params:  []v1alpha1.Param{{Name: "name", Value: "value"}},
 			}},
 		},
 	}
@@ -92,11 +134,17 @@ func TestPipelineRun(t *testing.T) {
 	pipelineRun := tb.PipelineRun("pear", "foo", tb.PipelineRunSpec(
 		"tomatoes", tb.PipelineRunServiceAccount("sa"),
 		tb.PipelineRunParam("first-param", "first-value"),
+		tb.PipelineRunArrayParam("second-param", "-v", "-q"),
 		tb.PipelineRunTimeout(&metav1.Duration{Duration: 1 * time.Hour}),
+		tb.PipelineRunFailurePolicy(v1alpha1.PipelineRunFailurePolicyFailFast),
+		tb.PipelineRunMaxParallelTasks(2),
+		tb.PipelineRunConcurrency("ci-${params.branch}", v1alpha1.PipelineRunConcurrencyPolicySkip),
+		tb.PipelineRunResumeFrom("apple"),
 		tb.PipelineRunResourceBinding("some-resource", tb.PipelineResourceBindingRef("my-special-resource")),
 	), tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
 		Type: apis.ConditionSucceeded,
 	}), tb.PipelineRunStartTime(startTime),
+		tb.PipelineRunArtifactStorage("apple"),
 	), tb.PipelineRunLabel("label-key", "label-value"))
 	expectedPipelineRun := &v1alpha1.PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -107,12 +155,15 @@ func TestPipelineRun(t *testing.T) {
 			},
 		},
 		Spec: v1alpha1.PipelineRunSpec{
-			PipelineRef:    v1alpha1.PipelineRef{Name: "tomatoes"},
+			PipelineRef:    &v1alpha1.PipelineRef{Name: "tomatoes"},
 			Trigger:        v1alpha1.PipelineTrigger{Type: v1alpha1.PipelineTriggerTypeManual},
 			ServiceAccount: "sa",
 			Params: []v1alpha1.Param{{
 				Name:  "first-param",
 				Value: "first-value",
+			}, {
+				Name:       "second-param",
+				ArrayValue: []string{"-v", "-q"},
 			}},
 			Timeout: &metav1.Duration{Duration: 1 * time.Hour},
 			Resources: []v1alpha1.PipelineResourceBinding{{
@@ -121,12 +172,48 @@ func TestPipelineRun(t *testing.T) {
 					Name: "my-special-resource",
 				},
 			}},
+			FailurePolicy:    v1alpha1.PipelineRunFailurePolicyFailFast,
+			MaxParallelTasks: 2,
+			Concurrency: &v1alpha1.PipelineRunConcurrency{
+				Group:  "ci-${params.branch}",
+				Policy: v1alpha1.PipelineRunConcurrencyPolicySkip,
+			},
+			ResumeFrom: &v1alpha1.PipelineRunRef{Name: "apple"},
 		},
 		Status: v1alpha1.PipelineRunStatus{
 			Status: duckv1beta1.Status{
 				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded}},
 			},
-			StartTime: &metav1.Time{Time: startTime},
+			StartTime:       &metav1.Time{Time: startTime},
+			ArtifactStorage: "apple",
+		},
+	}
+	if d := cmp.Diff(expectedPipelineRun, pipelineRun); d != "" {
+		t.Fatalf("PipelineRun diff -want, +got: %v", d)
+	}
+}
+
+func TestPipelineRunWithPipelineSpec(t *testing.T) {
+	pipelineRun := tb.PipelineRun("pear", "foo", tb.PipelineRunSpec("",
+		tb.PipelineRunPipelineSpec(
+			tb.PipelineTask("foo", "", tb.PipelineTaskSpec(tb.Step("step", "myimage"))),
+		),
+	))
+	expectedPipelineRun := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pear",
+			Namespace: "foo",
+		},
+		Spec: v1alpha1.PipelineRunSpec{
+			PipelineSpec: &v1alpha1.PipelineSpec{
+				Tasks: []v1alpha1.PipelineTask{{
+					Name: "foo",
+					TaskSpec: &v1alpha1.TaskSpec{
+						Steps: []v1alpha1.Step{{Container: corev1.Container{Name: "step", Image: "myimage"}}},
+					},
+				}},
+			},
+			Trigger: v1alpha1.PipelineTrigger{Type: v1alpha1.PipelineTriggerTypeManual},
 		},
 	}
 	if d := cmp.Diff(expectedPipelineRun, pipelineRun); d != "" {
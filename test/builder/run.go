@@ -0,0 +1,141 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RunOp is an operation which modifies a Run struct.
+type RunOp func(*v1alpha1.Run)
+
+// RunStatusOp is an operation which modifies a RunStatus struct.
+type RunStatusOp func(*v1alpha1.RunStatus)
+
+// Run creates a Run with default values.
+// Any number of Run modifier can be passed to transform it.
+func Run(name, namespace string, ops ...RunOp) *v1alpha1.Run {
+	r := &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+
+	for _, op := range ops {
+		op(r)
+	}
+
+	return r
+}
+
+// RunRef sets the custom task, with specified apiVersion, kind and name, the Run refers to.
+func RunRef(apiVersion, kind, name string) RunOp {
+	return func(r *v1alpha1.Run) {
+		r.Spec.Ref = &v1alpha1.TaskRef{
+			APIVersion: apiVersion,
+			Kind:       v1alpha1.TaskKind(kind),
+			Name:       name,
+		}
+	}
+}
+
+// RunParam adds a Param, with specified name and value, to the Run.
+func RunParam(name, value string) RunOp {
+	return func(r *v1alpha1.Run) {
+		r.Spec.Params = append(r.Spec.Params, v1alpha1.Param{
+			Name:  name,
+			Value: value,
+		})
+	}
+}
+
+// RunTimeout sets the timeout of the Run.
+func RunTimeout(d time.Duration) RunOp {
+	return func(r *v1alpha1.Run) {
+		r.Spec.Timeout = &metav1.Duration{Duration: d}
+	}
+}
+
+// RunCancelled sets the status of the Run to cancelled.
+func RunCancelled(r *v1alpha1.Run) {
+	r.Spec.Status = v1alpha1.RunSpecStatusCancelled
+}
+
+// RunLabel adds a label, with specified key and value, to the Run.
+func RunLabel(key, value string) RunOp {
+	return func(r *v1alpha1.Run) {
+		if r.ObjectMeta.Labels == nil {
+			r.ObjectMeta.Labels = map[string]string{}
+		}
+		r.ObjectMeta.Labels[key] = value
+	}
+}
+
+// RunOwnerReference sets the OwnerReference, with specified kind and name, to the Run.
+func RunOwnerReference(kind, name string, ops ...OwnerReferenceOp) RunOp {
+	return func(r *v1alpha1.Run) {
+		o := &metav1.OwnerReference{
+			Kind: kind,
+			Name: name,
+		}
+		for _, op := range ops {
+			op(o)
+		}
+		r.ObjectMeta.OwnerReferences = append(r.ObjectMeta.OwnerReferences, *o)
+	}
+}
+
+// RunStatus sets the RunStatus to the Run.
+// Any number of RunStatus modifier can be passed to transform it.
+func RunStatus(ops ...RunStatusOp) RunOp {
+	return func(r *v1alpha1.Run) {
+		status := &r.Status
+		for _, op := range ops {
+			op(status)
+		}
+		r.Status = *status
+	}
+}
+
+// RunStartTime sets the start time to the RunStatus.
+func RunStartTime(startTime time.Time) RunStatusOp {
+	return func(s *v1alpha1.RunStatus) {
+		s.StartTime = &metav1.Time{Time: startTime}
+	}
+}
+
+// RunStatusCondition adds an apis.Condition to the RunStatus.
+func RunStatusCondition(condition apis.Condition) RunStatusOp {
+	return func(s *v1alpha1.RunStatus) {
+		s.Conditions = append(s.Conditions, condition)
+	}
+}
+
+// RunResult adds a result, with specified name and value, to the RunStatus.
+func RunResult(name, value string) RunStatusOp {
+	return func(s *v1alpha1.RunStatus) {
+		s.Results = append(s.Results, v1alpha1.TaskRunResult{
+			Name:  name,
+			Value: value,
+		})
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScheduledPipelineRunOp is an operation which modifies a ScheduledPipelineRun struct.
+type ScheduledPipelineRunOp func(*v1alpha1.ScheduledPipelineRun)
+
+// ScheduledPipelineRunStatusOp is an operation which modifies a ScheduledPipelineRunStatus struct.
+type ScheduledPipelineRunStatusOp func(*v1alpha1.ScheduledPipelineRunStatus)
+
+// ScheduledPipelineRun creates a ScheduledPipelineRun, with the specified cron schedule,
+// creating PipelineRuns of the Pipeline with the specified name.
+// Any number of ScheduledPipelineRun modifier can be passed to transform it.
+func ScheduledPipelineRun(name, namespace, schedule, pipelineName string, ops ...ScheduledPipelineRunOp) *v1alpha1.ScheduledPipelineRun {
+	spr := &v1alpha1.ScheduledPipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: v1alpha1.ScheduledPipelineRunSpec{
+			Schedule: schedule,
+			PipelineRunTemplate: v1alpha1.PipelineRunTemplate{
+				Spec: v1alpha1.PipelineRunSpec{
+					PipelineRef: &v1alpha1.PipelineRef{Name: pipelineName},
+				},
+			},
+		},
+	}
+
+	for _, op := range ops {
+		op(spr)
+	}
+
+	return spr
+}
+
+// ScheduledPipelineRunCreationTimestamp sets the creation time of the ScheduledPipelineRun.
+func ScheduledPipelineRunCreationTimestamp(t time.Time) ScheduledPipelineRunOp {
+	return func(spr *v1alpha1.ScheduledPipelineRun) {
+		spr.CreationTimestamp = metav1.Time{Time: t}
+	}
+}
+
+// ScheduledPipelineRunTimeZone sets the time zone of the schedule.
+func ScheduledPipelineRunTimeZone(timeZone string) ScheduledPipelineRunOp {
+	return func(spr *v1alpha1.ScheduledPipelineRun) {
+		spr.Spec.TimeZone = timeZone
+	}
+}
+
+// ScheduledPipelineRunConcurrencyPolicy sets the concurrency policy of the ScheduledPipelineRun.
+func ScheduledPipelineRunConcurrencyPolicy(policy v1alpha1.ScheduledPipelineRunConcurrencyPolicy) ScheduledPipelineRunOp {
+	return func(spr *v1alpha1.ScheduledPipelineRun) {
+		spr.Spec.ConcurrencyPolicy = policy
+	}
+}
+
+// ScheduledPipelineRunHistoryLimit sets the number of finished PipelineRuns to keep.
+func ScheduledPipelineRunHistoryLimit(limit int32) ScheduledPipelineRunOp {
+	return func(spr *v1alpha1.ScheduledPipelineRun) {
+		spr.Spec.HistoryLimit = &limit
+	}
+}
+
+// ScheduledPipelineRunSuspend suspends the ScheduledPipelineRun.
+func ScheduledPipelineRunSuspend(spr *v1alpha1.ScheduledPipelineRun) {
+	spr.Spec.Suspend = true
+}
+
+// ScheduledPipelineRunTemplateLabel adds a label to the PipelineRuns created by the ScheduledPipelineRun.
+func ScheduledPipelineRunTemplateLabel(key, value string) ScheduledPipelineRunOp {
+	return func(spr *v1alpha1.ScheduledPipelineRun) {
+		template := &spr.Spec.PipelineRunTemplate
+		if template.Labels == nil {
+			template.Labels = map[string]string{}
+		}
+		template.Labels[key] = value
+	}
+}
+
+// ScheduledPipelineRunTemplateSpec transforms the spec of the PipelineRuns created by the
+// ScheduledPipelineRun with any number of PipelineRunSpec modifier.
+func ScheduledPipelineRunTemplateSpec(ops ...PipelineRunSpecOp) ScheduledPipelineRunOp {
+	return func(spr *v1alpha1.ScheduledPipelineRun) {
+		for _, op := range ops {
+			op(&spr.Spec.PipelineRunTemplate.Spec)
+		}
+	}
+}
+
+// ScheduledPipelineRunStatus sets the ScheduledPipelineRunStatus to the ScheduledPipelineRun.
+// Any number of ScheduledPipelineRunStatus modifier can be passed to transform it.
+func ScheduledPipelineRunStatus(ops ...ScheduledPipelineRunStatusOp) ScheduledPipelineRunOp {
+	return func(spr *v1alpha1.ScheduledPipelineRun) {
+		status := &spr.Status
+		for _, op := range ops {
+			op(status)
+		}
+		spr.Status = *status
+	}
+}
+
+// ScheduledPipelineRunLastScheduleTime sets the last time a PipelineRun was due.
+func ScheduledPipelineRunLastScheduleTime(t time.Time) ScheduledPipelineRunStatusOp {
+	return func(s *v1alpha1.ScheduledPipelineRunStatus) {
+		s.LastScheduleTime = &metav1.Time{Time: t}
+	}
+}
+
+// ScheduledPipelineRunActive sets the names of the PipelineRuns still running.
+func ScheduledPipelineRunActive(names ...string) ScheduledPipelineRunStatusOp {
+	return func(s *v1alpha1.ScheduledPipelineRunStatus) {
+		s.Active = names
+	}
+}
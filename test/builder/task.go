@@ -111,6 +111,16 @@ func ClusterTask(name string, ops ...ClusterTaskOp) *v1alpha1.ClusterTask {
 	return t
 }
 
+// TaskLabel adds a label with the specified key and value to the Task.
+func TaskLabel(key, value string) TaskOp {
+	return func(t *v1alpha1.Task) {
+		if t.ObjectMeta.Labels == nil {
+			t.ObjectMeta.Labels = map[string]string{}
+		}
+		t.ObjectMeta.Labels[key] = value
+	}
+}
+
 // ClusterTaskSpec sets the specified spec of the cluster task.
 // Any number of TaskSpec modifier can be passed to create it.
 func ClusterTaskSpec(ops ...TaskSpecOp) ClusterTaskOp {
@@ -138,9 +148,16 @@ func TaskSpec(ops ...TaskSpecOp) TaskOp {
 // Step adds a step with the specified name and image to the TaskSpec.
 // Any number of Container modifier can be passed to transform it.
 func Step(name, image string, ops ...ContainerOp) TaskSpecOp {
+	return ScriptStep(name, image, "", ops...)
+}
+
+// ScriptStep adds a step with the specified name and image running script
+// to the TaskSpec. Any number of Container modifier can be passed to
+// transform it.
+func ScriptStep(name, image, script string, ops ...ContainerOp) TaskSpecOp {
 	return func(spec *v1alpha1.TaskSpec) {
 		if spec.Steps == nil {
-			spec.Steps = []corev1.Container{}
+			spec.Steps = []v1alpha1.Step{}
 		}
 		step := &corev1.Container{
 			Name:  name,
@@ -149,7 +166,22 @@ func Step(name, image string, ops ...ContainerOp) TaskSpecOp {
 		for _, op := range ops {
 			op(step)
 		}
-		spec.Steps = append(spec.Steps, *step)
+		spec.Steps = append(spec.Steps, v1alpha1.Step{Container: *step, Script: script})
+	}
+}
+
+// Sidecar adds a sidecar with the specified name and image to the TaskSpec.
+// Any number of Container modifier can be passed to transform it.
+func Sidecar(name, image string, ops ...ContainerOp) TaskSpecOp {
+	return func(spec *v1alpha1.TaskSpec) {
+		sidecar := &corev1.Container{
+			Name:  name,
+			Image: image,
+		}
+		for _, op := range ops {
+			op(sidecar)
+		}
+		spec.Sidecars = append(spec.Sidecars, *sidecar)
 	}
 }
 
@@ -165,6 +197,13 @@ func TaskContainerTemplate(ops ...ContainerOp) TaskSpecOp {
 	}
 }
 
+// TaskResult adds a result declaration to the TaskSpec.
+func TaskResult(name, desc string) TaskSpecOp {
+	return func(spec *v1alpha1.TaskSpec) {
+		spec.Results = append(spec.Results, v1alpha1.TaskResult{Name: name, Description: desc})
+	}
+}
+
 // TaskVolume adds a volume with specified name to the TaskSpec.
 // Any number of Volume modifier can be passed to transform it.
 func TaskVolume(name string, ops ...VolumeOp) TaskSpecOp {
@@ -261,6 +300,34 @@ func ParamDefault(value string) TaskParamOp {
 	}
 }
 
+// ParamType sets the type to the TaskParam.
+func ParamType(paramType v1alpha1.ParamType) TaskParamOp {
+	return func(tp *v1alpha1.TaskParam) {
+		tp.Type = paramType
+	}
+}
+
+// ParamArrayDefault sets the default value of an array TaskParam.
+func ParamArrayDefault(values ...string) TaskParamOp {
+	return func(tp *v1alpha1.TaskParam) {
+		tp.ArrayDefault = values
+	}
+}
+
+// ParamEnum sets the values the TaskParam may take.
+func ParamEnum(values ...string) TaskParamOp {
+	return func(tp *v1alpha1.TaskParam) {
+		tp.Enum = values
+	}
+}
+
+// ParamPattern sets the pattern the values of the TaskParam must match.
+func ParamPattern(pattern string) TaskParamOp {
+	return func(tp *v1alpha1.TaskParam) {
+		tp.Pattern = pattern
+	}
+}
+
 // TaskRun creates a TaskRun with default values.
 // Any number of TaskRun modifier can be passed to transform it.
 func TaskRun(name, namespace string, ops ...TaskRunOp) *v1alpha1.TaskRun {
@@ -314,6 +381,13 @@ func StepState(ops ...StepStateOp) TaskRunStatusOp {
 	}
 }
 
+// TaskRunResult adds a result emitted by the TaskRun's steps to the TaskRunStatus.
+func TaskRunResult(name, value string) TaskRunStatusOp {
+	return func(s *v1alpha1.TaskRunStatus) {
+		s.TaskResults = append(s.TaskResults, v1alpha1.TaskRunResult{Name: name, Value: value})
+	}
+}
+
 // TaskRunStartTime sets the start time to the TaskRunStatus.
 func TaskRunStartTime(startTime time.Time) TaskRunStatusOp {
 	return func(s *v1alpha1.TaskRunStatus) {
@@ -321,6 +395,13 @@ func TaskRunStartTime(startTime time.Time) TaskRunStatusOp {
 	}
 }
 
+// TaskRunRunningTime sets the time the pod of the TaskRun left Pending to the TaskRunStatus.
+func TaskRunRunningTime(runningTime time.Time) TaskRunStatusOp {
+	return func(s *v1alpha1.TaskRunStatus) {
+		s.RunningTime = &metav1.Time{Time: runningTime}
+	}
+}
+
 // TaskRunTimeout sets the timeout duration to the TaskRunSpec.
 func TaskRunTimeout(d time.Duration) TaskRunSpecOp {
 	return func(spec *v1alpha1.TaskRunSpec) {
@@ -328,6 +409,13 @@ func TaskRunTimeout(d time.Duration) TaskRunSpecOp {
 	}
 }
 
+// TaskRunPendingTimeout sets the time the pod of the TaskRun can be pending to the TaskRunSpec.
+func TaskRunPendingTimeout(d time.Duration) TaskRunSpecOp {
+	return func(spec *v1alpha1.TaskRunSpec) {
+		spec.PendingTimeout = &metav1.Duration{Duration: d}
+	}
+}
+
 // TaskRunNodeSelector sets the NodeSelector to the PipelineSpec.
 func TaskRunNodeSelector(values map[string]string) TaskRunSpecOp {
 	return func(spec *v1alpha1.TaskRunSpec) {
@@ -349,6 +437,13 @@ func TaskRunAffinity(affinity *corev1.Affinity) TaskRunSpecOp {
 	}
 }
 
+// TaskRunCloudEventSink sets the URL CloudEvents are sent to to the TaskRunSpec.
+func TaskRunCloudEventSink(sink string) TaskRunSpecOp {
+	return func(spec *v1alpha1.TaskRunSpec) {
+		spec.CloudEventSink = sink
+	}
+}
+
 // StateTerminated set Terminated to the StepState.
 func StateTerminated(exitcode int) StepStateOp {
 	return func(s *v1alpha1.StepState) {
@@ -483,6 +578,16 @@ func TaskRunInputsParam(name, value string) TaskRunInputsOp {
 	}
 }
 
+// TaskRunInputsArrayParam add an array param, with specified name and values, to the TaskRunInputs.
+func TaskRunInputsArrayParam(name string, values ...string) TaskRunInputsOp {
+	return func(i *v1alpha1.TaskRunInputs) {
+		i.Params = append(i.Params, v1alpha1.Param{
+			Name:       name,
+			ArrayValue: values,
+		})
+	}
+}
+
 // TaskRunInputsResource adds a resource, with specified name, to the TaskRunInputs.
 // Any number of TaskResourceBinding modifier can be passed to transform it.
 func TaskRunInputsResource(name string, ops ...TaskResourceBindingOp) TaskRunInputsOp {
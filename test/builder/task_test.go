@@ -41,11 +41,15 @@ func TestTask(t *testing.T) {
 		tb.TaskInputs(
 			tb.InputsResource("workspace", v1alpha1.PipelineResourceTypeGit, tb.ResourceTargetPath("/foo/bar")),
 			tb.InputsParam("param", tb.ParamDescription("mydesc"), tb.ParamDefault("default")),
+			tb.InputsParam("array-param", tb.ParamType(v1alpha1.ParamTypeArray), tb.ParamArrayDefault("-v"),
+				tb.ParamEnum("-v", "-q"), tb.ParamPattern("^-")),
 		),
 		tb.TaskOutputs(tb.OutputsResource("myotherimage", v1alpha1.PipelineResourceTypeImage)),
 		tb.Step("mycontainer", "myimage", tb.Command("/mycmd"), tb.Args(
 			"--my-other-arg=${inputs.resources.workspace.url}",
 		)),
+		tb.ScriptStep("myscript", "myimage", "echo ${inputs.params.param}"),
+		tb.Sidecar("mysidecar", "redis", tb.Args("--port", "6379")),
 		tb.TaskVolume("foo", tb.VolumeSource(corev1.VolumeSource{
 			HostPath: &corev1.HostPathVolumeSource{Path: "/foo/bar"},
 		})),
@@ -56,11 +60,22 @@ func TestTask(t *testing.T) {
 	expectedTask := &v1alpha1.Task{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "foo"},
 		Spec: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:    "mycontainer",
 				Image:   "myimage",
 				Command: []string{"/mycmd"},
 				Args:    []string{"--my-other-arg=${inputs.resources.workspace.url}"},
+			}}, {
+				Container: corev1.Container{
+					Name:  "myscript",
+					Image: "myimage",
+				},
+				Script: "echo ${inputs.params.param}",
+			}},
+			Sidecars: []corev1.Container{{
+				Name:  "mysidecar",
+				Image: "redis",
+				Args:  []string{"--port", "6379"},
 			}},
 			Inputs: &v1alpha1.Inputs{
 				Resources: []v1alpha1.TaskResource{{
@@ -68,7 +83,13 @@ func TestTask(t *testing.T) {
 					Type:       v1alpha1.PipelineResourceTypeGit,
 					TargetPath: "/foo/bar",
 				}},
-				Params: []v1alpha1.TaskParam{{Name: "param", Description: "mydesc", Default: "default"}},
+				Params: []v1alpha1.TaskParam{{Name: "param", Description: "mydesc", Default: "default"}, {
+					Name:         "array-param",
+					Type:         v1alpha1.ParamTypeArray,
+					ArrayDefault: []string{"-v"},
+					Enum:         []string{"-v", "-q"},
+					Pattern:      "^-",
+				}},
 			},
 			Outputs: &v1alpha1.Outputs{
 				Resources: []v1alpha1.TaskResource{{
@@ -104,12 +125,12 @@ func TestClusterTask(t *testing.T) {
 	expectedTask := &v1alpha1.ClusterTask{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-clustertask"},
 		Spec: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:    "mycontainer",
 				Image:   "myimage",
 				Command: []string{"/mycmd"},
 				Args:    []string{"--my-other-arg=${inputs.resources.workspace.url}"},
-			}},
+			}}},
 		},
 	}
 	if d := cmp.Diff(expectedTask, task); d != "" {
@@ -141,6 +162,7 @@ func TestTaskRunWitTaskRef(t *testing.T) {
 					tb.TaskResourceBindingResourceSpec(&v1alpha1.PipelineResourceSpec{Type: v1alpha1.PipelineResourceTypeCluster}),
 				),
 				tb.TaskRunInputsParam("iparam", "ivalue"),
+				tb.TaskRunInputsArrayParam("arrayparam", "a", "b"),
 			),
 			tb.TaskRunOutputs(
 				tb.TaskRunOutputsResource(gitResource.Name,
@@ -180,7 +202,7 @@ func TestTaskRunWitTaskRef(t *testing.T) {
 					ResourceSpec: &v1alpha1.PipelineResourceSpec{Type: v1alpha1.PipelineResourceType("cluster")},
 					Paths:        []string{"source-folder"},
 				}},
-				Params: []v1alpha1.Param{{Name: "iparam", Value: "ivalue"}},
+				Params: []v1alpha1.Param{{Name: "iparam", Value: "ivalue"}, {Name: "arrayparam", ArrayValue: []string{"a", "b"}}},
 			},
 			Outputs: v1alpha1.TaskRunOutputs{
 				Resources: []v1alpha1.TaskResourceBinding{{
@@ -220,6 +242,7 @@ func TestTaskRunWithTaskSpec(t *testing.T) {
 		tb.TaskTrigger("mytrigger", v1alpha1.TaskTriggerTypeManual),
 		tb.TaskRunServiceAccount("sa"),
 		tb.TaskRunTimeout(2*time.Minute),
+		tb.TaskRunPendingTimeout(time.Minute),
 	))
 	expectedTaskRun := &v1alpha1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -227,11 +250,11 @@ func TestTaskRunWithTaskSpec(t *testing.T) {
 		},
 		Spec: v1alpha1.TaskRunSpec{
 			TaskSpec: &v1alpha1.TaskSpec{
-				Steps: []corev1.Container{{
+				Steps: []v1alpha1.Step{{Container: corev1.Container{
 					Name:    "step",
 					Image:   "image",
 					Command: []string{"/mycmd"},
-				}},
+				}}},
 			},
 			Trigger: v1alpha1.TaskTrigger{
 				Name: "mytrigger",
@@ -239,6 +262,7 @@ func TestTaskRunWithTaskSpec(t *testing.T) {
 			},
 			ServiceAccount: "sa",
 			Timeout:        &metav1.Duration{Duration: 2 * time.Minute},
+			PendingTimeout: &metav1.Duration{Duration: time.Minute},
 		},
 	}
 	if d := cmp.Diff(expectedTaskRun, taskRun); d != "" {
@@ -256,11 +280,11 @@ func TestResolvedTaskResources(t *testing.T) {
 	)
 	expectedResolvedTaskResources := &resources.ResolvedTaskResources{
 		TaskSpec: &v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:    "step",
 				Image:   "image",
 				Command: []string{"/mycmd"},
-			}},
+			}}},
 		},
 		Inputs: map[string]*v1alpha1.PipelineResource{
 			"foo": {
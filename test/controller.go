@@ -39,14 +39,18 @@ func GetLogMessages(logs *observer.ObservedLogs) []string {
 // Data represents the desired state of the system (i.e. existing resources) to seed controllers
 // with.
 type Data struct {
-	PipelineRuns      []*v1alpha1.PipelineRun
-	Pipelines         []*v1alpha1.Pipeline
-	TaskRuns          []*v1alpha1.TaskRun
-	Tasks             []*v1alpha1.Task
-	ClusterTasks      []*v1alpha1.ClusterTask
-	PipelineResources []*v1alpha1.PipelineResource
-	Pods              []*corev1.Pod
-	Namespaces        []*corev1.Namespace
+	PipelineRuns          []*v1alpha1.PipelineRun
+	Pipelines             []*v1alpha1.Pipeline
+	TaskRuns              []*v1alpha1.TaskRun
+	Tasks                 []*v1alpha1.Task
+	ClusterTasks          []*v1alpha1.ClusterTask
+	PipelineResources     []*v1alpha1.PipelineResource
+	Conditions            []*v1alpha1.Condition
+	Approvals             []*v1alpha1.Approval
+	Runs                  []*v1alpha1.Run
+	ScheduledPipelineRuns []*v1alpha1.ScheduledPipelineRun
+	Pods                  []*corev1.Pod
+	Namespaces            []*corev1.Namespace
 }
 
 // Clients holds references to clients which are useful for reconciler tests.
@@ -57,13 +61,17 @@ type Clients struct {
 
 // Informers holds references to informers which are useful for reconciler tests.
 type Informers struct {
-	PipelineRun      informersv1alpha1.PipelineRunInformer
-	Pipeline         informersv1alpha1.PipelineInformer
-	TaskRun          informersv1alpha1.TaskRunInformer
-	Task             informersv1alpha1.TaskInformer
-	ClusterTask      informersv1alpha1.ClusterTaskInformer
-	PipelineResource informersv1alpha1.PipelineResourceInformer
-	Pod              coreinformers.PodInformer
+	PipelineRun          informersv1alpha1.PipelineRunInformer
+	Pipeline             informersv1alpha1.PipelineInformer
+	TaskRun              informersv1alpha1.TaskRunInformer
+	Task                 informersv1alpha1.TaskInformer
+	ClusterTask          informersv1alpha1.ClusterTaskInformer
+	PipelineResource     informersv1alpha1.PipelineResourceInformer
+	Condition            informersv1alpha1.ConditionInformer
+	Approval             informersv1alpha1.ApprovalInformer
+	Run                  informersv1alpha1.RunInformer
+	ScheduledPipelineRun informersv1alpha1.ScheduledPipelineRunInformer
+	Pod                  coreinformers.PodInformer
 }
 
 // TestAssets holds references to the controller, logs, clients, and informers.
@@ -96,6 +104,18 @@ func SeedTestData(d Data) (Clients, Informers) {
 	for _, tr := range d.TaskRuns {
 		objs = append(objs, tr)
 	}
+	for _, c := range d.Conditions {
+		objs = append(objs, c)
+	}
+	for _, a := range d.Approvals {
+		objs = append(objs, a)
+	}
+	for _, r := range d.Runs {
+		objs = append(objs, r)
+	}
+	for _, spr := range d.ScheduledPipelineRuns {
+		objs = append(objs, spr)
+	}
 
 	kubeObjs := []runtime.Object{}
 	for _, p := range d.Pods {
@@ -112,13 +132,17 @@ func SeedTestData(d Data) (Clients, Informers) {
 	kubeInformer := kubeinformers.NewSharedInformerFactory(c.Kube, 0)
 
 	i := Informers{
-		PipelineRun:      sharedInformer.Tekton().V1alpha1().PipelineRuns(),
-		Pipeline:         sharedInformer.Tekton().V1alpha1().Pipelines(),
-		TaskRun:          sharedInformer.Tekton().V1alpha1().TaskRuns(),
-		Task:             sharedInformer.Tekton().V1alpha1().Tasks(),
-		ClusterTask:      sharedInformer.Tekton().V1alpha1().ClusterTasks(),
-		PipelineResource: sharedInformer.Tekton().V1alpha1().PipelineResources(),
-		Pod:              kubeInformer.Core().V1().Pods(),
+		PipelineRun:          sharedInformer.Tekton().V1alpha1().PipelineRuns(),
+		Pipeline:             sharedInformer.Tekton().V1alpha1().Pipelines(),
+		TaskRun:              sharedInformer.Tekton().V1alpha1().TaskRuns(),
+		Task:                 sharedInformer.Tekton().V1alpha1().Tasks(),
+		ClusterTask:          sharedInformer.Tekton().V1alpha1().ClusterTasks(),
+		PipelineResource:     sharedInformer.Tekton().V1alpha1().PipelineResources(),
+		Condition:            sharedInformer.Tekton().V1alpha1().Conditions(),
+		Approval:             sharedInformer.Tekton().V1alpha1().Approvals(),
+		Run:                  sharedInformer.Tekton().V1alpha1().Runs(),
+		ScheduledPipelineRun: sharedInformer.Tekton().V1alpha1().ScheduledPipelineRuns(),
+		Pod:                  kubeInformer.Core().V1().Pods(),
 	}
 
 	for _, pr := range d.PipelineRuns {
@@ -139,6 +163,18 @@ func SeedTestData(d Data) (Clients, Informers) {
 	for _, r := range d.PipelineResources {
 		i.PipelineResource.Informer().GetIndexer().Add(r)
 	}
+	for _, c := range d.Conditions {
+		i.Condition.Informer().GetIndexer().Add(c)
+	}
+	for _, a := range d.Approvals {
+		i.Approval.Informer().GetIndexer().Add(a)
+	}
+	for _, r := range d.Runs {
+		i.Run.Informer().GetIndexer().Add(r)
+	}
+	for _, spr := range d.ScheduledPipelineRuns {
+		i.ScheduledPipelineRun.Informer().GetIndexer().Add(spr)
+	}
 	for _, p := range d.Pods {
 		i.Pod.Informer().GetIndexer().Add(p)
 	}
@@ -44,18 +44,23 @@ func TailLogs(ctx context.Context, cfg *rest.Config, out io.Writer, name, namesp
 		return err
 	}
 
-	pipelineName := pipelineRun.Spec.PipelineRef.Name
-	if pipelineName == "" {
-		return fmt.Errorf("Expected pipeline ref to be set")
-	}
-
-	pp, err := pclient.Pipelines(namespace).Get(pipelineName, metav1.GetOptions{IncludeUninitialized: true})
-	if err != nil {
-		return err
+	spec := pipelineRun.Spec.PipelineSpec
+	if spec == nil {
+		if pipelineRun.Spec.PipelineRef == nil || pipelineRun.Spec.PipelineRef.Name == "" {
+			return fmt.Errorf("Expected pipeline ref or pipeline spec to be set")
+		}
+		pp, err := pclient.Pipelines(namespace).Get(pipelineRun.Spec.PipelineRef.Name, metav1.GetOptions{IncludeUninitialized: true})
+		if err != nil {
+			return err
+		}
+		spec = &pp.Spec
 	}
 
 	var expectedTaskRuns []string
-	for _, pt := range pp.Spec.Tasks {
+	for _, pt := range spec.Tasks {
+		expectedTaskRuns = append(expectedTaskRuns, fmt.Sprintf("%s-%s", pipelineRun.Name, pt.Name))
+	}
+	for _, pt := range spec.Finally {
 		expectedTaskRuns = append(expectedTaskRuns, fmt.Sprintf("%s-%s", pipelineRun.Name, pt.Name))
 	}
 
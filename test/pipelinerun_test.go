@@ -397,15 +397,22 @@ func checkLabelPropagation(t *testing.T, c *clients, namespace string, pipelineR
 	}
 	// This label is added to every TaskRun by the PipelineRun controller
 	labels[pipeline.GroupName+pipeline.PipelineRunLabelKey] = pr.Name
-	if tr.Spec.TaskRef != nil {
-		task, err := c.TaskClient.Get(tr.Spec.TaskRef.Name, metav1.GetOptions{})
+	trs, ok := pr.Status.TaskRuns[tr.Name]
+	if !ok {
+		t.Fatalf("Couldn't find TaskRun %s in the status of PipelineRun %s", tr.Name, pr.Name)
+	}
+	for _, pt := range p.Spec.Tasks {
+		if pt.Name != trs.PipelineTaskName || pt.TaskRef == nil {
+			continue
+		}
+		task, err := c.TaskClient.Get(pt.TaskRef.Name, metav1.GetOptions{})
 		if err != nil {
 			t.Fatalf("Couldn't get expected Task for %s: %s", tr.Name, err)
 		}
 		for key, val := range task.ObjectMeta.Labels {
 			labels[key] = val
 		}
-		// This label is added to TaskRuns that reference a Task by the TaskRun controller
+		// This label is added to TaskRuns that run a referenced Task by the PipelineRun controller
 		labels[pipeline.GroupName+pipeline.TaskLabelKey] = task.Name
 	}
 	assertLabelsMatch(t, labels, tr.ObjectMeta.Labels)